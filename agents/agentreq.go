@@ -128,7 +128,7 @@ func (ar *AgentRequest) FieldAsInterface(fldPath []string) (val interface{}, err
 		} else {
 			val = ar.CGRReply
 		}
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		if len(fldPath) != 1 {
 			val, err = ar.diamreq.FieldAsInterface(fldPath[1:])
 		} else {
@@ -224,6 +224,9 @@ func (ar *AgentRequest) SetFields(tplFlds []*config.FCTemplate) (err error) {
 				}
 				return
 			}
+			if out, err = valueAsLayoutType(out, tplFld.Layout); err != nil {
+				return fmt.Errorf("field <%s>: %s", tplFld.Tag, err.Error())
+			}
 			var fullPath *utils.FullPath
 			if fullPath, err = utils.GetFullFieldPath(tplFld.Path, ar); err != nil {
 				return
@@ -254,6 +257,20 @@ func (ar *AgentRequest) SetFields(tplFlds []*config.FCTemplate) (err error) {
 	return
 }
 
+// valueAsLayoutType converts the value to the type selected through the layout of the template field
+// so the agents encoding typed replies (ie: CHFAgent JSON body) do not need to guess the type out of the string
+func valueAsLayoutType(val interface{}, layout string) (interface{}, error) {
+	switch layout {
+	case utils.MetaInteger:
+		return utils.IfaceAsTInt64(val)
+	case utils.MetaFloat:
+		return utils.IfaceAsFloat64(val)
+	case utils.MetaBool:
+		return utils.IfaceAsBool(val)
+	}
+	return val, nil
+}
+
 // Set implements utils.NMInterface
 func (ar *AgentRequest) SetAsSlice(fullPath *utils.FullPath, nm *utils.DataLeaf) (err error) {
 	switch fullPath.PathSlice[0] {
@@ -275,10 +292,10 @@ func (ar *AgentRequest) SetAsSlice(fullPath *utils.FullPath, nm *utils.DataLeaf)
 			PathSlice: fullPath.PathSlice[1:],
 			Path:      fullPath.Path[5:],
 		}, []*utils.DataNode{{Type: utils.NMDataType, Value: nm}})
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		return ar.diamreq.SetAsSlice(&utils.FullPath{
 			PathSlice: fullPath.PathSlice[1:],
-			Path:      fullPath.Path[9:],
+			Path:      fullPath.Path[len(fullPath.PathSlice[0])+1:],
 		}, []*utils.DataNode{{Type: utils.NMDataType, Value: nm}})
 	case utils.MetaTmp:
 		_, err = ar.tmp.Set(fullPath.PathSlice[1:], []*utils.DataNode{{Type: utils.NMDataType, Value: nm}})
@@ -303,7 +320,7 @@ func (ar *AgentRequest) RemoveAll(prefix string) error {
 		ar.CGRReply = &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
 	case utils.MetaRep:
 		ar.Reply.RemoveAll()
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		ar.diamreq.RemoveAll()
 	case utils.MetaTmp:
 		ar.tmp = &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
@@ -334,10 +351,10 @@ func (ar *AgentRequest) Remove(fullPath *utils.FullPath) error {
 			PathSlice: fullPath.PathSlice[1:],
 			Path:      fullPath.Path[5:],
 		})
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		return ar.diamreq.Remove(&utils.FullPath{
 			PathSlice: fullPath.PathSlice[1:],
-			Path:      fullPath.Path[9:],
+			Path:      fullPath.Path[len(fullPath.PathSlice[0])+1:],
 		})
 	case utils.MetaTmp:
 		return ar.tmp.Remove(utils.CloneStringSlice(fullPath.PathSlice[1:]))
@@ -414,10 +431,10 @@ func (ar *AgentRequest) Append(fullPath *utils.FullPath, val *utils.DataLeaf) (e
 			PathSlice: fullPath.PathSlice[1:],
 			Path:      fullPath.Path[5:],
 		}, val)
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		return ar.diamreq.Append(&utils.FullPath{
 			PathSlice: fullPath.PathSlice[1:],
-			Path:      fullPath.Path[9:],
+			Path:      fullPath.Path[len(fullPath.PathSlice[0])+1:],
 		}, val)
 	case utils.MetaTmp:
 		_, err = ar.tmp.Append(fullPath.PathSlice[1:], val)
@@ -449,10 +466,10 @@ func (ar *AgentRequest) Compose(fullPath *utils.FullPath, val *utils.DataLeaf) (
 			PathSlice: fullPath.PathSlice[1:],
 			Path:      fullPath.Path[5:],
 		}, val)
-	case utils.MetaDiamreq:
+	case utils.MetaDiamreq, utils.MetaRadDAReq:
 		return ar.diamreq.Compose(&utils.FullPath{
 			PathSlice: fullPath.PathSlice[1:],
-			Path:      fullPath.Path[9:],
+			Path:      fullPath.Path[len(fullPath.PathSlice[0])+1:],
 		}, val)
 	case utils.MetaTmp:
 		return ar.tmp.Compose(fullPath.PathSlice[1:], val)
@@ -2729,3 +2729,25 @@ func TestAgentRequestParseFieldDateTimeError2(t *testing.T) {
 		t.Errorf("Expected <%+v> but received <%+v>", expected, err)
 	}
 }
+
+func TestAgReqValueAsLayoutType(t *testing.T) {
+	for layout, exp := range map[string]interface{}{
+		utils.MetaInteger: int64(123),
+		utils.MetaFloat:   123.,
+		time.RFC3339:      "0123",
+	} {
+		if rcv, err := valueAsLayoutType("0123", layout); err != nil {
+			t.Error(err)
+		} else if rcv != exp {
+			t.Errorf("Expected %v (%T), received %v (%T)", exp, exp, rcv, rcv)
+		}
+	}
+	if rcv, err := valueAsLayoutType("true", utils.MetaBool); err != nil {
+		t.Error(err)
+	} else if rcv != true {
+		t.Errorf("Expected true, received %v", rcv)
+	}
+	if _, err := valueAsLayoutType("SUCCESS", utils.MetaInteger); err == nil {
+		t.Error("Expected error converting a non numeric value")
+	}
+}
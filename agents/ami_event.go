@@ -0,0 +1,230 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AMI events followed by AsteriskAgent
+const (
+	AMINewchannel  = "Newchannel"
+	AMIBridgeEnter = "BridgeEnter"
+	AMIHangup      = "Hangup"
+
+	amiChannel     = "Channel"
+	amiUniqueID    = "Uniqueid"
+	amiLinkedID    = "Linkedid"
+	amiCallerIDNum = "CallerIDNum"
+	amiExten       = "Exten"
+	amiCause       = "Cause"
+	amiCauseTxt    = "Cause-txt"
+	amiTimestamp   = "Timestamp"
+)
+
+// NewAMIEvent parses the event received over the Asterisk Manager Interface
+// the cgr_ channel variables exported with channelvars within manager.conf are populating the event
+func NewAMIEvent(amiEv map[string]string, asteriskIP, asteriskAlias string) *AMIEvent {
+	ev := &AMIEvent{
+		amiEv:         amiEv,
+		asteriskIP:    asteriskIP,
+		asteriskAlias: asteriskAlias,
+		chanVars:      make(map[string]string),
+		opts:          make(map[string]interface{}),
+	}
+	for k, v := range amiEv {
+		if !strings.HasPrefix(k, amiChanVariable+"(") ||
+			v == utils.EmptyString {
+			continue
+		}
+		vrbl := strings.TrimSuffix(strings.TrimPrefix(k, amiChanVariable+"("), ")")
+		if utils.CGROptionsSet.Has(vrbl) {
+			ev.opts[vrbl] = v
+		} else {
+			ev.chanVars[vrbl] = v
+		}
+	}
+	return ev
+}
+
+// AMIEvent is one event received over the Asterisk Manager Interface
+type AMIEvent struct {
+	amiEv         map[string]string
+	asteriskIP    string
+	asteriskAlias string
+	chanVars      map[string]string // channel variables
+	opts          map[string]interface{}
+}
+
+func (ev *AMIEvent) EventType() string {
+	return ev.amiEv[amiEvent]
+}
+
+// Channel returns the name of the channel, used to control it
+func (ev *AMIEvent) Channel() string {
+	return ev.amiEv[amiChannel]
+}
+
+// UniqueID identifies the channel, used as OriginID
+func (ev *AMIEvent) UniqueID() string {
+	return ev.amiEv[amiUniqueID]
+}
+
+// IsOriginator returns true for the channel originating the call
+// the other legs are linked to it
+func (ev *AMIEvent) IsOriginator() bool {
+	return ev.amiEv[amiLinkedID] == utils.EmptyString ||
+		ev.amiEv[amiLinkedID] == ev.UniqueID()
+}
+
+// Timestamp returns the time of the event out of the Timestamp header (timestampevents within manager.conf)
+// defaults to the time the event was received
+func (ev *AMIEvent) Timestamp() time.Time {
+	if ts, err := strconv.ParseFloat(ev.amiEv[amiTimestamp], 64); err == nil {
+		sec, dec := math.Modf(ts)
+		return time.Unix(int64(sec), int64(dec*float64(time.Second)))
+	}
+	return time.Now()
+}
+
+func (ev *AMIEvent) RequestType() string {
+	return utils.FirstNonEmpty(ev.chanVars[utils.CGRReqType], config.CgrConfig().GeneralCfg().DefaultReqType)
+}
+
+func (ev *AMIEvent) Subsystems() string {
+	return ev.chanVars[utils.CGRFlags]
+}
+
+func (ev *AMIEvent) DisconnectCause() string {
+	return utils.FirstNonEmpty(ev.amiEv[amiCauseTxt], ev.amiEv[amiCause])
+}
+
+func (ev *AMIEvent) AsMapStringInterface() (mp map[string]interface{}) {
+	mp = make(map[string]interface{})
+	for k, v := range ev.chanVars {
+		if !primaryFields.Has(k) {
+			mp[k] = v
+		}
+	}
+	mp[utils.EventName] = SMAAuthorization
+	mp[utils.OriginID] = ev.UniqueID()
+	mp[utils.RequestType] = ev.RequestType()
+	for fld, vrbl := range map[string]string{
+		utils.Tenant:   utils.CGRTenant,
+		utils.Category: utils.CGRCategory,
+		utils.Subject:  utils.CGRSubject,
+		utils.Route:    utils.CGRRoute,
+	} {
+		if val := ev.chanVars[vrbl]; val != utils.EmptyString {
+			mp[fld] = val
+		}
+	}
+	mp[utils.OriginHost] = utils.FirstNonEmpty(ev.chanVars[utils.CGROriginHost], ev.asteriskAlias, ev.asteriskIP)
+	mp[utils.AccountField] = utils.FirstNonEmpty(ev.chanVars[utils.CGRAccount], ev.amiEv[amiCallerIDNum])
+	mp[utils.Destination] = utils.FirstNonEmpty(ev.chanVars[utils.CGRDestination], ev.amiEv[amiExten])
+	mp[utils.SetupTime] = ev.Timestamp()
+	mp[utils.Source] = utils.AsteriskAgent
+	return
+}
+
+// AsCGREvent converts the Newchannel event into CGREvent
+func (ev *AMIEvent) AsCGREvent() *utils.CGREvent {
+	setupTime := ev.Timestamp()
+	return &utils.CGREvent{
+		Tenant: utils.FirstNonEmpty(ev.chanVars[utils.CGRTenant],
+			config.CgrConfig().GeneralCfg().DefaultTenant),
+		ID:      utils.UUIDSha1Prefix(),
+		Time:    &setupTime,
+		Event:   ev.AsMapStringInterface(),
+		APIOpts: ev.opts,
+	}
+}
+
+// UpdateCGREvent updates the cached event with the BridgeEnter (answer) and Hangup information
+func (ev *AMIEvent) UpdateCGREvent(cgrEv *utils.CGREvent) (err error) {
+	switch ev.EventType() {
+	case AMIBridgeEnter:
+		cgrEv.Event[utils.EventName] = SMASessionStart
+		cgrEv.Event[utils.AnswerTime] = ev.Timestamp()
+	case AMIHangup:
+		cgrEv.Event[utils.EventName] = SMASessionTerminate
+		cgrEv.Event[utils.DisconnectCause] = ev.DisconnectCause()
+		cgrEv.Event[utils.Usage] = time.Duration(0)
+		if _, has := cgrEv.Event[utils.AnswerTime]; has {
+			var aTime time.Time
+			if aTime, err = utils.IfaceAsTime(cgrEv.Event[utils.AnswerTime],
+				config.CgrConfig().GeneralCfg().DefaultTimezone); err != nil {
+				return
+			}
+			cgrEv.Event[utils.Usage] = ev.Timestamp().Sub(aTime)
+		}
+	}
+	for k, v := range ev.opts {
+		cgrEv.APIOpts[k] = v
+	}
+	return
+}
+
+func (ev *AMIEvent) V1AuthorizeArgs() (args *sessions.V1AuthorizeArgs) {
+	args = &sessions.V1AuthorizeArgs{
+		CGREvent: ev.AsCGREvent(),
+	}
+	if ev.Subsystems() == utils.EmptyString {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cgr_flags variable is not set, using defaults",
+			utils.AsteriskAgent))
+		args.GetMaxUsage = true
+		return
+	}
+	args.ParseFlags(ev.Subsystems(), utils.PlusChar)
+	return
+}
+
+func (ev *AMIEvent) V1InitSessionArgs(cgrEv utils.CGREvent) (args *sessions.V1InitSessionArgs) {
+	args = &sessions.V1InitSessionArgs{
+		CGREvent: &cgrEv,
+	}
+	subsystems, err := cgrEv.FieldAsString(utils.CGRFlags)
+	if err != nil {
+		args.InitSession = true
+		return
+	}
+	args.ParseFlags(subsystems, utils.PlusChar)
+	return
+}
+
+func (ev *AMIEvent) V1TerminateSessionArgs(cgrEv utils.CGREvent) (args *sessions.V1TerminateSessionArgs) {
+	args = &sessions.V1TerminateSessionArgs{
+		CGREvent: &cgrEv,
+	}
+	subsystems, err := cgrEv.FieldAsString(utils.CGRFlags)
+	if err != nil {
+		args.TerminateSession = true
+		return
+	}
+	args.ParseFlags(subsystems, utils.PlusChar)
+	return
+}
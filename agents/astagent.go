@@ -63,6 +63,7 @@ func NewAsteriskAgent(cgrCfg *config.CGRConfig, astConnIdx int,
 		astConnIdx:  astConnIdx,
 		connMgr:     connMgr,
 		eventsCache: make(map[string]*utils.CGREvent),
+		amiChannels: make(map[string]string),
 	}
 	return sma
 }
@@ -77,6 +78,9 @@ type AsteriskAgent struct {
 	astErrChan  chan error
 	eventsCache map[string]*utils.CGREvent // used to gather information about events during various phases
 	evCacheMux  sync.RWMutex               // Protect eventsCache
+	amiConn     *amiConn                   // used instead of astConn for the *ami connections
+	amiEvChan   chan map[string]string
+	amiChannels map[string]string // channel names indexed on Uniqueid, needed to control the channels over AMI, protected by evCacheMux
 }
 
 func (sma *AsteriskAgent) connectAsterisk(stopChan <-chan struct{}) (err error) {
@@ -92,6 +96,9 @@ func (sma *AsteriskAgent) connectAsterisk(stopChan <-chan struct{}) (err error)
 
 // ListenAndServe is called to start the service
 func (sma *AsteriskAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
+	if sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Type == utils.MetaAMI {
+		return sma.listenAndServeAMI(stopChan)
+	}
 	if err = sma.connectAsterisk(stopChan); err != nil {
 		return
 	}
@@ -140,6 +147,10 @@ func (sma *AsteriskAgent) hangupChannel(channelID, warnMsg string) {
 	if warnMsg != "" {
 		utils.Logger.Warning(warnMsg)
 	}
+	if sma.amiConn != nil {
+		sma.hangupAMIChannel(channelID)
+		return
+	}
 	if _, err := sma.astConn.Call(aringo.HTTP_DELETE, fmt.Sprintf("http://%s/ari/channels/%s",
 		sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address, channelID),
 		url.Values{"reason": {"congestion"}}); err != nil {
@@ -338,6 +349,9 @@ func (sma *AsteriskAgent) V1DisconnectSession(args utils.AttrDisconnectSession,
 // V1GetActiveSessionIDs is internal method to  get all active sessions in asterisk
 func (sma *AsteriskAgent) V1GetActiveSessionIDs(ignParam string,
 	sessionIDs *[]*sessions.SessionID) error {
+	if sma.amiConn != nil {
+		return sma.amiActiveSessionIDs(sessionIDs)
+	}
 	var slMpIface []map[string]interface{} // decode the result from ari into a slice of map[string]interface{}
 	if byts, err := sma.astConn.Call(
 		aringo.HTTP_GET,
@@ -0,0 +1,317 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AMI actions used to control the channels
+const (
+	amiSetvar           = "Setvar"
+	amiAbsoluteTimeout  = "AbsoluteTimeout"
+	amiCoreShowChannels = "CoreShowChannels"
+	amiCongestionCause  = "34" // Q.850 Normal circuit congestion, same as the ARI congestion reason
+)
+
+func (sma *AsteriskAgent) connectAMI(stopChan <-chan struct{}) (err error) {
+	connCfg := sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx]
+	sma.amiEvChan = make(chan map[string]string)
+	sma.astErrChan = make(chan error)
+	sma.amiConn, err = newAMIConn(connCfg.Address, connCfg.User, connCfg.Password,
+		sma.amiEvChan, sma.astErrChan, stopChan, connCfg.ConnectAttempts, connCfg.Reconnects)
+	return
+}
+
+// listenAndServeAMI follows the calls over the Asterisk Manager Interface
+// so the calls are charged without passing through a Stasis application
+func (sma *AsteriskAgent) listenAndServeAMI(stopChan <-chan struct{}) (err error) {
+	if err = sma.connectAMI(stopChan); err != nil {
+		return
+	}
+	connCfg := sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx]
+	utils.Logger.Info(fmt.Sprintf("<%s> successfully connected to Asterisk Manager Interface at: <%s>",
+		utils.AsteriskAgent, connCfg.Address))
+	for {
+		select {
+		case <-stopChan:
+			sma.amiConn.Close()
+			return
+		case err = <-sma.astErrChan:
+			return
+		case amiRawEv := <-sma.amiEvChan:
+			sma.handleAMIEvent(NewAMIEvent(amiRawEv,
+				strings.Split(connCfg.Address, ":")[0], connCfg.Alias))
+		}
+	}
+}
+
+// handleAMIEvent updates the cached events in the order they are received
+// the calls towards SessionS are done asynchronously
+func (sma *AsteriskAgent) handleAMIEvent(ev *AMIEvent) {
+	uID := ev.UniqueID()
+	switch ev.EventType() {
+	case AMINewchannel:
+		if !ev.IsOriginator() { // only the calling leg is charged
+			return
+		}
+		authArgs := ev.V1AuthorizeArgs()
+		sma.evCacheMux.Lock()
+		sma.eventsCache[uID] = authArgs.CGREvent.Clone()
+		sma.amiChannels[uID] = ev.Channel()
+		sma.evCacheMux.Unlock()
+		go sma.handleAMINewchannel(uID, authArgs)
+	case AMIBridgeEnter:
+		sma.evCacheMux.Lock()
+		cgrEv, has := sma.eventsCache[uID]
+		if !has { // not handled by us
+			sma.evCacheMux.Unlock()
+			return
+		}
+		if _, answered := cgrEv.Event[utils.AnswerTime]; answered { // ie. transfers
+			sma.evCacheMux.Unlock()
+			return
+		}
+		err := ev.UpdateCGREvent(cgrEv)
+		initArgs := ev.V1InitSessionArgs(*cgrEv.Clone())
+		sma.evCacheMux.Unlock()
+		if err != nil {
+			sma.hangupChannel(uID,
+				fmt.Sprintf("<%s> error: %s when attempting to initiate session for channelID: %s",
+					utils.AsteriskAgent, err.Error(), uID))
+			return
+		}
+		go sma.handleAMIBridgeEnter(uID, initArgs)
+	case AMIHangup:
+		sma.evCacheMux.Lock()
+		cgrEv, has := sma.eventsCache[uID]
+		if !has { // not handled by us
+			sma.evCacheMux.Unlock()
+			return
+		}
+		delete(sma.eventsCache, uID)
+		delete(sma.amiChannels, uID)
+		err := ev.UpdateCGREvent(cgrEv)
+		sma.evCacheMux.Unlock()
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s when attempting to destroy session for channelID: %s",
+					utils.AsteriskAgent, err.Error(), uID))
+			return
+		}
+		go sma.handleAMIHangup(uID, cgrEv, ev.V1TerminateSessionArgs(*cgrEv.Clone()))
+	}
+}
+
+// handleAMINewchannel authorizes the call, disconnecting it if not allowed
+// the authorization results are exported as channel variables
+func (sma *AsteriskAgent) handleAMINewchannel(uID string, authArgs *sessions.V1AuthorizeArgs) {
+	var authReply sessions.V1AuthorizeReply
+	if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+		utils.SessionSv1AuthorizeEvent, authArgs, &authReply); err != nil {
+		sma.forgetAMIChannel(uID,
+			fmt.Sprintf("<%s> error: %s authorizing session for channelID: %s",
+				utils.AsteriskAgent, err.Error(), uID))
+		return
+	}
+	if authReply.Attributes != nil {
+		for _, fldName := range authReply.Attributes.AlteredFields {
+			fldName = strings.TrimPrefix(fldName, utils.MetaReq+utils.NestingSep)
+			if _, has := authReply.Attributes.CGREvent.Event[fldName]; !has {
+				continue //maybe removed
+			}
+			fldVal, err := authReply.Attributes.CGREvent.FieldAsString(fldName)
+			if err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf(
+						"<%s> error <%s> extracting attribute field: <%s>",
+						utils.AsteriskAgent, err.Error(), fldName))
+			}
+			if !sma.setAMIChannelVar(uID, fldName, fldVal) {
+				return
+			}
+		}
+	}
+	if authArgs.GetMaxUsage {
+		if authReply.MaxUsage == nil || *authReply.MaxUsage == time.Duration(0) {
+			sma.forgetAMIChannel(uID, utils.EmptyString)
+			return
+		}
+		if !sma.setAMIChannelVar(uID, CGRMaxSessionTime,
+			strconv.Itoa(int(authReply.MaxUsage.Milliseconds()))) {
+			return
+		}
+	}
+	if authReply.ResourceAllocation != nil {
+		if !sma.setAMIChannelVar(uID,
+			ARICGRResourceAllocation, *authReply.ResourceAllocation) {
+			return
+		}
+	}
+	if authReply.RouteProfiles != nil {
+		for i, route := range authReply.RouteProfiles.RouteIDs() {
+			if !sma.setAMIChannelVar(uID,
+				CGRRoute+strconv.Itoa(i+1), route) {
+				return
+			}
+		}
+	}
+}
+
+// handleAMIBridgeEnter initiates the session once the call is answered
+// the maximum usage is enforced with an absolute timeout on the channel
+func (sma *AsteriskAgent) handleAMIBridgeEnter(uID string, initArgs *sessions.V1InitSessionArgs) {
+	var initReply sessions.V1InitSessionReply
+	if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+		utils.SessionSv1InitiateSession,
+		initArgs, &initReply); err != nil {
+		sma.hangupChannel(uID,
+			fmt.Sprintf("<%s> error: %s when attempting to initiate session for channelID: %s",
+				utils.AsteriskAgent, err.Error(), uID))
+		return
+	}
+	if !initArgs.InitSession || initReply.MaxUsage == nil ||
+		*initReply.MaxUsage < 0 { // unlimited
+		return
+	}
+	if *initReply.MaxUsage == time.Duration(0) {
+		sma.hangupChannel(uID, utils.EmptyString)
+		return
+	}
+	chanName, has := sma.amiChannelName(uID)
+	if !has {
+		return
+	}
+	if _, err := sma.amiConn.Call(map[string]string{
+		amiAction:  amiAbsoluteTimeout,
+		amiChannel: chanName,
+		"Timeout":  strconv.Itoa(int(math.Ceil(initReply.MaxUsage.Seconds()))),
+	}); err != nil {
+		sma.hangupChannel(uID,
+			fmt.Sprintf("<%s> error: %s setting the absolute timeout for channelID: %s",
+				utils.AsteriskAgent, err.Error(), uID))
+	}
+}
+
+// handleAMIHangup terminates the session, creating also the CDR if requested
+func (sma *AsteriskAgent) handleAMIHangup(uID string, cgrEv *utils.CGREvent, tsArgs *sessions.V1TerminateSessionArgs) {
+	var reply string
+	if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+		utils.SessionSv1TerminateSession,
+		tsArgs, &reply); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> Error: %s when attempting to terminate session for channelID: %s",
+			utils.AsteriskAgent, err.Error(), uID))
+	}
+	if sma.cgrCfg.AsteriskAgentCfg().CreateCDR {
+		if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+			utils.SessionSv1ProcessCDR,
+			cgrEv, &reply); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> Error: %s when attempting to process CDR for channelID: %s",
+				utils.AsteriskAgent, err.Error(), uID))
+		}
+	}
+}
+
+// forgetAMIChannel disconnects a channel not authorized
+// the channel is removed from cache first so its Hangup will not terminate the session
+func (sma *AsteriskAgent) forgetAMIChannel(uID, warnMsg string) {
+	sma.evCacheMux.Lock()
+	delete(sma.eventsCache, uID)
+	sma.evCacheMux.Unlock()
+	sma.hangupChannel(uID, warnMsg)
+	sma.evCacheMux.Lock()
+	delete(sma.amiChannels, uID)
+	sma.evCacheMux.Unlock()
+}
+
+// amiChannelName returns the name of the channel with the Uniqueid
+func (sma *AsteriskAgent) amiChannelName(uID string) (chanName string, has bool) {
+	sma.evCacheMux.RLock()
+	chanName, has = sma.amiChannels[uID]
+	sma.evCacheMux.RUnlock()
+	return
+}
+
+// setAMIChannelVar will set the value of a variable, disconnecting the channel on error
+func (sma *AsteriskAgent) setAMIChannelVar(uID string, vrblName, vrblVal string) (success bool) {
+	chanName, has := sma.amiChannelName(uID)
+	if !has { // hangup already received
+		return
+	}
+	if _, err := sma.amiConn.Call(map[string]string{
+		amiAction:  amiSetvar,
+		amiChannel: chanName,
+		"Variable": vrblName,
+		"Value":    vrblVal,
+	}); err != nil {
+		sma.forgetAMIChannel(uID,
+			fmt.Sprintf("<%s> error: <%s> setting <%s> for channelID: <%s>",
+				utils.AsteriskAgent, err.Error(), vrblName, uID))
+		return
+	}
+	return true
+}
+
+// hangupAMIChannel disconnects the channel with congestion cause
+func (sma *AsteriskAgent) hangupAMIChannel(uID string) {
+	chanName, has := sma.amiChannelName(uID)
+	if !has {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed disconnecting channel <%s>, err: %s",
+				utils.AsteriskAgent, uID, utils.ErrNotFound.Error()))
+		return
+	}
+	if _, err := sma.amiConn.Call(map[string]string{
+		amiAction:  AMIHangup,
+		amiChannel: chanName,
+		amiCause:   amiCongestionCause,
+	}); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed disconnecting channel <%s>, err: %s",
+				utils.AsteriskAgent, uID, err.Error()))
+	}
+}
+
+// amiActiveSessionIDs lists the channels active within Asterisk
+func (sma *AsteriskAgent) amiActiveSessionIDs(sessionIDs *[]*sessions.SessionID) (err error) {
+	var chans []map[string]string
+	if chans, err = sma.amiConn.Call(map[string]string{amiAction: amiCoreShowChannels}); err != nil {
+		return
+	}
+	if len(chans) == 0 {
+		return utils.ErrNoActiveSession
+	}
+	originHost := strings.Split(sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address, ":")[0]
+	sIDs := make([]*sessions.SessionID, len(chans))
+	for i, ch := range chans {
+		sIDs[i] = &sessions.SessionID{
+			OriginHost: originHost,
+			OriginID:   ch[amiUniqueID],
+		}
+	}
+	*sessionIDs = sIDs
+	return
+}
@@ -0,0 +1,249 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestReadAMIMessage(t *testing.T) {
+	rdr := textproto.NewReader(bufio.NewReader(strings.NewReader(
+		"Event: Newchannel\r\n" +
+			"Channel: PJSIP/1001-00000001\r\n" +
+			"Uniqueid: 1610000000.1\r\n" +
+			"ChanVariable: cgr_reqtype=*prepaid\r\n" +
+			"ChanVariable: cgr_flags=\r\n\r\n" +
+			"Response: Success\r\n" +
+			"ActionID: 1\r\n\r\n")))
+	exp := map[string]string{
+		amiEvent:                          AMINewchannel,
+		amiChannel:                        "PJSIP/1001-00000001",
+		amiUniqueID:                       "1610000000.1",
+		amiChanVariable + "(cgr_reqtype)": utils.MetaPrepaid,
+		amiChanVariable + "(cgr_flags)":   utils.EmptyString,
+	}
+	if rcv, err := readAMIMessage(rdr); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	exp = map[string]string{amiResponse: amiSuccess, amiActionID: "1"}
+	if rcv, err := readAMIMessage(rdr); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestAMIEventAsCGREvent(t *testing.T) {
+	ev := NewAMIEvent(map[string]string{
+		amiEvent:                          AMINewchannel,
+		amiChannel:                        "PJSIP/1001-00000001",
+		amiUniqueID:                       "1610000000.1",
+		amiLinkedID:                       "1610000000.1",
+		amiCallerIDNum:                    "1001",
+		amiExten:                          "1002",
+		amiTimestamp:                      "1610000000.500000",
+		amiChanVariable + "(cgr_reqtype)": utils.MetaPrepaid,
+		amiChanVariable + "(cgr_subject)": "1003",
+		amiChanVariable + "(" + utils.OptsSessionsTTL + ")": "30s",
+	}, "127.0.0.1", utils.EmptyString)
+	if !ev.IsOriginator() {
+		t.Error("Expected the originating channel")
+	}
+	setupTime := time.Unix(1610000000, 500000000)
+	cgrEv := ev.AsCGREvent()
+	exp := map[string]interface{}{
+		utils.EventName:    SMAAuthorization,
+		utils.OriginID:     "1610000000.1",
+		utils.RequestType:  utils.MetaPrepaid,
+		utils.Subject:      "1003",
+		utils.OriginHost:   "127.0.0.1",
+		utils.AccountField: "1001",
+		utils.Destination:  "1002",
+		utils.SetupTime:    setupTime,
+		utils.Source:       utils.AsteriskAgent,
+	}
+	if !reflect.DeepEqual(exp, cgrEv.Event) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(cgrEv.Event))
+	}
+	if !cgrEv.Time.Equal(setupTime) {
+		t.Errorf("Expected: %s, received: %s", setupTime, cgrEv.Time)
+	}
+	if cgrEv.APIOpts[utils.OptsSessionsTTL] != "30s" {
+		t.Errorf("Unexpected options: %s", utils.ToJSON(cgrEv.APIOpts))
+	}
+
+	ev = NewAMIEvent(map[string]string{
+		amiEvent:     AMIHangup,
+		amiUniqueID:  "1610000000.1",
+		amiCauseTxt:  "Normal Clearing",
+		amiTimestamp: "1610000010.500000",
+	}, "127.0.0.1", utils.EmptyString)
+	cgrEv.Event[utils.AnswerTime] = setupTime
+	if err := ev.UpdateCGREvent(cgrEv); err != nil {
+		t.Fatal(err)
+	}
+	if cgrEv.Event[utils.Usage] != 10*time.Second {
+		t.Errorf("Expected usage: %s, received: %v", 10*time.Second, cgrEv.Event[utils.Usage])
+	}
+	if cgrEv.Event[utils.DisconnectCause] != "Normal Clearing" {
+		t.Errorf("Unexpected disconnect cause: %v", cgrEv.Event[utils.DisconnectCause])
+	}
+}
+
+// testAMIServer emulates the Asterisk Manager Interface
+// the actions received are posted on the actions channel after being answered
+type testAMIServer struct {
+	conn    net.Conn
+	wrMux   sync.Mutex
+	actions chan map[string]string
+}
+
+func (srv *testAMIServer) write(t *testing.T, msg string) {
+	srv.wrMux.Lock()
+	defer srv.wrMux.Unlock()
+	if _, err := srv.conn.Write([]byte(msg)); err != nil {
+		t.Error(err)
+	}
+}
+
+func (srv *testAMIServer) serve(t *testing.T) {
+	srv.write(t, "Asterisk Call Manager/5.0.1\r\n")
+	rdr := textproto.NewReader(bufio.NewReader(srv.conn))
+	for {
+		action, err := readAMIMessage(rdr)
+		if err != nil {
+			return
+		}
+		srv.write(t, "Response: Success\r\nActionID: "+action[amiActionID]+"\r\n\r\n")
+		srv.actions <- action
+	}
+}
+
+func (srv *testAMIServer) action(t *testing.T, name string) map[string]string {
+	for {
+		select {
+		case action := <-srv.actions:
+			if action[amiAction] == name {
+				return action
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("action %s not received", name)
+		}
+	}
+}
+
+func TestAsteriskAgentAMI(t *testing.T) {
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	srv := &testAMIServer{actions: make(chan map[string]string, 10)}
+	go func() {
+		if srv.conn, err = l.Accept(); err == nil {
+			srv.serve(t)
+		}
+	}()
+
+	terminated := make(chan *sessions.V1TerminateSessionArgs, 1)
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1AuthorizeEvent: func(arg interface{}, rply interface{}) error {
+			rply.(*sessions.V1AuthorizeReply).MaxUsage = utils.DurationPointer(time.Hour)
+			return nil
+		},
+		utils.SessionSv1InitiateSession: func(arg interface{}, rply interface{}) error {
+			rply.(*sessions.V1InitSessionReply).MaxUsage = utils.DurationPointer(29500 * time.Millisecond)
+			return nil
+		},
+		utils.SessionSv1TerminateSession: func(arg interface{}, rply interface{}) error {
+			terminated <- arg.(*sessions.V1TerminateSessionArgs)
+			*rply.(*string) = utils.OK
+			return nil
+		},
+	}}
+	sSChan := make(chan rpcclient.ClientConnector, 1)
+	sSChan <- sS
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AsteriskAgentCfg().AsteriskConns[0].Address = l.Addr().String()
+	cfg.AsteriskAgentCfg().AsteriskConns[0].Type = utils.MetaAMI
+	cfg.AsteriskAgentCfg().SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): sSChan,
+	})
+	sma := NewAsteriskAgent(cfg, 0, connMgr)
+	stopChan := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() { errChan <- sma.ListenAndServe(stopChan) }()
+	defer func() {
+		close(stopChan)
+		if err := <-errChan; err != nil {
+			t.Error(err)
+		}
+	}()
+	if login := srv.action(t, amiLogin); login["Username"] != "cgrates" {
+		t.Errorf("Unexpected login: %s", utils.ToJSON(login))
+	}
+
+	// the called leg is not charged
+	srv.write(t, "Event: Newchannel\r\nChannel: PJSIP/1002-00000002\r\n"+
+		"Uniqueid: 1610000000.2\r\nLinkedid: 1610000000.1\r\nExten: s\r\n\r\n")
+	srv.write(t, "Event: Newchannel\r\nChannel: PJSIP/1001-00000001\r\n"+
+		"Uniqueid: 1610000000.1\r\nLinkedid: 1610000000.1\r\nCallerIDNum: 1001\r\nExten: 1002\r\n"+
+		"Timestamp: 1610000000.000000\r\n\r\n")
+	if setVar := srv.action(t, amiSetvar); setVar[amiChannel] != "PJSIP/1001-00000001" ||
+		setVar["Variable"] != CGRMaxSessionTime || setVar["Value"] != "3600000" {
+		t.Errorf("Unexpected Setvar: %s", utils.ToJSON(setVar))
+	}
+	srv.write(t, "Event: BridgeEnter\r\nChannel: PJSIP/1001-00000001\r\n"+
+		"Uniqueid: 1610000000.1\r\nLinkedid: 1610000000.1\r\nTimestamp: 1610000005.000000\r\n\r\n")
+	if timeout := srv.action(t, amiAbsoluteTimeout); timeout[amiChannel] != "PJSIP/1001-00000001" ||
+		timeout["Timeout"] != "30" {
+		t.Errorf("Unexpected AbsoluteTimeout: %s", utils.ToJSON(timeout))
+	}
+	srv.write(t, "Event: Hangup\r\nChannel: PJSIP/1001-00000001\r\n"+
+		"Uniqueid: 1610000000.1\r\nLinkedid: 1610000000.1\r\nCause: 16\r\nCause-txt: Normal Clearing\r\n"+
+		"Timestamp: 1610000015.000000\r\n\r\n")
+	select {
+	case args := <-terminated:
+		if args.CGREvent.Event[utils.OriginID] != "1610000000.1" ||
+			args.CGREvent.Event[utils.Usage] != 10*time.Second {
+			t.Errorf("Unexpected terminate event: %s", utils.ToJSON(args.CGREvent))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("session not terminated")
+	}
+	if _, has := sma.amiChannelName("1610000000.1"); has {
+		t.Error("Expected the channel removed from cache")
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	// MetaCHFStatusCode is used in the reply fields to overwrite the HTTP status code of the reply
+	MetaCHFStatusCode = "*chfStatusCode"
+
+	chfChargingDataPath = "/chargingdata"
+	chfUpdatePath       = "update"
+	chfReleasePath      = "release"
+)
+
+// NewCHFAgent is the constructor for CHFAgent
+func NewCHFAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
+	connMgr *engine.ConnManager) (ca *CHFAgent, err error) {
+	ca = &CHFAgent{cgrCfg: cgrCfg, fltrS: fltrS, connMgr: connMgr}
+	err = ca.initCHFServer()
+	return
+}
+
+// CHFAgent translates the 5G Nchf_ConvergedCharging requests towards CGRateS infrastructure
+type CHFAgent struct {
+	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
+	fltrS   *engine.FilterS   // connection towards FilterS
+	server  *http.Server
+	connMgr *engine.ConnManager
+}
+
+// initCHFServer instantiates the HTTP/2 server
+func (ca *CHFAgent) initCHFServer() (_ error) {
+	ca.server = &http.Server{Addr: ca.cgrCfg.CHFAgentCfg().Listen}
+	if !strings.HasSuffix(ca.cgrCfg.CHFAgentCfg().ListenNet, utils.TLSNoCaps) {
+		ca.server.Handler = h2c.NewHandler(ca, new(http2.Server)) // HTTP/2 over cleartext
+		return
+	}
+	cert, err := tls.LoadX509KeyPair(ca.cgrCfg.TLSCfg().ServerCerificate, ca.cgrCfg.TLSCfg().ServerKey)
+	if err != nil {
+		return err
+	}
+	ca.server.Handler = ca
+	ca.server.TLSConfig = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	return http2.ConfigureServer(ca.server, new(http2.Server))
+}
+
+// ListenAndServe will run the HTTP/2 handler doing also the connection to listen address
+func (ca *CHFAgent) ListenAndServe() (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
+		utils.CHFAgent, ca.cgrCfg.CHFAgentCfg().ListenNet, ca.cgrCfg.CHFAgentCfg().Listen))
+	if ca.server.TLSConfig != nil {
+		err = ca.server.ListenAndServeTLS(utils.EmptyString, utils.EmptyString)
+	} else {
+		err = ca.server.ListenAndServe()
+	}
+	if err == http.ErrServerClosed { // stopped by Shutdown
+		err = nil
+	}
+	return
+}
+
+// Reload will reinitialize the server
+// this is in order to monitor if we receive error on ListenAndServe
+func (ca *CHFAgent) Reload() (err error) {
+	return ca.initCHFServer()
+}
+
+// Shutdown stops the HTTP/2 server
+func (ca *CHFAgent) Shutdown() error {
+	return ca.server.Shutdown(context.Background())
+}
+
+// ServeHTTP implements http.Handler interface
+// routes the chargingdata create, update and release operations
+func (ca *CHFAgent) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rsrcPath := ca.cgrCfg.CHFAgentCfg().APIRoot + chfChargingDataPath
+	if !strings.HasPrefix(req.URL.Path, rsrcPath) {
+		chfWriteProblem(w, http.StatusNotFound, chfCauseResourceURINotFound, req.URL.Path)
+		return
+	}
+	if req.Method != http.MethodPost {
+		chfWriteProblem(w, http.StatusMethodNotAllowed, chfCauseInvalidMsgFormat, req.Method)
+		return
+	}
+	var op, ref string
+	switch pathItms := strings.Split(strings.Trim(req.URL.Path[len(rsrcPath):], utils.Slash), utils.Slash); {
+	case len(pathItms) == 1 && pathItms[0] == utils.EmptyString:
+		op, ref = utils.CHFCreate, utils.GenUUID() // never numeric so it stays a string within the replies
+	case len(pathItms) == 2 && pathItms[1] == chfUpdatePath:
+		op, ref = utils.CHFUpdate, pathItms[0]
+	case len(pathItms) == 2 && pathItms[1] == chfReleasePath:
+		op, ref = utils.CHFRelease, pathItms[0]
+	default:
+		chfWriteProblem(w, http.StatusNotFound, chfCauseResourceURINotFound, req.URL.Path)
+		return
+	}
+	chfReq := make(utils.MapStorage)
+	if err := json.NewDecoder(req.Body).Decode(&chfReq); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s decoding ChargingDataRequest from %s",
+				utils.CHFAgent, err.Error(), req.RemoteAddr))
+		chfWriteProblem(w, http.StatusBadRequest, chfCauseInvalidMsgFormat, err.Error())
+		return
+	}
+	rplyNM, err := ca.processRequest(chfReq, op, ref, req.RemoteAddr)
+	if err != nil {
+		chfWriteProblem(w, http.StatusInternalServerError, chfCauseSystemFailure, err.Error())
+		return
+	}
+	status := http.StatusOK
+	switch op {
+	case utils.CHFCreate:
+		status = http.StatusCreated
+		scheme := "http"
+		if req.TLS != nil {
+			scheme = "https"
+		}
+		w.Header().Set("Location", scheme+"://"+req.Host+rsrcPath+utils.Slash+ref)
+	case utils.CHFRelease:
+		status = http.StatusNoContent
+	}
+	if err = chfWriteReply(w, status, rplyNM); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s writing ChargingDataResponse: %s to %s",
+				utils.CHFAgent, err.Error(), rplyNM, req.RemoteAddr))
+	}
+}
+
+// processRequest passes the ChargingDataRequest through the request processors
+// returning the reply fields populated by them
+func (ca *CHFAgent) processRequest(chfReq utils.MapStorage, op, ref, rmtAddr string) (rplyNM *utils.OrderedNavigableMap, err error) {
+	reqVars := &utils.DataNode{
+		Type: utils.NMMapType,
+		Map: map[string]*utils.DataNode{
+			utils.MetaCmd:         utils.NewLeafNode(op),
+			utils.ChargingDataRef: utils.NewLeafNode(ref),
+			utils.RemoteHost:      utils.NewLeafNode(rmtAddr),
+		},
+	}
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	rplyNM = utils.NewOrderedNavigableMap() // share it among different processors
+	opts := utils.MapStorage{}
+	var processed bool
+	for _, reqProcessor := range ca.cgrCfg.CHFAgentCfg().RequestProcessors {
+		var lclProcessed bool
+		if lclProcessed, err = processRequest(
+			reqProcessor,
+			NewAgentRequest(
+				chfDataProvider{chfReq}, reqVars, cgrRplyNM, rplyNM,
+				opts, reqProcessor.Tenant,
+				ca.cgrCfg.GeneralCfg().DefaultTenant,
+				utils.FirstNonEmpty(ca.cgrCfg.CHFAgentCfg().Timezone,
+					ca.cgrCfg.GeneralCfg().DefaultTimezone),
+				ca.fltrS, nil),
+			utils.CHFAgent, ca.connMgr,
+			ca.cgrCfg.CHFAgentCfg().SessionSConns,
+			nil, ca.fltrS); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing request: %s from %s",
+					utils.CHFAgent, err.Error(), chfReq, rmtAddr))
+			return
+		}
+		processed = processed || lclProcessed
+		if lclProcessed && !reqProcessor.Flags.GetBool(utils.MetaContinue) {
+			break
+		}
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring request %s from %s",
+				utils.CHFAgent, chfReq, rmtAddr))
+		err = utils.ErrNotFound
+	}
+	return
+}
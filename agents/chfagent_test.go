@@ -0,0 +1,175 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestCHFAgent(t *testing.T) *CHFAgent {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CHFAgentCfg().RequestProcessors = []*config.RequestProcessor{
+		{
+			ID:      "ChargingDataCreate",
+			Filters: []string{"*string:~*vars.*cmd:Create"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "InvocationSequenceNumber", Path: "*rep.invocationSequenceNumber", Type: utils.MetaVariable,
+					Value:  config.NewRSRParsersMustCompile("~*req.invocationSequenceNumber", utils.InfieldSep),
+					Layout: utils.MetaInteger},
+				{Tag: "RatingGroup", Path: "*rep.multipleUnitInformation[0].ratingGroup", Type: utils.MetaVariable,
+					Value:  config.NewRSRParsersMustCompile("~*req.multipleUnitUsage[0].ratingGroup", utils.InfieldSep),
+					Layout: utils.MetaInteger},
+				{Tag: "GrantedTime", Path: "*rep.multipleUnitInformation[0].grantedUnit.time", Type: utils.MetaConstant,
+					Value:  config.NewRSRParsersMustCompile("3600", utils.InfieldSep),
+					Layout: utils.MetaFloat},
+				{Tag: "ResultCode", Path: "*rep.multipleUnitInformation[0].resultCode", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("SUCCESS", utils.InfieldSep)},
+				{Tag: "Subscriber", Path: "*rep.multipleUnitInformation[0].uPFID", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("0123", utils.InfieldSep)},
+				{Tag: "FinalUnit", Path: "*rep.multipleUnitInformation[0].finalUnitIndication", Type: utils.MetaConstant,
+					Value:  config.NewRSRParsersMustCompile("true", utils.InfieldSep),
+					Layout: utils.MetaBool},
+			},
+		},
+		{
+			ID:      "ChargingDataUpdate",
+			Filters: []string{"*string:~*vars.*cmd:Update"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "StatusCode", Path: "*rep." + MetaCHFStatusCode, Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("403", utils.InfieldSep)},
+				{Tag: "ChargingDataRef", Path: "*rep.chargingDataRef", Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*vars.ChargingDataRef", utils.InfieldSep)},
+			},
+		},
+		{
+			ID:      "ChargingDataRelease",
+			Filters: []string{"*string:~*vars.*cmd:Release"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		},
+	}
+	for _, rp := range cfg.CHFAgentCfg().RequestProcessors {
+		for _, fld := range rp.ReplyFields {
+			fld.ComputePath()
+		}
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	ca, err := NewCHFAgent(cfg, engine.NewFilterS(cfg, nil, dm), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca
+}
+
+func TestCHFAgentServeHTTP(t *testing.T) {
+	ca := newTestCHFAgent(t)
+	rsrc := "/nchf-convergedcharging/v3/chargingdata"
+
+	w := httptest.NewRecorder()
+	ca.ServeHTTP(w, httptest.NewRequest(http.MethodPost, rsrc,
+		strings.NewReader(`{"invocationSequenceNumber":0,"multipleUnitUsage":[{"ratingGroup":10,"requestedUnit":{"time":3600}}]}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Unexpected status: %d, body: %s", w.Code, w.Body.String())
+	}
+	loc := w.Header().Get("Location")
+	if !strings.HasPrefix(loc, "http://example.com"+rsrc+utils.Slash) {
+		t.Errorf("Unexpected location: <%s>", loc)
+	}
+	var rply map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{
+		"invocationSequenceNumber": 0.,
+		"multipleUnitInformation": []interface{}{
+			map[string]interface{}{
+				"ratingGroup":         10.,
+				"grantedUnit":         map[string]interface{}{"time": 3600.},
+				"resultCode":          "SUCCESS",
+				"uPFID":               "0123",
+				"finalUnitIndication": true,
+			},
+		},
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	ref := loc[strings.LastIndex(loc, utils.Slash)+1:]
+	w = httptest.NewRecorder()
+	ca.ServeHTTP(w, httptest.NewRequest(http.MethodPost, rsrc+utils.Slash+ref+"/update",
+		strings.NewReader(`{"invocationSequenceNumber":1}`)))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Unexpected status: %d, body: %s", w.Code, w.Body.String())
+	}
+	if w.Body.String() != `{"chargingDataRef":"`+ref+`"}`+"\n" {
+		t.Errorf("Unexpected body: %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ca.ServeHTTP(w, httptest.NewRequest(http.MethodPost, rsrc+utils.Slash+ref+"/release",
+		strings.NewReader(`{"invocationSequenceNumber":2}`)))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Errorf("Unexpected status: %d, body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCHFAgentServeHTTPErrors(t *testing.T) {
+	ca := newTestCHFAgent(t)
+	rsrc := "/nchf-convergedcharging/v3/chargingdata"
+	for _, tc := range []struct {
+		method, path, body string
+		status             int
+		cause              string
+	}{
+		{http.MethodGet, rsrc, `{}`, http.StatusMethodNotAllowed, chfCauseInvalidMsgFormat},
+		{http.MethodPost, "/nchf-convergedcharging/v1/chargingdata", `{}`, http.StatusNotFound, chfCauseResourceURINotFound},
+		{http.MethodPost, rsrc + "/ref/cancel", `{}`, http.StatusNotFound, chfCauseResourceURINotFound},
+		{http.MethodPost, rsrc, `{`, http.StatusBadRequest, chfCauseInvalidMsgFormat},
+	} {
+		w := httptest.NewRecorder()
+		ca.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+		var prblm chfProblemDetails
+		if w.Code != tc.status {
+			t.Errorf("Expected status %d for %s %s, received: %d", tc.status, tc.method, tc.path, w.Code)
+		} else if err := json.Unmarshal(w.Body.Bytes(), &prblm); err != nil {
+			t.Error(err)
+		} else if prblm.Status != tc.status || prblm.Cause != tc.cause {
+			t.Errorf("Unexpected problem details: %+v", prblm)
+		}
+	}
+
+	ca.cgrCfg.CHFAgentCfg().RequestProcessors = nil // nothing to process the request
+	w := httptest.NewRecorder()
+	ca.ServeHTTP(w, httptest.NewRequest(http.MethodPost, rsrc, strings.NewReader(`{}`)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Unexpected status: %d, body: %s", w.Code, w.Body.String())
+	}
+}
@@ -21,6 +21,7 @@ package agents
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"net"
 	"strings"
 	"sync"
@@ -56,6 +57,9 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		raa:     make(map[string]chan *diam.Message),
 		dpa:     make(map[string]chan *diam.Message),
 		peers:   make(map[string]diam.Conn),
+
+		relayPeers: newDiamPeers(cgrCfg.DiameterAgentCfg()),
+		hopByHopID: rand.Uint32(),
 	}
 	dictsPath := cgrCfg.DiameterAgentCfg().DictionariesPath
 	if len(dictsPath) != 0 {
@@ -94,6 +98,9 @@ type DiameterAgent struct {
 	peers    map[string]diam.Conn // peer index by OriginHost;OriginRealm
 	dpa      map[string]chan *diam.Message
 	dpaLck   sync.RWMutex
+
+	relayPeers map[string]*diamPeer // outbound peers indexed on ID
+	hopByHopID uint32               // last Hop-by-Hop Identifier used when relaying
 }
 
 // ListenAndServe is called when DiameterAgent is started, usually from within cmd/cgr-engine
@@ -111,6 +118,9 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 		utils.FirstNonEmpty(srv.Addr, ":3868")); err != nil {
 		return
 	}
+	for _, peer := range da.relayPeers {
+		go da.connectPeer(peer, stopChan)
+	}
 	errChan := make(chan error)
 	go func() {
 		errChan <- srv.Serve(lsn)
@@ -181,6 +191,10 @@ func (da *DiameterAgent) handleMessageAsync(c diam.Conn, m *diam.Message) {
 
 // handleALL is the handler of all messages coming in via Diameter
 func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
+	if route := da.relayRoute(m); route != nil {
+		da.relayMessage(c, m, route)
+		return
+	}
 	dApp, err := m.Dictionary().App(m.Header.ApplicationID)
 	if err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> decoding app: %d, err: %s",
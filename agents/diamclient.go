@@ -29,6 +29,7 @@ import (
 	"github.com/fiorix/go-diameter/v4/diam"
 	"github.com/fiorix/go-diameter/v4/diam/avp"
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
 	"github.com/fiorix/go-diameter/v4/diam/sm"
 )
 
@@ -36,6 +37,17 @@ var dictOnce sync.Once
 
 func NewDiameterClient(addr, originHost, originRealm string, vendorId int, productName string,
 	firmwareRev int, dictsDir string, network string) (dc *DiameterClient, err error) {
+	return newDiameterClient(addr, originHost, originRealm, vendorId, productName,
+		firmwareRev, dictsDir, network, 5*time.Second, nil, false)
+}
+
+// newDiameterClient connects to the diameter peer, advertising the given applications within CER
+// with no applications it will advertise the credit control one
+// relay clients only handle the answers to their own requests, the requests of the peer
+// (ie: RAR, ASR) are answered with DIAMETER_UNABLE_TO_DELIVER since they are not routed back
+func newDiameterClient(addr, originHost, originRealm string, vendorId int, productName string,
+	firmwareRev int, dictsDir string, network string, watchdogInterval time.Duration,
+	appIDs []uint32, relay bool) (dc *DiameterClient, err error) {
 	cfg := &sm.Settings{
 		OriginHost:       datatype.DiameterIdentity(originHost),
 		OriginRealm:      datatype.DiameterIdentity(originRealm),
@@ -70,11 +82,7 @@ func NewDiameterClient(addr, originHost, originRealm string, vendorId int, produ
 		MaxRetransmits:     3,
 		RetransmitInterval: time.Second,
 		EnableWatchdog:     true,
-		WatchdogInterval:   5 * time.Second,
-		AuthApplicationID: []*diam.AVP{
-			// Advertise support for credit control application
-			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4)), // RFC 4006
-		},
+		WatchdogInterval:   watchdogInterval,
 	}
 	if len(dictsDir) != 0 {
 		dictOnce.Do(func() { err = loadDictionaries(dictsDir, "DiameterClient") })
@@ -82,11 +90,29 @@ func NewDiameterClient(addr, originHost, originRealm string, vendorId int, produ
 			return nil, err
 		}
 	}
+	if len(appIDs) == 0 {
+		// Advertise support for credit control application
+		appIDs = []uint32{4} // RFC 4006
+	}
+	for _, appID := range appIDs {
+		if dApp, err := dict.Default.App(appID); err == nil && dApp.Type == "acct" {
+			cli.AcctApplicationID = append(cli.AcctApplicationID,
+				diam.NewAVP(avp.AcctApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID)))
+			continue
+		}
+		cli.AuthApplicationID = append(cli.AuthApplicationID,
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID)))
+	}
 	conn, err := cli.DialNetwork(network, addr)
 	if err != nil {
 		return nil, err
 	}
-	dc = &DiameterClient{conn: conn, handlers: dSM, received: make(chan *diam.Message)}
+	dc = &DiameterClient{conn: conn, handlers: dSM,
+		originHost: originHost, originRealm: originRealm,
+		pending: make(map[uint32]chan *diam.Message)}
+	if !relay {
+		dc.received = make(chan *diam.Message)
+	}
 	dSM.HandleFunc("ALL", dc.handleALL)
 	return dc, nil
 }
@@ -94,7 +120,13 @@ func NewDiameterClient(addr, originHost, originRealm string, vendorId int, produ
 type DiameterClient struct {
 	conn     diam.Conn
 	handlers diam.Handler
-	received chan *diam.Message
+	received chan *diam.Message // nil for relay clients
+
+	originHost  string
+	originRealm string
+
+	pendingLck sync.Mutex
+	pending    map[uint32]chan *diam.Message // answers waited for, indexed on Hop-by-Hop Identifier
 }
 
 func (dc *DiameterClient) SendMessage(m *diam.Message) error {
@@ -102,9 +134,60 @@ func (dc *DiameterClient) SendMessage(m *diam.Message) error {
 	return err
 }
 
+// SendRequest sends the request and waits for its answer, correlated on the Hop-by-Hop Identifier
+func (dc *DiameterClient) SendRequest(m *diam.Message, rplyTimeout time.Duration) (a *diam.Message, err error) {
+	ansChan := make(chan *diam.Message, 1)
+	dc.pendingLck.Lock()
+	dc.pending[m.Header.HopByHopID] = ansChan
+	dc.pendingLck.Unlock()
+	defer func() {
+		dc.pendingLck.Lock()
+		delete(dc.pending, m.Header.HopByHopID)
+		dc.pendingLck.Unlock()
+	}()
+	if err = dc.SendMessage(m); err != nil {
+		return
+	}
+	select {
+	case a = <-ansChan:
+		return
+	case <-dc.conn.(diam.CloseNotifier).CloseNotify():
+		return nil, utils.ErrDisconnected
+	case <-time.After(rplyTimeout):
+		return nil, utils.ErrReplyTimeout
+	}
+}
+
+// CloseNotify returns a channel that is closed when the connection towards the peer is lost
+func (dc *DiameterClient) CloseNotify() <-chan struct{} {
+	return dc.conn.(diam.CloseNotifier).CloseNotify()
+}
+
+// Close closes the connection towards the peer
+func (dc *DiameterClient) Close() {
+	dc.conn.Close()
+}
+
 func (dc *DiameterClient) handleALL(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		dc.pendingLck.Lock()
+		ansChan, has := dc.pending[m.Header.HopByHopID]
+		dc.pendingLck.Unlock()
+		if has {
+			ansChan <- m
+			return
+		}
+	}
+	if dc.received == nil &&
+		m.Header.CommandFlags&diam.RequestFlag != 0 { // relay clients are not routing back the requests of the peer
+		utils.Logger.Warning(fmt.Sprintf("<DiameterClient> Cannot deliver request from %s:\n%s", c.RemoteAddr(), m))
+		writeOnConn(c, diamRelayErr(m, diam.UnableToDeliver, dc.originHost, dc.originRealm))
+		return
+	}
 	utils.Logger.Warning(fmt.Sprintf("<DiameterClient> Received unexpected message from %s:\n%s", c.RemoteAddr(), m))
-	dc.received <- m
+	if dc.received != nil {
+		dc.received <- m
+	}
 }
 
 // Returns the message out of received buffer
@@ -0,0 +1,219 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// newDiamPeers builds the outbound peers out of config
+// each peer will advertise within CER the applications of the routes it is part of
+func newDiamPeers(daCfg *config.DiameterAgentCfg) (peers map[string]*diamPeer) {
+	peers = make(map[string]*diamPeer)
+	for _, peerCfg := range daCfg.Peers {
+		peers[peerCfg.ID] = &diamPeer{cfg: peerCfg}
+	}
+	for _, route := range daCfg.Routes {
+		for _, peerID := range route.PeerIDs {
+			peer, has := peers[peerID]
+			if !has {
+				continue
+			}
+			for _, appID := range route.ApplicationIDs {
+				var known bool
+				for _, peerAppID := range peer.appIDs {
+					if peerAppID == uint32(appID) {
+						known = true
+						break
+					}
+				}
+				if !known {
+					peer.appIDs = append(peer.appIDs, uint32(appID))
+				}
+			}
+		}
+	}
+	return
+}
+
+// diamPeer is an outbound peer the requests are relayed to
+type diamPeer struct {
+	cfg    *config.DiameterPeerCfg
+	appIDs []uint32 // applications advertised within CER
+
+	sync.RWMutex
+	dc *DiameterClient // nil while not connected
+}
+
+// client returns the connection towards the peer, nil if the peer is down
+func (p *diamPeer) client() (dc *DiameterClient) {
+	p.RLock()
+	dc = p.dc
+	p.RUnlock()
+	return
+}
+
+func (p *diamPeer) setClient(dc *DiameterClient) {
+	p.Lock()
+	p.dc = dc
+	p.Unlock()
+}
+
+// connectPeer keeps the peer connected until stopChan is closed
+// the connection is monitored with DWR/DWA and recreated after reconnect_interval once lost
+func (da *DiameterAgent) connectPeer(p *diamPeer, stopChan <-chan struct{}) {
+	daCfg := da.cgrCfg.DiameterAgentCfg()
+	for {
+		dc, err := newDiameterClient(p.cfg.Address, daCfg.OriginHost, daCfg.OriginRealm,
+			daCfg.VendorID, daCfg.ProductName, utils.DiameterFirmwareRevision, utils.EmptyString,
+			p.cfg.Transport, p.cfg.WatchdogInterval, p.appIDs, true)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> could not connect to peer <%s> at <%s>, err: %s",
+				utils.DiameterAgent, p.cfg.ID, p.cfg.Address, err.Error()))
+		} else {
+			utils.Logger.Info(fmt.Sprintf("<%s> connected to peer <%s> at <%s>",
+				utils.DiameterAgent, p.cfg.ID, p.cfg.Address))
+			p.setClient(dc)
+			select {
+			case <-dc.CloseNotify():
+				p.setClient(nil)
+				utils.Logger.Warning(fmt.Sprintf("<%s> lost connection to peer <%s> at <%s>",
+					utils.DiameterAgent, p.cfg.ID, p.cfg.Address))
+			case <-stopChan:
+				p.setClient(nil)
+				dc.Close()
+				return
+			}
+		}
+		select {
+		case <-time.After(p.cfg.ReconnectInterval):
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+// relayRoute returns the route of the request, nil if the request is to be processed locally
+// requests addressed to our own host or realm are never relayed
+func (da *DiameterAgent) relayRoute(m *diam.Message) *config.DiameterRouteCfg {
+	daCfg := da.cgrCfg.DiameterAgentCfg()
+	if len(daCfg.Routes) == 0 ||
+		m.Header.CommandFlags&diam.RequestFlag == 0 {
+		return nil
+	}
+	if dstHostAVP, err := m.FindAVP(avp.DestinationHost, 0); err == nil {
+		if dstHost, _ := diamAVPAsString(dstHostAVP); dstHost == daCfg.OriginHost {
+			return nil
+		}
+	}
+	dstRealmAVP, err := m.FindAVP(avp.DestinationRealm, 0)
+	if err != nil {
+		return nil
+	}
+	dstRealm, _ := diamAVPAsString(dstRealmAVP)
+	if dstRealm == utils.EmptyString ||
+		dstRealm == daCfg.OriginRealm {
+		return nil
+	}
+	for _, route := range daCfg.Routes {
+		if route.Realm != utils.MetaAny &&
+			route.Realm != dstRealm {
+			continue
+		}
+		if len(route.ApplicationIDs) == 0 {
+			return route
+		}
+		for _, appID := range route.ApplicationIDs {
+			if uint32(appID) == m.Header.ApplicationID {
+				return route
+			}
+		}
+	}
+	return nil
+}
+
+// relayMessage forwards the request to the peers of the route, in order
+// it fails over to the next peer when one is down or does not answer within relay_timeout
+func (da *DiameterAgent) relayMessage(c diam.Conn, m *diam.Message, route *config.DiameterRouteCfg) {
+	daCfg := da.cgrCfg.DiameterAgentCfg()
+	rrAVPs, _ := m.FindAVPsWithPath([]interface{}{avp.RouteRecord}, 0)
+	for _, rrAVP := range rrAVPs {
+		if rr, _ := diamAVPAsString(rrAVP); rr == daCfg.OriginHost {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> loop detected relaying message: %s", utils.DiameterAgent, m))
+			writeOnConn(c, da.relayErr(m, diam.LoopDetected))
+			return
+		}
+	}
+	m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity(daCfg.OriginHost))
+	hopByHopID := m.Header.HopByHopID // restored on the answer
+	for _, peerID := range route.PeerIDs {
+		peer, has := da.relayPeers[peerID]
+		if !has { // peers are only created on start
+			continue
+		}
+		dc := peer.client()
+		if dc == nil {
+			continue
+		}
+		m.Header.HopByHopID = atomic.AddUint32(&da.hopByHopID, 1)
+		a, err := dc.SendRequest(m, daCfg.RelayTimeout)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed relaying message to peer <%s>, err: %s",
+					utils.DiameterAgent, peerID, err.Error()))
+			m.Header.CommandFlags |= diam.RetransmittedFlag // the next peer might receive a duplicate
+			continue
+		}
+		a.Header.HopByHopID = hopByHopID
+		writeOnConn(c, a)
+		return
+	}
+	m.Header.HopByHopID = hopByHopID
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> no peer available relaying message: %s", utils.DiameterAgent, m))
+	writeOnConn(c, da.relayErr(m, diam.UnableToDeliver))
+}
+
+// relayErr builds the protocol error answer for a request we could not relay
+func (da *DiameterAgent) relayErr(m *diam.Message, resCode uint32) *diam.Message {
+	return diamRelayErr(m, resCode, da.cgrCfg.DiameterAgentCfg().OriginHost,
+		da.cgrCfg.DiameterAgentCfg().OriginRealm)
+}
+
+// diamRelayErr builds the protocol error answer of the relay, originated by our host
+func diamRelayErr(m *diam.Message, resCode uint32, originHost, originRealm string) (a *diam.Message) {
+	a = diamBareErr(m, resCode)
+	a.Header.CommandFlags |= m.Header.CommandFlags & diam.ProxiableFlag
+	if sessIDAVP, err := m.FindAVP(avp.SessionID, 0); err == nil {
+		a.InsertAVP(sessIDAVP)
+	}
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(originHost))
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(originRealm))
+	return
+}
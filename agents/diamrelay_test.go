@@ -0,0 +1,274 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+// testDiamConn records the messages written by the agent
+type testDiamConn struct {
+	diam.Conn
+	written chan []byte
+}
+
+func (c *testDiamConn) Write(b []byte) (int, error) {
+	c.written <- append([]byte{}, b...)
+	return len(b), nil
+}
+
+func (c *testDiamConn) RemoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 3868}
+}
+
+func (c *testDiamConn) answer(t *testing.T) *diam.Message {
+	select {
+	case b := <-c.written:
+		a, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	case <-time.After(time.Second):
+		t.Fatal("no answer written")
+	}
+	return nil
+}
+
+// testDiamPeer starts a diameter server handling the CCRs
+func testDiamPeer(t *testing.T, originHost string, ccrHandler diam.HandlerFunc) string {
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	dSM := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(originHost),
+		OriginRealm:      datatype.DiameterIdentity("roaming.org"),
+		VendorID:         0,
+		ProductName:      datatype.UTF8String(originHost),
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+		FirmwareRevision: 1,
+	})
+	dSM.HandleFunc("CCR", ccrHandler)
+	go diam.Serve(l, dSM)
+	return l.Addr().String()
+}
+
+func testDiamCCR(dstRealm string) (m *diam.Message) {
+	m = diam.NewRequest(diam.CreditControl, 4, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("session1"))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("client"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("client.org"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity(dstRealm))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(1))
+	m.NewAVP(avp.CCRequestNumber, avp.Mbit, 0, datatype.Unsigned32(0))
+	return
+}
+
+func testDiamResultCode(t *testing.T, a *diam.Message) string {
+	rcAVP, err := a.FindAVP(avp.ResultCode, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := diamAVPAsString(rcAVP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rc
+}
+
+func TestDiamRelayRoute(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().Routes = []*config.DiameterRouteCfg{
+		{Realm: "roaming.org", ApplicationIDs: []int{4}, PeerIDs: []string{"dra1"}},
+		{Realm: utils.MetaAny, PeerIDs: []string{"dra2"}},
+	}
+	da := &DiameterAgent{cgrCfg: cfg}
+	if route := da.relayRoute(testDiamCCR("roaming.org")); route == nil ||
+		!reflect.DeepEqual(route.PeerIDs, []string{"dra1"}) {
+		t.Errorf("Unexpected route: %s", utils.ToJSON(route))
+	}
+	m := testDiamCCR("roaming.org")
+	m.Header.ApplicationID = 16777238
+	if route := da.relayRoute(m); route == nil ||
+		!reflect.DeepEqual(route.PeerIDs, []string{"dra2"}) {
+		t.Errorf("Unexpected route: %s", utils.ToJSON(route))
+	}
+	if route := da.relayRoute(testDiamCCR("cgrates.org")); route != nil {
+		t.Errorf("Expected local processing, received route: %s", utils.ToJSON(route))
+	}
+	m = testDiamCCR("roaming.org")
+	m.NewAVP(avp.DestinationHost, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DA"))
+	if route := da.relayRoute(m); route != nil {
+		t.Errorf("Expected local processing, received route: %s", utils.ToJSON(route))
+	}
+	cfg.DiameterAgentCfg().Routes = nil
+	if route := da.relayRoute(testDiamCCR("roaming.org")); route != nil {
+		t.Errorf("Expected local processing, received route: %s", utils.ToJSON(route))
+	}
+}
+
+func TestDiamRelayFailover(t *testing.T) {
+	silentAddr := testDiamPeer(t, "silent", func(c diam.Conn, m *diam.Message) {})
+	relayed := make(chan *diam.Message, 1)
+	answeringAddr := testDiamPeer(t, "answering", func(c diam.Conn, m *diam.Message) {
+		relayed <- m
+		a := m.Answer(diam.Success)
+		a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("answering"))
+		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("roaming.org"))
+		a.WriteTo(c)
+	})
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	downAddr := l.Addr().String()
+	l.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	daCfg := cfg.DiameterAgentCfg()
+	daCfg.DictionariesPath = utils.EmptyString
+	daCfg.OriginHost = "CGR-DRA"
+	daCfg.RelayTimeout = 100 * time.Millisecond
+	for _, peer := range []struct{ id, addr string }{
+		{"down", downAddr}, {"silent", silentAddr}, {"answering", answeringAddr}} {
+		peerCfg := config.NewDefaultDiameterPeerCfg()
+		peerCfg.ID = peer.id
+		peerCfg.Address = peer.addr
+		peerCfg.ReconnectInterval = 50 * time.Millisecond
+		daCfg.Peers = append(daCfg.Peers, peerCfg)
+	}
+	daCfg.Routes = []*config.DiameterRouteCfg{
+		{Realm: "roaming.org", ApplicationIDs: []int{4}, PeerIDs: []string{"down", "silent", "answering"}},
+		{Realm: "unreachable.org", PeerIDs: []string{"down"}},
+	}
+	da, err := NewDiameterAgent(cfg, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(da.relayPeers["silent"].appIDs, []uint32{4}) {
+		t.Errorf("Unexpected advertised applications: %+v", da.relayPeers["silent"].appIDs)
+	}
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	for _, peer := range da.relayPeers {
+		go da.connectPeer(peer, stopChan)
+	}
+	for i := 0; i < 50; i++ {
+		if da.relayPeers["silent"].client() != nil &&
+			da.relayPeers["answering"].client() != nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if da.relayPeers["answering"].client() == nil {
+		t.Fatal("peers not connected")
+	}
+
+	c := &testDiamConn{written: make(chan []byte, 1)}
+	m := testDiamCCR("roaming.org")
+	hopByHopID := m.Header.HopByHopID
+	da.handleMessage(c, m)
+	a := c.answer(t)
+	if rc := testDiamResultCode(t, a); rc != "2001" {
+		t.Errorf("Expected result code 2001, received: %s", rc)
+	}
+	if a.Header.HopByHopID != hopByHopID {
+		t.Errorf("Expected Hop-by-Hop Identifier %d, received: %d", hopByHopID, a.Header.HopByHopID)
+	}
+	select {
+	case rcv := <-relayed:
+		if rcv.Header.CommandFlags&diam.RetransmittedFlag == 0 {
+			t.Error("Expected the T flag on the failed over request")
+		}
+		if rrAVP, err := rcv.FindAVP(avp.RouteRecord, 0); err != nil {
+			t.Error(err)
+		} else if rr, _ := diamAVPAsString(rrAVP); rr != "CGR-DRA" {
+			t.Errorf("Expected Route-Record CGR-DRA, received: %s", rr)
+		}
+	default:
+		t.Error("request not relayed to the answering peer")
+	}
+
+	m = testDiamCCR("roaming.org")
+	m.NewAVP(avp.RouteRecord, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DRA"))
+	da.handleMessage(c, m)
+	if rc := testDiamResultCode(t, c.answer(t)); rc != "3005" {
+		t.Errorf("Expected result code 3005, received: %s", rc)
+	}
+
+	da.handleMessage(c, testDiamCCR("unreachable.org"))
+	a = c.answer(t)
+	if rc := testDiamResultCode(t, a); rc != "3002" {
+		t.Errorf("Expected result code 3002, received: %s", rc)
+	}
+	if a.Header.CommandFlags&diam.ErrorFlag == 0 {
+		t.Error("Expected the E flag on the protocol error")
+	}
+	if ohAVP, err := a.FindAVP(avp.OriginHost, 0); err != nil {
+		t.Error(err)
+	} else if oh, _ := diamAVPAsString(ohAVP); oh != "CGR-DRA" {
+		t.Errorf("Expected Origin-Host CGR-DRA, received: %s", oh)
+	}
+}
+
+func TestDiamRelayClientPeerRequest(t *testing.T) {
+	dc := &DiameterClient{originHost: "CGR-DRA", originRealm: "cgrates.org",
+		pending: make(map[uint32]chan *diam.Message)}
+	c := &testDiamConn{written: make(chan []byte, 1)}
+	m := diam.NewRequest(diam.ReAuth, 4, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("session1"))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("answering"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("roaming.org"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity("client.org"))
+	m.NewAVP(avp.DestinationHost, avp.Mbit, 0, datatype.DiameterIdentity("client"))
+	dc.handleALL(c, m)
+	a := c.answer(t)
+	if a.Header.CommandCode != diam.ReAuth ||
+		a.Header.CommandFlags&diam.RequestFlag != 0 {
+		t.Errorf("Expected RAA, received: %s", a)
+	}
+	if rc := testDiamResultCode(t, a); rc != "3002" {
+		t.Errorf("Expected result code 3002, received: %s", rc)
+	}
+	if sIDAVP, err := a.FindAVP(avp.SessionID, 0); err != nil {
+		t.Error(err)
+	} else if sID, _ := diamAVPAsString(sIDAVP); sID != "session1" {
+		t.Errorf("Expected Session-Id session1, received: %s", sID)
+	}
+	if ohAVP, err := a.FindAVP(avp.OriginHost, 0); err != nil {
+		t.Error(err)
+	} else if oh, _ := diamAVPAsString(ohAVP); oh != "CGR-DRA" {
+		t.Errorf("Expected Origin-Host CGR-DRA, received: %s", oh)
+	}
+}
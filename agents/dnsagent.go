@@ -19,8 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/cgrates/cgrates/config"
@@ -39,14 +43,19 @@ func NewDNSAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
 
 // DNSAgent translates DNS requests towards CGRateS infrastructure
 type DNSAgent struct {
-	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
-	fltrS   *engine.FilterS   // connection towards FilterS
-	server  *dns.Server
-	connMgr *engine.ConnManager
+	cgrCfg    *config.CGRConfig // loaded CGRateS configuration
+	fltrS     *engine.FilterS   // connection towards FilterS
+	server    *dns.Server
+	dohServer *http.Server // DNS-over-HTTPS server, replacing server for the https listen_net
+	connMgr   *engine.ConnManager
 }
 
 // initDNSServer instantiates the DNS server
 func (da *DNSAgent) initDNSServer() (_ error) {
+	da.server, da.dohServer = nil, nil
+	if da.cgrCfg.DNSAgentCfg().ListenNet == dnsDoHNet {
+		return da.initDoHServer()
+	}
 	da.server = &dns.Server{
 		Addr: da.cgrCfg.DNSAgentCfg().Listen,
 		Net:  da.cgrCfg.DNSAgentCfg().ListenNet,
@@ -67,11 +76,35 @@ func (da *DNSAgent) initDNSServer() (_ error) {
 	return
 }
 
+// initDoHServer instantiates the DNS-over-HTTPS server using the certificate from tls section
+func (da *DNSAgent) initDoHServer() (_ error) {
+	cert, err := tls.LoadX509KeyPair(da.cgrCfg.TLSCfg().ServerCerificate, da.cgrCfg.TLSCfg().ServerKey)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(dnsDoHPath, da.handleDoH)
+	da.dohServer = &http.Server{
+		Addr:    da.cgrCfg.DNSAgentCfg().Listen,
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		},
+	}
+	return
+}
+
 // ListenAndServe will run the DNS handler doing also the connection to listen address
 func (da *DNSAgent) ListenAndServe() (err error) {
 	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
 		utils.DNSAgent, da.cgrCfg.DNSAgentCfg().ListenNet, da.cgrCfg.DNSAgentCfg().Listen))
-	return da.server.ListenAndServe()
+	if da.dohServer == nil {
+		return da.server.ListenAndServe()
+	}
+	if err = da.dohServer.ListenAndServeTLS(utils.EmptyString, utils.EmptyString); err == http.ErrServerClosed { // stopped by Shutdown
+		err = nil
+	}
+	return
 }
 
 // Reload will reinitialize the server
@@ -106,9 +139,44 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 
 // Shutdown stops the DNS server
 func (da *DNSAgent) Shutdown() error {
+	if da.dohServer != nil {
+		return da.dohServer.Shutdown(context.Background())
+	}
 	return da.server.Shutdown()
 }
 
+// handleDoH is the entry point of the DNS-over-HTTPS requests
+// the query is received either base64url encoded within the dns parameter of GET or as body of POST
+func (da *DNSAgent) handleDoH(w http.ResponseWriter, req *http.Request) {
+	var msg []byte
+	var err error
+	switch req.Method {
+	case http.MethodGet:
+		msg, err = base64.RawURLEncoding.DecodeString(req.URL.Query().Get(dnsDoHParam))
+	case http.MethodPost:
+		if req.Header.Get("Content-Type") != dnsDoHContentType {
+			http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+			return
+		}
+		msg, err = io.ReadAll(io.LimitReader(req.Body, dns.MaxMsgSize))
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	dnsReq := new(dns.Msg)
+	if err == nil {
+		err = dnsReq.Unpack(msg)
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s decoding DNS-over-HTTPS message from %s",
+				utils.DNSAgent, err.Error(), req.RemoteAddr))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	da.handleMessage(newDoHResponseWriter(w, req), dnsReq) // synchronous since the reply is written before returning
+}
+
 // handleMessage is the entry point of all DNS requests
 // requests are reaching here asynchronously
 func (da *DNSAgent) handleQuestion(dnsDP utils.DataProvider, rply *dns.Msg, q *dns.Question, rmtAddr string) (processed bool, err error) {
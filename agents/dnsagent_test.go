@@ -0,0 +1,214 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/miekg/dns"
+)
+
+// testDNSAgentServe starts the agent answering SRV and TXT queries and returns its address
+func testDNSAgentServe(t *testing.T, listenNet string) string {
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.TLSCfg().ServerCerificate = "../data/tls/server.crt"
+	cfg.TLSCfg().ServerKey = "../data/tls/server.key"
+	cfg.DNSAgentCfg().Listen = addr
+	cfg.DNSAgentCfg().ListenNet = listenNet
+	cfg.DNSAgentCfg().RequestProcessors = []*config.RequestProcessor{
+		{
+			ID:      "SRV",
+			Filters: []string{"*string:~*vars.QueryType:SRV"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "Priority", Path: "*rep.Answer.Priority", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("10", utils.InfieldSep)},
+				{Tag: "Weight", Path: "*rep.Answer.Weight", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("60", utils.InfieldSep)},
+				{Tag: "Port", Path: "*rep.Answer.Port", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("5060", utils.InfieldSep)},
+				{Tag: "Target", Path: "*rep.Answer.Target", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("sip1.cgrates.org.", utils.InfieldSep)},
+			},
+		},
+		{
+			ID:      "TXT",
+			Filters: []string{"*string:~*vars.QueryType:TXT"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "Txt", Path: "*rep.Answer.Txt", Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("https://certs.cgrates.org/sp.pem", utils.InfieldSep)},
+			},
+		},
+	}
+	for _, rp := range cfg.DNSAgentCfg().RequestProcessors {
+		for _, fld := range rp.ReplyFields {
+			fld.ComputePath()
+		}
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	da, err := NewDNSAgent(cfg, engine.NewFilterS(cfg, nil, dm), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errChan := make(chan error, 1)
+	go func() { errChan <- da.ListenAndServe() }()
+	t.Cleanup(func() {
+		if err := da.Shutdown(); err != nil {
+			t.Error(err)
+		}
+		if err := <-errChan; err != nil {
+			t.Error(err)
+		}
+	})
+	for i := 0; i < 50; i++ {
+		var conn net.Conn
+		if conn, err = net.Dial(utils.TCP, addr); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func testDNSCheckAnswers(t *testing.T, rply *dns.Msg, qType uint16) {
+	if rply.Rcode != dns.RcodeSuccess {
+		t.Fatalf("expecting: <%s>, received: <%s>", dns.RcodeToString[dns.RcodeSuccess], dns.RcodeToString[rply.Rcode])
+	}
+	if len(rply.Answer) != 1 {
+		t.Fatalf("expecting one answer, received: %s", utils.ToJSON(rply.Answer))
+	}
+	switch qType {
+	case dns.TypeSRV:
+		if srv, canCast := rply.Answer[0].(*dns.SRV); !canCast {
+			t.Errorf("expecting: <*dns.SRV>, received: <%T>", rply.Answer[0])
+		} else if srv.Priority != 10 || srv.Weight != 60 ||
+			srv.Port != 5060 || srv.Target != "sip1.cgrates.org." {
+			t.Errorf("unexpected answer: %s", srv)
+		}
+	case dns.TypeTXT:
+		if txt, canCast := rply.Answer[0].(*dns.TXT); !canCast {
+			t.Errorf("expecting: <*dns.TXT>, received: <%T>", rply.Answer[0])
+		} else if len(txt.Txt) != 1 || txt.Txt[0] != "https://certs.cgrates.org/sp.pem" {
+			t.Errorf("unexpected answer: %s", txt)
+		}
+	}
+}
+
+func TestDNSAgentServeTLS(t *testing.T) {
+	addr := testDNSAgentServe(t, "tcp-tls")
+	clnt := &dns.Client{
+		Net:       "tcp-tls",
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	for _, qType := range []uint16{dns.TypeSRV, dns.TypeTXT} {
+		m := new(dns.Msg)
+		m.SetQuestion("_sip._udp.cgrates.org.", qType)
+		rply, _, err := clnt.Exchange(m, addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testDNSCheckAnswers(t, rply, qType)
+	}
+}
+
+func TestDNSAgentServeHTTPS(t *testing.T) {
+	addr := testDNSAgentServe(t, dnsDoHNet)
+	clnt := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	url := "https://" + addr + dnsDoHPath
+	exchange := func(req *http.Request) (rply *dns.Msg) {
+		rsp, err := clnt.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rsp.Body.Close()
+		if rsp.StatusCode != http.StatusOK {
+			t.Fatalf("expecting: <%d>, received: <%d>", http.StatusOK, rsp.StatusCode)
+		}
+		if ct := rsp.Header.Get("Content-Type"); ct != dnsDoHContentType {
+			t.Errorf("expecting: <%s>, received: <%s>", dnsDoHContentType, ct)
+		}
+		body, err := io.ReadAll(rsp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rply = new(dns.Msg)
+		if err = rply.Unpack(body); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion("_sip._udp.cgrates.org.", dns.TypeSRV)
+	msg, err := m.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodGet, url+"?dns="+base64.RawURLEncoding.EncodeToString(msg), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testDNSCheckAnswers(t, exchange(req), dns.TypeSRV)
+
+	m.SetQuestion("cert.cgrates.org.", dns.TypeTXT)
+	if msg, err = m.Pack(); err != nil {
+		t.Fatal(err)
+	}
+	if req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(msg)); err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", dnsDoHContentType)
+	testDNSCheckAnswers(t, exchange(req), dns.TypeTXT)
+
+	if req, err = http.NewRequest(http.MethodGet, url+"?dns=invalid", nil); err != nil {
+		t.Fatal(err)
+	}
+	if rsp, err := clnt.Do(req); err != nil {
+		t.Fatal(err)
+	} else {
+		rsp.Body.Close()
+		if rsp.StatusCode != http.StatusBadRequest {
+			t.Errorf("expecting: <%d>, received: <%d>", http.StatusBadRequest, rsp.StatusCode)
+		}
+	}
+}
@@ -39,6 +39,16 @@ func processRequest(reqProcessor *config.RequestProcessor, agReq *AgentRequest,
 		return
 	}
 	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	var rgReqs map[string]*AgentRequest
+	if reqProcessor.Flags.Has(utils.MetaMSCC) { // one credit control per rating group
+		var mscc map[string]interface{}
+		if rgReqs, mscc, err = msccRequests(agReq); err != nil {
+			return
+		}
+		if len(mscc) != 0 {
+			cgrEv.Event[utils.MSCC] = mscc
+		}
+	}
 	var reqType string
 	for _, typ := range []string{
 		utils.MetaDryRun, utils.MetaAuthorize,
@@ -190,6 +200,11 @@ func processRequest(reqProcessor *config.RequestProcessor, agReq *AgentRequest,
 	if err = agReq.SetFields(reqProcessor.ReplyFields); err != nil {
 		return
 	}
+	if rgReqs != nil {
+		if err = msccReply(agReq, rgReqs); err != nil {
+			return
+		}
+	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
 		utils.Logger.Info(
 			fmt.Sprintf("<%s> LOG, Diameter reply: %s",
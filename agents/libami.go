@@ -0,0 +1,326 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/aringo"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// constants used over the Asterisk Manager Interface
+const (
+	amiAction       = "Action"
+	amiActionID     = "ActionID"
+	amiResponse     = "Response"
+	amiEvent        = "Event"
+	amiMessage      = "Message"
+	amiEventList    = "EventList"
+	amiSuccess      = "Success"
+	amiStart        = "start"
+	amiComplete     = "Complete"
+	amiChanVariable = "ChanVariable"
+	amiLogin        = "Login"
+	amiLogoff       = "Logoff"
+
+	amiActionTimeout = 5 * time.Second
+)
+
+var errAMIDisconnected = errors.New("AMI_DISCONNECTED")
+
+// newAMIConn connects to the Asterisk Manager Interface and logs in
+// events are posted on evChan, the error is posted on errChan once the reconnects are exhausted
+func newAMIConn(address, user, password string, evChan chan map[string]string,
+	errChan chan error, stopChan <-chan struct{}, connectAttempts, reconnects int) (ami *amiConn, err error) {
+	if connectAttempts == 0 {
+		return nil, aringo.ErrZeroConnectAttempts
+	}
+	ami = &amiConn{
+		address:    address,
+		user:       user,
+		password:   password,
+		reconnects: reconnects,
+		evChan:     evChan,
+		errChan:    errChan,
+		stopChan:   stopChan,
+		actions:    make(map[string]*amiPendingAction),
+	}
+	if err = ami.connect(); err != nil {
+		delay := aringo.Fib()
+		for i := 0; connectAttempts == -1 || i < connectAttempts-1; i++ { // -1 for infinite attempts
+			time.Sleep(delay())
+			if err = ami.connect(); err == nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// amiConn is one connection towards the Asterisk Manager Interface
+type amiConn struct {
+	address    string
+	user       string
+	password   string
+	reconnects int
+	evChan     chan map[string]string // events coming from Asterisk are posted here
+	errChan    chan error             // errors are posted here
+	stopChan   <-chan struct{}        // signal the listener to stop
+
+	actionID   uint64
+	wrMux      sync.Mutex // protects the writes on conn
+	conn       net.Conn
+	actionsMux sync.Mutex
+	actions    map[string]*amiPendingAction // actions waiting for their response, indexed on ActionID
+}
+
+// amiPendingAction gathers the response of one action
+// list actions (ie. CoreShowChannels) will also gather the events up to the one completing the list
+type amiPendingAction struct {
+	rply   map[string]string
+	events []map[string]string
+	done   chan struct{}
+}
+
+// connect dials the Asterisk, logs in and starts the listener
+func (ami *amiConn) connect() (err error) {
+	var conn net.Conn
+	if conn, err = net.Dial(utils.TCP, ami.address); err != nil {
+		return
+	}
+	var rdr *textproto.Reader
+	if rdr, err = ami.login(conn); err != nil {
+		conn.Close()
+		return
+	}
+	ami.wrMux.Lock()
+	ami.conn = conn
+	ami.wrMux.Unlock()
+	go ami.readMessages(rdr, conn)
+	return
+}
+
+// login authenticates the connection before the listener is started
+// the returned reader is to be used further by the listener since it might have buffered events
+func (ami *amiConn) login(conn net.Conn) (rdr *textproto.Reader, err error) {
+	conn.SetDeadline(time.Now().Add(amiActionTimeout))
+	defer conn.SetDeadline(time.Time{})
+	rdr = textproto.NewReader(bufio.NewReader(conn))
+	if _, err = rdr.ReadLine(); err != nil { // banner, ie. Asterisk Call Manager/5.0.1
+		return
+	}
+	if _, err = conn.Write(amiActionBytes(map[string]string{
+		amiAction:  amiLogin,
+		"Username": ami.user,
+		"Secret":   ami.password,
+		"Events":   "on",
+	})); err != nil {
+		return
+	}
+	var rply map[string]string
+	for rply[amiResponse] == utils.EmptyString { // skip the events sent before the response
+		if rply, err = readAMIMessage(rdr); err != nil {
+			return
+		}
+	}
+	if rply[amiResponse] != amiSuccess {
+		err = fmt.Errorf("login failed: %s", rply[amiMessage])
+	}
+	return
+}
+
+// readMessages dispatches the messages read from the connection until the connection is lost
+func (ami *amiConn) readMessages(rdr *textproto.Reader, conn net.Conn) {
+	for {
+		msg, err := readAMIMessage(rdr)
+		if err != nil {
+			conn.Close()
+			ami.dropActions()
+			select {
+			case <-ami.stopChan:
+				return // stopped by us, do not try to reconnect
+			default:
+			}
+			if errConn := ami.connect(); errConn != nil {
+				delay := aringo.Fib()
+				for i := 0; i < ami.reconnects-1; i++ { // attempt reconnect
+					time.Sleep(delay())
+					if errConn = ami.connect(); errConn == nil { // the new listener will pick up the events
+						return
+					}
+				}
+				ami.errChan <- err // reconnect did not succeed, pass the original error and give up
+			}
+			return
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		if ami.dispatchAction(msg) {
+			continue
+		}
+		if _, isEv := msg[amiEvent]; !isEv {
+			continue
+		}
+		select {
+		case ami.evChan <- msg:
+		case <-ami.stopChan:
+			conn.Close()
+			return
+		}
+	}
+}
+
+// dispatchAction delivers the message to the action waiting for it
+// returns false if the message is not part of an action reply
+func (ami *amiConn) dispatchAction(msg map[string]string) bool {
+	actID, has := msg[amiActionID]
+	if !has {
+		return false
+	}
+	ami.actionsMux.Lock()
+	defer ami.actionsMux.Unlock()
+	pending, has := ami.actions[actID]
+	if !has {
+		return false
+	}
+	if _, isRply := msg[amiResponse]; isRply {
+		pending.rply = msg
+		if !strings.EqualFold(msg[amiEventList], amiStart) {
+			delete(ami.actions, actID)
+			close(pending.done)
+		}
+		return true
+	}
+	if strings.EqualFold(msg[amiEventList], amiComplete) {
+		delete(ami.actions, actID)
+		close(pending.done)
+		return true
+	}
+	pending.events = append(pending.events, msg)
+	return true
+}
+
+// dropActions releases the actions still waiting when the connection is lost
+func (ami *amiConn) dropActions() {
+	ami.actionsMux.Lock()
+	for actID, pending := range ami.actions {
+		delete(ami.actions, actID)
+		close(pending.done)
+	}
+	ami.actionsMux.Unlock()
+}
+
+// sendAction writes the action and waits for its response
+func (ami *amiConn) sendAction(action map[string]string) (rply map[string]string, events []map[string]string, err error) {
+	actID := strconv.FormatUint(atomic.AddUint64(&ami.actionID, 1), 10)
+	pending := &amiPendingAction{done: make(chan struct{})}
+	ami.actionsMux.Lock()
+	ami.actions[actID] = pending
+	ami.actionsMux.Unlock()
+	action[amiActionID] = actID
+	ami.wrMux.Lock()
+	_, err = ami.conn.Write(amiActionBytes(action))
+	ami.wrMux.Unlock()
+	if err == nil {
+		select {
+		case <-pending.done:
+			if pending.rply == nil {
+				err = errAMIDisconnected
+			}
+			return pending.rply, pending.events, err
+		case <-time.After(amiActionTimeout):
+			err = utils.ErrReplyTimeout
+		}
+	}
+	ami.actionsMux.Lock()
+	delete(ami.actions, actID)
+	ami.actionsMux.Unlock()
+	return
+}
+
+// amiActionBytes encodes the action, the Action key first
+func amiActionBytes(action map[string]string) []byte {
+	var msg strings.Builder
+	msg.WriteString(amiAction + ": " + action[amiAction] + "\r\n")
+	for k, v := range action {
+		if k != amiAction {
+			msg.WriteString(k + ": " + v + "\r\n")
+		}
+	}
+	msg.WriteString("\r\n")
+	return []byte(msg.String())
+}
+
+// Call sends the action and returns an error if Asterisk does not reply with success
+func (ami *amiConn) Call(action map[string]string) (events []map[string]string, err error) {
+	var rply map[string]string
+	if rply, events, err = ami.sendAction(action); err != nil {
+		return
+	}
+	if rply[amiResponse] != amiSuccess {
+		err = fmt.Errorf("%s failed: %s", action[amiAction], rply[amiMessage])
+	}
+	return
+}
+
+// Close logs off and closes the connection
+func (ami *amiConn) Close() (err error) {
+	ami.sendAction(map[string]string{amiAction: amiLogoff})
+	ami.wrMux.Lock()
+	err = ami.conn.Close()
+	ami.wrMux.Unlock()
+	return
+}
+
+// readAMIMessage reads one message made of "Key: Value" lines terminated by an empty line
+// the channel variables are populated as ChanVariable(name) keys
+func readAMIMessage(rdr *textproto.Reader) (msg map[string]string, err error) {
+	msg = make(map[string]string)
+	for {
+		var line string
+		if line, err = rdr.ReadLine(); err != nil {
+			return
+		}
+		if line == utils.EmptyString {
+			return
+		}
+		idx := strings.Index(line, ":")
+		if idx == -1 {
+			continue // ie. the output of the Command action
+		}
+		key, val := line[:idx], strings.TrimSpace(line[idx+1:])
+		if strings.HasPrefix(key, amiChanVariable) { // ChanVariable: name=value
+			if vIdx := strings.Index(val, utils.AttrValueSep); vIdx != -1 {
+				key, val = amiChanVariable+"("+val[:vIdx]+")", val[vIdx+1:]
+			}
+		}
+		msg[key] = val
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// causes used in the ProblemDetails, as defined in 3GPP TS 29.500
+const (
+	chfCauseInvalidMsgFormat    = "INVALID_MSG_FORMAT"
+	chfCauseResourceURINotFound = "RESOURCE_URI_STRUCTURE_NOT_FOUND"
+	chfCauseSystemFailure       = "SYSTEM_FAILURE"
+)
+
+// chfMultipleUnitUsage is the array of the ChargingDataRequest carrying the usage per rating group
+const chfMultipleUnitUsage = "multipleUnitUsage"
+
+// chfDataProvider is the DataProvider of the ChargingDataRequest body
+type chfDataProvider struct {
+	utils.MapStorage
+}
+
+// msccDataProviders returns one DataProvider for each item of the multipleUnitUsage array
+// scoped to the content of the item so the templates can use paths like ~*req.ratingGroup
+func (dP chfDataProvider) msccDataProviders() (dPs []utils.DataProvider, err error) {
+	itmsIface, has := dP.MapStorage[chfMultipleUnitUsage]
+	if !has {
+		return
+	}
+	itms, canCast := itmsIface.([]interface{})
+	if !canCast {
+		return nil, fmt.Errorf("cannot cast <%s> to array", chfMultipleUnitUsage)
+	}
+	dPs = make([]utils.DataProvider, len(itms))
+	for i, itm := range itms {
+		mp, canCast := itm.(map[string]interface{})
+		if !canCast {
+			return nil, fmt.Errorf("cannot cast <%s[%d]> to object", chfMultipleUnitUsage, i)
+		}
+		dPs[i] = utils.MapStorage(mp)
+	}
+	return
+}
+
+// msccTemplates returns the templates for the multipleUnitUsage items
+func (chfDataProvider) msccTemplates() (string, string) {
+	return utils.MetaCHFMSCCReq, utils.MetaCHFMSCCRep
+}
+
+// chfProblemDetails is the error body of the Nchf replies
+type chfProblemDetails struct {
+	Status int    `json:"status"`
+	Cause  string `json:"cause"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// chfWriteProblem writes the ProblemDetails with the given status
+func chfWriteProblem(w http.ResponseWriter, status int, cause, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&chfProblemDetails{Status: status, Cause: cause, Detail: detail})
+}
+
+// chfWriteReply encodes the reply fields as JSON body of the ChargingDataResponse
+// the status can be overwritten from the reply fields using MetaCHFStatusCode
+func chfWriteReply(w http.ResponseWriter, status int, rplyNM *utils.OrderedNavigableMap) (err error) {
+	body := chfDataNodeAsInterface(rplyNM.Interface().(*utils.DataNode)).(map[string]interface{})
+	if stsIface, has := body[MetaCHFStatusCode]; has {
+		delete(body, MetaCHFStatusCode)
+		var sts int64
+		if sts, err = utils.IfaceAsTInt64(stsIface); err != nil {
+			return
+		}
+		status = int(sts)
+	}
+	if len(body) == 0 {
+		if status == http.StatusOK { // nothing to send back
+			status = http.StatusNoContent
+		}
+		w.WriteHeader(status)
+		return
+	}
+	if status == http.StatusNoContent { // the release has content
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(body)
+}
+
+// chfDataNodeAsInterface converts the DataNode into the structure used for the JSON body
+// the fields populated by templates are slices so the ones with only one value become the value itself
+// the values are encoded with the type given by the layout of their template (*integer, *float or *bool)
+func chfDataNodeAsInterface(nd *utils.DataNode) interface{} {
+	switch nd.Type {
+	case utils.NMDataType:
+		return nd.Value.Data
+	case utils.NMSliceType:
+		if len(nd.Slice) == 1 && nd.Slice[0].Type == utils.NMDataType {
+			return nd.Slice[0].Value.Data
+		}
+		sls := make([]interface{}, len(nd.Slice))
+		for i, itm := range nd.Slice {
+			sls[i] = chfDataNodeAsInterface(itm)
+		}
+		return sls
+	default:
+		mp := make(map[string]interface{}, len(nd.Map))
+		for k, itm := range nd.Map {
+			mp[k] = chfDataNodeAsInterface(itm)
+		}
+		return mp
+	}
+}
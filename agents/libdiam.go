@@ -244,12 +244,8 @@ func messageSetAVPsWithPath(m *diam.Message, pathStr []string,
 		}
 		msgAVP = diam.NewAVP(dictAVPs[i].Code, avp.Mbit, dictAVPs[i].VendorID, typeVal) // FixMe: maybe Mbit with dictionary one
 		if i > 0 && !newBranch {
-			avps, err := m.FindAVPsWithPath(path[:i], dict.UndefinedVendorID)
-			if err != nil {
-				return err
-			}
-			if len(avps) != 0 { // Group AVP already in the message
-				prevGrpData, ok := avps[len(avps)-1].Data.(*diam.GroupedAVP) // Take the last avp found to append there
+			if prevAVP := lastAVPWithPath(m.AVP, dictAVPs[:i]); prevAVP != nil { // Group AVP already in the message
+				prevGrpData, ok := prevAVP.Data.(*diam.GroupedAVP) // Take the last avp found to append there
 				if ok {
 					prevGrpData.AVP = append(prevGrpData.AVP, msgAVP)
 					m.Header.MessageLength += uint32(msgAVP.Len())
@@ -422,6 +418,27 @@ func (dP *diameterDP) FieldAsInterface(fldPath []string) (data interface{}, err
 }
 
 // updateDiamMsgFromNavMap will update the diameter message with items from navigable map
+// lastAVPWithPath returns the last AVP matching the path, descending only within the last AVP of each level
+// so the grouped AVPs are populated within the latest branch
+func lastAVPWithPath(avps []*diam.AVP, dictAVPs []*dict.AVP) (lastAVP *diam.AVP) {
+	for _, dictAVP := range dictAVPs {
+		lastAVP = nil
+		for _, a := range avps {
+			if a.Code == dictAVP.Code && a.VendorID == dictAVP.VendorID {
+				lastAVP = a
+			}
+		}
+		if lastAVP == nil {
+			return
+		}
+		avps = nil
+		if grpData, isGrp := lastAVP.Data.(*diam.GroupedAVP); isGrp {
+			avps = grpData.AVP
+		}
+	}
+	return
+}
+
 func updateDiamMsgFromNavMap(m *diam.Message, navMp *utils.OrderedNavigableMap, tmz string) (err error) {
 	// write reply into message
 	for el := navMp.GetFirstElement(); el != nil; el = el.Next() {
@@ -493,3 +510,33 @@ type diamMsgData struct {
 	m    *diam.Message
 	vars *utils.DataNode
 }
+
+// msccDataProviders returns one DataProvider for each Multiple-Services-Credit-Control AVP
+// scoped to the content of the group so the templates can use paths like ~*req.Rating-Group
+func (dP *diameterDP) msccDataProviders() (dPs []utils.DataProvider, err error) {
+	var avps []*diam.AVP
+	if avps, err = dP.m.FindAVPsWithPath([]interface{}{"Multiple-Services-Credit-Control"},
+		dict.UndefinedVendorID); err != nil {
+		return
+	}
+	dPs = make([]utils.DataProvider, len(avps))
+	for i, avp := range avps {
+		grp, canCast := avp.Data.(*diam.GroupedAVP)
+		if !canCast {
+			return nil, fmt.Errorf("cannot cast AVP <%d> to grouped", avp.Code)
+		}
+		m := diam.NewMessage(dP.m.Header.CommandCode, dP.m.Header.CommandFlags,
+			dP.m.Header.ApplicationID, dP.m.Header.HopByHopID, dP.m.Header.EndToEndID,
+			dP.m.Dictionary())
+		for _, gAVP := range grp.AVP {
+			m.AddAVP(gAVP)
+		}
+		dPs[i] = newDADataProvider(dP.c, m)
+	}
+	return
+}
+
+// msccTemplates returns the templates for the Multiple-Services-Credit-Control AVPs
+func (dP *diameterDP) msccTemplates() (string, string) {
+	return utils.MetaMSCCReq, utils.MetaMSCCRep
+}
@@ -21,6 +21,7 @@ package agents
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -29,6 +30,16 @@ import (
 	"github.com/miekg/dns"
 )
 
+const (
+	dnsTXTMaxLen = 255 // maximum length of a character-string within TXT records
+
+	// DNS-over-HTTPS as defined by RFC 8484
+	dnsDoHNet         = "https"
+	dnsDoHPath        = "/dns-query"
+	dnsDoHParam       = "dns"
+	dnsDoHContentType = "application/dns-message"
+)
+
 func newDnsReply(req *dns.Msg) (rply *dns.Msg) {
 	rply = new(dns.Msg)
 	rply.SetReply(req)
@@ -54,6 +65,62 @@ func dnsWriteMsg(w dns.ResponseWriter, msg *dns.Msg) (err error) {
 	return
 }
 
+// newDoHResponseWriter returns the dns.ResponseWriter over the HTTP reply
+func newDoHResponseWriter(w http.ResponseWriter, req *http.Request) *dohResponseWriter {
+	return &dohResponseWriter{w: w, req: req}
+}
+
+// dohResponseWriter writes the DNS answers within the body of the HTTP replies
+type dohResponseWriter struct {
+	w   http.ResponseWriter
+	req *http.Request
+}
+
+// LocalAddr implements dns.ResponseWriter
+func (dw *dohResponseWriter) LocalAddr() net.Addr {
+	if addr, canCast := dw.req.Context().Value(http.LocalAddrContextKey).(net.Addr); canCast {
+		return addr
+	}
+	return dohAddr(dw.req.Host)
+}
+
+// RemoteAddr implements dns.ResponseWriter
+func (dw *dohResponseWriter) RemoteAddr() net.Addr { return dohAddr(dw.req.RemoteAddr) }
+
+// WriteMsg implements dns.ResponseWriter
+func (dw *dohResponseWriter) WriteMsg(m *dns.Msg) (err error) {
+	var b []byte
+	if b, err = m.Pack(); err != nil {
+		return
+	}
+	_, err = dw.Write(b)
+	return
+}
+
+// Write implements dns.ResponseWriter
+func (dw *dohResponseWriter) Write(b []byte) (int, error) {
+	dw.w.Header().Set("Content-Type", dnsDoHContentType)
+	return dw.w.Write(b)
+}
+
+// Close implements dns.ResponseWriter, the connection is handled by the HTTP server
+func (*dohResponseWriter) Close() error { return nil }
+
+// TsigStatus implements dns.ResponseWriter
+func (*dohResponseWriter) TsigStatus() error { return nil }
+
+// TsigTimersOnly implements dns.ResponseWriter
+func (*dohResponseWriter) TsigTimersOnly(bool) {}
+
+// Hijack implements dns.ResponseWriter
+func (*dohResponseWriter) Hijack() {}
+
+// dohAddr is the address of the HTTP peer
+type dohAddr string
+
+func (dohAddr) Network() string  { return utils.TCP }
+func (a dohAddr) String() string { return string(a) }
+
 func newDnsDP(req *dns.Msg) utils.DataProvider {
 	return &dnsDP{
 		req:  config.NewObjectDP(req),
@@ -595,6 +662,10 @@ func updateDnsAnswer(q []dns.RR, qType uint16, qName string, path []string, valu
 	switch v := q[idx].(type) {
 	case *dns.NAPTR:
 		err = updateDnsNAPTRAnswer(v, path, value)
+	case *dns.SRV:
+		err = updateDnsSRVAnswer(v, path, value)
+	case *dns.TXT:
+		err = updateDnsTXTAnswer(v, path, value)
 	case *dns.A:
 		if len(path) < 1 ||
 			(path[0] != utils.DNSHdr && len(path) != 1) ||
@@ -632,6 +703,10 @@ func newDNSAnswer(qType uint16, qName string) (a dns.RR, err error) {
 		a = &dns.A{Hdr: hdr}
 	case dns.TypeNAPTR:
 		a = &dns.NAPTR{Hdr: hdr}
+	case dns.TypeSRV:
+		a = &dns.SRV{Hdr: hdr}
+	case dns.TypeTXT:
+		a = &dns.TXT{Hdr: hdr}
 	default:
 		err = fmt.Errorf("unsupported DNS type: <%v>", dns.TypeToString[qType])
 	}
@@ -673,6 +748,69 @@ func updateDnsNAPTRAnswer(v *dns.NAPTR, path []string, value interface{}) (err e
 	return
 }
 
+func updateDnsSRVAnswer(v *dns.SRV, path []string, value interface{}) (err error) {
+	if len(path) < 1 ||
+		(path[0] != utils.DNSHdr && len(path) != 1) ||
+		(path[0] == utils.DNSHdr && len(path) != 2) {
+		return utils.ErrWrongPath
+	}
+	switch path[0] {
+	case utils.DNSHdr:
+		return updateDnsRRHeader(&v.Hdr, path[1:], value)
+	case utils.DNSPriority:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Priority = uint16(vItm)
+	case utils.Weight:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Weight = uint16(vItm)
+	case utils.DNSPort:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Port = uint16(vItm)
+	case utils.DNSTarget:
+		v.Target = utils.IfaceAsString(value)
+	default:
+		return utils.ErrWrongPath
+	}
+	return
+}
+
+// updateDnsTXTAnswer sets the text of the answer
+// values longer than 255 bytes are split into multiple character-strings, ie. STIR/SHAKEN certificate URLs
+func updateDnsTXTAnswer(v *dns.TXT, path []string, value interface{}) (err error) {
+	if len(path) < 1 ||
+		(path[0] != utils.DNSHdr && len(path) != 1) ||
+		(path[0] == utils.DNSHdr && len(path) != 2) {
+		return utils.ErrWrongPath
+	}
+	switch path[0] {
+	case utils.DNSHdr:
+		return updateDnsRRHeader(&v.Hdr, path[1:], value)
+	case utils.DNSTxt:
+		v.Txt = splitDnsTXT(utils.IfaceAsString(value))
+	default:
+		return utils.ErrWrongPath
+	}
+	return
+}
+
+// splitDnsTXT splits the text into character-strings of maximum 255 bytes
+func splitDnsTXT(txt string) (strs []string) {
+	for len(txt) > dnsTXTMaxLen {
+		strs = append(strs, txt[:dnsTXTMaxLen])
+		txt = txt[dnsTXTMaxLen:]
+	}
+	return append(strs, txt)
+}
+
 func updateDnsRRHeader(v *dns.RR_Header, path []string, value interface{}) (err error) {
 	if len(path) != 1 {
 		return utils.ErrWrongPath
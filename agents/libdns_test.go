@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -209,3 +210,112 @@ func TestUpdateDNSMsgFromNM(t *testing.T) {
 	}
 
 }
+
+func TestAppendDNSAnswerTypeSRV(t *testing.T) {
+	if a, err := newDNSAnswer(dns.TypeSRV, "_sip._udp.cgrates.org."); err != nil {
+		t.Error(err)
+	} else if _, canCast := a.(*dns.SRV); !canCast {
+		t.Errorf("expecting: <*dns.SRV>, received: <%T>", a)
+	} else if a.Header().Rrtype != dns.TypeSRV {
+		t.Errorf("expecting: <%+v>, received: <%+v>", dns.TypeSRV, a.Header().Rrtype)
+	}
+}
+
+func TestAppendDNSAnswerTypeTXT(t *testing.T) {
+	if a, err := newDNSAnswer(dns.TypeTXT, "cert.cgrates.org."); err != nil {
+		t.Error(err)
+	} else if _, canCast := a.(*dns.TXT); !canCast {
+		t.Errorf("expecting: <*dns.TXT>, received: <%T>", a)
+	} else if a.Header().Rrtype != dns.TypeTXT {
+		t.Errorf("expecting: <%+v>, received: <%+v>", dns.TypeTXT, a.Header().Rrtype)
+	}
+}
+
+func TestUpdateDNSMsgFromNMSRV(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("_sip._udp.cgrates.org.", dns.TypeSRV)
+	nM := utils.NewOrderedNavigableMap()
+	for _, fld := range []struct {
+		path []string
+		val  interface{}
+	}{
+		{[]string{utils.DNSAnswer, utils.DNSPriority}, 10},
+		{[]string{utils.DNSAnswer, utils.Weight}, "60"},
+		{[]string{utils.DNSAnswer, utils.DNSPort}, 5060},
+		{[]string{utils.DNSAnswer, utils.DNSTarget}, "sip1.cgrates.org."},
+		{[]string{utils.DNSAnswer, utils.DNSHdr, utils.DNSTtl}, 300},
+	} {
+		nM.SetAsSlice(&utils.FullPath{
+			Path:      strings.Join(fld.path, utils.NestingSep),
+			PathSlice: fld.path,
+		}, []*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: fld.val}}})
+	}
+	if err := updateDNSMsgFromNM(m, nM, m.Question[0].Qtype, m.Question[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	exp := &dns.SRV{
+		Hdr: dns.RR_Header{Name: "_sip._udp.cgrates.org.", Rrtype: dns.TypeSRV,
+			Class: dns.ClassINET, Ttl: 300},
+		Priority: 10,
+		Weight:   60,
+		Port:     5060,
+		Target:   "sip1.cgrates.org.",
+	}
+	if len(m.Answer) != 1 {
+		t.Fatalf("expecting one answer, received: %s", utils.ToJSON(m.Answer))
+	} else if !reflect.DeepEqual(exp, m.Answer[0]) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(m.Answer[0]))
+	}
+
+	path := []string{utils.DNSAnswer, utils.DNSPort}
+	nM = utils.NewOrderedNavigableMap()
+	nM.SetAsSlice(&utils.FullPath{
+		Path:      strings.Join(path, utils.NestingSep),
+		PathSlice: path,
+	}, []*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: "RandomValue"}}})
+	if err := updateDNSMsgFromNM(m, nM, m.Question[0].Qtype, m.Question[0].Name); err == nil ||
+		err.Error() != `item: <[Answer Port]>, err: strconv.ParseInt: parsing "RandomValue": invalid syntax` {
+		t.Error(err)
+	}
+}
+
+func TestUpdateDNSMsgFromNMTXT(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cert.cgrates.org.", dns.TypeTXT)
+	certURL := "https://certs.cgrates.org/" + strings.Repeat("a", 300) + ".pem"
+	path := []string{utils.DNSAnswer, utils.DNSTxt}
+	nM := utils.NewOrderedNavigableMap()
+	nM.SetAsSlice(&utils.FullPath{
+		Path:      strings.Join(path, utils.NestingSep),
+		PathSlice: path,
+	}, []*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: certURL}}})
+	if err := updateDNSMsgFromNM(m, nM, m.Question[0].Qtype, m.Question[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Answer) != 1 {
+		t.Fatalf("expecting one answer, received: %s", utils.ToJSON(m.Answer))
+	}
+	txt, canCast := m.Answer[0].(*dns.TXT)
+	if !canCast {
+		t.Fatalf("expecting: <*dns.TXT>, received: <%T>", m.Answer[0])
+	}
+	if len(txt.Txt) != 2 || len(txt.Txt[0]) != 255 {
+		t.Errorf("expecting two character-strings, received: %q", txt.Txt)
+	} else if strings.Join(txt.Txt, utils.EmptyString) != certURL {
+		t.Errorf("expecting: %q, received: %q", certURL, strings.Join(txt.Txt, utils.EmptyString))
+	}
+	if _, err := m.Pack(); err != nil {
+		t.Error(err)
+	}
+
+	path = []string{utils.DNSAnswer, utils.DNSTarget}
+	nM = utils.NewOrderedNavigableMap()
+	nM.SetAsSlice(&utils.FullPath{
+		Path:      strings.Join(path, utils.NestingSep),
+		PathSlice: path,
+	}, []*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: "sip1.cgrates.org."}}})
+	if err := updateDNSMsgFromNM(m, nM, m.Question[0].Qtype, m.Question[0].Name); err == nil ||
+		err.Error() != `item: <[Answer Target]>, err: WRONG_PATH` {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// msccDataProvider is implemented by the requests carrying Multiple-Services Credit Control
+type msccDataProvider interface {
+	msccDataProviders() ([]utils.DataProvider, error)
+	msccTemplates() (reqTpl, rplyTpl string) // the templates fanning in and out one credit control
+}
+
+// msccRequests fans in the Multiple-Services Credit Control of the request
+// each credit control is processed with the request template of the provider into its own AgentRequest, indexed on rating group
+// the returned map is to be sent towards SessionS within the MSCC field of the event
+func msccRequests(agReq *AgentRequest) (rgReqs map[string]*AgentRequest, mscc map[string]interface{}, err error) {
+	msccDP, canCast := agReq.Request.(msccDataProvider)
+	if !canCast {
+		return nil, nil, fmt.Errorf("request does not support <%s>", utils.MetaMSCC)
+	}
+	var dPs []utils.DataProvider
+	if dPs, err = msccDP.msccDataProviders(); err != nil {
+		return
+	}
+	reqTpl, _ := msccDP.msccTemplates()
+	tpl := config.CgrConfig().TemplatesCfg()[reqTpl]
+	rgReqs = make(map[string]*AgentRequest)
+	mscc = make(map[string]interface{})
+	for _, dP := range dPs {
+		rgReq := NewAgentRequest(dP, agReq.Vars, nil, agReq.Reply, agReq.Opts,
+			nil, agReq.Tenant, agReq.Timezone, agReq.filterS, agReq.ExtraDP)
+		if err = rgReq.SetFields(tpl); err != nil {
+			return
+		}
+		rgEv := utils.NMAsCGREvent(rgReq.CGRRequest, agReq.Tenant, utils.NestingSep, nil).Event
+		rg := utils.IfaceAsString(rgEv[utils.RatingGroup])
+		if rg == utils.EmptyString {
+			return nil, nil, utils.NewErrMandatoryIeMissing(utils.RatingGroup)
+		}
+		delete(rgEv, utils.RatingGroup)
+		rgReqs[rg] = rgReq
+		mscc[rg] = rgEv
+	}
+	return
+}
+
+// msccReply fans out the rating groups out of the SessionS reply
+// the reply template of the provider is executed for each rating group with its own request and reply, populating the shared Reply
+// the position of the rating group within the reply is available as *vars.MSCCIndex
+func msccReply(agReq *AgentRequest, rgReqs map[string]*AgentRequest) (err error) {
+	msccNd, has := agReq.CGRReply.Map[utils.MSCC]
+	if !has || msccNd.Type != utils.NMMapType {
+		return
+	}
+	msccDP, canCast := agReq.Request.(msccDataProvider)
+	if !canCast {
+		return fmt.Errorf("request does not support <%s>", utils.MetaMSCC)
+	}
+	rgs := make([]string, 0, len(msccNd.Map))
+	for rg := range msccNd.Map {
+		rgs = append(rgs, rg)
+	}
+	sort.Strings(rgs)
+	_, rplyTpl := msccDP.msccTemplates()
+	tpl := config.CgrConfig().TemplatesCfg()[rplyTpl]
+	var idx int
+	for _, rg := range rgs {
+		rgReq, has := rgReqs[rg]
+		if !has {
+			continue
+		}
+		rgVars := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+		if agReq.Vars != nil { // keep the variables of the request
+			for k, v := range agReq.Vars.Map {
+				rgVars.Map[k] = v
+			}
+		}
+		rgVars.Map[utils.MSCCIndex] = utils.NewLeafNode(idx)
+		rgReq.Vars = rgVars
+		idx++
+		rgRply := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			utils.RatingGroup: utils.NewLeafNode(rg),
+		}}
+		for k, v := range msccNd.Map[rg].Map {
+			rgRply.Map[k] = v
+		}
+		rgReq.CGRReply = rgRply
+		if err = rgReq.SetFields(tpl); err != nil {
+			return
+		}
+	}
+	return
+}
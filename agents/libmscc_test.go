@@ -0,0 +1,205 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func TestMSCCFanInOut(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	m.NewAVP("Multiple-Services-Credit-Control", avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(432, avp.Mbit, 0, datatype.Unsigned32(1)), // Rating-Group
+			diam.NewAVP(437, avp.Mbit, 0, &diam.GroupedAVP{ // Requested-Service-Unit
+				AVP: []*diam.AVP{
+					diam.NewAVP(420, avp.Mbit, 0, datatype.Unsigned32(300)), // CC-Time
+				}}),
+		}})
+	m.NewAVP("Multiple-Services-Credit-Control", avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(432, avp.Mbit, 0, datatype.Unsigned32(2)),
+			diam.NewAVP(437, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(421, avp.Mbit, 0, datatype.Unsigned64(1024)), // CC-Total-Octets
+				}}),
+			diam.NewAVP(446, avp.Mbit, 0, &diam.GroupedAVP{ // Used-Service-Unit
+				AVP: []*diam.AVP{
+					diam.NewAVP(421, avp.Mbit, 0, datatype.Unsigned64(512)),
+				}}),
+		}})
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	agReq := NewAgentRequest(newDADataProvider(nil, m), nil, nil, nil, nil, nil,
+		"cgrates.org", utils.EmptyString, engine.NewFilterS(cfg, nil, dm), nil)
+
+	rgReqs, mscc, err := msccRequests(agReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expMSCC := map[string]interface{}{
+		"1": map[string]interface{}{utils.Usage: "300s"},
+		"2": map[string]interface{}{utils.Usage: "1024", utils.LastUsed: "512"},
+	}
+	if !reflect.DeepEqual(expMSCC, mscc) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expMSCC), utils.ToJSON(mscc))
+	}
+
+	agReq.CGRReply.Map[utils.MSCC] = &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		"1": {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			utils.CapMaxUsage:         utils.NewLeafNode(300 * time.Second),
+			utils.FinalUnitIndication: utils.NewLeafNode(false),
+		}},
+		"2": {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			utils.CapMaxUsage:         utils.NewLeafNode(time.Duration(256)),
+			utils.FinalUnitIndication: utils.NewLeafNode(true),
+		}},
+	}}
+	if err = msccReply(agReq, rgReqs); err != nil {
+		t.Fatal(err)
+	}
+	a := m.Answer(diam.Success)
+	if err = updateDiamMsgFromNavMap(a, agReq.Reply, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		path []interface{}
+		exp  []datatype.Type
+	}{
+		{[]interface{}{"Multiple-Services-Credit-Control", "Rating-Group"},
+			[]datatype.Type{datatype.Unsigned32(1), datatype.Unsigned32(2)}},
+		{[]interface{}{"Multiple-Services-Credit-Control", "Granted-Service-Unit", "CC-Time"},
+			[]datatype.Type{datatype.Unsigned32(300)}},
+		{[]interface{}{"Multiple-Services-Credit-Control", "Granted-Service-Unit", "CC-Total-Octets"},
+			[]datatype.Type{datatype.Unsigned64(256)}},
+		{[]interface{}{"Multiple-Services-Credit-Control", "Final-Unit-Indication", "Final-Unit-Action"},
+			[]datatype.Type{datatype.Enumerated(0)}},
+		{[]interface{}{"Multiple-Services-Credit-Control", "Result-Code"},
+			[]datatype.Type{datatype.Unsigned32(2001), datatype.Unsigned32(2001)}},
+	} {
+		avps, err := a.FindAVPsWithPath(tc.path, dict.UndefinedVendorID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rcv := make([]datatype.Type, len(avps))
+		for i, a := range avps {
+			rcv[i] = a.Data
+		}
+		if !reflect.DeepEqual(tc.exp, rcv) {
+			t.Errorf("Expected %v for %v, received %v", tc.exp, tc.path, rcv)
+		}
+	}
+	// the final unit indication belongs to the second rating group
+	if msccs, err := a.FindAVPsWithPath([]interface{}{"Multiple-Services-Credit-Control"},
+		dict.UndefinedVendorID); err != nil {
+		t.Fatal(err)
+	} else if len(msccs) != 2 || len(msccs[1].Data.(*diam.GroupedAVP).AVP) != 4 {
+		t.Errorf("Unexpected Multiple-Services-Credit-Control: %v", msccs)
+	}
+}
+
+func TestMSCCFanInOutCHF(t *testing.T) {
+	chfReq := make(utils.MapStorage)
+	if err := json.Unmarshal([]byte(`{
+		"subscriberIdentifier": "imsi-001010000000001",
+		"multipleUnitUsage": [
+			{"ratingGroup": 10, "requestedUnit": {"time": 300}},
+			{"ratingGroup": 20, "requestedUnit": {"totalVolume": 1024},
+				"usedUnitContainer": [{"totalVolume": 512}]}
+		]
+	}`), &chfReq); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		utils.MetaCmd: utils.NewLeafNode(utils.CHFUpdate),
+	}}
+	agReq := NewAgentRequest(chfDataProvider{chfReq}, reqVars, nil, nil, nil, nil,
+		"cgrates.org", utils.EmptyString, engine.NewFilterS(cfg, nil, dm), nil)
+
+	rgReqs, mscc, err := msccRequests(agReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expMSCC := map[string]interface{}{
+		"10": map[string]interface{}{utils.Usage: "300s"},
+		"20": map[string]interface{}{utils.Usage: "1024", utils.LastUsed: "512"},
+	}
+	if !reflect.DeepEqual(expMSCC, mscc) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expMSCC), utils.ToJSON(mscc))
+	}
+
+	agReq.CGRReply = &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+		utils.MSCC: {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			"10": {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+				utils.CapMaxUsage:         utils.NewLeafNode(300 * time.Second),
+				utils.FinalUnitIndication: utils.NewLeafNode(false),
+			}},
+			"20": {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+				utils.CapMaxUsage:         utils.NewLeafNode(time.Duration(256)),
+				utils.FinalUnitIndication: utils.NewLeafNode(true),
+			}},
+		}},
+	}}
+	if err = msccReply(agReq, rgReqs); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{
+		"multipleUnitInformation": []interface{}{
+			map[string]interface{}{
+				"ratingGroup": int64(10),
+				"grantedUnit": map[string]interface{}{"time": int64(300)},
+				"resultCode":  "SUCCESS",
+			},
+			map[string]interface{}{
+				"ratingGroup":         int64(20),
+				"grantedUnit":         map[string]interface{}{"totalVolume": int64(256)},
+				"finalUnitIndication": map[string]interface{}{"finalUnitAction": "TERMINATE"},
+				"resultCode":          "SUCCESS",
+			},
+		},
+	}
+	if rcv := chfDataNodeAsInterface(agReq.Reply.Interface().(*utils.DataNode)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	// the variables of the request are not altered by the fan out
+	if _, has := reqVars.Map[utils.MSCCIndex]; has {
+		t.Errorf("Unexpected %s within the request variables", utils.MSCCIndex)
+	}
+}
+
+func TestMSCCRequestsUnsupported(t *testing.T) {
+	agReq := NewAgentRequest(utils.MapStorage{}, nil, nil, nil, nil, nil,
+		"cgrates.org", utils.EmptyString, nil, nil)
+	if _, _, err := msccRequests(agReq); err == nil {
+		t.Error("Expected error for request without Multiple-Services Credit Control support")
+	}
+}
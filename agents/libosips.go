@@ -0,0 +1,159 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// MI commands used by OpenSIPSAgent
+const (
+	osipsDlgList    = "dlg_list"
+	osipsDlgListCtx = "dlg_list_ctx"
+	osipsDlgEndDlg  = "dlg_end_dlg"
+
+	osipsJSONRPCVersion = "2.0"
+	osipsContentType    = "application/json"
+	osipsBufferSize     = 65535 // maximum size of an event_datagram message
+)
+
+// osipsNotification is the JSON-RPC notification sent by the event_datagram module
+type osipsNotification struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// osipsMIRequest is the JSON-RPC request towards the MI interface
+type osipsMIRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	ID      uint64      `json:"id"`
+}
+
+// osipsMIReply is the JSON-RPC reply of the MI interface
+type osipsMIReply struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// osipsDialog is one dialog as listed by the dlg_list and dlg_list_ctx commands
+type osipsDialog struct {
+	ID        json.Number `json:"ID"`
+	State     int         `json:"state"`
+	Timestart int64       `json:"timestart"`
+	CallID    string      `json:"callid"`
+	Caller    struct {
+		Tag string `json:"tag"`
+	} `json:"caller"`
+	Context struct {
+		Values []map[string]interface{} `json:"values"`
+	} `json:"context"`
+}
+
+// osipsDlgListReply is the reply of dlg_list and dlg_list_ctx commands
+type osipsDlgListReply struct {
+	Dialogs []*osipsDialog `json:"Dialogs"`
+}
+
+// newOsipsMIConn returns the connection towards the MI JSON interface of one OpenSIPS
+func newOsipsMIConn(connCfg *config.OsipsConnCfg, replyTimeout time.Duration) (mi *osipsMIConn, err error) {
+	var miURL *url.URL
+	if miURL, err = url.Parse(connCfg.MiAddr); err != nil {
+		return
+	}
+	return &osipsMIConn{
+		cfg:        connCfg,
+		host:       miURL.Hostname(),
+		httpClient: &http.Client{Timeout: replyTimeout},
+	}, nil
+}
+
+// osipsMIConn sends the MI commands over HTTP
+type osipsMIConn struct {
+	cfg        *config.OsipsConnCfg
+	host       string // used to match the events sent by the same OpenSIPS
+	httpClient *http.Client
+	reqID      uint64
+}
+
+// originHost identifies the OpenSIPS in the events
+func (mi *osipsMIConn) originHost() string {
+	return utils.FirstNonEmpty(mi.cfg.Alias, mi.host)
+}
+
+// Call executes the MI command and unmarshals its result into reply
+// the request is retried on transport errors for the configured number of reconnects
+func (mi *osipsMIConn) Call(method string, params interface{}, reply interface{}) (err error) {
+	var body []byte
+	if body, err = json.Marshal(&osipsMIRequest{
+		JSONRPC: osipsJSONRPCVersion,
+		Method:  method,
+		Params:  params,
+		ID:      atomic.AddUint64(&mi.reqID, 1),
+	}); err != nil {
+		return
+	}
+	var rply *osipsMIReply
+	delay := utils.FibDuration(time.Millisecond)
+	for i := 0; i <= mi.cfg.Reconnects; i++ {
+		if i != 0 {
+			time.Sleep(delay())
+		}
+		if rply, err = mi.post(body); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return
+	}
+	if rply.Error != nil {
+		return fmt.Errorf("%s failed: %s", method, rply.Error.Message)
+	}
+	if reply == nil {
+		return
+	}
+	return json.Unmarshal(rply.Result, reply)
+}
+
+func (mi *osipsMIConn) post(body []byte) (rply *osipsMIReply, err error) {
+	var resp *http.Response
+	if resp, err = mi.httpClient.Post(mi.cfg.MiAddr, osipsContentType, bytes.NewReader(body)); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	rply = new(osipsMIReply)
+	err = json.NewDecoder(resp.Body).Decode(rply)
+	return
+}
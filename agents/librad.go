@@ -20,11 +20,28 @@ package agents
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
 )
 
+// Dynamic Authorization (RFC 5176) packet codes, not known by radigo
+const (
+	radDisconnectRequest radigo.PacketCode = 40
+	radDisconnectACK     radigo.PacketCode = 41
+	radDisconnectNAK     radigo.PacketCode = 42
+	radCoARequest        radigo.PacketCode = 43
+	radCoAACK            radigo.PacketCode = 44
+	radCoANAK            radigo.PacketCode = 45
+	radErrorCauseAttr                      = 101 // Error-Cause attribute number
+)
+
 // radReplyAppendAttributes appends attributes to a RADIUS reply based on predefined template
 func radReplyAppendAttributes(reply *radigo.Packet, rplNM *utils.OrderedNavigableMap) (err error) {
 	for el := rplNM.GetFirstElement(); el != nil; el = el.Next() {
@@ -164,3 +181,87 @@ func radauthReq(flags utils.FlagsWithParams, req *radigo.Packet, aReq *AgentRequ
 	}
 
 }
+
+// encodeDARequest encodes the Dynamic Authorization request
+// the Request Authenticator is computed the same way as for the Accounting-Request
+func encodeDARequest(pkt *radigo.Packet, secret string) (b []byte, err error) {
+	var buf [4096]byte
+	var n int
+	if n, err = pkt.Encode(buf[:]); err != nil {
+		return
+	}
+	b = buf[:n]
+	copy(b[4:20], make([]byte, 16))
+	hash := md5.New()
+	hash.Write(b)
+	hash.Write([]byte(secret))
+	copy(b[4:20], hash.Sum(nil))
+	copy(pkt.Authenticator[:], b[4:20])
+	return
+}
+
+// checkDAReply verifies the reply of the Dynamic Authorization request
+// matched is false if the reply does not belong to the request so it can be ignored
+func checkDAReply(rply, req []byte, secret string) (matched bool, err error) {
+	if len(rply) < 20 || int(binary.BigEndian.Uint16(rply[2:4])) != len(rply) ||
+		rply[1] != req[1] {
+		return
+	}
+	hash := md5.New()
+	hash.Write(rply[:4])
+	hash.Write(req[4:20])
+	hash.Write(rply[20:])
+	hash.Write([]byte(secret))
+	if !bytes.Equal(hash.Sum(nil), rply[4:20]) {
+		return
+	}
+	matched = true
+	switch code := radigo.PacketCode(rply[0]); {
+	case code == radDisconnectACK && radigo.PacketCode(req[0]) == radDisconnectRequest,
+		code == radCoAACK && radigo.PacketCode(req[0]) == radCoARequest:
+	case code == radDisconnectNAK, code == radCoANAK:
+		var errCause uint32
+		for avps := rply[20:]; len(avps) >= 2 && int(avps[1]) >= 2 && int(avps[1]) <= len(avps); avps = avps[avps[1]:] {
+			if avps[0] == radErrorCauseAttr && avps[1] == 6 {
+				errCause = binary.BigEndian.Uint32(avps[2:6])
+			}
+		}
+		err = fmt.Errorf("NAK received with Error-Cause: %d", errCause)
+	default:
+		err = fmt.Errorf("unexpected reply code: %d", code)
+	}
+	return
+}
+
+// sendDARequest sends the encoded Dynamic Authorization request to the client and waits for its ACK,
+// retransmitting it if no reply is received within the reply timeout
+func sendDARequest(req []byte, address, secret string, opts *config.DAClientOpts) (err error) {
+	var conn net.Conn
+	if conn, err = net.Dial(opts.Transport, address); err != nil {
+		return
+	}
+	defer conn.Close()
+	var buf [4096]byte
+	for i := 0; i <= opts.Retransmits; i++ {
+		if _, err = conn.Write(req); err != nil {
+			return
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(opts.ReplyTimeout)); err != nil {
+			return
+		}
+		for {
+			var n int
+			if n, err = conn.Read(buf[:]); err != nil {
+				break
+			}
+			var matched bool
+			if matched, err = checkDAReply(buf[:n], req, secret); matched {
+				return
+			}
+		}
+		if nErr, canCast := err.(net.Error); !canCast || !nErr.Timeout() {
+			return
+		}
+	}
+	return utils.ErrTimedOut
+}
@@ -19,12 +19,17 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"crypto/md5"
+	"encoding/binary"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
 )
@@ -141,3 +146,145 @@ func TestRadiusDPFieldAsString(t *testing.T) {
 		t.Errorf("Expecting: flopsy, received: <%s>", data)
 	}
 }
+
+// buildDAReply mimics the NAS building the reply for a Dynamic Authorization request
+func buildDAReply(code radigo.PacketCode, req []byte, secret string, avps []byte) (rply []byte) {
+	rply = make([]byte, 20+len(avps))
+	rply[0], rply[1] = byte(code), req[1]
+	binary.BigEndian.PutUint16(rply[2:4], uint16(len(rply)))
+	copy(rply[4:20], req[4:20])
+	copy(rply[20:], avps)
+	hash := md5.New()
+	hash.Write(rply)
+	hash.Write([]byte(secret))
+	copy(rply[4:20], hash.Sum(nil))
+	return
+}
+
+func TestRadEncodeDARequest(t *testing.T) {
+	pkt := radigo.NewPacket(radDisconnectRequest, 7, dictRad, coder, "CGRateS.org")
+	if err := pkt.AddAVPWithName("User-Name", "1001", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	req, err := encodeDARequest(pkt, "CGRateS.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req[0] != byte(radDisconnectRequest) || req[1] != 7 ||
+		int(binary.BigEndian.Uint16(req[2:4])) != len(req) {
+		t.Fatalf("Unexpected header: %v", req[:4])
+	}
+	b := make([]byte, len(req))
+	copy(b, req)
+	copy(b[4:20], make([]byte, 16))
+	hash := md5.New()
+	hash.Write(b)
+	hash.Write([]byte("CGRateS.org"))
+	if exp := hash.Sum(nil); !reflect.DeepEqual(exp, req[4:20]) {
+		t.Errorf("Expected authenticator %v, received %v", exp, req[4:20])
+	}
+}
+
+func TestRadCheckDAReply(t *testing.T) {
+	pkt := radigo.NewPacket(radCoARequest, 3, dictRad, coder, "CGRateS.org")
+	req, err := encodeDARequest(pkt, "CGRateS.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matched, err := checkDAReply(buildDAReply(radCoAACK, req, "CGRateS.org", nil),
+		req, "CGRateS.org"); err != nil || !matched {
+		t.Errorf("Unexpected reply check: %v, err: %v", matched, err)
+	}
+	if matched, _ := checkDAReply(buildDAReply(radCoAACK, req, "wrong", nil),
+		req, "CGRateS.org"); matched {
+		t.Error("Expected reply with wrong secret to be ignored")
+	}
+	errCause := []byte{radErrorCauseAttr, 6, 0, 0, 0x01, 0xf7} // 503 Session Context Not Found
+	if matched, err := checkDAReply(buildDAReply(radCoANAK, req, "CGRateS.org", errCause),
+		req, "CGRateS.org"); !matched || err == nil ||
+		err.Error() != "NAK received with Error-Cause: 503" {
+		t.Errorf("Unexpected reply check: %v, err: %v", matched, err)
+	}
+	if _, err := checkDAReply(buildDAReply(radDisconnectACK, req, "CGRateS.org", nil),
+		req, "CGRateS.org"); err == nil || err.Error() != "unexpected reply code: 41" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRadSendDARequest(t *testing.T) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go func() { // fake NAS ignoring the first request to force the retransmit
+		var buf [4096]byte
+		for i := 0; ; i++ {
+			n, addr, err := conn.ReadFrom(buf[:])
+			if err != nil {
+				return
+			}
+			if i == 0 {
+				continue
+			}
+			conn.WriteTo(buildDAReply(radDisconnectACK, buf[:n], "CGRateS.org", nil), addr)
+		}
+	}()
+	pkt := radigo.NewPacket(radDisconnectRequest, 1, dictRad, coder, "CGRateS.org")
+	req, err := encodeDARequest(pkt, "CGRateS.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &config.DAClientOpts{Transport: utils.UDP,
+		ReplyTimeout: 50 * time.Millisecond, Retransmits: 1}
+	if err = sendDARequest(req, conn.LocalAddr().String(), "CGRateS.org", opts); err != nil {
+		t.Error(err)
+	}
+	opts.Retransmits = 0
+	if err = sendDARequest(req, conn.LocalAddr().String(), "wrong", opts); err != utils.ErrTimedOut {
+		t.Errorf("Expected %v, received %v", utils.ErrTimedOut, err)
+	}
+}
+
+func TestRadV1GetActiveSessionIDs(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	oldCache := engine.Cache
+	engine.Cache = engine.NewCacheS(cfg, dm, nil)
+	defer func() { engine.Cache = oldCache }()
+	ra := &RadiusAgent{cgrCfg: cfg}
+	var sIDs []*sessions.SessionID
+	if err := ra.V1GetActiveSessionIDs(utils.EmptyString, &sIDs); err != utils.ErrNoActiveSession {
+		t.Errorf("Expected %v, received %v", utils.ErrNoActiveSession, err)
+	}
+	for _, originID := range []string{"session1", "auth1"} {
+		if err := engine.Cache.Set(utils.CacheRadiusPackets, originID,
+			&radPacketData{client: "127.0.0.1"}, nil, true, utils.NonTransactional); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ra.updateCachedRequest(&utils.CGREvent{Event: map[string]interface{}{
+		utils.OriginID:   "session1",
+		utils.OriginHost: "192.168.1.1",
+	}}, false)
+	ra.updateCachedRequest(&utils.CGREvent{Event: map[string]interface{}{ // authorized only
+		utils.OriginID:   "auth1",
+		utils.OriginHost: "192.168.1.1",
+	}}, false)
+	if err := ra.V1GetActiveSessionIDs(utils.EmptyString, &sIDs); err != utils.ErrNoActiveSession {
+		t.Errorf("Expected %v, received %v", utils.ErrNoActiveSession, err)
+	}
+	ra.updateCachedRequest(&utils.CGREvent{Event: map[string]interface{}{ // initiated, keeping the OriginHost
+		utils.OriginID: "session1",
+	}}, true)
+	ra.updateCachedRequest(&utils.CGREvent{Event: map[string]interface{}{ // not cached
+		utils.OriginID:   "session2",
+		utils.OriginHost: "192.168.1.1",
+	}}, true)
+	exp := []*sessions.SessionID{{OriginHost: "192.168.1.1", OriginID: "session1"}}
+	if err := ra.V1GetActiveSessionIDs(utils.EmptyString, &sIDs); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, sIDs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(sIDs))
+	}
+}
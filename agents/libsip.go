@@ -69,3 +69,13 @@ func bareSipErr(m sipingo.Message, err string) sipingo.Message {
 	m.PrepareReply()
 	return m
 }
+
+// sipOptionsReply answers the OPTIONS keep-alive probes with 200 OK
+func sipOptionsReply(m sipingo.Message) sipingo.Message {
+	m[requestHeader] = sipOK
+	if !sipTagRgx.MatchString(m[toHeader]) { // final responses need the To tag
+		m[toHeader] += ";tag=" + utils.UUIDSha1Prefix()
+	}
+	m.PrepareReply()
+	return m
+}
@@ -0,0 +1,436 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPP 3.4 command IDs
+const (
+	smppGenericNack     uint32 = 0x80000000
+	smppBindReceiver    uint32 = 0x00000001
+	smppBindTransmitter uint32 = 0x00000002
+	smppSubmitSM        uint32 = 0x00000004
+	smppDeliverSM       uint32 = 0x00000005
+	smppUnbind          uint32 = 0x00000006
+	smppBindTransceiver uint32 = 0x00000009
+	smppEnquireLink     uint32 = 0x00000015
+	smppRespMask        uint32 = 0x80000000
+
+	smppHeaderLen     = 16
+	smppMaxPDULen     = 65536
+	smppMessagePayTag = 0x0424 // message_payload TLV
+	smppUDHIndicator  = 0x40   // esm_class UDHI flag
+)
+
+// SMPP 3.4 command_status values
+const (
+	smppStatusOK         uint32 = 0x00000000
+	smppStatusInvMsgLen  uint32 = 0x00000001
+	smppStatusInvCmdID   uint32 = 0x00000003
+	smppStatusInvBndSts  uint32 = 0x00000004
+	smppStatusAlyBnd     uint32 = 0x00000005
+	smppStatusSysErr     uint32 = 0x00000008
+	smppStatusInvDstAdr  uint32 = 0x0000000B
+	smppStatusBindFail   uint32 = 0x0000000D
+	smppStatusInvPaswd   uint32 = 0x0000000E
+	smppStatusSubmitFail uint32 = 0x00000045
+	smppStatusThrottled  uint32 = 0x00000058
+	smppStatusXTAppn     uint32 = 0x00000064
+	smppStatusXPAppn     uint32 = 0x00000065
+	smppStatusXRAppn     uint32 = 0x00000066
+	smppStatusUnknownErr uint32 = 0x000000FF
+)
+
+// smppStatuses maps the command_status names usable within the reply fields
+var smppStatuses = map[string]uint32{
+	"ESME_ROK":         smppStatusOK,
+	"ESME_RINVMSGLEN":  smppStatusInvMsgLen,
+	"ESME_RINVCMDID":   smppStatusInvCmdID,
+	"ESME_RINVBNDSTS":  smppStatusInvBndSts,
+	"ESME_RALYBND":     smppStatusAlyBnd,
+	"ESME_RSYSERR":     smppStatusSysErr,
+	"ESME_RINVDSTADR":  smppStatusInvDstAdr,
+	"ESME_RBINDFAIL":   smppStatusBindFail,
+	"ESME_RINVPASWD":   smppStatusInvPaswd,
+	"ESME_RSUBMITFAIL": smppStatusSubmitFail,
+	"ESME_RTHROTTLED":  smppStatusThrottled,
+	"ESME_RX_T_APPN":   smppStatusXTAppn,
+	"ESME_RX_P_APPN":   smppStatusXPAppn,
+	"ESME_RX_R_APPN":   smppStatusXRAppn,
+	"ESME_RUNKNOWNERR": smppStatusUnknownErr,
+}
+
+// smppCommands are the names of the commands passed to the request processors
+var smppCommands = map[uint32]string{
+	smppSubmitSM:  utils.SMPPSubmitSM,
+	smppDeliverSM: utils.SMPPDeliverSM,
+}
+
+// smppCommandStatus returns the command_status out of its name or number
+func smppCommandStatus(sts string) (uint32, error) {
+	if code, has := smppStatuses[sts]; has {
+		return code, nil
+	}
+	code, err := strconv.ParseUint(sts, 0, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported command_status: <%s>", sts)
+	}
+	return uint32(code), nil
+}
+
+// smppPDU is the SMPP protocol data unit
+type smppPDU struct {
+	CommandID uint32
+	Status    uint32
+	Seq       uint32
+	Body      []byte
+}
+
+// readSMPPPDU reads one PDU out of the reader
+func readSMPPPDU(r io.Reader) (p *smppPDU, err error) {
+	var hdr [smppHeaderLen]byte
+	if _, err = io.ReadFull(r, hdr[:]); err != nil {
+		return
+	}
+	pduLen := binary.BigEndian.Uint32(hdr[0:4])
+	if pduLen < smppHeaderLen || pduLen > smppMaxPDULen {
+		return nil, fmt.Errorf("invalid command_length: %d", pduLen)
+	}
+	p = &smppPDU{
+		CommandID: binary.BigEndian.Uint32(hdr[4:8]),
+		Status:    binary.BigEndian.Uint32(hdr[8:12]),
+		Seq:       binary.BigEndian.Uint32(hdr[12:16]),
+		Body:      make([]byte, pduLen-smppHeaderLen),
+	}
+	_, err = io.ReadFull(r, p.Body)
+	return
+}
+
+// Encode returns the PDU as sent on wire
+func (p *smppPDU) Encode() (b []byte) {
+	b = make([]byte, smppHeaderLen+len(p.Body))
+	binary.BigEndian.PutUint32(b[0:4], uint32(len(b)))
+	binary.BigEndian.PutUint32(b[4:8], p.CommandID)
+	binary.BigEndian.PutUint32(b[8:12], p.Status)
+	binary.BigEndian.PutUint32(b[12:16], p.Seq)
+	copy(b[smppHeaderLen:], p.Body)
+	return
+}
+
+// smppBodyReader decodes the mandatory parameters of a PDU body
+type smppBodyReader struct {
+	b   []byte
+	err error
+}
+
+func (r *smppBodyReader) cString() (s string) {
+	if r.err != nil {
+		return
+	}
+	idx := bytes.IndexByte(r.b, 0)
+	if idx == -1 {
+		r.err = errors.New("C-Octet String not terminated")
+		return
+	}
+	s, r.b = string(r.b[:idx]), r.b[idx+1:]
+	return
+}
+
+func (r *smppBodyReader) octets(n int) (o []byte) {
+	if r.err != nil {
+		return
+	}
+	if len(r.b) < n {
+		r.err = errors.New("PDU body too short")
+		return
+	}
+	o, r.b = r.b[:n], r.b[n:]
+	return
+}
+
+func (r *smppBodyReader) byte() (b byte) {
+	if o := r.octets(1); r.err == nil {
+		b = o[0]
+	}
+	return
+}
+
+// smppAppendCString appends the null terminated string to the body
+func smppAppendCString(b []byte, s string) []byte {
+	return append(append(b, s...), 0)
+}
+
+// smppBind holds the parameters of the bind operations
+type smppBind struct {
+	SystemID         string
+	Password         string
+	SystemType       string
+	InterfaceVersion byte
+	AddrTON          byte
+	AddrNPI          byte
+	AddressRange     string
+}
+
+// decodeSMPPBind decodes the body of the bind operations
+func decodeSMPPBind(body []byte) (bnd *smppBind, err error) {
+	r := &smppBodyReader{b: body}
+	bnd = &smppBind{
+		SystemID:         r.cString(),
+		Password:         r.cString(),
+		SystemType:       r.cString(),
+		InterfaceVersion: r.byte(),
+		AddrTON:          r.byte(),
+		AddrNPI:          r.byte(),
+		AddressRange:     r.cString(),
+	}
+	return bnd, r.err
+}
+
+// Encode returns the body of the bind operation
+func (bnd *smppBind) Encode() (b []byte) {
+	b = smppAppendCString(b, bnd.SystemID)
+	b = smppAppendCString(b, bnd.Password)
+	b = smppAppendCString(b, bnd.SystemType)
+	b = append(b, bnd.InterfaceVersion, bnd.AddrTON, bnd.AddrNPI)
+	return smppAppendCString(b, bnd.AddressRange)
+}
+
+// smppSM holds the parameters of submit_sm and deliver_sm
+type smppSM struct {
+	ServiceType          string
+	SourceAddrTON        byte
+	SourceAddrNPI        byte
+	SourceAddr           string
+	DestAddrTON          byte
+	DestAddrNPI          byte
+	DestinationAddr      string
+	ESMClass             byte
+	ProtocolID           byte
+	PriorityFlag         byte
+	ScheduleDeliveryTime string
+	ValidityPeriod       string
+	RegisteredDelivery   byte
+	ReplaceIfPresentFlag byte
+	DataCoding           byte
+	SMDefaultMsgID       byte
+	ShortMessage         []byte
+	TLVs                 map[uint16][]byte
+}
+
+// decodeSMPPSM decodes the body of submit_sm and deliver_sm
+func decodeSMPPSM(body []byte) (sm *smppSM, err error) {
+	r := &smppBodyReader{b: body}
+	sm = &smppSM{
+		ServiceType:          r.cString(),
+		SourceAddrTON:        r.byte(),
+		SourceAddrNPI:        r.byte(),
+		SourceAddr:           r.cString(),
+		DestAddrTON:          r.byte(),
+		DestAddrNPI:          r.byte(),
+		DestinationAddr:      r.cString(),
+		ESMClass:             r.byte(),
+		ProtocolID:           r.byte(),
+		PriorityFlag:         r.byte(),
+		ScheduleDeliveryTime: r.cString(),
+		ValidityPeriod:       r.cString(),
+		RegisteredDelivery:   r.byte(),
+		ReplaceIfPresentFlag: r.byte(),
+		DataCoding:           r.byte(),
+		SMDefaultMsgID:       r.byte(),
+	}
+	sm.ShortMessage = r.octets(int(r.byte()))
+	for r.err == nil && len(r.b) != 0 {
+		hdr := r.octets(4)
+		if r.err != nil {
+			break
+		}
+		if sm.TLVs == nil {
+			sm.TLVs = make(map[uint16][]byte)
+		}
+		sm.TLVs[binary.BigEndian.Uint16(hdr[0:2])] = r.octets(int(binary.BigEndian.Uint16(hdr[2:4])))
+	}
+	return sm, r.err
+}
+
+// message returns the user data of the short message, without the UDH
+// together with the concatenation info out of UDH if present
+func (sm *smppSM) message() (msg []byte, ref, total, seq int) {
+	if msg = sm.ShortMessage; len(msg) == 0 {
+		msg = sm.TLVs[smppMessagePayTag]
+	}
+	if sm.ESMClass&smppUDHIndicator == 0 || len(msg) == 0 ||
+		int(msg[0])+1 > len(msg) {
+		return
+	}
+	udh := msg[1 : int(msg[0])+1]
+	msg = msg[int(msg[0])+1:]
+	for len(udh) >= 2 && int(udh[1])+2 <= len(udh) {
+		ie := udh[2 : int(udh[1])+2]
+		switch {
+		case udh[0] == 0x00 && len(ie) == 3: // concatenated short messages, 8-bit reference
+			ref, total, seq = int(ie[0]), int(ie[1]), int(ie[2])
+		case udh[0] == 0x08 && len(ie) == 4: // concatenated short messages, 16-bit reference
+			ref, total, seq = int(binary.BigEndian.Uint16(ie[0:2])), int(ie[2]), int(ie[3])
+		}
+		udh = udh[int(udh[1])+2:]
+	}
+	return
+}
+
+// smppDecodeText decodes the user data based on data_coding
+// GSM default alphabet is considered as IA5 since it is mostly sent unpacked by the SMSCs
+func smppDecodeText(dataCoding byte, msg []byte) string {
+	switch dataCoding {
+	case 0x03: // Latin 1
+		rs := make([]rune, len(msg))
+		for i, b := range msg {
+			rs[i] = rune(b)
+		}
+		return string(rs)
+	case 0x08: // UCS2
+		u16 := make([]uint16, len(msg)/2)
+		for i := range u16 {
+			u16[i] = binary.BigEndian.Uint16(msg[2*i:])
+		}
+		return string(utf16.Decode(u16))
+	default:
+		return string(msg)
+	}
+}
+
+// AsMapStorage returns the parameters of the short message used as request within the request processors
+func (sm *smppSM) AsMapStorage() (ms utils.MapStorage) {
+	msg, ref, total, seq := sm.message()
+	ms = utils.MapStorage{
+		"service_type":            sm.ServiceType,
+		"source_addr_ton":         int(sm.SourceAddrTON),
+		"source_addr_npi":         int(sm.SourceAddrNPI),
+		"source_addr":             sm.SourceAddr,
+		"dest_addr_ton":           int(sm.DestAddrTON),
+		"dest_addr_npi":           int(sm.DestAddrNPI),
+		"destination_addr":        sm.DestinationAddr,
+		"esm_class":               int(sm.ESMClass),
+		"protocol_id":             int(sm.ProtocolID),
+		"priority_flag":           int(sm.PriorityFlag),
+		"schedule_delivery_time":  sm.ScheduleDeliveryTime,
+		"validity_period":         sm.ValidityPeriod,
+		"registered_delivery":     int(sm.RegisteredDelivery),
+		"replace_if_present_flag": int(sm.ReplaceIfPresentFlag),
+		"data_coding":             int(sm.DataCoding),
+		"sm_default_msg_id":       int(sm.SMDefaultMsgID),
+		"short_message":           smppDecodeText(sm.DataCoding, msg),
+	}
+	if total != 0 {
+		ms["concat_ref"] = ref
+		ms["concat_total"] = total
+		ms["concat_seq"] = seq
+	}
+	return
+}
+
+// smppConn is a bound SMPP session, matching the responses with the requests sent
+type smppConn struct {
+	conn     net.Conn
+	systemID string // system_id of the peer
+	bound    bool
+
+	wLk     sync.Mutex
+	seq     uint32
+	pendLk  sync.Mutex
+	pending map[uint32]chan *smppPDU
+}
+
+func newSMPPConn(conn net.Conn) *smppConn {
+	return &smppConn{conn: conn, pending: make(map[uint32]chan *smppPDU)}
+}
+
+// writePDU sends the PDU over the connection
+func (c *smppConn) writePDU(p *smppPDU) (err error) {
+	c.wLk.Lock()
+	_, err = c.conn.Write(p.Encode())
+	c.wLk.Unlock()
+	return
+}
+
+// reply sends the response for the request with the given status and body
+func (c *smppConn) reply(req *smppPDU, status uint32, body []byte) error {
+	return c.writePDU(&smppPDU{CommandID: req.CommandID | smppRespMask,
+		Status: status, Seq: req.Seq, Body: body})
+}
+
+// request sends the request and waits for its response
+func (c *smppConn) request(cmdID uint32, body []byte, timeout time.Duration) (rply *smppPDU, err error) {
+	ch := make(chan *smppPDU, 1)
+	c.pendLk.Lock()
+	c.seq++
+	seq := c.seq
+	c.pending[seq] = ch
+	c.pendLk.Unlock()
+	defer func() {
+		c.pendLk.Lock()
+		delete(c.pending, seq)
+		c.pendLk.Unlock()
+	}()
+	if err = c.writePDU(&smppPDU{CommandID: cmdID, Seq: seq, Body: body}); err != nil {
+		return
+	}
+	select {
+	case rply = <-ch:
+		if rply.CommandID == smppGenericNack {
+			return nil, fmt.Errorf("generic_nack received with status: %d", rply.Status)
+		}
+	case <-time.After(timeout):
+		err = utils.ErrTimedOut
+	}
+	return
+}
+
+// readLoop reads the PDUs, dispatching the responses towards the requests waiting for them
+// and the requests towards the handler
+func (c *smppConn) readLoop(handler func(*smppConn, *smppPDU)) (err error) {
+	for {
+		var p *smppPDU
+		if p, err = readSMPPPDU(c.conn); err != nil {
+			return
+		}
+		if p.CommandID&smppRespMask == 0 {
+			handler(c, p)
+			continue
+		}
+		c.pendLk.Lock()
+		ch, has := c.pending[p.Seq]
+		c.pendLk.Unlock()
+		if has {
+			ch <- p
+		}
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// buildSMPPSMBody encodes the body of a submit_sm with the given parameters
+func buildSMPPSMBody(src, dst string, esmClass, dataCoding byte, msg []byte, tlvs ...[]byte) (b []byte) {
+	b = smppAppendCString(b, utils.EmptyString)        // service_type
+	b = append(b, 1, 1)                                // source_addr_ton, source_addr_npi
+	b = smppAppendCString(b, src)                      // source_addr
+	b = append(b, 1, 1)                                // dest_addr_ton, dest_addr_npi
+	b = smppAppendCString(b, dst)                      // destination_addr
+	b = append(b, esmClass, 0, 0)                      // esm_class, protocol_id, priority_flag
+	b = smppAppendCString(b, utils.EmptyString)        // schedule_delivery_time
+	b = smppAppendCString(b, utils.EmptyString)        // validity_period
+	b = append(b, 1, 0, dataCoding, 0, byte(len(msg))) // registered_delivery, replace_if_present_flag, data_coding, sm_default_msg_id, sm_length
+	b = append(b, msg...)
+	for _, tlv := range tlvs {
+		b = append(b, tlv...)
+	}
+	return
+}
+
+func TestSMPPPDUEncodeDecode(t *testing.T) {
+	p := &smppPDU{CommandID: smppSubmitSM, Seq: 7, Body: []byte{0x61, 0x00}}
+	b := p.Encode()
+	if !bytes.Equal(b[:16], []byte{0, 0, 0, 18, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 7}) {
+		t.Errorf("Unexpected header: %x", b[:16])
+	}
+	if rcv, err := readSMPPPDU(bytes.NewReader(b)); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(p, rcv) {
+		t.Errorf("Expected %+v, received %+v", p, rcv)
+	}
+	if _, err := readSMPPPDU(bytes.NewReader([]byte{0, 0, 0, 8, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0, 1})); err == nil {
+		t.Error("Expected error for invalid command_length")
+	}
+}
+
+func TestSMPPBindEncodeDecode(t *testing.T) {
+	bnd := &smppBind{SystemID: "esme1", Password: "secret", SystemType: "VMA", InterfaceVersion: 0x34}
+	if rcv, err := decodeSMPPBind(bnd.Encode()); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(bnd, rcv) {
+		t.Errorf("Expected %+v, received %+v", bnd, rcv)
+	}
+	if _, err := decodeSMPPBind([]byte("esme1")); err == nil {
+		t.Error("Expected error for not terminated system_id")
+	}
+}
+
+func TestSMPPCommandStatus(t *testing.T) {
+	if sts, err := smppCommandStatus("ESME_RTHROTTLED"); err != nil {
+		t.Error(err)
+	} else if sts != smppStatusThrottled {
+		t.Errorf("Unexpected status: %d", sts)
+	}
+	if sts, err := smppCommandStatus("0x45"); err != nil {
+		t.Error(err)
+	} else if sts != smppStatusSubmitFail {
+		t.Errorf("Unexpected status: %d", sts)
+	}
+	if _, err := smppCommandStatus("ESME_NOT_DEFINED"); err == nil {
+		t.Error("Expected error for unknown status")
+	}
+}
+
+func TestSMPPSMAsMapStorage(t *testing.T) {
+	sm, err := decodeSMPPSM(buildSMPPSMBody("1001", "1002", 0, 0, []byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := utils.MapStorage{
+		"service_type":            "",
+		"source_addr_ton":         1,
+		"source_addr_npi":         1,
+		"source_addr":             "1001",
+		"dest_addr_ton":           1,
+		"dest_addr_npi":           1,
+		"destination_addr":        "1002",
+		"esm_class":               0,
+		"protocol_id":             0,
+		"priority_flag":           0,
+		"schedule_delivery_time":  "",
+		"validity_period":         "",
+		"registered_delivery":     1,
+		"replace_if_present_flag": 0,
+		"data_coding":             0,
+		"sm_default_msg_id":       0,
+		"short_message":           "hello",
+	}
+	if rcv := sm.AsMapStorage(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestSMPPSMConcatUCS2(t *testing.T) {
+	// UDH with 8-bit reference concatenation followed by "hé" in UCS2
+	msg := []byte{0x05, 0x00, 0x03, 0x2A, 0x02, 0x01, 0x00, 0x68, 0x00, 0xE9}
+	sm, err := decodeSMPPSM(buildSMPPSMBody("1001", "1002", smppUDHIndicator, 0x08, msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := sm.AsMapStorage()
+	if ms["short_message"] != "hé" {
+		t.Errorf("Unexpected short_message: %q", ms["short_message"])
+	}
+	if ms["concat_ref"] != 42 || ms["concat_total"] != 2 || ms["concat_seq"] != 1 {
+		t.Errorf("Unexpected concatenation info: %s", utils.ToJSON(ms))
+	}
+}
+
+func TestSMPPSMMessagePayload(t *testing.T) {
+	tlv := []byte{0x04, 0x24, 0x00, 0x03, 'h', 0xE9, '!'} // message_payload in Latin 1
+	sm, err := decodeSMPPSM(buildSMPPSMBody("1001", "1002", 0, 0x03, nil, tlv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := sm.AsMapStorage()["short_message"]; rcv != "hé!" {
+		t.Errorf("Unexpected short_message: %q", rcv)
+	}
+	if _, err := decodeSMPPSM(buildSMPPSMBody("1001", "1002", 0, 0, []byte("hello"))[:20]); err == nil {
+		t.Error("Expected error for truncated body")
+	}
+}
@@ -0,0 +1,428 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewOpenSIPSAgent returns the agent receiving the OpenSIPS events over event_datagram
+func NewOpenSIPSAgent(oaCfg *config.OsipsAgentCfg,
+	connMgr *engine.ConnManager, timezone string) (oa *OpenSIPSAgent) {
+	return &OpenSIPSAgent{
+		cfg:      oaCfg,
+		connMgr:  connMgr,
+		timezone: timezone,
+		dialogs:  make(map[string]OsipsEvent),
+	}
+}
+
+// OpenSIPSAgent charges the OpenSIPS dialogs based on E_DLG_STATE_CHANGED and E_ACC_CDR events
+// the dialogs are controlled over the MI JSON interface
+type OpenSIPSAgent struct {
+	cfg      *config.OsipsAgentCfg
+	connMgr  *engine.ConnManager
+	timezone string
+	conns    []*osipsMIConn
+
+	lnMux sync.RWMutex
+	ln    net.PacketConn
+
+	dlgsMux sync.Mutex
+	dialogs map[string]OsipsEvent // confirmed dialogs, indexed on OriginID
+}
+
+// ListenAndServe connects to the MI interfaces and reads the events until Shutdown
+func (oa *OpenSIPSAgent) ListenAndServe() (err error) {
+	replyTimeout := config.CgrConfig().GeneralCfg().ReplyTimeout
+	conns := make([]*osipsMIConn, len(oa.cfg.MiConns))
+	for connIdx, connCfg := range oa.cfg.MiConns {
+		if conns[connIdx], err = newOsipsMIConn(connCfg, replyTimeout); err != nil {
+			return
+		}
+	}
+	var ln net.PacketConn
+	if ln, err = net.ListenPacket(utils.UDP, oa.cfg.ListenUDP); err != nil {
+		return
+	}
+	oa.lnMux.Lock()
+	oa.conns = conns
+	oa.ln = ln
+	oa.lnMux.Unlock()
+	utils.Logger.Info(fmt.Sprintf("<%s> start listening for OpenSIPS events on <%s>",
+		utils.OpenSIPSAgent, oa.cfg.ListenUDP))
+	buf := make([]byte, osipsBufferSize)
+	for {
+		var n int
+		var addr net.Addr
+		if n, addr, err = ln.ReadFrom(buf); err != nil {
+			if strings.Contains(err.Error(), "use of closed network connection") { // closed by us
+				err = nil
+			}
+			return
+		}
+		var notif osipsNotification
+		if err := json.Unmarshal(buf[:n], &notif); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> unmarshalling event data: %s, error: %s",
+				utils.OpenSIPSAgent, buf[:n], err.Error()))
+			continue
+		}
+		oa.handleEvent(&notif, oa.connIdx(addr))
+	}
+}
+
+// Shutdown stops listening for events
+func (oa *OpenSIPSAgent) Shutdown() (err error) {
+	oa.lnMux.Lock()
+	defer oa.lnMux.Unlock()
+	if oa.ln == nil {
+		return
+	}
+	err = oa.ln.Close()
+	oa.ln = nil
+	return
+}
+
+// Reload drops the cached dialogs
+// only used on reload
+func (oa *OpenSIPSAgent) Reload() {
+	oa.dlgsMux.Lock()
+	oa.dialogs = make(map[string]OsipsEvent)
+	oa.dlgsMux.Unlock()
+}
+
+// connIdx returns the MI connection of the OpenSIPS sending the event
+// defaults to the first connection if the source is not matching any MI address
+func (oa *OpenSIPSAgent) connIdx(addr net.Addr) int {
+	udpAddr, canCast := addr.(*net.UDPAddr)
+	if !canCast {
+		return 0
+	}
+	for i, conn := range oa.miConns() {
+		if conn.host == udpAddr.IP.String() {
+			return i
+		}
+	}
+	return 0
+}
+
+func (oa *OpenSIPSAgent) miConns() []*osipsMIConn {
+	oa.lnMux.RLock()
+	defer oa.lnMux.RUnlock()
+	return oa.conns
+}
+
+// miConn returns the MI connection with the given index
+func (oa *OpenSIPSAgent) miConn(connIdx int) (*osipsMIConn, error) {
+	conns := oa.miConns()
+	if connIdx < 0 || connIdx >= len(conns) { // protection against index out of range panic
+		return nil, fmt.Errorf("Index out of range[0,%v): %v ", len(conns), connIdx)
+	}
+	return conns[connIdx], nil
+}
+
+// handleEvent dispatches the event received over event_datagram
+// the dialog cache is updated in order of events, the SessionS calls are done asynchronously
+func (oa *OpenSIPSAgent) handleEvent(notif *osipsNotification, connIdx int) {
+	conn, err := oa.miConn(connIdx)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.OpenSIPSAgent, err.Error()))
+		return
+	}
+	oev := NewOsipsEvent(notif.Method, notif.Params, conn.originHost())
+	if oev.MissingParameter() {
+		utils.Logger.Err(fmt.Sprintf("<%s> mandatory IE missing out from event: %s",
+			utils.OpenSIPSAgent, oev))
+		return
+	}
+	switch notif.Method {
+	case OsipsDlgStateChanged:
+		switch oev[osipsNewState] {
+		case osipsDlgStateConfirmed:
+			oa.onDlgConfirmed(oev, connIdx)
+		case osipsDlgStateDeleted:
+			oa.onDlgDeleted(oev)
+		}
+	case OsipsAccCDR:
+		go oa.onAccCDR(oev, connIdx)
+	}
+}
+
+// onDlgConfirmed retrieves the dialog values over MI and initiates the session
+func (oa *OpenSIPSAgent) onDlgConfirmed(oev OsipsEvent, connIdx int) {
+	conn, err := oa.miConn(connIdx)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.OpenSIPSAgent, err.Error()))
+		return
+	}
+	var dlgs osipsDlgListReply
+	if err = conn.Call(osipsDlgListCtx, map[string]string{
+		osipsCallID:  oev[osipsCallID],
+		osipsFromTag: oev[osipsFromTag],
+	}, &dlgs); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> could not list dialog %s, error: %s",
+			utils.OpenSIPSAgent, oev.OriginID(), err.Error()))
+		return
+	}
+	var dlg *osipsDialog
+	for _, d := range dlgs.Dialogs {
+		if d.CallID == oev[osipsCallID] && d.Caller.Tag == oev[osipsFromTag] {
+			dlg = d
+			break
+		}
+	}
+	if dlg == nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> dialog %s not found", utils.OpenSIPSAgent, oev.OriginID()))
+		return
+	}
+	dlgEv := NewOsipsDlgEvent(dlg, oev[utils.OriginHost])
+	if dlgEv[utils.RequestType] == utils.MetaNone { // Do not process this request
+		return
+	}
+	oa.dlgsMux.Lock()
+	oa.dialogs[dlgEv.OriginID()] = dlgEv
+	oa.dlgsMux.Unlock()
+	go oa.initSession(dlgEv, connIdx)
+}
+
+func (oa *OpenSIPSAgent) initSession(oev OsipsEvent, connIdx int) {
+	initSessionArgs := oev.V1InitSessionArgs(oa.timezone)
+	if initSessionArgs == nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate init session arguments",
+			utils.OpenSIPSAgent, oev.OriginID()))
+		return
+	}
+	initSessionArgs.CGREvent.Event[OsipsConnID] = connIdx // Attach the connection ID so we can properly disconnect later
+	var initReply sessions.V1InitSessionReply
+	if err := oa.connMgr.Call(oa.cfg.SessionSConns, oa, utils.SessionSv1InitiateSession,
+		initSessionArgs, &initReply); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> could not process answer for event %s, error: %s",
+				utils.OpenSIPSAgent, oev.OriginID(), err.Error()))
+		oa.disconnectSession(connIdx, oev[OsipsDialogID])
+	}
+}
+
+// onDlgDeleted terminates the session of a confirmed dialog
+func (oa *OpenSIPSAgent) onDlgDeleted(oev OsipsEvent) {
+	oa.dlgsMux.Lock()
+	dlgEv, has := oa.dialogs[oev.OriginID()]
+	delete(oa.dialogs, oev.OriginID())
+	oa.dlgsMux.Unlock()
+	if !has { // not confirmed or not charged
+		return
+	}
+	dlgEv[EVENT] = oev[EVENT]
+	go oa.terminateSession(dlgEv, time.Now())
+}
+
+func (oa *OpenSIPSAgent) terminateSession(oev OsipsEvent, endTime time.Time) {
+	tsArgs := oev.V1TerminateSessionArgs(oa.timezone)
+	if tsArgs == nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate terminate session arguments",
+			utils.OpenSIPSAgent, oev.OriginID()))
+		return
+	}
+	if _, has := tsArgs.CGREvent.Event[utils.Usage]; !has {
+		tsArgs.CGREvent.Event[utils.Usage] = endTime.Sub(*tsArgs.CGREvent.Time)
+	}
+	var reply string
+	if err := oa.connMgr.Call(oa.cfg.SessionSConns, oa, utils.SessionSv1TerminateSession,
+		tsArgs, &reply); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> could not terminate session with event %s, error: %s",
+				utils.OpenSIPSAgent, oev.OriginID(), err.Error()))
+		// no return here since we want CDR anyhow
+	}
+	if oa.cfg.CreateCdr || strings.Contains(oev[utils.CGRFlags], utils.MetaCDRs) {
+		if err := oa.connMgr.Call(oa.cfg.SessionSConns, oa, utils.SessionSv1ProcessCDR,
+			tsArgs.CGREvent, &reply); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> failed processing CGREvent: %s, error: %s",
+				utils.OpenSIPSAgent, utils.ToJSON(tsArgs.CGREvent), err.Error()))
+		}
+	}
+}
+
+// onAccCDR sends the CDR generated by the acc module to SessionS
+func (oa *OpenSIPSAgent) onAccCDR(oev OsipsEvent, connIdx int) {
+	if oev[utils.RequestType] == utils.MetaNone { // Do not process this request
+		return
+	}
+	procCDRArgs := oev.V1ProcessCDRArgs(oa.timezone)
+	if procCDRArgs == nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate process cdr session arguments",
+			utils.OpenSIPSAgent, oev.OriginID()))
+		return
+	}
+	procCDRArgs.Event[OsipsConnID] = connIdx // Attach the connection ID
+	var reply string
+	if err := oa.connMgr.Call(oa.cfg.SessionSConns, oa, utils.SessionSv1ProcessCDR,
+		procCDRArgs, &reply); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed processing CGREvent: %s, error: %s",
+			utils.OpenSIPSAgent, utils.ToJSON(procCDRArgs), err.Error()))
+	}
+}
+
+// disconnectSession ends the dialog over MI
+func (oa *OpenSIPSAgent) disconnectSession(connIdx int, dlgID string) (err error) {
+	var conn *osipsMIConn
+	if conn, err = oa.miConn(connIdx); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.OpenSIPSAgent, err.Error()))
+		return
+	}
+	if err = conn.Call(osipsDlgEndDlg, map[string]string{"dialog_id": dlgID}, nil); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed ending dialog: %s, connection id: %v, error %s",
+			utils.OpenSIPSAgent, dlgID, connIdx, err.Error()))
+	}
+	return
+}
+
+// rpcclient.ClientConnector interface
+func (oa *OpenSIPSAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(oa, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is internal method to disconnect session in OpenSIPS
+func (oa *OpenSIPSAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	dlgID := utils.IfaceAsString(args.EventStart[OsipsDialogID])
+	connIdxIface, has := args.EventStart[OsipsConnID]
+	if !has || dlgID == utils.EmptyString {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to disconnect <%s:%s>",
+				utils.OpenSIPSAgent, utils.ErrNotFound.Error(), OsipsConnID,
+				OsipsDialogID, dlgID))
+		return
+	}
+	connIdx, err := utils.IfaceAsTInt64(connIdxIface)
+	if err != nil {
+		return err
+	}
+	if err = oa.disconnectSession(int(connIdx), dlgID); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs returns a list of CGRIDs based on active sessions from agent
+func (oa *OpenSIPSAgent) V1GetActiveSessionIDs(ignParam string, sessionIDs *[]*sessions.SessionID) (err error) {
+	var listed int
+	for i, conn := range oa.miConns() {
+		var dlgs osipsDlgListReply
+		if err := conn.Call(osipsDlgList, nil, &dlgs); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> failed listing dialogs on connIdx<%v>, error %s",
+				utils.OpenSIPSAgent, i, err.Error()))
+			continue
+		}
+		listed++
+		for _, dlg := range dlgs.Dialogs {
+			*sessionIDs = append(*sessionIDs, &sessions.SessionID{
+				OriginHost: conn.originHost(),
+				OriginID:   dlg.CallID + utils.InfieldSep + dlg.Caller.Tag,
+			})
+		}
+	}
+	if listed == 0 {
+		return errors.New("failed executing dialog list")
+	}
+	if len(*sessionIDs) == 0 {
+		return utils.ErrNoActiveSession
+	}
+	return
+}
+
+// V1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (*OpenSIPSAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*OpenSIPSAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*OpenSIPSAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (oa *OpenSIPSAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(oa, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is internal method to disconnect session in OpenSIPS
+func (oa *OpenSIPSAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return oa.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is internal method to get all active sessions in OpenSIPS
+func (oa *OpenSIPSAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return oa.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (oa *OpenSIPSAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return oa.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (oa *OpenSIPSAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return oa.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (oa *OpenSIPSAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return oa.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (oa *OpenSIPSAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return oa.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return oa.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return oa.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return oa.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return oa.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestOsipsEventAccCDR(t *testing.T) {
+	oev := NewOsipsEvent(OsipsAccCDR, map[string]interface{}{
+		osipsMethod:                   "INVITE",
+		osipsCallID:                   "call1",
+		osipsFromTag:                  "tag1",
+		osipsToTag:                    "tag2",
+		osipsSIPCode:                  "200",
+		osipsSIPReason:                "OK",
+		osipsTime:                     1610000005.,
+		osipsCreated:                  1610000000.,
+		osipsDuration:                 10.,
+		osipsMsDuration:               10500.,
+		utils.AccountField:            "1001",
+		utils.Destination:             "1002",
+		utils.CGRFlags:                "*accounts",
+		utils.OptsSessionsTTLMaxDelay: "2s",
+	}, "osips1")
+	if oev.MissingParameter() {
+		t.Fatal("Unexpected missing parameter")
+	}
+	cgrEv := oev.V1ProcessCDRArgs(utils.EmptyString)
+	if cgrEv == nil {
+		t.Fatal("Expected process CDR arguments")
+	}
+	exp := map[string]interface{}{
+		utils.OriginID:        "call1;tag1",
+		utils.OriginHost:      "osips1",
+		utils.AccountField:    "1001",
+		utils.Destination:     "1002",
+		utils.SetupTime:       "1610000000",
+		utils.AnswerTime:      "1610000005",
+		utils.Usage:           "10500ms",
+		utils.DisconnectCause: "OK",
+		utils.Source:          utils.OpenSIPSAgent,
+		utils.RequestType:     config.CgrConfig().GeneralCfg().DefaultReqType,
+	}
+	if !reflect.DeepEqual(exp, cgrEv.Event) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(cgrEv.Event))
+	}
+	if !cgrEv.Time.Equal(time.Unix(1610000005, 0)) {
+		t.Errorf("Unexpected time: %s", cgrEv.Time)
+	}
+	if expOpts := map[string]interface{}{utils.OptsSessionsTTLMaxDelay: "2s"}; !reflect.DeepEqual(expOpts, cgrEv.APIOpts) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expOpts), utils.ToJSON(cgrEv.APIOpts))
+	}
+	if oev = NewOsipsEvent(OsipsAccCDR, map[string]interface{}{osipsCallID: "call1"}, "osips1"); !oev.MissingParameter() {
+		t.Error("Expected missing parameter")
+	}
+}
+
+// testOsipsMIServer emulates the MI JSON interface of OpenSIPS
+type testOsipsMIServer struct {
+	dlgs   []*osipsDialog
+	ctxDlg *osipsDialog
+	ended  chan string
+}
+
+func (srv *testOsipsMIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string            `json:"method"`
+		Params map[string]string `json:"params"`
+		ID     uint64            `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var result interface{}
+	switch req.Method {
+	case osipsDlgList:
+		result = &osipsDlgListReply{Dialogs: srv.dlgs}
+	case osipsDlgListCtx:
+		result = &osipsDlgListReply{Dialogs: []*osipsDialog{srv.ctxDlg}}
+	case osipsDlgEndDlg:
+		srv.ended <- req.Params["dialog_id"]
+		result = utils.OK
+	default:
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID,
+			"error": map[string]interface{}{"code": -32601, "message": "Method not found"}})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID, "result": result})
+}
+
+func TestOpenSIPSAgent(t *testing.T) {
+	ctxDlg := &osipsDialog{ID: "1234", State: 4, Timestart: 1610000005, CallID: "call1"}
+	ctxDlg.Caller.Tag = "tag1"
+	ctxDlg.Context.Values = []map[string]interface{}{
+		{utils.AccountField: "1001"},
+		{utils.Destination: "1002"},
+	}
+	listDlg := &osipsDialog{ID: "1235", State: 4, CallID: "call2"}
+	listDlg.Caller.Tag = "tag2"
+	mi := &testOsipsMIServer{dlgs: []*osipsDialog{listDlg}, ctxDlg: ctxDlg, ended: make(chan string, 1)}
+	httpSrv := httptest.NewServer(mi)
+	defer httpSrv.Close()
+
+	initiated := make(chan *sessions.V1InitSessionArgs, 1)
+	terminated := make(chan *sessions.V1TerminateSessionArgs, 1)
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1InitiateSession: func(arg interface{}, rply interface{}) error {
+			initiated <- arg.(*sessions.V1InitSessionArgs)
+			return nil
+		},
+		utils.SessionSv1TerminateSession: func(arg interface{}, rply interface{}) error {
+			terminated <- arg.(*sessions.V1TerminateSessionArgs)
+			*rply.(*string) = utils.OK
+			return nil
+		},
+	}}
+	sSChan := make(chan rpcclient.ClientConnector, 1)
+	sSChan <- sS
+	cfg := config.NewDefaultCGRConfig()
+	oaCfg := cfg.OsipsAgentCfg()
+	oaCfg.ListenUDP = "127.0.0.1:0"
+	oaCfg.MiConns[0].MiAddr = httpSrv.URL
+	oaCfg.MiConns[0].Alias = "osips1"
+	oaCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	engine.Cache.Clear([]string{utils.CacheRPCConnections})
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): sSChan,
+	})
+	oa := NewOpenSIPSAgent(oaCfg, connMgr, utils.EmptyString)
+	errChan := make(chan error, 1)
+	go func() { errChan <- oa.ListenAndServe() }()
+	defer func() {
+		if err := oa.Shutdown(); err != nil {
+			t.Error(err)
+		}
+		if err := <-errChan; err != nil {
+			t.Error(err)
+		}
+	}()
+	var addr net.Addr
+	for i := 0; i < 50 && addr == nil; i++ {
+		oa.lnMux.RLock()
+		if oa.ln != nil {
+			addr = oa.ln.LocalAddr()
+		}
+		oa.lnMux.RUnlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if addr == nil {
+		t.Fatal("agent not listening")
+	}
+	conn, err := net.Dial(utils.UDP, addr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	sendEvent := func(method string, params map[string]interface{}) {
+		if _, err := conn.Write([]byte(utils.ToJSON(&osipsNotification{Method: method, Params: params}))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sendEvent(OsipsDlgStateChanged, map[string]interface{}{osipsCallID: "call1", osipsFromTag: "tag1",
+		osipsHashEntry: 0, osipsHashID: 1234, osipsOldState: 2, osipsNewState: 4})
+	select {
+	case args := <-initiated:
+		if args.CGREvent.Event[utils.OriginID] != "call1;tag1" ||
+			args.CGREvent.Event[utils.AccountField] != "1001" ||
+			args.CGREvent.Event[utils.OriginHost] != "osips1" ||
+			args.CGREvent.Event[OsipsDialogID] != "1234" {
+			t.Errorf("Unexpected initiate event: %s", utils.ToJSON(args.CGREvent))
+		}
+		var reply string
+		if err := oa.V1DisconnectSession(utils.AttrDisconnectSession{EventStart: args.CGREvent.Event}, &reply); err != nil {
+			t.Error(err)
+		} else if reply != utils.OK {
+			t.Errorf("Unexpected reply: %s", reply)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("session not initiated")
+	}
+	if dlgID := <-mi.ended; dlgID != "1234" {
+		t.Errorf("Unexpected dialog ended: %s", dlgID)
+	}
+
+	sendEvent(OsipsDlgStateChanged, map[string]interface{}{osipsCallID: "call1", osipsFromTag: "tag1",
+		osipsHashEntry: 0, osipsHashID: 1234, osipsOldState: 4, osipsNewState: 5})
+	select {
+	case args := <-terminated:
+		if args.CGREvent.Event[utils.OriginID] != "call1;tag1" {
+			t.Errorf("Unexpected terminate event: %s", utils.ToJSON(args.CGREvent))
+		}
+		if _, has := args.CGREvent.Event[utils.Usage]; !has {
+			t.Errorf("Expected usage in terminate event: %s", utils.ToJSON(args.CGREvent))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("session not terminated")
+	}
+
+	var sIDs []*sessions.SessionID
+	if err := oa.V1GetActiveSessionIDs(utils.EmptyString, &sIDs); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []*sessions.SessionID{{OriginHost: "osips1", OriginID: "call2;tag2"}}; !reflect.DeepEqual(exp, sIDs) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(sIDs))
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// OpenSIPS events followed by OpenSIPSAgent
+const (
+	OsipsAccCDR          = "E_ACC_CDR"
+	OsipsDlgStateChanged = "E_DLG_STATE_CHANGED"
+	OsipsConnID          = "OsipsConnID"   // used to share connID info in event for remote disconnects
+	OsipsDialogID        = "OsipsDialogID" // the dialog_id used by dlg_end_dlg
+
+	osipsDlgStateConfirmed = "4"
+	osipsDlgStateDeleted   = "5"
+
+	osipsCallID     = "callid"
+	osipsFromTag    = "from_tag"
+	osipsToTag      = "to_tag"
+	osipsMethod     = "method"
+	osipsHashEntry  = "hash_entry"
+	osipsHashID     = "hash_id"
+	osipsOldState   = "old_state"
+	osipsNewState   = "new_state"
+	osipsTime       = "time"
+	osipsCreated    = "created"
+	osipsSetupTime  = "setuptime"
+	osipsDuration   = "duration"
+	osipsMsDuration = "ms_duration"
+	osipsSIPCode    = "sip_code"
+	osipsSIPReason  = "sip_reason"
+)
+
+var osipsReservedEventFields = utils.NewStringSet([]string{EVENT, utils.CGRFlags,
+	osipsCallID, osipsFromTag, osipsToTag, osipsMethod, osipsHashEntry, osipsHashID,
+	osipsOldState, osipsNewState, osipsTime, osipsCreated, osipsSetupTime,
+	osipsDuration, osipsMsDuration, osipsSIPCode, osipsSIPReason})
+
+// NewOsipsEvent builds the event out of the parameters received over event_datagram
+func NewOsipsEvent(evName string, params map[string]interface{}, originHost string) (oev OsipsEvent) {
+	oev = make(OsipsEvent)
+	for k, v := range params {
+		oev[k] = utils.IfaceAsString(v)
+	}
+	oev[EVENT] = evName
+	oev[utils.OriginHost] = utils.FirstNonEmpty(oev[utils.OriginHost], originHost)
+	return
+}
+
+// NewOsipsDlgEvent builds the event out of the dialog listed over MI
+// the dialog values (ie. $dlg_val(Account)) are populating the event
+func NewOsipsDlgEvent(dlg *osipsDialog, originHost string) (oev OsipsEvent) {
+	oev = make(OsipsEvent)
+	for _, vals := range dlg.Context.Values {
+		for k, v := range vals {
+			oev[k] = utils.IfaceAsString(v)
+		}
+	}
+	oev[EVENT] = OsipsDlgStateChanged
+	oev[osipsCallID] = dlg.CallID
+	oev[osipsFromTag] = dlg.Caller.Tag
+	oev[OsipsDialogID] = dlg.ID.String()
+	if _, has := oev[utils.AnswerTime]; !has && dlg.Timestart != 0 {
+		oev[utils.AnswerTime] = utils.IfaceAsString(dlg.Timestart)
+	}
+	oev[utils.OriginHost] = utils.FirstNonEmpty(oev[utils.OriginHost], originHost)
+	return
+}
+
+// OsipsEvent represents one event received from OpenSIPS
+type OsipsEvent map[string]string
+
+// OriginID is built out of the Call-ID and the From tag, the same way dlg_list is reporting the dialogs
+func (oev OsipsEvent) OriginID() string {
+	if oID := oev[utils.OriginID]; oID != utils.EmptyString {
+		return oID
+	}
+	return oev[osipsCallID] + utils.InfieldSep + oev[osipsFromTag]
+}
+
+// MissingParameter returns true if the event cannot identify the dialog
+func (oev OsipsEvent) MissingParameter() bool {
+	if oev[utils.OriginID] != utils.EmptyString {
+		return false
+	}
+	return utils.IsSliceMember([]string{
+		oev[osipsCallID],
+		oev[osipsFromTag],
+	}, utils.EmptyString)
+}
+
+// AsMapStringInterface converts OsipsEvent into event used by other subsystems
+func (oev OsipsEvent) AsMapStringInterface() (mp map[string]interface{}) {
+	mp = make(map[string]interface{})
+	for k, v := range oev {
+		if !osipsReservedEventFields.Has(k) && // reserved attributes not getting into event
+			!utils.CGROptionsSet.Has(k) { // also omit the options
+			mp[k] = v
+		}
+	}
+	mp[utils.OriginID] = oev.OriginID()
+	if oev[EVENT] == OsipsAccCDR {
+		oev.populateCDRFields(mp)
+	}
+	if _, has := mp[utils.Source]; !has {
+		mp[utils.Source] = utils.OpenSIPSAgent
+	}
+	if _, has := mp[utils.RequestType]; !has {
+		mp[utils.RequestType] = config.CgrConfig().GeneralCfg().DefaultReqType
+	}
+	return
+}
+
+// populateCDRFields adds the accounting information of E_ACC_CDR if not already provided by extra attributes
+func (oev OsipsEvent) populateCDRFields(mp map[string]interface{}) {
+	if _, has := mp[utils.SetupTime]; !has && oev[osipsCreated] != utils.EmptyString {
+		mp[utils.SetupTime] = oev[osipsCreated]
+	}
+	if _, has := mp[utils.AnswerTime]; !has && oev[osipsTime] != utils.EmptyString {
+		mp[utils.AnswerTime] = oev[osipsTime]
+	}
+	if _, has := mp[utils.Usage]; !has {
+		if msDur := oev[osipsMsDuration]; msDur != utils.EmptyString {
+			mp[utils.Usage] = msDur + "ms"
+		} else if dur := oev[osipsDuration]; dur != utils.EmptyString {
+			mp[utils.Usage] = dur + "s"
+		}
+	}
+	if _, has := mp[utils.DisconnectCause]; !has && oev[osipsSIPReason] != utils.EmptyString {
+		mp[utils.DisconnectCause] = oev[osipsSIPReason]
+	}
+}
+
+// GetOptions returns the options populated by the event
+func (oev OsipsEvent) GetOptions() (mp map[string]interface{}) {
+	mp = make(map[string]interface{})
+	for k := range utils.CGROptionsSet {
+		if val, has := oev[k]; has {
+			mp[k] = val
+		}
+	}
+	return
+}
+
+// AsCGREvent converts OsipsEvent into CGREvent
+func (oev OsipsEvent) AsCGREvent(timezone string) (cgrEv *utils.CGREvent, err error) {
+	ev := oev.AsMapStringInterface()
+	evTime := time.Now()
+	if aTime, has := ev[utils.AnswerTime]; has {
+		if evTime, err = utils.ParseTimeDetectLayout(utils.IfaceAsString(aTime), timezone); err != nil {
+			return
+		}
+	}
+	return &utils.CGREvent{
+		Tenant: utils.FirstNonEmpty(oev[utils.Tenant],
+			config.CgrConfig().GeneralCfg().DefaultTenant),
+		ID:      utils.UUIDSha1Prefix(),
+		Time:    &evTime,
+		Event:   ev,
+		APIOpts: oev.GetOptions(),
+	}, nil
+}
+
+// String is used for pretty printing event in logs
+func (oev OsipsEvent) String() string {
+	return utils.ToJSON(oev)
+}
+
+// V1InitSessionArgs returns the arguments used in SessionSv1.InitSession
+func (oev OsipsEvent) V1InitSessionArgs(timezone string) (args *sessions.V1InitSessionArgs) {
+	cgrEv, err := oev.AsCGREvent(timezone)
+	if err != nil {
+		return
+	}
+	args = &sessions.V1InitSessionArgs{ // defaults
+		CGREvent: cgrEv,
+	}
+	subsystems, has := oev[utils.CGRFlags]
+	if !has {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cgr_flags is not set, using defaults",
+			utils.OpenSIPSAgent))
+		args.InitSession = true
+		return
+	}
+	args.ParseFlags(subsystems, utils.InfieldSep)
+	return
+}
+
+// V1TerminateSessionArgs returns the arguments used in SessionSv1.TerminateSession
+func (oev OsipsEvent) V1TerminateSessionArgs(timezone string) (args *sessions.V1TerminateSessionArgs) {
+	cgrEv, err := oev.AsCGREvent(timezone)
+	if err != nil {
+		return
+	}
+	args = &sessions.V1TerminateSessionArgs{ // defaults
+		TerminateSession: true,
+		CGREvent:         cgrEv,
+	}
+	subsystems, has := oev[utils.CGRFlags]
+	if !has {
+		utils.Logger.Warning(fmt.Sprintf("<%s> cgr_flags is not set, using defaults",
+			utils.OpenSIPSAgent))
+		return
+	}
+	args.ParseFlags(subsystems, utils.InfieldSep)
+	return
+}
+
+// V1ProcessCDRArgs returns the arguments used in SessionSv1.ProcessCDR
+func (oev OsipsEvent) V1ProcessCDRArgs(timezone string) (args *utils.CGREvent) {
+	var err error
+	if args, err = oev.AsCGREvent(timezone); err != nil {
+		return
+	}
+	return
+}
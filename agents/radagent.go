@@ -20,12 +20,17 @@ package agents
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
 
+	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
 )
 
 const (
@@ -51,15 +56,15 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 			return
 		}
 	}
-	dicts := radigo.NewDictionaries(dts)
-	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr}
-	secrets := radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)
+	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr,
+		dicts:   radigo.NewDictionaries(dts),
+		secrets: radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)}
 	ra.rsAuth = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
-		cgrCfg.RadiusAgentCfg().ListenAuth, secrets, dicts,
+		cgrCfg.RadiusAgentCfg().ListenAuth, ra.secrets, ra.dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
 			radigo.AccessRequest: ra.handleAuth}, nil)
 	ra.rsAcct = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
-		cgrCfg.RadiusAgentCfg().ListenAcct, secrets, dicts,
+		cgrCfg.RadiusAgentCfg().ListenAcct, ra.secrets, ra.dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
 			radigo.AccountingRequest: ra.handleAcct}, nil)
 	return
@@ -69,8 +74,20 @@ type RadiusAgent struct {
 	cgrCfg  *config.CGRConfig // reference for future config reloads
 	connMgr *engine.ConnManager
 	filterS *engine.FilterS
+	dicts   *radigo.Dictionaries
+	secrets *radigo.Secrets
 	rsAuth  *radigo.Server
 	rsAcct  *radigo.Server
+	daID    uint32 // identifier of the last Dynamic Authorization request
+}
+
+// radPacketData is the cached data needed to build the Dynamic Authorization requests
+type radPacketData struct {
+	pkt        *radigo.Packet
+	vars       *utils.DataNode
+	client     string // host of the client, used to index the client configuration
+	originHost string // OriginHost of the session, known once the request fields are processed
+	initiated  bool   // the session was initiated, the authorized only requests are not active sessions
 }
 
 // handleAuth handles RADIUS Authorization request
@@ -84,6 +101,7 @@ func (ra *RadiusAgent) handleAuth(req *radigo.Packet) (rpl *radigo.Packet, err e
 	opts := utils.MapStorage{}
 	var processed bool
 	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.RemoteHost: utils.NewLeafNode(req.RemoteAddr().String())}}
+	ra.cacheRequest(req, dcdr, reqVars)
 	for _, reqProcessor := range ra.cgrCfg.RadiusAgentCfg().RequestProcessors {
 		agReq := NewAgentRequest(dcdr, reqVars, cgrRplyNM, rplyNM, opts,
 			reqProcessor.Tenant, ra.cgrCfg.GeneralCfg().DefaultTenant,
@@ -129,6 +147,7 @@ func (ra *RadiusAgent) handleAcct(req *radigo.Packet) (rpl *radigo.Packet, err e
 	opts := utils.MapStorage{}
 	var processed bool
 	reqVars := &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{utils.RemoteHost: utils.NewLeafNode(req.RemoteAddr().String())}}
+	ra.cacheRequest(req, dcdr, reqVars)
 	for _, reqProcessor := range ra.cgrCfg.RadiusAgentCfg().RequestProcessors {
 		agReq := NewAgentRequest(dcdr, reqVars, cgrRplyNM, rplyNM, opts,
 			reqProcessor.Tenant, ra.cgrCfg.GeneralCfg().DefaultTenant,
@@ -171,6 +190,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 		return
 	}
 	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	ra.updateCachedRequest(cgrEv, false)
 	var reqType string
 	for _, typ := range []string{
 		utils.MetaDryRun, utils.MetaAuthorize,
@@ -222,7 +242,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1AuthorizeReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		agReq.setCGRReply(rply, err)
@@ -238,10 +258,13 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1InitSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1InitiateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
 			initArgs, rply)
 		rply.SetMaxUsageNeeded(initArgs.InitSession)
 		agReq.setCGRReply(rply, err)
+		if err == nil && initArgs.InitSession {
+			ra.updateCachedRequest(cgrEv, true)
+		}
 	case utils.MetaUpdate:
 		updateArgs := sessions.NewV1UpdateSessionArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -249,10 +272,13 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1UpdateSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1UpdateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1UpdateSession,
 			updateArgs, rply)
 		rply.SetMaxUsageNeeded(updateArgs.UpdateSession)
 		agReq.setCGRReply(rply, err)
+		if err == nil && updateArgs.UpdateSession { // SessionS initiates the session if missing
+			ra.updateCachedRequest(cgrEv, true)
+		}
 	case utils.MetaTerminate:
 		terminateArgs := sessions.NewV1TerminateSessionArgs(
 			reqProcessor.Flags.Has(utils.MetaAccounts),
@@ -263,9 +289,13 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		var rply string
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1TerminateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1TerminateSession,
 			terminateArgs, &rply)
 		agReq.setCGRReply(nil, err)
+		if err == nil { // the session is no longer active
+			engine.Cache.Remove(utils.CacheRadiusPackets, utils.IfaceAsString(cgrEv.Event[utils.OriginID]),
+				true, utils.NonTransactional)
+		}
 	case utils.MetaMessage:
 		evArgs := sessions.NewV1ProcessMessageArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -283,7 +313,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1ProcessMessageReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage, evArgs, rply)
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessMessage, evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if evArgs.Debit {
@@ -298,7 +328,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			Paginator: cgrArgs,
 		}
 		rply := new(sessions.V1ProcessEventReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
@@ -306,6 +336,10 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			cgrEv.Event[utils.Usage] = rply.MaxUsage // make sure the CDR reflects the debit
 		}
 		agReq.setCGRReply(rply, err)
+		if err == nil && (reqProcessor.Flags.GetBool(utils.MetaInitiate) ||
+			reqProcessor.Flags.GetBool(utils.MetaUpdate)) {
+			ra.updateCachedRequest(cgrEv, true)
+		}
 	case utils.MetaCDRs: // allow this method
 	case utils.MetaRadauth:
 		if pass, err := radauthReq(reqProcessor.Flags, req, agReq, rpl); err != nil {
@@ -317,7 +351,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.GetBool(utils.MetaCDRs) {
 		var rplyCDRs string
-		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessCDR,
 			cgrEv, &rplyCDRs); err != nil {
 			agReq.CGRReply.Map[utils.Error] = utils.NewLeafNode(err.Error())
 		}
@@ -357,3 +391,238 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	err = <-errListen
 	return
 }
+
+// cacheRequest caches the request data needed to build the Dynamic Authorization requests
+func (ra *RadiusAgent) cacheRequest(req *radigo.Packet, dP utils.DataProvider, reqVars *utils.DataNode) {
+	if len(ra.cgrCfg.RadiusAgentCfg().RequestsCacheKey) == 0 {
+		return
+	}
+	client, _, err := net.SplitHostPort(req.RemoteAddr().String())
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed retrieving the client host, err: %s, request: %s",
+				utils.RadiusAgent, err.Error(), utils.ToJSON(req)))
+		return
+	}
+	key, err := ra.cgrCfg.RadiusAgentCfg().RequestsCacheKey.ParseDataProvider(
+		NewAgentRequest(dP, reqVars, nil, nil, nil, nil,
+			ra.cgrCfg.GeneralCfg().DefaultTenant,
+			ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil))
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed building the cache key, err: %s, request: %s",
+				utils.RadiusAgent, err.Error(), utils.ToJSON(req)))
+		return
+	}
+	pd := &radPacketData{pkt: req, vars: reqVars, client: client}
+	if x, has := engine.Cache.Get(utils.CacheRadiusPackets, key); has { // keep the session state out of the previous requests
+		pd.originHost = x.(*radPacketData).originHost
+		pd.initiated = x.(*radPacketData).initiated
+	}
+	if err = engine.Cache.Set(utils.CacheRadiusPackets, key, pd,
+		nil, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed caching the request with key: <%s>, err: %s",
+				utils.RadiusAgent, key, err.Error()))
+	}
+}
+
+// updateCachedRequest records the OriginHost of the event and if the session was initiated
+// within the cached request having its OriginID
+func (ra *RadiusAgent) updateCachedRequest(cgrEv *utils.CGREvent, initiated bool) {
+	originID := utils.IfaceAsString(cgrEv.Event[utils.OriginID])
+	if originID == utils.EmptyString {
+		return
+	}
+	x, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		return
+	}
+	pd := x.(*radPacketData)
+	originHost := utils.FirstNonEmpty(utils.IfaceAsString(cgrEv.Event[utils.OriginHost]), pd.originHost)
+	initiated = initiated || pd.initiated
+	if pd.originHost == originHost && pd.initiated == initiated {
+		return
+	}
+	if err := engine.Cache.Set(utils.CacheRadiusPackets, originID,
+		&radPacketData{pkt: pd.pkt, vars: pd.vars, client: pd.client,
+			originHost: originHost, initiated: initiated},
+		nil, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed caching the request with key: <%s>, err: %s",
+				utils.RadiusAgent, originID, err.Error()))
+	}
+}
+
+// sendDARequest builds the Dynamic Authorization request out of the template
+// and the cached request of the session, sending it to the client
+func (ra *RadiusAgent) sendDARequest(code radigo.PacketCode, tplID, originID string,
+	extraVars map[string]*utils.DataNode) (err error) {
+	tpl, has := ra.cgrCfg.TemplatesCfg()[tplID]
+	if !has {
+		return fmt.Errorf("no template with id: <%s>", tplID)
+	}
+	x, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		return utils.ErrNotFound
+	}
+	pd := x.(*radPacketData)
+	daOpts, has := ra.cgrCfg.RadiusAgentCfg().ClientDaAddresses[pd.client]
+	if !has {
+		return fmt.Errorf("no Dynamic Authorization address for client <%s>", pd.client)
+	}
+	vars := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	for k, v := range pd.vars.Map {
+		vars.Map[k] = v
+	}
+	for k, v := range extraVars {
+		vars.Map[k] = v
+	}
+	aReq := NewAgentRequest(newRADataProvider(pd.pkt), vars, nil, nil, nil, nil,
+		ra.cgrCfg.GeneralCfg().DefaultTenant,
+		ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil)
+	if err = aReq.SetFields(tpl); err != nil {
+		return
+	}
+	secret := ra.secrets.GetSecret(pd.client)
+	pkt := radigo.NewPacket(code, uint8(atomic.AddUint32(&ra.daID, 1)),
+		ra.dicts.GetInstance(pd.client), radigo.NewCoder(), secret)
+	if err = radReplyAppendAttributes(pkt, aReq.diamreq); err != nil {
+		return
+	}
+	var req []byte
+	if req, err = encodeDARequest(pkt, secret); err != nil {
+		return
+	}
+	return sendDARequest(req,
+		net.JoinHostPort(utils.FirstNonEmpty(daOpts.Host, pd.client), strconv.Itoa(daOpts.Port)),
+		secret, daOpts)
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (ra *RadiusAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(ra, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession sends a Disconnect-Request to the client
+func (ra *RadiusAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	originID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = ra.sendDARequest(radDisconnectRequest, ra.cgrCfg.RadiusAgentCfg().DMRTemplate,
+		utils.IfaceAsString(originID), map[string]*utils.DataNode{
+			utils.DisconnectCause: utils.NewLeafNode(args.Reason)}); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot disconnect session with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, originID, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs returns the initiated sessions having their requests cached, indexed on OriginID
+func (ra *RadiusAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	var sIDs []*sessions.SessionID
+	for _, originID := range engine.Cache.GetItemIDs(utils.CacheRadiusPackets, utils.EmptyString) {
+		x, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+		if !has || !x.(*radPacketData).initiated {
+			continue
+		}
+		sIDs = append(sIDs, &sessions.SessionID{
+			OriginHost: x.(*radPacketData).originHost,
+			OriginID:   originID,
+		})
+	}
+	if len(sIDs) == 0 {
+		return utils.ErrNoActiveSession
+	}
+	*sessionIDs = sIDs
+	return nil
+}
+
+// V1ReAuthorize sends a CoA-Request to the client
+func (ra *RadiusAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot send CoA-Request, missing session ID",
+				utils.RadiusAgent))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = ra.sendDARequest(radCoARequest, ra.cgrCfg.RadiusAgentCfg().CoATemplate,
+		originID, nil); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send CoA-Request with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, originID, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (ra *RadiusAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(ra, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is internal method to disconnect session
+func (ra *RadiusAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return ra.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is internal method to get all active sessions
+func (ra *RadiusAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return ra.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return ra.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return ra.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return ra.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (ra *RadiusAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return ra.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return ra.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return ra.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return ra.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return ra.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
@@ -19,9 +19,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bufio"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,12 +41,19 @@ const (
 	bufferSize      = 5000
 	ackMethod       = "ACK"
 	inviteMethod    = "INVITE"
+	optionsMethod   = "OPTIONS"
 	requestHeader   = "Request"
 	callIDHeader    = "Call-ID"
 	fromHeader      = "From"
+	toHeader        = "To"
 	sipServerErr    = "SIP/2.0 500 Internal Server Error"
+	sipOK           = "SIP/2.0 200 OK"
+	sipTLSNet       = "tcp-tls"
 	userAgentHeader = "User-Agent"
 	method          = "Method"
+
+	contentLengthHeader = "Content-Length"
+	sipCRLF             = "\r\n"
 )
 
 var (
@@ -101,7 +113,13 @@ func (sa *SIPAgent) ListenAndServe() (err error) {
 		utils.SIPAgent, sa.cfg.SIPAgentCfg().ListenNet, sa.cfg.SIPAgentCfg().Listen))
 	switch sa.cfg.SIPAgentCfg().ListenNet {
 	case utils.TCP:
-		return sa.serveTCP(sa.stopChan)
+		return sa.serveTCP(sa.stopChan, nil)
+	case sipTLSNet:
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(sa.cfg.TLSCfg().ServerCerificate, sa.cfg.TLSCfg().ServerKey); err != nil {
+			return
+		}
+		return sa.serveTCP(sa.stopChan, &tls.Config{Certificates: []tls.Certificate{cert}})
 	case utils.UDP:
 		return sa.serveUDP(sa.stopChan)
 	default:
@@ -129,7 +147,7 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 		select {
 		case <-stop:
 			wg.Wait()
-			return
+			return nil // ignore the read timeouts
 		default:
 		}
 		conn.SetDeadline(time.Now().Add(time.Second))
@@ -151,7 +169,7 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 		}
 		wg.Add(1)
 		go func(message string, saddr net.Addr, conn net.PacketConn) {
-			sa.answerMessage(message, saddr.String(), func(ans []byte) (werr error) {
+			sa.answerMessage(message, saddr.String(), false, func(ans []byte) (werr error) {
 				_, werr = conn.WriteTo(ans, saddr)
 				return
 			}) // do not log the received error because is already logged in function so for now just ignore it
@@ -160,7 +178,8 @@ func (sa *SIPAgent) serveUDP(stop chan struct{}) (err error) {
 	}
 }
 
-func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
+// serveTCP listens for SIP over TCP, securing the connections with TLS when tlsCfg is provided
+func (sa *SIPAgent) serveTCP(stop chan struct{}, tlsCfg *tls.Config) (err error) {
 	var l *net.TCPListener
 	var addr *net.TCPAddr
 	if addr, err = net.ResolveTCPAddr("tcp", sa.cfg.SIPAgentCfg().Listen); err != nil {
@@ -183,7 +202,7 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 		select {
 		case <-stop:
 			wg.Wait()
-			return
+			return nil // ignore the read timeouts
 		default:
 		}
 		l.SetDeadline(time.Now().Add(time.Second))
@@ -197,38 +216,99 @@ func (sa *SIPAgent) serveTCP(stop chan struct{}) (err error) {
 					utils.SIPAgent, err.Error()))
 			return
 		}
+		if tlsCfg != nil {
+			conn = tls.Server(conn, tlsCfg)
+		}
 		wg.Add(1)
 		go func(conn net.Conn) {
-			buf := make([]byte, bufferSize)
-			for {
-				select {
-				case <-stop:
-					conn.Close()
-					wg.Done()
-					return
-				default:
-				}
-				conn.SetReadDeadline(time.Now().Add(time.Second))
-				n, err := conn.Read(buf)
-				if err != nil {
-					continue
-				}
-				// echo response
-				if n < 50 {
-					conn.Write(buf[:n])
-					continue
-				}
+			sa.serveConn(conn, stop)
+			wg.Done()
+		}(conn)
+	}
+}
 
-				sa.answerMessage(string(buf[:n]), conn.LocalAddr().String(), func(ans []byte) (werr error) {
-					_, werr = conn.Write(ans)
-					return
-				}) // do not log the received error because is already logged in function so for now just ignore it
+// serveConn reads the SIP messages out of a stream connection until it is closed or the agent stopped
+func (sa *SIPAgent) serveConn(conn net.Conn, stop chan struct{}) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+		case <-done:
+		}
+		conn.Close()
+	}()
+	rmtAddr := conn.RemoteAddr().String()
+	rdr := bufio.NewReaderSize(conn, bufferSize)
+	for {
+		message, err := readSIPMessage(rdr)
+		if err != nil {
+			if err != io.EOF {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: %s reading from: %s",
+						utils.SIPAgent, err.Error(), rmtAddr))
 			}
-		}(conn)
+			return
+		}
+		if message == utils.EmptyString { // keep-alive ping, answer with pong
+			conn.Write([]byte(sipCRLF))
+			continue
+		}
+		sa.answerMessage(message, rmtAddr, true, func(ans []byte) (werr error) {
+			_, werr = conn.Write(ans)
+			return
+		}) // do not log the received error because is already logged in function so for now just ignore it
+	}
+}
+
+// readSIPMessage reads one SIP message out of a stream, framed by its Content-Length header
+// the keep-alive pings (empty lines) are returned as empty messages
+func readSIPMessage(rdr *bufio.Reader) (message string, err error) {
+	var msg strings.Builder
+	var cntLen int
+	for {
+		var line string
+		if line, err = rdr.ReadString('\n'); err != nil {
+			return
+		}
+		if strings.TrimRight(line, sipCRLF) != utils.EmptyString {
+			if msg.Len()+len(line) > bufferSize {
+				return utils.EmptyString, fmt.Errorf("message exceeds %d bytes", bufferSize)
+			}
+			if hdr := strings.SplitN(line, utils.InInFieldSep, 2); len(hdr) == 2 {
+				if hdrName := strings.TrimSpace(hdr[0]); strings.EqualFold(hdrName, contentLengthHeader) ||
+					hdrName == "l" { // compact form
+					if cntLen, err = strconv.Atoi(strings.TrimSpace(hdr[1])); err != nil {
+						return
+					}
+				}
+			}
+			msg.WriteString(line)
+			continue
+		}
+		if msg.Len() == 0 { // keep-alive, consume the double CRLF
+			if nxt, _ := rdr.Peek(len(sipCRLF)); string(nxt) == sipCRLF {
+				rdr.Discard(len(sipCRLF))
+			}
+			return
+		}
+		msg.WriteString(sipCRLF)
+		break
 	}
+	if cntLen < 0 || msg.Len()+cntLen > bufferSize {
+		return utils.EmptyString, fmt.Errorf("invalid %s: %d", contentLengthHeader, cntLen)
+	}
+	body := make([]byte, cntLen)
+	if _, err = io.ReadFull(rdr, body); err != nil {
+		return
+	}
+	msg.Write(body)
+	return msg.String(), nil
 }
 
-func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte) error) (err error) {
+// answerMessage processes the message and writes back the answer
+// over reliable transports (TCP/TLS) the answers are not retransmitted so the ACKs are ignored
+func (sa *SIPAgent) answerMessage(messageStr, addr string, reliable bool, write func(ans []byte) error) (err error) {
 	var sipMessage sipingo.Message // recreate map SIP
 	if sipMessage, err = sipingo.NewMessage(messageStr); err != nil {
 		utils.Logger.Warning(
@@ -236,12 +316,15 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 				utils.SIPAgent, err.Error(), messageStr))
 		return // do we need to return error in case we can't parse the message?
 	}
-	tags := sipTagRgx.FindStringSubmatch(sipMessage[fromHeader])
-	// in case we get a wrong sip message ( without tag in the From header) the next line should panic
-	key := utils.ConcatenatedKey(sipMessage[callIDHeader], tags[1])
+	var fromTag string
+	if tags := sipTagRgx.FindStringSubmatch(sipMessage[fromHeader]); len(tags) > 1 {
+		fromTag = tags[1]
+	}
+	key := utils.ConcatenatedKey(sipMessage[callIDHeader], fromTag)
 	method := sipMessage.MethodFrom(requestHeader)
 	if ackMethod == method {
-		if sa.cfg.SIPAgentCfg().RetransmissionTimer == 0 { // ignore ACK
+		if reliable ||
+			sa.cfg.SIPAgentCfg().RetransmissionTimer == 0 { // ignore ACK
 			return
 		}
 		sa.ackLocks.Lock()
@@ -265,6 +348,7 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 	}
 	// because we expext to send codes from 300-699 we wait for the ACK every time
 	if method != inviteMethod || // only invitest need ACK
+		reliable ||
 		sa.cfg.SIPAgentCfg().RetransmissionTimer == 0 {
 		return // disabled ACK
 	}
@@ -355,6 +439,9 @@ func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string)
 		return sErr
 	}
 	if !processed {
+		if sipMessage.MethodFrom(requestHeader) == optionsMethod { // keep-alive probe
+			return sipOptionsReply(sipMessage)
+		}
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
 				utils.SIPAgent, sipMessage, remoteHost))
@@ -388,7 +475,7 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 	for _, typ := range []string{
 		utils.MetaDryRun, utils.MetaAuthorize, /*
 			utils.MetaInitiate, utils.MetaUpdate,
-			utils.MetaTerminate, utils.MetaMessage,
+			utils.MetaTerminate, */utils.MetaMessage, /*
 			utils.MetaCDRs, */utils.MetaEvent, utils.MetaNone} {
 		if reqProcessor.Flags.Has(typ) { // request type is identified through flags
 			reqType = typ
@@ -439,6 +526,33 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		agReq.setCGRReply(rply, err)
+	case utils.MetaMessage:
+		msgArgs := sessions.NewV1ProcessMessageArgs(
+			reqProcessor.Flags.GetBool(utils.MetaAttributes),
+			reqProcessor.Flags.ParamsSlice(utils.MetaAttributes, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaThresholds),
+			reqProcessor.Flags.ParamsSlice(utils.MetaThresholds, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaStats),
+			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
+			reqProcessor.Flags.GetBool(utils.MetaResources),
+			reqProcessor.Flags.Has(utils.MetaAccounts),
+			reqProcessor.Flags.GetBool(utils.MetaRoutes),
+			reqProcessor.Flags.Has(utils.MetaRoutesIgnoreErrors),
+			reqProcessor.Flags.Has(utils.MetaRoutesEventCost),
+			cgrEv, cgrArgs,
+			reqProcessor.Flags.Has(utils.MetaFD),
+			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
+		)
+		rply := new(sessions.V1ProcessMessageReply)
+		err = sa.connMgr.Call(sa.cfg.SIPAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage,
+			msgArgs, rply)
+		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
+			cgrEv.Event[utils.Usage] = 0 // avoid further debits
+		} else if msgArgs.Debit {
+			cgrEv.Event[utils.Usage] = rply.MaxUsage // make sure the CDR reflects the debit
+		}
+		rply.SetMaxUsageNeeded(msgArgs.Debit)
+		agReq.setCGRReply(rply, err)
 	case utils.MetaEvent:
 		evArgs := &sessions.V1ProcessEventArgs{
 			Flags:     reqProcessor.Flags.SliceFlags(),
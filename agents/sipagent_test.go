@@ -0,0 +1,193 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bufio"
+	"crypto/tls"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/sipingo"
+)
+
+const (
+	testSIPRegister = "REGISTER sip:cgrates.org SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP 192.168.56.203:5060;branch=z9hG4bK-1\r\n" +
+		"From: <sip:1001@cgrates.org>;tag=a1b2\r\n" +
+		"To: <sip:1001@cgrates.org>\r\n" +
+		"Call-ID: reg1@192.168.56.203\r\n" +
+		"CSeq: 1 REGISTER\r\n" +
+		"Contact: <sip:1001@192.168.56.203:5060;transport=tcp>\r\n" +
+		"Expires: 3600\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	testSIPOptions = "OPTIONS sip:cgrates.org SIP/2.0\r\n" +
+		"Via: SIP/2.0/TCP 192.168.56.203:5060;branch=z9hG4bK-2\r\n" +
+		"From: <sip:sbc@cgrates.org>;tag=c3d4\r\n" +
+		"To: <sip:cgrates.org>\r\n" +
+		"Call-ID: opt1@192.168.56.203\r\n" +
+		"CSeq: 1 OPTIONS\r\n" +
+		"Content-Length: 0\r\n\r\n"
+)
+
+func TestReadSIPMessage(t *testing.T) {
+	msg := "MESSAGE sip:1002@cgrates.org SIP/2.0\r\n" +
+		"From: <sip:1001@cgrates.org>;tag=e5f6\r\n" +
+		"Call-ID: msg1@192.168.56.203\r\n" +
+		"l: 5\r\n\r\n" +
+		"Hello"
+	rdr := bufio.NewReader(strings.NewReader("\r\n\r\n" + testSIPRegister + msg))
+	if rcv, err := readSIPMessage(rdr); err != nil {
+		t.Error(err)
+	} else if rcv != utils.EmptyString {
+		t.Errorf("Expected keep-alive, received: %q", rcv)
+	}
+	if rcv, err := readSIPMessage(rdr); err != nil {
+		t.Error(err)
+	} else if rcv != testSIPRegister {
+		t.Errorf("Expected: %q, received: %q", testSIPRegister, rcv)
+	}
+	if rcv, err := readSIPMessage(rdr); err != nil {
+		t.Error(err)
+	} else if rcv != msg {
+		t.Errorf("Expected: %q, received: %q", msg, rcv)
+	}
+	if _, err := readSIPMessage(rdr); err != io.EOF {
+		t.Errorf("Expected EOF, received: %v", err)
+	}
+	rdr = bufio.NewReader(strings.NewReader("MESSAGE sip:1002@cgrates.org SIP/2.0\r\nContent-Length: 50000\r\n\r\n"))
+	if _, err := readSIPMessage(rdr); err == nil {
+		t.Error("Expected error for oversized message")
+	}
+}
+
+func testSIPAgentServe(t *testing.T, listenNet string, dial func(addr string) (net.Conn, error)) {
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.TLSCfg().ServerCerificate = "../data/tls/server.crt"
+	cfg.TLSCfg().ServerKey = "../data/tls/server.key"
+	cfg.SIPAgentCfg().Listen = addr
+	cfg.SIPAgentCfg().ListenNet = listenNet
+	errFld := &config.FCTemplate{Tag: "Request", Path: utils.MetaRep + utils.NestingSep + "Request",
+		Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile(sipServerErr, utils.InfieldSep)}
+	errFld.ComputePath()
+	cfg.TemplatesCfg()[utils.MetaErr] = []*config.FCTemplate{errFld}
+	rplyFld := &config.FCTemplate{Tag: "Request", Path: utils.MetaRep + utils.NestingSep + "Request",
+		Type: utils.MetaConstant, Value: config.NewRSRParsersMustCompile("SIP/2.0 200 OK", utils.InfieldSep)}
+	rplyFld.ComputePath()
+	cfg.SIPAgentCfg().RequestProcessors = []*config.RequestProcessor{{
+		ID:          "register",
+		Filters:     []string{"*string:~*vars.Method:REGISTER"},
+		Flags:       utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+		ReplyFields: []*config.FCTemplate{rplyFld},
+	}}
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	sa, err := NewSIPAgent(nil, cfg, engine.NewFilterS(cfg, nil, dm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	errChan := make(chan error, 1)
+	go func() { errChan <- sa.ListenAndServe() }()
+	defer func() {
+		sa.Shutdown()
+		if err := <-errChan; err != nil {
+			t.Error(err)
+		}
+	}()
+	var conn net.Conn
+	for i := 0; i < 50; i++ {
+		if conn, err = dial(addr); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	rdr := bufio.NewReader(conn)
+
+	if _, err = conn.Write([]byte("\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	if pong, err := rdr.ReadString('\n'); err != nil {
+		t.Fatal(err)
+	} else if pong != "\r\n" {
+		t.Errorf("Expected pong, received: %q", pong)
+	}
+	for _, req := range []string{testSIPRegister, testSIPOptions} {
+		if _, err = conn.Write([]byte(req)); err != nil {
+			t.Fatal(err)
+		}
+		rplyStr, err := readSIPMessage(rdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rply, err := sipingo.NewMessage(rplyStr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rply[requestHeader] != sipOK {
+			t.Errorf("Expected: %q, received: %q", sipOK, rply[requestHeader])
+		}
+		if reqMsg, _ := sipingo.NewMessage(req); rply[callIDHeader] != reqMsg[callIDHeader] {
+			t.Errorf("Expected Call-ID: %q, received: %q", reqMsg[callIDHeader], rply[callIDHeader])
+		}
+	}
+}
+
+func TestSIPAgentServeTCP(t *testing.T) {
+	testSIPAgentServe(t, utils.TCP, func(addr string) (net.Conn, error) {
+		return net.Dial(utils.TCP, addr)
+	})
+}
+
+func TestSIPAgentServeTLS(t *testing.T) {
+	testSIPAgentServe(t, sipTLSNet, func(addr string) (net.Conn, error) {
+		return tls.Dial(utils.TCP, addr, &tls.Config{InsecureSkipVerify: true})
+	})
+}
+
+func TestSIPOptionsReply(t *testing.T) {
+	m, err := sipingo.NewMessage(testSIPOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rply := sipOptionsReply(m)
+	if rply[requestHeader] != sipOK {
+		t.Errorf("Expected: %q, received: %q", sipOK, rply[requestHeader])
+	}
+	if !sipTagRgx.MatchString(rply[toHeader]) {
+		t.Errorf("Expected To tag, received: %q", rply[toHeader])
+	}
+}
@@ -0,0 +1,396 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	// SMPPCommandStatus is the reply field controlling the command_status of the response
+	SMPPCommandStatus = "CommandStatus"
+	// SMPPMessageID is the reply field overwriting the message_id of the response
+	SMPPMessageID = "MessageID"
+	// SMPPRoute is the reply field with the id of the smsc_conn to forward the message to
+	SMPPRoute = "Route"
+
+	smppInterfaceVersion = 0x34
+)
+
+// NewSMPPAgent is the constructor for SMPPAgent
+func NewSMPPAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
+	connMgr *engine.ConnManager) *SMPPAgent {
+	return &SMPPAgent{
+		cgrCfg:  cgrCfg,
+		fltrS:   fltrS,
+		connMgr: connMgr,
+		conns:   make(map[*smppConn]struct{}),
+		smscs:   make(map[string]*smppConn),
+		stop:    make(chan struct{}),
+	}
+}
+
+// SMPPAgent translates the submit_sm and deliver_sm PDUs towards CGRateS infrastructure
+// acting as SMSC for the ESMEs binding to it and as ESME towards the configured SMSCs
+type SMPPAgent struct {
+	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
+	fltrS   *engine.FilterS   // connection towards FilterS
+	connMgr *engine.ConnManager
+
+	lk       sync.RWMutex
+	listener net.Listener
+	conns    map[*smppConn]struct{} // all the connections, closed on shutdown
+	smscs    map[string]*smppConn   // bound connections towards SMSCs, indexed on id
+	stop     chan struct{}
+}
+
+// ListenAndServe binds to the SMSCs and accepts the binds from ESMEs
+// blocking until the agent is shut down
+func (sa *SMPPAgent) ListenAndServe() (err error) {
+	sa.lk.RLock()
+	stop := sa.stop
+	sa.lk.RUnlock()
+	for _, connCfg := range sa.cgrCfg.SMPPAgentCfg().SMSCConns {
+		go sa.handleSMSC(connCfg, stop)
+	}
+	listen := sa.cgrCfg.SMPPAgentCfg().Listen
+	if listen == utils.EmptyString { // no ESMEs accepted
+		<-stop
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s>",
+		utils.SMPPAgent, listen))
+	var lstnr net.Listener
+	if lstnr, err = net.Listen(utils.TCP, listen); err != nil {
+		return
+	}
+	sa.lk.Lock()
+	sa.listener = lstnr
+	sa.lk.Unlock()
+	for {
+		var conn net.Conn
+		if conn, err = lstnr.Accept(); err != nil {
+			select {
+			case <-stop: // stopped by Shutdown
+				return nil
+			default:
+				return
+			}
+		}
+		go sa.serveConn(newSMPPConn(conn))
+	}
+}
+
+// Reload recreates the stop channel so the agent can be started again
+func (sa *SMPPAgent) Reload() (err error) {
+	sa.lk.Lock()
+	sa.stop = make(chan struct{})
+	sa.lk.Unlock()
+	return
+}
+
+// Shutdown stops listening and closes all the SMPP connections
+func (sa *SMPPAgent) Shutdown() (err error) {
+	sa.lk.Lock()
+	defer sa.lk.Unlock()
+	close(sa.stop)
+	if sa.listener != nil {
+		err = sa.listener.Close()
+		sa.listener = nil
+	}
+	for c := range sa.conns {
+		c.conn.Close()
+	}
+	return
+}
+
+// serveConn reads the PDUs of one connection until it is closed
+func (sa *SMPPAgent) serveConn(c *smppConn) (err error) {
+	sa.lk.Lock()
+	sa.conns[c] = struct{}{}
+	sa.lk.Unlock()
+	err = c.readLoop(sa.handlePDU)
+	sa.lk.Lock()
+	delete(sa.conns, c)
+	if c.systemID != utils.EmptyString && sa.smscs[c.systemID] == c {
+		delete(sa.smscs, c.systemID)
+	}
+	sa.lk.Unlock()
+	c.conn.Close()
+	return
+}
+
+// handleSMSC keeps the connection bound to the SMSC, rebinding after ReconnectInterval
+func (sa *SMPPAgent) handleSMSC(connCfg *config.SMSCConnCfg, stop chan struct{}) {
+	for {
+		if err := sa.bindSMSC(connCfg); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s with smsc connection: <%s>",
+					utils.SMPPAgent, err.Error(), connCfg.ID))
+		}
+		select {
+		case <-stop:
+			return
+		case <-time.After(connCfg.ReconnectInterval):
+		}
+	}
+}
+
+// bindSMSC binds as transceiver to the SMSC and serves the connection until it is closed
+func (sa *SMPPAgent) bindSMSC(connCfg *config.SMSCConnCfg) (err error) {
+	var conn net.Conn
+	if conn, err = net.DialTimeout(utils.TCP, connCfg.Address,
+		sa.cgrCfg.SMPPAgentCfg().ReplyTimeout); err != nil {
+		return
+	}
+	c := newSMPPConn(conn)
+	c.systemID = connCfg.ID
+	c.bound = true // the SMSC will not send PDUs before answering the bind
+	errChan := make(chan error, 1)
+	go func() { errChan <- sa.serveConn(c) }()
+	var rply *smppPDU
+	if rply, err = c.request(smppBindTransceiver, (&smppBind{
+		SystemID:         connCfg.SystemID,
+		Password:         connCfg.Password,
+		SystemType:       connCfg.SystemType,
+		InterfaceVersion: smppInterfaceVersion,
+	}).Encode(), sa.cgrCfg.SMPPAgentCfg().ReplyTimeout); err != nil {
+		conn.Close()
+		return
+	}
+	if rply.Status != smppStatusOK {
+		conn.Close()
+		return fmt.Errorf("bind failed with status: %d", rply.Status)
+	}
+	sa.lk.Lock()
+	sa.smscs[connCfg.ID] = c
+	sa.lk.Unlock()
+	utils.Logger.Info(fmt.Sprintf("<%s> bound to smsc connection: <%s>",
+		utils.SMPPAgent, connCfg.ID))
+	return <-errChan
+}
+
+// authorize checks the password of the bind against the configured ones
+func (sa *SMPPAgent) authorize(bnd *smppBind) bool {
+	pswds := sa.cgrCfg.SMPPAgentCfg().ClientPasswords
+	if len(pswds) == 0 {
+		return true
+	}
+	pswd, has := pswds[bnd.SystemID]
+	if !has {
+		pswd, has = pswds[utils.MetaDefault]
+	}
+	return has && pswd == bnd.Password
+}
+
+// handlePDU answers the session management PDUs and processes the short messages asynchronously
+func (sa *SMPPAgent) handlePDU(c *smppConn, p *smppPDU) {
+	var err error
+	switch p.CommandID {
+	case smppBindReceiver, smppBindTransmitter, smppBindTransceiver:
+		if c.bound {
+			err = c.reply(p, smppStatusAlyBnd, nil)
+			break
+		}
+		var bnd *smppBind
+		if bnd, err = decodeSMPPBind(p.Body); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s decoding bind from %s",
+					utils.SMPPAgent, err.Error(), c.conn.RemoteAddr()))
+			err = c.reply(p, smppStatusBindFail, nil)
+			break
+		}
+		if !sa.authorize(bnd) {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> unauthorized bind for system_id: <%s> from %s",
+					utils.SMPPAgent, bnd.SystemID, c.conn.RemoteAddr()))
+			err = c.reply(p, smppStatusInvPaswd, nil)
+			break
+		}
+		c.systemID, c.bound = bnd.SystemID, true
+		err = c.reply(p, smppStatusOK,
+			smppAppendCString(nil, sa.cgrCfg.SMPPAgentCfg().SystemID))
+	case smppEnquireLink:
+		err = c.reply(p, smppStatusOK, nil)
+	case smppUnbind:
+		c.reply(p, smppStatusOK, nil)
+		err = c.conn.Close()
+	case smppSubmitSM, smppDeliverSM:
+		if !c.bound {
+			err = c.reply(p, smppStatusInvBndSts, nil)
+			break
+		}
+		go sa.processSM(c, p)
+	default:
+		err = c.writePDU(&smppPDU{CommandID: smppGenericNack,
+			Status: smppStatusInvCmdID, Seq: p.Seq})
+	}
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s answering command: %d to %s",
+				utils.SMPPAgent, err.Error(), p.CommandID, c.conn.RemoteAddr()))
+	}
+}
+
+// processSM passes the short message through the request processors and answers it
+// forwarding it first if a route was selected
+func (sa *SMPPAgent) processSM(c *smppConn, p *smppPDU) {
+	status := smppStatusOK
+	var msgID string
+	sm, err := decodeSMPPSM(p.Body)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s decoding %s from %s",
+				utils.SMPPAgent, err.Error(), smppCommands[p.CommandID], c.conn.RemoteAddr()))
+		status = smppStatusInvMsgLen
+	} else {
+		status, msgID = sa.processRequest(c, p, sm)
+	}
+	if status == smppStatusOK && msgID == utils.EmptyString &&
+		p.CommandID == smppSubmitSM {
+		msgID = utils.UUIDSha1Prefix()
+	}
+	if err = c.reply(p, status, smppAppendCString(nil, msgID)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s answering %s to %s",
+				utils.SMPPAgent, err.Error(), smppCommands[p.CommandID], c.conn.RemoteAddr()))
+	}
+}
+
+// processRequest runs the request processors returning the command_status and message_id of the response
+// when a route is selected the message is forwarded and the processors run again for the submit_sm_resp,
+// so the message can be authorized before forwarding and charged only once the SMSC accepted it
+func (sa *SMPPAgent) processRequest(c *smppConn, p *smppPDU, sm *smppSM) (status uint32, msgID string) {
+	smppReq := sm.AsMapStorage()
+	reqVars := &utils.DataNode{
+		Type: utils.NMMapType,
+		Map: map[string]*utils.DataNode{
+			utils.MetaCmd:    utils.NewLeafNode(smppCommands[p.CommandID]),
+			utils.SystemID:   utils.NewLeafNode(c.systemID),
+			utils.RemoteHost: utils.NewLeafNode(c.conn.RemoteAddr().String()),
+		},
+	}
+	rplyNM, processed, err := sa.runRequestProcessors(smppReq, reqVars)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing request: %s from %s",
+				utils.SMPPAgent, err.Error(), smppReq, c.conn.RemoteAddr()))
+		return smppStatusSysErr, utils.EmptyString
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring request %s from %s",
+				utils.SMPPAgent, smppReq, c.conn.RemoteAddr()))
+		return smppStatusSysErr, utils.EmptyString
+	}
+	if stsStr := smppReplyField(rplyNM, SMPPCommandStatus); stsStr != utils.EmptyString {
+		if status, err = smppCommandStatus(stsStr); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing reply for request: %s from %s",
+					utils.SMPPAgent, err.Error(), smppReq, c.conn.RemoteAddr()))
+			return smppStatusSysErr, utils.EmptyString
+		}
+		if status != smppStatusOK {
+			return
+		}
+	}
+	msgID = smppReplyField(rplyNM, SMPPMessageID)
+	route := smppReplyField(rplyNM, SMPPRoute)
+	if route == utils.EmptyString {
+		return
+	}
+	if msgID, err = sa.forward(route, p.Body); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s forwarding request: %s from %s to route: <%s>",
+				utils.SMPPAgent, err.Error(), smppReq, c.conn.RemoteAddr(), route))
+		return smppStatusSubmitFail, utils.EmptyString
+	}
+	reqVars.Map[utils.MetaCmd] = utils.NewLeafNode(utils.SMPPSubmitSMResp)
+	reqVars.Map[SMPPRoute] = utils.NewLeafNode(route)
+	reqVars.Map[SMPPMessageID] = utils.NewLeafNode(msgID)
+	if _, _, err = sa.runRequestProcessors(smppReq, reqVars); err != nil {
+		// the message is already accepted by the SMSC so the ESME still gets the message_id
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing %s for request: %s from %s forwarded to route: <%s>",
+				utils.SMPPAgent, err.Error(), utils.SMPPSubmitSMResp, smppReq, c.conn.RemoteAddr(), route))
+	}
+	return
+}
+
+// runRequestProcessors passes the request through the request processors returning the reply fields populated by them
+func (sa *SMPPAgent) runRequestProcessors(smppReq utils.MapStorage, reqVars *utils.DataNode) (
+	rplyNM *utils.OrderedNavigableMap, processed bool, err error) {
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	rplyNM = utils.NewOrderedNavigableMap() // share it among different processors
+	opts := utils.MapStorage{}
+	for _, reqProcessor := range sa.cgrCfg.SMPPAgentCfg().RequestProcessors {
+		var lclProcessed bool
+		if lclProcessed, err = processRequest(
+			reqProcessor,
+			NewAgentRequest(
+				smppReq, reqVars, cgrRplyNM, rplyNM,
+				opts, reqProcessor.Tenant,
+				sa.cgrCfg.GeneralCfg().DefaultTenant,
+				utils.FirstNonEmpty(sa.cgrCfg.SMPPAgentCfg().Timezone,
+					sa.cgrCfg.GeneralCfg().DefaultTimezone),
+				sa.fltrS, nil),
+			utils.SMPPAgent, sa.connMgr,
+			sa.cgrCfg.SMPPAgentCfg().SessionSConns,
+			nil, sa.fltrS); err != nil {
+			return
+		}
+		processed = processed || lclProcessed
+		if lclProcessed && !reqProcessor.Flags.GetBool(utils.MetaContinue) {
+			break
+		}
+	}
+	return
+}
+
+// forward submits the short message over the bound SMSC connection returning the remote message_id
+func (sa *SMPPAgent) forward(route string, body []byte) (msgID string, err error) {
+	sa.lk.RLock()
+	c, has := sa.smscs[route]
+	sa.lk.RUnlock()
+	if !has {
+		return utils.EmptyString, fmt.Errorf("no bound smsc connection with id: <%s>", route)
+	}
+	var rply *smppPDU
+	if rply, err = c.request(smppSubmitSM, body,
+		sa.cgrCfg.SMPPAgentCfg().ReplyTimeout); err != nil {
+		return
+	}
+	if rply.Status != smppStatusOK {
+		return utils.EmptyString, fmt.Errorf("submit_sm failed with status: %d", rply.Status)
+	}
+	return (&smppBodyReader{b: rply.Body}).cString(), nil
+}
+
+// smppReplyField returns the value of the reply field populated by the templates
+func smppReplyField(rplyNM *utils.OrderedNavigableMap, fld string) (val string) {
+	val, _ = rplyNM.FieldAsString([]string{fld, "0"})
+	return
+}
@@ -0,0 +1,189 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestSMPPAgent() *SMPPAgent {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SMPPAgentCfg().ClientPasswords = map[string]string{"esme1": "secret"}
+	cfg.SMPPAgentCfg().RequestProcessors = []*config.RequestProcessor{
+		{
+			ID:      "Throttled",
+			Filters: []string{"*string:~*req.destination_addr:1003"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "CommandStatus", Path: utils.MetaRep + utils.NestingSep + SMPPCommandStatus, Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("ESME_RTHROTTLED", utils.InfieldSep)},
+			},
+		},
+		{
+			ID:      "Routed",
+			Filters: []string{"*string:~*vars.*cmd:submit_sm", "*string:~*req.destination_addr:1004", "*string:~*vars.SystemID:esme1"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "Route", Path: utils.MetaRep + utils.NestingSep + SMPPRoute, Type: utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("smsc1", utils.InfieldSep)},
+			},
+		},
+		{
+			ID:      "Accepted",
+			Filters: []string{"*string:~*vars.*cmd:submit_sm", "*string:~*req.short_message:hello"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			ReplyFields: []*config.FCTemplate{
+				{Tag: "MessageID", Path: utils.MetaRep + utils.NestingSep + SMPPMessageID, Type: utils.MetaVariable,
+					Value: config.NewRSRParsersMustCompile("~*req.source_addr", utils.InfieldSep)},
+			},
+		},
+		{
+			ID:      "Forwarded",
+			Filters: []string{"*string:~*vars.*cmd:submit_sm_resp"},
+			Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaNone}),
+			RequestFields: []*config.FCTemplate{
+				{Tag: "Forwarded", Path: utils.MetaUCH + utils.NestingSep + "SMPPForwarded", Type: utils.MetaComposed,
+					Value: config.NewRSRParsersMustCompile("~*vars.Route;:;~*vars.MessageID", utils.InfieldSep)},
+			},
+		},
+	}
+	for _, rp := range cfg.SMPPAgentCfg().RequestProcessors {
+		for _, fld := range rp.RequestFields {
+			fld.ComputePath()
+		}
+		for _, fld := range rp.ReplyFields {
+			fld.ComputePath()
+		}
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	return NewSMPPAgent(cfg, engine.NewFilterS(cfg, nil, dm), nil)
+}
+
+// newTestESME connects a new ESME to the agent
+func newTestESME(sa *SMPPAgent) *smppConn {
+	cl, srv := net.Pipe()
+	go sa.serveConn(newSMPPConn(srv))
+	esme := newSMPPConn(cl)
+	go esme.readLoop(func(*smppConn, *smppPDU) {})
+	return esme
+}
+
+func TestSMPPAgentBind(t *testing.T) {
+	sa := newTestSMPPAgent()
+	esme := newTestESME(sa)
+	defer esme.conn.Close()
+	if rply, err := esme.request(smppSubmitSM,
+		buildSMPPSMBody("1001", "1002", 0, 0, []byte("hello")), time.Second); err != nil {
+		t.Fatal(err)
+	} else if rply.Status != smppStatusInvBndSts {
+		t.Errorf("Unexpected status: %d", rply.Status)
+	}
+	if rply, err := esme.request(smppBindTransceiver,
+		(&smppBind{SystemID: "esme1", Password: "wrong"}).Encode(), time.Second); err != nil {
+		t.Fatal(err)
+	} else if rply.Status != smppStatusInvPaswd {
+		t.Errorf("Unexpected status: %d", rply.Status)
+	}
+	if rply, err := esme.request(smppBindTransceiver,
+		(&smppBind{SystemID: "esme1", Password: "secret"}).Encode(), time.Second); err != nil {
+		t.Fatal(err)
+	} else if rply.Status != smppStatusOK {
+		t.Errorf("Unexpected status: %d", rply.Status)
+	} else if sysID := (&smppBodyReader{b: rply.Body}).cString(); sysID != "CGRateS" {
+		t.Errorf("Unexpected system_id: <%s>", sysID)
+	}
+	if rply, err := esme.request(smppEnquireLink, nil, time.Second); err != nil {
+		t.Fatal(err)
+	} else if rply.CommandID != smppEnquireLink|smppRespMask || rply.Status != smppStatusOK {
+		t.Errorf("Unexpected reply: %+v", rply)
+	}
+	if _, err := esme.request(0x00000103, nil, time.Second); err == nil { // data_sm not supported
+		t.Error("Expected generic_nack")
+	}
+}
+
+func TestSMPPAgentSubmitSM(t *testing.T) {
+	sa := newTestSMPPAgent()
+	esme := newTestESME(sa)
+	defer esme.conn.Close()
+	if _, err := esme.request(smppBindTransmitter,
+		(&smppBind{SystemID: "esme1", Password: "secret"}).Encode(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// fake SMSC answering the forwarded submit_sm
+	cl, srv := net.Pipe()
+	defer cl.Close()
+	smsc := newSMPPConn(srv)
+	var fwdBody []byte
+	go smsc.readLoop(func(c *smppConn, p *smppPDU) {
+		fwdBody = p.Body
+		c.reply(p, smppStatusOK, smppAppendCString(nil, "remote1"))
+	})
+	sa.smscs["smsc1"] = newSMPPConn(cl)
+	go sa.smscs["smsc1"].readLoop(sa.handlePDU)
+
+	for _, tc := range []struct {
+		dst, msg string
+		status   uint32
+		msgID    string
+	}{
+		{"1002", "hello", smppStatusOK, "1001"},
+		{"1003", "hello", smppStatusThrottled, utils.EmptyString},
+		{"1004", "routed", smppStatusOK, "remote1"},
+		{"1002", "not processed", smppStatusSysErr, utils.EmptyString},
+	} {
+		body := buildSMPPSMBody("1001", tc.dst, 0, 0, []byte(tc.msg))
+		rply, err := esme.request(smppSubmitSM, body, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rply.Status != tc.status {
+			t.Errorf("Expected status %d for %s, received: %d", tc.status, tc.dst, rply.Status)
+		}
+		if msgID := (&smppBodyReader{b: rply.Body}).cString(); msgID != tc.msgID {
+			t.Errorf("Expected message_id <%s> for %s, received: <%s>", tc.msgID, tc.dst, msgID)
+		}
+		if tc.msgID == "remote1" && string(fwdBody) != string(body) {
+			t.Errorf("Unexpected forwarded body: %q", fwdBody)
+		}
+	}
+	if fwd, has := engine.Cache.Get(utils.CacheUCH, "SMPPForwarded"); !has || fwd != "smsc1:remote1" {
+		t.Errorf("Expected the submit_sm_resp processed, received: %v", fwd)
+	}
+
+	engine.Cache.Remove(utils.CacheUCH, "SMPPForwarded", true, utils.NonTransactional)
+	delete(sa.smscs, "smsc1") // route not bound
+	if rply, err := esme.request(smppSubmitSM,
+		buildSMPPSMBody("1001", "1004", 0, 0, []byte("routed")), time.Second); err != nil {
+		t.Fatal(err)
+	} else if rply.Status != smppStatusSubmitFail {
+		t.Errorf("Unexpected status: %d", rply.Status)
+	}
+	if _, has := engine.Cache.Get(utils.CacheUCH, "SMPPForwarded"); has {
+		t.Error("Expected no processing of the failed forward")
+	}
+}
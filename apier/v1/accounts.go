@@ -182,6 +182,9 @@ func (apierSv1 *APIerSv1) SetAccount(attr *utils.AttrSetAccount, reply *string)
 	if missing := utils.MissingStructFields(attr, []string{utils.AccountField}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
+	if err := engine.CheckCreditLimits(attr.CreditLimits); err != nil {
+		return utils.NewErrServerError(err)
+	}
 	tnt := attr.Tenant
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
@@ -277,6 +280,9 @@ func (apierSv1 *APIerSv1) SetAccount(attr *utils.AttrSetAccount, reply *string)
 		if alNeg, has := attr.ExtraOptions[utils.AllowNegative]; has {
 			ub.AllowNegative = alNeg
 		}
+		if attr.CreditLimits != nil {
+			ub.CreditLimits = attr.CreditLimits
+		}
 		if dis, has := attr.ExtraOptions[utils.Disabled]; has {
 			ub.Disabled = dis
 		}
@@ -466,6 +472,11 @@ func (apierSv1 *APIerSv1) modifyBalance(aType string, attr *AttrAddBalance, repl
 		at.ExtraData = *attr.ActionExtraData
 	}
 	at.SetAccountIDs(utils.StringMap{accID: true})
+	if aType == utils.MetaDebit {
+		at.SetLedgerSource(utils.MetaApier, utils.APIerSv1DebitBalance)
+	} else {
+		at.SetLedgerSource(utils.MetaApier, utils.APIerSv1AddBalance)
+	}
 
 	if attr.Overwrite {
 		aType += "_reset" // => *topup_reset/*debit_reset
@@ -548,6 +559,7 @@ func (apierSv1 *APIerSv1) SetBalance(attr *utils.AttrSetBalance, reply *string)
 		at.ExtraData = *attr.ActionExtraData
 	}
 	at.SetAccountIDs(utils.StringMap{accID: true})
+	at.SetLedgerSource(utils.MetaApier, utils.APIerSv1SetBalance)
 	if balance.TimingIDs != nil {
 		for _, timingID := range balance.TimingIDs.Slice() {
 			var tmg *utils.TPTiming
@@ -630,6 +642,7 @@ func (apierSv1 *APIerSv1) SetBalances(attr *utils.AttrSetBalances, reply *string
 		}
 
 		at.SetAccountIDs(utils.StringMap{accID: true})
+		at.SetLedgerSource(utils.MetaApier, utils.APIerSv1SetBalances)
 		if balFltr.TimingIDs != nil {
 			for _, timingID := range balFltr.TimingIDs.Slice() {
 				var tmg *utils.TPTiming
@@ -697,6 +710,7 @@ func (apierSv1 *APIerSv1) RemoveBalances(attr *utils.AttrSetBalance, reply *stri
 		at.ExtraData = *attr.ActionExtraData
 	}
 	at.SetAccountIDs(utils.StringMap{accID: true})
+	at.SetLedgerSource(utils.MetaApier, utils.APIerSv1RemoveBalances)
 	a := &engine.Action{
 		ActionType: utils.MetaRemoveBalance,
 		Balance:    balance,
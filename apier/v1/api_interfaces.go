@@ -43,6 +43,7 @@ type StatSv1Interface interface {
 	GetStatQueuesForEvent(args *utils.CGREvent, reply *[]string) (err error)
 	GetQueueStringMetrics(args *utils.TenantIDWithAPIOpts, reply *map[string]string) (err error)
 	GetQueueFloatMetrics(args *utils.TenantIDWithAPIOpts, reply *map[string]float64) (err error)
+	GetQueueWindows(args *utils.TenantIDWithAPIOpts, reply *[]*engine.StatWindow) (err error)
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -152,6 +153,9 @@ type CDRsV1Interface interface {
 	ProcessEvent(arg *engine.ArgV1ProcessEvent, reply *string) error
 	ProcessExternalCDR(cdr *engine.ExternalCDRWithAPIOpts, reply *string) error
 	RateCDRs(arg *engine.ArgRateCDRs, reply *string) error
+	StartRerate(args *engine.ArgsStartRerate, reply *string) error
+	GetRerateStatus(args *utils.TenantIDWithAPIOpts, reply *engine.RerateJob) error
+	CancelRerate(args *utils.TenantIDWithAPIOpts, reply *string) error
 	StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error
 	GetCDRsCount(args *utils.RPCCDRsFilterWithAPIOpts, reply *int64) error
 	GetCDRs(args *utils.RPCCDRsFilterWithAPIOpts, reply *[]*engine.CDR) error
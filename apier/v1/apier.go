@@ -239,6 +239,7 @@ func (apierSv1 *APIerSv1) ExecuteAction(attr *utils.AttrExecuteAction, reply *st
 	at := &engine.ActionTiming{
 		ActionsID: attr.ActionsId,
 	}
+	at.SetLedgerSource(utils.MetaApier, attr.ActionsId)
 	tnt := attr.Tenant
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
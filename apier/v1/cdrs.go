@@ -109,6 +109,21 @@ func (cdrSv1 *CDRsV1) RateCDRs(arg *engine.ArgRateCDRs, reply *string) error {
 	return cdrSv1.CDRs.V1RateCDRs(arg, reply)
 }
 
+// StartRerate starts a background job rerating the CDRs matching the filter
+func (cdrSv1 *CDRsV1) StartRerate(args *engine.ArgsStartRerate, reply *string) error {
+	return cdrSv1.CDRs.V1StartRerate(args, reply)
+}
+
+// GetRerateStatus returns the progress of a rerate job
+func (cdrSv1 *CDRsV1) GetRerateStatus(args *utils.TenantIDWithAPIOpts, reply *engine.RerateJob) error {
+	return cdrSv1.CDRs.V1GetRerateStatus(args, reply)
+}
+
+// CancelRerate stops a running rerate job
+func (cdrSv1 *CDRsV1) CancelRerate(args *utils.TenantIDWithAPIOpts, reply *string) error {
+	return cdrSv1.CDRs.V1CancelRerate(args, reply)
+}
+
 // StoreSMCost will store
 func (cdrSv1 *CDRsV1) StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error {
 	return cdrSv1.CDRs.V1StoreSessionCost(attr, reply)
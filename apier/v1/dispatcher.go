@@ -278,6 +278,12 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithAPIO
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+// GetQueueWindows implements StatSv1GetQueueWindows
+func (dSts *DispatcherStatSv1) GetQueueWindows(args *utils.TenantIDWithAPIOpts,
+	reply *[]*engine.StatWindow) error {
+	return dSts.dS.StatSv1GetQueueWindows(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithAPIOpts,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -766,6 +772,11 @@ func (dS *DispatcherSv1) RemoteSleep(args *utils.DurationArgs, reply *string) (e
 	return dS.dS.DispatcherSv1RemoteSleep(args, reply)
 }
 
+// GetHostsStatus returns the status of the DispatcherHosts as found by the health checks
+func (dS *DispatcherSv1) GetHostsStatus(args *utils.TenantWithAPIOpts, reply *map[string]*dispatchers.HostStatus) (err error) {
+	return dS.dS.DispatcherSv1GetHostsStatus(args, reply)
+}
+
 /*
 func (dSv1 DispatcherSv1) Apier(args *utils.MethodParameters, reply *interface{}) (err error) {
 	return dSv1.dS.V1Apier(new(APIerSv1), args, reply)
@@ -807,6 +818,18 @@ func (dS *DispatcherSCDRsV1) RateCDRs(args *engine.ArgRateCDRs, reply *string) e
 	return dS.dS.CDRsV1RateCDRs(args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) StartRerate(args *engine.ArgsStartRerate, reply *string) error {
+	return dS.dS.CDRsV1StartRerate(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetRerateStatus(args *utils.TenantIDWithAPIOpts, reply *engine.RerateJob) error {
+	return dS.dS.CDRsV1GetRerateStatus(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) CancelRerate(args *utils.TenantIDWithAPIOpts, reply *string) error {
+	return dS.dS.CDRsV1CancelRerate(args, reply)
+}
+
 func (dS *DispatcherSCDRsV1) ProcessExternalCDR(args *engine.ExternalCDRWithAPIOpts, reply *string) error {
 	return dS.dS.CDRsV1ProcessExternalCDR(args, reply)
 }
@@ -0,0 +1,145 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewInvoiceSv1 returns the RPC object for InvoiceS
+func NewInvoiceSv1(invS *engine.InvoiceService) *InvoiceSv1 {
+	return &InvoiceSv1{invS: invS}
+}
+
+// InvoiceSv1 exports RPC from InvoiceS
+type InvoiceSv1 struct {
+	invS *engine.InvoiceService
+}
+
+// Ping return pong if the service is active
+func (invSv1 *InvoiceSv1) Ping(ign *utils.CGREvent, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// CloseBillingPeriod issues the invoice of the account for the given period
+func (invSv1 *InvoiceSv1) CloseBillingPeriod(args *engine.ArgsCloseBillingPeriod, reply *engine.Invoice) error {
+	return invSv1.invS.V1CloseBillingPeriod(args, reply)
+}
+
+// GetInvoices returns the invoices matching the arguments
+func (invSv1 *InvoiceSv1) GetInvoices(args *engine.ArgsGetInvoices, reply *[]*engine.Invoice) error {
+	return invSv1.invS.V1GetInvoices(args, reply)
+}
+
+// getInvoice returns a copy of the invoice with the given ID from StorDB
+func (apierSv1 *APIerSv1) getInvoice(args *utils.TenantIDWithAPIOpts) (inv *engine.Invoice, err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return nil, utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	var invs []*engine.Invoice
+	if invs, err = apierSv1.CdrDb.GetInvoices(tnt, utils.EmptyString, args.ID); err != nil {
+		return
+	}
+	return invs[0].Clone(), nil // the internal StorDB returns its own objects
+}
+
+// updateInvoice applies updtFunc on a fresh copy of the invoice and stores it back,
+// locking on the same key as the invoice generation within InvoiceS
+func (apierSv1 *APIerSv1) updateInvoice(inv *engine.Invoice, updtFunc func(inv *engine.Invoice)) (err error) {
+	return guardian.Guardian.Guard(func() (gErr error) {
+		var invs []*engine.Invoice
+		if invs, gErr = apierSv1.CdrDb.GetInvoices(inv.Tenant, utils.EmptyString, inv.ID); gErr != nil {
+			return
+		}
+		updtInv := invs[0].Clone()
+		updtFunc(updtInv)
+		if gErr = apierSv1.CdrDb.SetInvoice(updtInv); gErr != nil {
+			return utils.NewErrServerError(gErr)
+		}
+		*inv = *updtInv
+		return
+	}, apierSv1.Config.GeneralCfg().LockingTimeout,
+		utils.InvoicesTBL+utils.ConcatenatedKey(inv.Tenant, inv.Account))
+}
+
+// VoidInvoice marks the invoice as voided so its billing period can be closed again
+func (apierSv1 *APIerSv1) VoidInvoice(args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	var inv *engine.Invoice
+	if inv, err = apierSv1.getInvoice(args); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if err = apierSv1.updateInvoice(inv, func(inv *engine.Invoice) {
+		inv.Status = utils.MetaVoided
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return
+}
+
+// ReissueInvoice voids the invoice and issues a new one for the same account and billing period
+func (apierSv1 *APIerSv1) ReissueInvoice(args *utils.TenantIDWithAPIOpts, reply *engine.Invoice) (err error) {
+	var inv *engine.Invoice
+	if inv, err = apierSv1.getInvoice(args); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	var status string
+	if err = apierSv1.updateInvoice(inv, func(inv *engine.Invoice) {
+		status = inv.Status
+		inv.Status = utils.MetaVoided
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	var newInv engine.Invoice
+	if err = apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().InvoiceSConns, nil,
+		utils.InvoiceSv1CloseBillingPeriod, &engine.ArgsCloseBillingPeriod{
+			Tenant:      inv.Tenant,
+			Account:     inv.Account,
+			PeriodStart: inv.PeriodStart.Format(time.RFC3339Nano),
+			PeriodEnd:   inv.PeriodEnd.Format(time.RFC3339Nano),
+			APIOpts:     args.APIOpts,
+		}, &newInv); err != nil {
+		if err.Error() == utils.ErrPartiallyExecuted.Error() { // new invoice stored but not exported
+			return
+		}
+		// restore the invoice since it was not replaced
+		if errSet := apierSv1.updateInvoice(inv, func(inv *engine.Invoice) {
+			inv.Status = status
+		}); errSet != nil {
+			return utils.APIErrorHandler(errSet)
+		}
+		return
+	}
+	if err = apierSv1.updateInvoice(inv, func(inv *engine.Invoice) {
+		inv.ReplacedBy = newInv.ID
+	}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = newInv
+	return
+}
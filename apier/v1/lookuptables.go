@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetLookupTableEntry returns the fields stored for one key of a LookupTable
+func (apierSv1 *APIerSv1) GetLookupTableEntry(arg *engine.LookupTableEntryArgs, reply *map[string]string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID, utils.Key}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	flds, err := apierSv1.DataManager.GetLookupTableEntry(tnt, arg.ID, arg.Key, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = flds
+	return nil
+}
+
+// GetLookupTableIDs returns list of LookupTable IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetLookupTableIDs(args *utils.PaginatorWithTenant, lktIDs *[]string) error {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.LookupTablePrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	ids := make(utils.StringSet) // the keys are stored per entry
+	for _, key := range keys {
+		if idx := strings.Index(key[len(prfx):], utils.ConcatenatedKeySep); idx != -1 {
+			ids.Add(key[len(prfx) : len(prfx)+idx])
+		}
+	}
+	retIDs := ids.AsSlice()
+	sort.Strings(retIDs)
+	*lktIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+// SetLookupTable adds or updates the entries of a LookupTable
+func (apierSv1 *APIerSv1) SetLookupTable(arg *engine.LookupTableWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(arg.LookupTable, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if arg.Tenant == utils.EmptyString {
+		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.SetLookupTable(arg.LookupTable); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheLookupTables and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheLookupTables: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for the entries of the LookupTable
+	if err := apierSv1.callCacheMultiple(utils.IfaceAsString(arg.APIOpts[utils.CacheOpt]), arg.Tenant, utils.CacheLookupTables,
+		arg.EntryIDs(), arg.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveLookupTable removes all the entries of a LookupTable
+func (apierSv1 *APIerSv1) RemoveLookupTable(arg *utils.TenantIDWithAPIOpts, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	entryIDs, err := apierSv1.DataManager.RemoveLookupTable(tnt, arg.ID)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheLookupTables and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheLookupTables: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for the entries of the LookupTable
+	if err := apierSv1.callCacheMultiple(utils.IfaceAsString(arg.APIOpts[utils.CacheOpt]), tnt, utils.CacheLookupTables,
+		entryIDs, arg.APIOpts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
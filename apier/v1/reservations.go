@@ -0,0 +1,116 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ReserveBalance holds the amount out of the account balances until it is committed, released or the TTL expires
+// the held amount is not available to GetMaxUsage or debits in the meantime
+func (apierSv1 *APIerSv1) ReserveBalance(attr *utils.AttrReserveBalance, reply *string) (err error) {
+	missing := utils.MissingStructFields(attr, []string{utils.AccountField, utils.ID,
+		utils.BalanceType, utils.TTL})
+	if attr.Amount <= 0 {
+		missing = append(missing, utils.Amount)
+	}
+	if len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	var ttl time.Duration
+	if ttl, err = utils.ParseDurationWithNanosecs(attr.TTL); err != nil {
+		return
+	}
+	tnt := attr.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	accID := utils.ConcatenatedKey(tnt, attr.Account)
+	if err = guardian.Guardian.Guard(func() (err error) {
+		var acc *engine.Account
+		if acc, err = apierSv1.DataManager.GetAccount(accID); err != nil {
+			return
+		}
+		if err = acc.ReserveBalance(attr.ID, attr.BalanceType, attr.BalanceID,
+			attr.Amount, time.Now().Add(ttl)); err != nil {
+			return
+		}
+		acc.SetLedgerSource(utils.MetaApier, attr.ID)
+		return apierSv1.DataManager.SetAccount(acc)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); err != nil {
+		if err != utils.ErrInsufficientCredit && err != utils.ErrExists &&
+			err != utils.ErrNegative {
+			err = utils.APIErrorHandler(err)
+		}
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// CommitReservation debits the amount out of the reservation, the rest of it being returned to the balances
+func (apierSv1 *APIerSv1) CommitReservation(attr *utils.AttrReservation, reply *string) (err error) {
+	return apierSv1.updateReservation(attr, func(acc *engine.Account) error {
+		return acc.CommitReservation(attr.ID, attr.Amount)
+	}, reply)
+}
+
+// ReleaseReservation returns the whole reserved amount to the balances
+func (apierSv1 *APIerSv1) ReleaseReservation(attr *utils.AttrReservation, reply *string) (err error) {
+	return apierSv1.updateReservation(attr, func(acc *engine.Account) error {
+		return acc.ReleaseReservation(attr.ID)
+	}, reply)
+}
+
+// updateReservation applies the update on the account locked by guardian
+func (apierSv1 *APIerSv1) updateReservation(attr *utils.AttrReservation,
+	update func(*engine.Account) error, reply *string) (err error) {
+	if missing := utils.MissingStructFields(attr, []string{utils.AccountField, utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := attr.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	accID := utils.ConcatenatedKey(tnt, attr.Account)
+	if err = guardian.Guardian.Guard(func() (err error) {
+		var acc *engine.Account
+		if acc, err = apierSv1.DataManager.GetAccount(accID); err != nil {
+			return
+		}
+		if err = update(acc); err != nil {
+			return
+		}
+		acc.SetLedgerSource(utils.MetaApier, attr.ID)
+		return apierSv1.DataManager.SetAccount(acc)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); err != nil {
+		if err != utils.ErrInsufficientCredit && err != utils.ErrExists &&
+			err != utils.ErrNegative {
+			err = utils.APIErrorHandler(err)
+		}
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,95 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestReserveBalance(t *testing.T) {
+	acntID := utils.ConcatenatedKey("cgrates.org", "rsvAccount")
+	if err := apierDebitStorage.SetAccountDrv(&engine.Account{
+		ID: acntID,
+		BalanceMap: map[string]engine.Balances{
+			utils.MetaMonetary: {&engine.Balance{Uuid: "uuid1", ID: "B1", Value: 10}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := apierDebit.ReserveBalance(&utils.AttrReserveBalance{
+		Account: "rsvAccount",
+		ID:      "RSV1",
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(
+		utils.BalanceType, utils.TTL, utils.Amount).Error() {
+		t.Errorf("Expected mandatory error, received %v", err)
+	}
+	if err := apierDebit.ReserveBalance(&utils.AttrReserveBalance{
+		Tenant:      "cgrates.org",
+		Account:     "rsvAccount",
+		ID:          "RSV1",
+		BalanceType: utils.MetaMonetary,
+		Amount:      15,
+		TTL:         "1h",
+	}, &reply); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	if err := apierDebit.ReserveBalance(&utils.AttrReserveBalance{
+		Tenant:      "cgrates.org",
+		Account:     "rsvAccount",
+		ID:          "RSV1",
+		BalanceType: utils.MetaMonetary,
+		Amount:      4,
+		TTL:         "1h",
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	if acc, err := dm.GetAccount(acntID); err != nil {
+		t.Fatal(err)
+	} else if val := acc.BalanceMap[utils.MetaMonetary][0].GetValue(); val != 6 ||
+		len(acc.Reservations) != 1 {
+		t.Errorf("Unexpected account: %s", utils.ToJSON(acc))
+	}
+	if err := apierDebit.CommitReservation(&utils.AttrReservation{
+		Tenant:  "cgrates.org",
+		Account: "rsvAccount",
+		ID:      "RSV1",
+		Amount:  utils.Float64Pointer(1),
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if acc, err := dm.GetAccount(acntID); err != nil {
+		t.Fatal(err)
+	} else if val := acc.BalanceMap[utils.MetaMonetary][0].GetValue(); val != 9 ||
+		len(acc.Reservations) != 0 {
+		t.Errorf("Unexpected account: %s", utils.ToJSON(acc))
+	}
+	if err := apierDebit.ReleaseReservation(&utils.AttrReservation{
+		Tenant:  "cgrates.org",
+		Account: "rsvAccount",
+		ID:      "RSV1",
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
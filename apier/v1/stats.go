@@ -73,6 +73,9 @@ func (apierSv1 *APIerSv1) SetStatQueueProfile(arg *engine.StatQueueProfileWithAP
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
+	if err = arg.CheckWindows(); err != nil {
+		return utils.NewErrServerError(err)
+	}
 	if err = apierSv1.DataManager.SetStatQueueProfile(arg.StatQueueProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
@@ -163,6 +166,11 @@ func (stsv1 *StatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithAPIOpts, repl
 	return stsv1.sS.V1GetQueueFloatMetrics(args.TenantID, reply)
 }
 
+// GetQueueWindows returns the closed time windows of a Queue
+func (stsv1 *StatSv1) GetQueueWindows(args *utils.TenantIDWithAPIOpts, reply *[]*engine.StatWindow) (err error) {
+	return stsv1.sS.V1GetQueueWindows(args.TenantID, reply)
+}
+
 // ResetStatQueue resets the stat queue
 func (stsv1 *StatSv1) ResetStatQueue(tntID *utils.TenantIDWithAPIOpts, reply *string) error {
 	return stsv1.sS.V1ResetStatQueue(tntID.TenantID, reply)
@@ -123,6 +123,7 @@ type AttrSetAccount struct {
 	ActionTriggerIDs       []string
 	ActionTriggerOverwrite bool
 	ExtraOptions           map[string]bool
+	CreditLimits           map[string]float64
 	ReloadScheduler        bool
 }
 
@@ -130,6 +131,9 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 	if missing := utils.MissingStructFields(attr, []string{utils.AccountField}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
+	if err := engine.CheckCreditLimits(attr.CreditLimits); err != nil {
+		return utils.NewErrServerError(err)
+	}
 	tnt := attr.Tenant
 	if tnt == utils.EmptyString {
 		tnt = apiv2.Config.GeneralCfg().DefaultTenant
@@ -251,6 +255,9 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 		if alNeg, has := attr.ExtraOptions[utils.AllowNegative]; has {
 			ub.AllowNegative = alNeg
 		}
+		if attr.CreditLimits != nil {
+			ub.CreditLimits = attr.CreditLimits
+		}
 		if dis, has := attr.ExtraOptions[utils.Disabled]; has {
 			ub.Disabled = dis
 		}
@@ -1898,14 +1898,14 @@ func testConsoleItStordbVersions(t *testing.T) {
 		"TpFilters":           1.,
 		"TpRates":             1.,
 		"TpRatingPlan":        1.,
-		"TpRatingPlans":       1.,
+		"TpRatingPlans":       2.,
 		"TpRatingProfile":     1.,
 		"TpRatingProfiles":    1.,
 		"TpResource":          1.,
 		"TpResources":         1.,
 		"TpRoutes":            1.,
 		"TpSharedGroups":      1.,
-		"TpStats":             1.,
+		"TpStats":             2.,
 		"TpThresholds":        1.,
 		"TpTiming":            1.,
 	}
@@ -459,6 +459,7 @@ func main() {
 	internalAPIerSv2Chan := make(chan rpcclient.ClientConnector, 1)
 	internalLoaderSChan := make(chan rpcclient.ClientConnector, 1)
 	internalEEsChan := make(chan rpcclient.ClientConnector, 1)
+	internalInvoiceSChan := make(chan rpcclient.ClientConnector, 1)
 
 	// initialize the connManager before creating the DMService
 	// because we need to pass the connection to it
@@ -483,6 +484,7 @@ func main() {
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCore):           internalCoreSv1Chan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs):           internalRALsChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs):            internalEEsChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices):       internalInvoiceSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaDispatchers):    internalDispatcherSChan,
 
 		utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS): internalSessionSChan,
@@ -495,6 +497,8 @@ func main() {
 		utils.AttributeS:      new(sync.WaitGroup),
 		utils.CDRServer:       new(sync.WaitGroup),
 		utils.ChargerS:        new(sync.WaitGroup),
+		utils.CHFAgent:        new(sync.WaitGroup),
+		utils.SMPPAgent:       new(sync.WaitGroup),
 		utils.CoreS:           new(sync.WaitGroup),
 		utils.DataDB:          new(sync.WaitGroup),
 		utils.DiameterAgent:   new(sync.WaitGroup),
@@ -506,7 +510,9 @@ func main() {
 		utils.FreeSWITCHAgent: new(sync.WaitGroup),
 		utils.GlobalVarS:      new(sync.WaitGroup),
 		utils.HTTPAgent:       new(sync.WaitGroup),
+		utils.InvoiceS:        new(sync.WaitGroup),
 		utils.KamailioAgent:   new(sync.WaitGroup),
+		utils.OpenSIPSAgent:   new(sync.WaitGroup),
 		utils.LoaderS:         new(sync.WaitGroup),
 		utils.RadiusAgent:     new(sync.WaitGroup),
 		utils.RALService:      new(sync.WaitGroup),
@@ -614,17 +620,23 @@ func main() {
 	cdrS := services.NewCDRServer(cfg, dmService, storDBService, filterSChan, server, internalCDRServerChan,
 		connManager, anz, srvDep)
 
+	invS := services.NewInvoiceService(cfg, dmService, storDBService, server, internalInvoiceSChan,
+		connManager, anz, srvDep)
+
 	smg := services.NewSessionService(cfg, dmService, server, internalSessionSChan, shdChan, connManager, anz, srvDep)
 
 	ldrs := services.NewLoaderService(cfg, dmService, filterSChan, server,
 		internalLoaderSChan, connManager, anz, srvDep)
 
 	srvManager.AddServices(gvService, attrS, chrS, tS, stS, reS, routeS, schS, rals,
-		apiSv1, apiSv2, cdrS, smg, coreS,
+		apiSv1, apiSv2, cdrS, invS, smg, coreS,
 		services.NewEventReaderService(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewDNSAgent(cfg, filterSChan, shdChan, connManager, srvDep),
+		services.NewCHFAgent(cfg, filterSChan, shdChan, connManager, srvDep),
+		services.NewSMPPAgent(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewFreeswitchAgent(cfg, shdChan, connManager, srvDep),
 		services.NewKamailioAgent(cfg, shdChan, connManager, srvDep),
+		services.NewOpenSIPSAgent(cfg, shdChan, connManager, srvDep),
 		services.NewAsteriskAgent(cfg, shdChan, connManager, srvDep),              // partial reload
 		services.NewRadiusAgent(cfg, filterSChan, shdChan, connManager, srvDep),   // partial reload
 		services.NewDiameterAgent(cfg, filterSChan, shdChan, connManager, srvDep), // partial reload
@@ -665,6 +677,7 @@ func main() {
 	engine.IntRPC.AddInternalRPCClient(utils.CoreSv1, internalCoreSv1Chan)
 	engine.IntRPC.AddInternalRPCClient(utils.RALsV1, internalRALsChan)
 	engine.IntRPC.AddInternalRPCClient(utils.EeSv1, internalEEsChan)
+	engine.IntRPC.AddInternalRPCClient(utils.InvoiceSv1, internalInvoiceSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.DispatcherSv1, internalDispatcherSChan)
 
 	initConfigSv1(internalConfigChan, server, anz)
@@ -19,12 +19,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package main
 
 import (
+	"encoding/csv"
 	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -112,6 +114,13 @@ var (
 		"CacheS component to contact for cache reloads, empty to disable automatic cache reloads")
 	schedulerAddress = cgrLoaderFlags.String(utils.SchedulerAddress, dfltCfg.LoaderCgrCfg().SchedulerConns[0], "")
 	rpcEncoding      = cgrLoaderFlags.String(utils.RpcEncodingCgr, rpcclient.JSONrpc, "RPC encoding used <*gob|*json>")
+
+	rateDeckPath = cgrLoaderFlags.String(utils.RateDeckPathCgr, utils.EmptyString,
+		"Path to the .csv or .xlsx carrier rate deck to be imported into storDb as a new version of the tariff plan")
+	rateDeckTemplate = cgrLoaderFlags.String(utils.RateDeckTemplateCgr, utils.EmptyString,
+		"The ID of the template used to parse the rate deck, empty for <Prefix,Rate,EffectiveDate,RateIncrement> columns")
+	rateDeckCategory = cgrLoaderFlags.String(utils.RateDeckCategoryCgr, utils.Call,
+		"The category of the RatingProfile activating the rate deck")
 )
 
 func loadConfig() (ldrCfg *config.CGRConfig) {
@@ -293,6 +302,50 @@ func importData(cfg *config.CGRConfig) (err error) {
 	return csvImporter.Run()
 }
 
+// importRateDeck imports the rate deck as the next version of the tariff plan
+// and prints the changes compared with the current tariff plan
+func importRateDeck(cfg *config.CGRConfig) (err error) {
+	if cfg.LoaderCgrCfg().TpID == utils.EmptyString {
+		return errors.New("TPid required")
+	}
+	var tpl []*config.FCTemplate
+	if *rateDeckTemplate != utils.EmptyString {
+		var has bool
+		if tpl, has = cfg.TemplatesCfg()[*rateDeckTemplate]; !has {
+			return fmt.Errorf("no template with id: <%s>", *rateDeckTemplate)
+		}
+	}
+	var rd engine.RateDeck
+	if rd, err = engine.ReadRateDeck(*rateDeckPath, cfg.LoaderCgrCfg().FieldSeparator,
+		tpl, cfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	var nextTPid string
+	if nextTPid, err = engine.NextRateDeckTPid(storDB, cfg.LoaderCgrCfg().TpID); err != nil {
+		return
+	}
+	var oldRows map[string]*engine.RateDeckRow
+	if oldRows, err = engine.TPPrefixRates(storDB, cfg.LoaderCgrCfg().TpID); err != nil {
+		return
+	}
+	if *verbose {
+		log.Printf("Comparing the rate deck with <%s>, importing it as <%s>", cfg.LoaderCgrCfg().TpID, nextTPid)
+	}
+	csvWriter := csv.NewWriter(os.Stdout)
+	csvWriter.Write(engine.RateDeckChangeCSVHeader)
+	for _, chng := range rd.Diff(oldRows) {
+		csvWriter.Write(chng.AsCSV())
+	}
+	if csvWriter.Flush(); csvWriter.Error() != nil {
+		return csvWriter.Error()
+	}
+	if *dryRun {
+		return
+	}
+	return rd.AsTP(nextTPid, *tenant, *rateDeckCategory, cfg.LoaderCgrCfg().TpID,
+		cfg.LoaderCgrCfg().RoundingMethod, time.Now()).SetTP(storDB)
+}
+
 func getLoader(cfg *config.CGRConfig) (loader engine.LoadReader, err error) {
 	if *fromStorDB { // Load Tariff Plan from storDb into dataDb
 		loader = storDB
@@ -327,7 +380,7 @@ func main() {
 	// we initialize connManager here with nil for InternalChannels
 	engine.NewConnManager(ldrCfg, nil)
 
-	if !*toStorDB {
+	if !*toStorDB && *rateDeckPath == utils.EmptyString {
 		if dataDB, err = engine.NewDataDBConn(ldrCfg.DataDbCfg().Type,
 			ldrCfg.DataDbCfg().Host, ldrCfg.DataDbCfg().Port,
 			ldrCfg.DataDbCfg().Name, ldrCfg.DataDbCfg().User,
@@ -338,7 +391,7 @@ func main() {
 		defer dataDB.Close()
 	}
 
-	if *fromStorDB || *toStorDB {
+	if *fromStorDB || *toStorDB || *rateDeckPath != utils.EmptyString {
 		if storDB, err = engine.NewStorDBConn(ldrCfg.StorDbCfg().Type,
 			ldrCfg.StorDbCfg().Host, ldrCfg.StorDbCfg().Port,
 			ldrCfg.StorDbCfg().Name, ldrCfg.StorDbCfg().User,
@@ -350,6 +403,13 @@ func main() {
 		defer storDB.Close()
 	}
 
+	if *rateDeckPath != utils.EmptyString { // Import the rate deck as a new version of the tariff plan into storDb
+		if err = importRateDeck(ldrCfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if !*dryRun && *toStorDB { // Import files from a directory into storDb
 		if err = importData(ldrCfg); err != nil {
 			log.Fatal(err)
@@ -278,4 +278,22 @@ func TestCGRLoaderFlags(t *testing.T) {
 	} else if *rpcEncoding != "*gob" {
 		t.Errorf("Expected **gob, received %+v", *rpcEncoding)
 	}
+
+	if err := cgrLoaderFlags.Parse([]string{"-ratedeck_path", "/tmp/ratedeck.xlsx"}); err != nil {
+		t.Error(err)
+	} else if *rateDeckPath != "/tmp/ratedeck.xlsx" {
+		t.Errorf("Expected /tmp/ratedeck.xlsx, received %+v", *rateDeckPath)
+	}
+
+	if err := cgrLoaderFlags.Parse([]string{"-ratedeck_template", "*ratedeck"}); err != nil {
+		t.Error(err)
+	} else if *rateDeckTemplate != "*ratedeck" {
+		t.Errorf("Expected *ratedeck, received %+v", *rateDeckTemplate)
+	}
+
+	if err := cgrLoaderFlags.Parse([]string{"-ratedeck_category", "sms"}); err != nil {
+		t.Error(err)
+	} else if *rateDeckCategory != "sms" {
+		t.Errorf("Expected sms, received %+v", *rateDeckCategory)
+	}
 }
@@ -29,6 +29,7 @@ type ApierCfg struct {
 	SchedulerConns  []string // connections towards Scheduler
 	AttributeSConns []string // connections towards AttributeS
 	EEsConns        []string // connections towards EEs
+	InvoiceSConns   []string // connections towards InvoiceS
 }
 
 func (aCfg *ApierCfg) loadFromJSONCfg(jsnCfg *ApierJsonCfg) (err error) {
@@ -78,6 +79,16 @@ func (aCfg *ApierCfg) loadFromJSONCfg(jsnCfg *ApierJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Invoices_conns != nil {
+		aCfg.InvoiceSConns = make([]string, len(*jsnCfg.Invoices_conns))
+		for idx, connID := range *jsnCfg.Invoices_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			aCfg.InvoiceSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				aCfg.InvoiceSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices)
+			}
+		}
+	}
 	return nil
 }
 
@@ -126,6 +137,16 @@ func (aCfg *ApierCfg) AsMapInterface() (initialMap map[string]interface{}) {
 		}
 		initialMap[utils.EEsConnsCfg] = eesConns
 	}
+	if aCfg.InvoiceSConns != nil {
+		invoiceSConns := make([]string, len(aCfg.InvoiceSConns))
+		for i, item := range aCfg.InvoiceSConns {
+			invoiceSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices) {
+				invoiceSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMap[utils.InvoiceSConnsCfg] = invoiceSConns
+	}
 	return
 }
 
@@ -158,5 +179,11 @@ func (aCfg ApierCfg) Clone() (cln *ApierCfg) {
 			cln.EEsConns[i] = k
 		}
 	}
+	if aCfg.InvoiceSConns != nil {
+		cln.InvoiceSConns = make([]string, len(aCfg.InvoiceSConns))
+		for i, k := range aCfg.InvoiceSConns {
+			cln.InvoiceSConns[i] = k
+		}
+	}
 	return
 }
@@ -31,6 +31,7 @@ func TestApierCfgloadFromJsonCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{utils.MetaInternal, "*conn1"},
 		Attributes_conns: &[]string{utils.MetaInternal, "*conn1"},
 		Ees_conns:        &[]string{utils.MetaInternal, "*conn1"},
+		Invoices_conns:   &[]string{utils.MetaInternal, "*conn1"},
 	}
 	expected := &ApierCfg{
 		Enabled:         false,
@@ -38,6 +39,7 @@ func TestApierCfgloadFromJsonCfg(t *testing.T) {
 		SchedulerConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		InvoiceSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices), "*conn1"},
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.apier.loadFromJSONCfg(jsonCfg); err != nil {
@@ -60,6 +62,7 @@ func TestApierCfgAsMapInterface1(t *testing.T) {
 		utils.SchedulerConnsCfg:  sls,
 		utils.AttributeSConnsCfg: sls,
 		utils.EEsConnsCfg:        sls,
+		utils.InvoiceSConnsCfg:   sls,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -76,6 +79,7 @@ func TestApierCfgAsMapInterface2(t *testing.T) {
        "ees_conns": ["*internal:*ees", "*conn1"],
        "caches_conns": ["*internal:*caches", "*conn1"],
        "scheduler_conns": ["*internal:*scheduler", "*conn1"],
+       "invoices_conns": ["*internal:*invoices", "*conn1"],
     },
 }`
 	expectedMap := map[string]interface{}{
@@ -84,6 +88,7 @@ func TestApierCfgAsMapInterface2(t *testing.T) {
 		utils.SchedulerConnsCfg:  []string{utils.MetaInternal, "*conn1"},
 		utils.AttributeSConnsCfg: []string{utils.MetaInternal, "*conn1"},
 		utils.EEsConnsCfg:        []string{utils.MetaInternal, "*conn1"},
+		utils.InvoiceSConnsCfg:   []string{utils.MetaInternal, "*conn1"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(myJSONStr); err != nil {
 		t.Error(err)
@@ -99,6 +104,7 @@ func TestApierCfgClone(t *testing.T) {
 		SchedulerConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		InvoiceSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaInvoices), "*conn1"},
 	}
 	rcv := sa.Clone()
 	if !reflect.DeepEqual(sa, rcv) {
@@ -116,4 +122,7 @@ func TestApierCfgClone(t *testing.T) {
 	if rcv.EEsConns[1] = ""; sa.EEsConns[1] != "*conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.InvoiceSConns[1] = ""; sa.InvoiceSConns[1] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -0,0 +1,138 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// CHFAgentCfg the config section that describes the 5G CHF Agent
+type CHFAgentCfg struct {
+	Enabled           bool
+	Listen            string
+	ListenNet         string // tcp or tcp-tls
+	APIRoot           string
+	SessionSConns     []string
+	Timezone          string
+	RequestProcessors []*RequestProcessor
+}
+
+func (ca *CHFAgentCfg) loadFromJSONCfg(jsnCfg *CHFAgentJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		ca.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen != nil {
+		ca.Listen = *jsnCfg.Listen
+	}
+	if jsnCfg.Listen_net != nil {
+		ca.ListenNet = *jsnCfg.Listen_net
+	}
+	if jsnCfg.Api_root != nil {
+		ca.APIRoot = *jsnCfg.Api_root
+	}
+	if jsnCfg.Timezone != nil {
+		ca.Timezone = *jsnCfg.Timezone
+	}
+	if jsnCfg.Sessions_conns != nil {
+		ca.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, connID := range *jsnCfg.Sessions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			ca.SessionSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				ca.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Request_processors != nil {
+		for _, reqProcJsn := range *jsnCfg.Request_processors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range ca.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				ca.RequestProcessors = append(ca.RequestProcessors, rp)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (ca *CHFAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:   ca.Enabled,
+		utils.ListenCfg:    ca.Listen,
+		utils.ListenNetCfg: ca.ListenNet,
+		utils.APIRootCfg:   ca.APIRoot,
+		utils.TimezoneCfg:  ca.Timezone,
+	}
+
+	requestProcessors := make([]map[string]interface{}, len(ca.RequestProcessors))
+	for i, item := range ca.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
+	}
+	initialMP[utils.RequestProcessorsCfg] = requestProcessors
+
+	if ca.SessionSConns != nil {
+		sessionSConns := make([]string, len(ca.SessionSConns))
+		for i, item := range ca.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of CHFAgentCfg
+func (ca CHFAgentCfg) Clone() (cln *CHFAgentCfg) {
+	cln = &CHFAgentCfg{
+		Enabled:   ca.Enabled,
+		Listen:    ca.Listen,
+		ListenNet: ca.ListenNet,
+		APIRoot:   ca.APIRoot,
+		Timezone:  ca.Timezone,
+	}
+	if ca.SessionSConns != nil {
+		cln.SessionSConns = make([]string, len(ca.SessionSConns))
+		for i, con := range ca.SessionSConns {
+			cln.SessionSConns[i] = con
+		}
+	}
+	if ca.RequestProcessors != nil {
+		cln.RequestProcessors = make([]*RequestProcessor, len(ca.RequestProcessors))
+		for i, req := range ca.RequestProcessors {
+			cln.RequestProcessors[i] = req.Clone()
+		}
+	}
+	return
+}
@@ -0,0 +1,146 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestCHFAgentCfgloadFromJsonCfg(t *testing.T) {
+	jsnCfg := &CHFAgentJsonCfg{
+		Enabled:        utils.BoolPointer(true),
+		Listen:         utils.StringPointer("127.0.0.1:2085"),
+		Listen_net:     utils.StringPointer("tcp-tls"),
+		Api_root:       utils.StringPointer("/nchf-convergedcharging/v2"),
+		Sessions_conns: &[]string{utils.MetaInternal, "*conn1"},
+		Timezone:       utils.StringPointer("UTC"),
+		Request_processors: &[]*ReqProcessorJsnCfg{
+			{
+				ID:             utils.StringPointer("ChargingDataCreate"),
+				Filters:        &[]string{"*string:~*vars.*cmd:Create"},
+				Flags:          &[]string{utils.MetaInitiate, utils.MetaAccounts},
+				Request_fields: &[]*FcTemplateJsonCfg{},
+				Reply_fields: &[]*FcTemplateJsonCfg{
+					{Tag: utils.StringPointer("InvocationSequenceNumber"), Path: utils.StringPointer("*rep.invocationSequenceNumber"),
+						Type: utils.StringPointer(utils.MetaVariable), Value: utils.StringPointer("~*req.invocationSequenceNumber")},
+				},
+			},
+		},
+	}
+	expected := &CHFAgentCfg{
+		Enabled:       true,
+		Listen:        "127.0.0.1:2085",
+		ListenNet:     "tcp-tls",
+		APIRoot:       "/nchf-convergedcharging/v2",
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:      "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "ChargingDataCreate",
+				Filters:       []string{"*string:~*vars.*cmd:Create"},
+				Flags:         utils.FlagsWithParamsFromSlice([]string{utils.MetaInitiate, utils.MetaAccounts}),
+				RequestFields: []*FCTemplate{},
+				ReplyFields: []*FCTemplate{
+					{Tag: "InvocationSequenceNumber", Path: "*rep.invocationSequenceNumber", Type: utils.MetaVariable,
+						Value: NewRSRParsersMustCompile("~*req.invocationSequenceNumber", utils.InfieldSep), Layout: "2006-01-02T15:04:05Z07:00"},
+				},
+			},
+		},
+	}
+	for _, v := range expected.RequestProcessors[0].ReplyFields {
+		v.ComputePath()
+	}
+	jsonCfg := NewDefaultCGRConfig()
+	if err = jsonCfg.chfAgentCfg.loadFromJSONCfg(jsnCfg, jsonCfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(jsonCfg.chfAgentCfg, expected) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.chfAgentCfg))
+	}
+}
+
+func TestCHFAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"chf_agent": {
+		"enabled": true,
+		"listen_net": "tcp-tls",
+		"sessions_conns": ["*internal", "*conn1"],
+		"request_processors": [
+			{
+				"id": "ChargingDataRelease",
+				"filters": ["*string:~*vars.*cmd:Release"],
+				"flags": ["*terminate"],
+			},
+		],
+	},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:       true,
+		utils.ListenCfg:        "127.0.0.1:2085",
+		utils.ListenNetCfg:     "tcp-tls",
+		utils.APIRootCfg:       "/nchf-convergedcharging/v3",
+		utils.SessionSConnsCfg: []string{utils.MetaInternal, "*conn1"},
+		utils.TimezoneCfg:      utils.EmptyString,
+		utils.RequestProcessorsCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:       "ChargingDataRelease",
+				utils.FiltersCfg:  []string{"*string:~*vars.*cmd:Release"},
+				utils.FlagsCfg:    []string{utils.MetaTerminate},
+				utils.TimezoneCfg: utils.EmptyString,
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.chfAgentCfg.AsMapInterface(utils.EmptyString); !reflect.DeepEqual(rcv, eMap) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestCHFAgentCfgClone(t *testing.T) {
+	ban := &CHFAgentCfg{
+		Enabled:       true,
+		Listen:        "127.0.0.1:2085",
+		ListenNet:     "tcp",
+		APIRoot:       "/nchf-convergedcharging/v3",
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:      "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "ChargingDataCreate",
+				Filters:       []string{"*string:~*vars.*cmd:Create"},
+				Flags:         utils.FlagsWithParamsFromSlice([]string{utils.MetaInitiate}),
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	rcv := ban.Clone()
+	if !reflect.DeepEqual(ban, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(ban), utils.ToJSON(rcv))
+	}
+	if rcv.SessionSConns[1] = utils.EmptyString; ban.SessionSConns[1] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.RequestProcessors[0].ID = utils.EmptyString; ban.RequestProcessors[0].ID != "ChargingDataCreate" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
@@ -40,13 +40,14 @@ import (
 )
 
 var (
-	dbDefaultsCfg     dbDefaults
-	cgrCfg            *CGRConfig  // will be shared
-	dfltFsConnConfig  *FsConnCfg  // Default FreeSWITCH Connection configuration, built out of json default configuration
-	dfltKamConnConfig *KamConnCfg // Default Kamailio Connection configuration
-	dfltRemoteHost    *RemoteHost
-	dfltAstConnCfg    *AsteriskConnCfg
-	dfltLoaderConfig  *LoaderSCfg
+	dbDefaultsCfg       dbDefaults
+	cgrCfg              *CGRConfig    // will be shared
+	dfltFsConnConfig    *FsConnCfg    // Default FreeSWITCH Connection configuration, built out of json default configuration
+	dfltKamConnConfig   *KamConnCfg   // Default Kamailio Connection configuration
+	dfltOsipsConnConfig *OsipsConnCfg // Default OpenSIPS Connection configuration
+	dfltRemoteHost      *RemoteHost
+	dfltAstConnCfg      *AsteriskConnCfg
+	dfltLoaderConfig    *LoaderSCfg
 )
 
 func newDbDefaults() dbDefaults {
@@ -121,6 +122,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.templates = make(map[string][]*FCTemplate)
 	cfg.generalCfg = new(GeneralCfg)
 	cfg.generalCfg.NodeID = utils.UUIDSha1Prefix()
+	cfg.dfltNodeID = cfg.generalCfg.NodeID
 	cfg.dataDbCfg = new(DataDbCfg)
 	cfg.dataDbCfg.Items = make(map[string]*ItemOpt)
 	cfg.dataDbCfg.Opts = make(map[string]interface{})
@@ -138,18 +140,23 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.ralsCfg = new(RalsCfg)
 	cfg.ralsCfg.MaxComputedUsage = make(map[string]time.Duration)
 	cfg.ralsCfg.BalanceRatingSubject = make(map[string]string)
+	cfg.ralsCfg.TieredRatingPlans = make(map[string]*TierCounterCfg)
 	cfg.schedulerCfg = new(SchedulerCfg)
 	cfg.cdrsCfg = new(CdrsCfg)
 	cfg.analyzerSCfg = new(AnalyzerSCfg)
+	cfg.invoiceSCfg = new(InvoiceSCfg)
 	cfg.sessionSCfg = new(SessionSCfg)
 	cfg.sessionSCfg.STIRCfg = new(STIRcfg)
 	cfg.sessionSCfg.DefaultUsage = make(map[string]time.Duration)
 	cfg.fsAgentCfg = new(FsAgentCfg)
 	cfg.kamAgentCfg = new(KamAgentCfg)
+	cfg.osipsAgentCfg = new(OsipsAgentCfg)
 	cfg.asteriskAgentCfg = new(AsteriskAgentCfg)
 	cfg.diameterAgentCfg = new(DiameterAgentCfg)
 	cfg.radiusAgentCfg = new(RadiusAgentCfg)
 	cfg.dnsAgentCfg = new(DNSAgentCfg)
+	cfg.chfAgentCfg = new(CHFAgentCfg)
+	cfg.smppAgentCfg = new(SMPPAgentCfg)
 	cfg.attributeSCfg = &AttributeSCfg{Opts: &AttributesOpts{}}
 	cfg.chargerSCfg = new(ChargerSCfg)
 	cfg.resourceSCfg = &ResourceSConfig{Opts: &ResourcesOpts{}}
@@ -206,6 +213,7 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	}
 	dfltFsConnConfig = cfg.fsAgentCfg.EventSocketConns[0] // We leave it crashing here on purpose if no Connection defaults defined
 	dfltKamConnConfig = cfg.kamAgentCfg.EvapiConns[0]
+	dfltOsipsConnConfig = cfg.osipsAgentCfg.MiConns[0]
 	dfltAstConnCfg = cfg.asteriskAgentCfg.AsteriskConns[0]
 	dfltLoaderConfig = cfg.loaderCfg[0].Clone()
 	dfltRemoteHost = new(RemoteHost)
@@ -265,6 +273,8 @@ type CGRConfig struct {
 	dfltEvRdr *EventReaderCfg   // default event reader
 	dfltEvExp *EventExporterCfg // default event exporter
 
+	dfltNodeID string // NodeID generated on start, used when not configured
+
 	loaderCfg    LoaderSCfgs   // LoaderS configs
 	httpAgentCfg HTTPAgentCfgs // HttpAgent configs
 
@@ -288,10 +298,13 @@ type CGRConfig struct {
 	sessionSCfg      *SessionSCfg      // SessionS config
 	fsAgentCfg       *FsAgentCfg       // FreeSWITCHAgent config
 	kamAgentCfg      *KamAgentCfg      // KamailioAgent config
+	osipsAgentCfg    *OsipsAgentCfg    // OpenSIPSAgent config
 	asteriskAgentCfg *AsteriskAgentCfg // AsteriskAgent config
 	diameterAgentCfg *DiameterAgentCfg // DiameterAgent config
 	radiusAgentCfg   *RadiusAgentCfg   // RadiusAgent config
 	dnsAgentCfg      *DNSAgentCfg      // DNSAgent config
+	chfAgentCfg      *CHFAgentCfg      // CHFAgent config
+	smppAgentCfg     *SMPPAgentCfg     // SMPPAgent config
 	attributeSCfg    *AttributeSCfg    // AttributeS config
 	chargerSCfg      *ChargerSCfg      // ChargerS config
 	resourceSCfg     *ResourceSConfig  // ResourceS config
@@ -312,6 +325,7 @@ type CGRConfig struct {
 	configSCfg       *ConfigSCfg       // ConfigS config
 	apiBanCfg        *APIBanCfg        // APIBan config
 	coreSCfg         *CoreSCfg         // CoreS config
+	invoiceSCfg      *InvoiceSCfg      // InvoiceS config
 
 	cacheDP    map[string]utils.MapStorage
 	cacheDPMux sync.RWMutex
@@ -320,7 +334,8 @@ type CGRConfig struct {
 var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 	utils.MetaResources, utils.MetaFilters, utils.MetaStats,
 	utils.MetaRoutes, utils.MetaThresholds, utils.MetaChargers,
-	utils.MetaDispatchers, utils.MetaDispatcherHosts})
+	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaExchangeRates,
+	utils.MetaLookupTables})
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
@@ -375,16 +390,17 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadHTTPCfg, cfg.loadDataDBCfg, cfg.loadStorDBCfg,
 		cfg.loadFilterSCfg, cfg.loadRalSCfg, cfg.loadSchedulerCfg,
 		cfg.loadCdrsCfg, cfg.loadSessionSCfg,
-		cfg.loadFreeswitchAgentCfg, cfg.loadKamAgentCfg,
+		cfg.loadFreeswitchAgentCfg, cfg.loadKamAgentCfg, cfg.loadOsipsAgentCfg,
 		cfg.loadAsteriskAgentCfg, cfg.loadDiameterAgentCfg, cfg.loadRadiusAgentCfg,
-		cfg.loadDNSAgentCfg, cfg.loadHTTPAgentCfg, cfg.loadAttributeSCfg,
+		cfg.loadDNSAgentCfg, cfg.loadCHFAgentCfg, cfg.loadSMPPAgentCfg, cfg.loadHTTPAgentCfg, cfg.loadAttributeSCfg,
 		cfg.loadChargerSCfg, cfg.loadResourceSCfg, cfg.loadStatSCfg,
 		cfg.loadThresholdSCfg, cfg.loadRouteSCfg, cfg.loadLoaderSCfg,
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTLSCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
 		cfg.loadSIPAgentCfg, cfg.loadRegistrarCCfg,
-		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg} {
+		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg,
+		cfg.loadInvoiceSCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -567,6 +583,15 @@ func (cfg *CGRConfig) loadKamAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.kamAgentCfg.loadFromJSONCfg(jsnKamAgentCfg)
 }
 
+// loadOsipsAgentCfg loads the OsipsAgent section of the configuration
+func (cfg *CGRConfig) loadOsipsAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnOsipsAgentCfg *OsipsAgentJsonCfg
+	if jsnOsipsAgentCfg, err = jsnCfg.OsipsAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.osipsAgentCfg.loadFromJSONCfg(jsnOsipsAgentCfg)
+}
+
 // loadAsteriskAgentCfg loads the AsteriskAgent section of the configuration
 func (cfg *CGRConfig) loadAsteriskAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnSMAstCfg *AsteriskAgentJsonCfg
@@ -603,6 +628,24 @@ func (cfg *CGRConfig) loadDNSAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.dnsAgentCfg.loadFromJSONCfg(jsnDNSCfg, cfg.generalCfg.RSRSep)
 }
 
+// loadCHFAgentCfg loads the CHFAgent section of the configuration
+func (cfg *CGRConfig) loadCHFAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnCHFCfg *CHFAgentJsonCfg
+	if jsnCHFCfg, err = jsnCfg.CHFAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.chfAgentCfg.loadFromJSONCfg(jsnCHFCfg, cfg.generalCfg.RSRSep)
+}
+
+// loadSMPPAgentCfg loads the SMPPAgent section of the configuration
+func (cfg *CGRConfig) loadSMPPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnSMPPCfg *SMPPAgentJsonCfg
+	if jsnSMPPCfg, err = jsnCfg.SMPPAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.smppAgentCfg.loadFromJSONCfg(jsnSMPPCfg, cfg.generalCfg.RSRSep)
+}
+
 // loadHTTPAgentCfg loads the HttpAgent section of the configuration
 func (cfg *CGRConfig) loadHTTPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnHTTPAgntCfg *[]*HttpAgentJsonCfg
@@ -755,6 +798,15 @@ func (cfg *CGRConfig) loadAnalyzerCgrCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.analyzerSCfg.loadFromJSONCfg(jsnAnalyzerCgrCfg)
 }
 
+// loadInvoiceSCfg loads the InvoiceS section of the configuration
+func (cfg *CGRConfig) loadInvoiceSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnInvoiceSCfg *InvoiceSJsonCfg
+	if jsnInvoiceSCfg, err = jsnCfg.InvoiceSCfgJson(); err != nil {
+		return
+	}
+	return cfg.invoiceSCfg.loadFromJSONCfg(jsnInvoiceSCfg)
+}
+
 // loadAPIBanCgrCfg loads the Analyzer section of the configuration
 func (cfg *CGRConfig) loadAPIBanCgrCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnAPIBanCfg *APIBanJsonCfg
@@ -859,6 +911,20 @@ func (cfg *CGRConfig) DNSAgentCfg() *DNSAgentCfg {
 	return cfg.dnsAgentCfg
 }
 
+// CHFAgentCfg returns the config for CHF Agent
+func (cfg *CGRConfig) CHFAgentCfg() *CHFAgentCfg {
+	cfg.lks[CHFAgentJson].Lock()
+	defer cfg.lks[CHFAgentJson].Unlock()
+	return cfg.chfAgentCfg
+}
+
+// SMPPAgentCfg returns the config for SMPP Agent
+func (cfg *CGRConfig) SMPPAgentCfg() *SMPPAgentCfg {
+	cfg.lks[SMPPAgentJson].Lock()
+	defer cfg.lks[SMPPAgentJson].Unlock()
+	return cfg.smppAgentCfg
+}
+
 // AttributeSCfg returns the config for AttributeS
 func (cfg *CGRConfig) AttributeSCfg() *AttributeSCfg {
 	cfg.lks[ATTRIBUTE_JSN].Lock()
@@ -922,6 +988,13 @@ func (cfg *CGRConfig) KamAgentCfg() *KamAgentCfg {
 	return cfg.kamAgentCfg
 }
 
+// OsipsAgentCfg returns the config for OsipsAgent
+func (cfg *CGRConfig) OsipsAgentCfg() *OsipsAgentCfg {
+	cfg.lks[OsipsAgentJSN].Lock()
+	defer cfg.lks[OsipsAgentJSN].Unlock()
+	return cfg.osipsAgentCfg
+}
+
 // AsteriskAgentCfg returns the config for AsteriskAgent
 func (cfg *CGRConfig) AsteriskAgentCfg() *AsteriskAgentCfg {
 	cfg.lks[AsteriskAgentJSN].Lock()
@@ -1013,6 +1086,11 @@ func (cfg *CGRConfig) GeneralCfg() *GeneralCfg {
 	return cfg.generalCfg
 }
 
+// NodeIDGenerated returns true if the NodeID was not configured, being generated on each start
+func (cfg *CGRConfig) NodeIDGenerated() bool {
+	return cfg.GeneralCfg().NodeID == cfg.dfltNodeID
+}
+
 // TLSCfg returns the config for Tls
 func (cfg *CGRConfig) TLSCfg() *TLSCfg {
 	cfg.lks[TlsCfgJson].Lock()
@@ -1062,6 +1140,13 @@ func (cfg *CGRConfig) AnalyzerSCfg() *AnalyzerSCfg {
 	return cfg.analyzerSCfg
 }
 
+// InvoiceSCfg returns the config for InvoiceS
+func (cfg *CGRConfig) InvoiceSCfg() *InvoiceSCfg {
+	cfg.lks[InvoiceSCfgJson].Lock()
+	defer cfg.lks[InvoiceSCfgJson].Unlock()
+	return cfg.invoiceSCfg
+}
+
 // ApierCfg reads the Apier configuration
 func (cfg *CGRConfig) ApierCfg() *ApierCfg {
 	cfg.lks[ApierS].Lock()
@@ -1216,10 +1301,13 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		AsteriskAgentJSN:   cfg.loadAsteriskAgentCfg,
 		FreeSWITCHAgentJSN: cfg.loadFreeswitchAgentCfg,
 		KamailioAgentJSN:   cfg.loadKamAgentCfg,
+		OsipsAgentJSN:      cfg.loadOsipsAgentCfg,
 		DA_JSN:             cfg.loadDiameterAgentCfg,
 		RA_JSN:             cfg.loadRadiusAgentCfg,
 		HttpAgentJson:      cfg.loadHTTPAgentCfg,
 		DNSAgentJson:       cfg.loadDNSAgentCfg,
+		CHFAgentJson:       cfg.loadCHFAgentCfg,
+		SMPPAgentJson:      cfg.loadSMPPAgentCfg,
 		ATTRIBUTE_JSN:      cfg.loadAttributeSCfg,
 		ChargerSCfgJson:    cfg.loadChargerSCfg,
 		RESOURCES_JSON:     cfg.loadResourceSCfg,
@@ -1241,6 +1329,7 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		ConfigSJson:        cfg.loadConfigSCfg,
 		APIBanCfgJson:      cfg.loadAPIBanCgrCfg,
 		CoreSCfgJson:       cfg.loadCoreSCfg,
+		InvoiceSCfgJson:    cfg.loadInvoiceSCfg,
 	}
 }
 
@@ -1402,9 +1491,9 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 	subsystemsThatNeedDataDB := utils.NewStringSet([]string{DATADB_JSN, SCHEDULER_JSN,
 		RALS_JSN, CDRS_JSN, SessionSJson, ATTRIBUTE_JSN,
 		ChargerSCfgJson, RESOURCES_JSON, STATS_JSON, THRESHOLDS_JSON,
-		RouteSJson, LoaderJson, DispatcherSJson, ApierS,
+		RouteSJson, LoaderJson, DispatcherSJson, ApierS, InvoiceSCfgJson,
 	})
-	subsystemsThatNeedStorDB := utils.NewStringSet([]string{STORDB_JSN, RALS_JSN, CDRS_JSN, ApierS})
+	subsystemsThatNeedStorDB := utils.NewStringSet([]string{STORDB_JSN, RALS_JSN, CDRS_JSN, ApierS, InvoiceSCfgJson})
 	needsDataDB := false
 	needsStorDB := false
 	for _, section := range sections {
@@ -1458,6 +1547,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[FreeSWITCHAgentJSN] <- struct{}{}
 		case KamailioAgentJSN:
 			cfg.rldChans[KamailioAgentJSN] <- struct{}{}
+		case OsipsAgentJSN:
+			cfg.rldChans[OsipsAgentJSN] <- struct{}{}
 		case DA_JSN:
 			cfg.rldChans[DA_JSN] <- struct{}{}
 		case RA_JSN:
@@ -1466,6 +1557,10 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[HttpAgentJson] <- struct{}{}
 		case DNSAgentJson:
 			cfg.rldChans[DNSAgentJson] <- struct{}{}
+		case CHFAgentJson:
+			cfg.rldChans[CHFAgentJson] <- struct{}{}
+		case SMPPAgentJson:
+			cfg.rldChans[SMPPAgentJson] <- struct{}{}
 		case ATTRIBUTE_JSN:
 			cfg.rldChans[ATTRIBUTE_JSN] <- struct{}{}
 		case ChargerSCfgJson:
@@ -1492,6 +1587,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
 		case RegistrarCJson:
 			cfg.rldChans[RegistrarCJson] <- struct{}{}
+		case InvoiceSCfgJson:
+			cfg.rldChans[InvoiceSCfgJson] <- struct{}{}
 		}
 	}
 }
@@ -1516,10 +1613,13 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		SessionSJson:       cfg.sessionSCfg.AsMapInterface(),
 		FreeSWITCHAgentJSN: cfg.fsAgentCfg.AsMapInterface(separator),
 		KamailioAgentJSN:   cfg.kamAgentCfg.AsMapInterface(),
+		OsipsAgentJSN:      cfg.osipsAgentCfg.AsMapInterface(),
 		AsteriskAgentJSN:   cfg.asteriskAgentCfg.AsMapInterface(),
 		DA_JSN:             cfg.diameterAgentCfg.AsMapInterface(separator),
 		RA_JSN:             cfg.radiusAgentCfg.AsMapInterface(separator),
 		DNSAgentJson:       cfg.dnsAgentCfg.AsMapInterface(separator),
+		CHFAgentJson:       cfg.chfAgentCfg.AsMapInterface(separator),
+		SMPPAgentJson:      cfg.smppAgentCfg.AsMapInterface(separator),
 		ATTRIBUTE_JSN:      cfg.attributeSCfg.AsMapInterface(),
 		ChargerSCfgJson:    cfg.chargerSCfg.AsMapInterface(),
 		RESOURCES_JSON:     cfg.resourceSCfg.AsMapInterface(),
@@ -1541,6 +1641,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		TemplatesJson:      cfg.templates.AsMapInterface(separator),
 		ConfigSJson:        cfg.configSCfg.AsMapInterface(),
 		CoreSCfgJson:       cfg.coreSCfg.AsMapInterface(),
+		InvoiceSCfgJson:    cfg.invoiceSCfg.AsMapInterface(),
 	}
 }
 
@@ -1645,6 +1746,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 		mp = cfg.FsAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case KamailioAgentJSN:
 		mp = cfg.KamAgentCfg().AsMapInterface()
+	case OsipsAgentJSN:
+		mp = cfg.OsipsAgentCfg().AsMapInterface()
 	case AsteriskAgentJSN:
 		mp = cfg.AsteriskAgentCfg().AsMapInterface()
 	case DA_JSN:
@@ -1653,6 +1756,10 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 		mp = cfg.RadiusAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case DNSAgentJson:
 		mp = cfg.DNSAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case CHFAgentJson:
+		mp = cfg.CHFAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ATTRIBUTE_JSN:
 		mp = cfg.AttributeSCfg().AsMapInterface()
 	case ChargerSCfgJson:
@@ -1701,6 +1808,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 		mp = cfg.AnalyzerSCfg().AsMapInterface()
 	case CoreSCfgJson:
 		mp = cfg.CoreSCfg().AsMapInterface()
+	case InvoiceSCfgJson:
+		mp = cfg.InvoiceSCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -1809,6 +1918,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 		mp = cfg.FsAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case KamailioAgentJSN:
 		mp = cfg.KamAgentCfg().AsMapInterface()
+	case OsipsAgentJSN:
+		mp = cfg.OsipsAgentCfg().AsMapInterface()
 	case AsteriskAgentJSN:
 		mp = cfg.AsteriskAgentCfg().AsMapInterface()
 	case DA_JSN:
@@ -1817,6 +1928,10 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 		mp = cfg.RadiusAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case DNSAgentJson:
 		mp = cfg.DNSAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case CHFAgentJson:
+		mp = cfg.CHFAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case SMPPAgentJson:
+		mp = cfg.SMPPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ATTRIBUTE_JSN:
 		mp = cfg.AttributeSCfg().AsMapInterface()
 	case ChargerSCfgJson:
@@ -1865,6 +1980,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 		mp = cfg.AnalyzerSCfg().AsMapInterface()
 	case CoreSCfgJson:
 		mp = cfg.CoreSCfg().AsMapInterface()
+	case InvoiceSCfgJson:
+		mp = cfg.InvoiceSCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -1919,6 +2036,7 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 
 		dfltEvRdr:        cfg.dfltEvRdr.Clone(),
 		dfltEvExp:        cfg.dfltEvExp.Clone(),
+		dfltNodeID:       cfg.dfltNodeID,
 		loaderCfg:        cfg.loaderCfg.Clone(),
 		httpAgentCfg:     cfg.httpAgentCfg.Clone(),
 		rpcConns:         cfg.rpcConns.Clone(),
@@ -1937,10 +2055,13 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		sessionSCfg:      cfg.sessionSCfg.Clone(),
 		fsAgentCfg:       cfg.fsAgentCfg.Clone(),
 		kamAgentCfg:      cfg.kamAgentCfg.Clone(),
+		osipsAgentCfg:    cfg.osipsAgentCfg.Clone(),
 		asteriskAgentCfg: cfg.asteriskAgentCfg.Clone(),
 		diameterAgentCfg: cfg.diameterAgentCfg.Clone(),
 		radiusAgentCfg:   cfg.radiusAgentCfg.Clone(),
 		dnsAgentCfg:      cfg.dnsAgentCfg.Clone(),
+		chfAgentCfg:      cfg.chfAgentCfg.Clone(),
+		smppAgentCfg:     cfg.smppAgentCfg.Clone(),
 		attributeSCfg:    cfg.attributeSCfg.Clone(),
 		chargerSCfg:      cfg.chargerSCfg.Clone(),
 		resourceSCfg:     cfg.resourceSCfg.Clone(),
@@ -1961,6 +2082,7 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		configSCfg:       cfg.configSCfg.Clone(),
 		apiBanCfg:        cfg.apiBanCfg.Clone(),
 		coreSCfg:         cfg.coreSCfg.Clone(),
+		invoiceSCfg:      cfg.invoiceSCfg.Clone(),
 
 		cacheDP: make(map[string]utils.MapStorage),
 	}
@@ -107,6 +107,8 @@ const CGRATES_CFG_JSON = `
 		"*account_action_plans": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*action_triggers": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*shared_groups": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*exchange_rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*lookup_tables": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*timings": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*resource_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*resources": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
@@ -121,6 +123,9 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*dispatcher_hosts": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*load_ids": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*tier_counters": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*rerate_jobs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*sessions_backup": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*versions": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*resource_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*stat_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -165,6 +170,8 @@ const CGRATES_CFG_JSON = `
 	},
 	"items":{
 		"*session_costs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*invoices": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*balance_ledger": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*cdrs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 		
 		"*tp_timings": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 					
 		"*tp_destinations": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -173,6 +180,8 @@ const CGRATES_CFG_JSON = `
 		"*tp_rating_plans": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*tp_rating_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*tp_shared_groups": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*tp_exchange_rates": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
+		"*tp_lookup_tables": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*tp_actions": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*tp_action_plans": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*tp_action_triggers": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
@@ -262,6 +271,8 @@ const CGRATES_CFG_JSON = `
 		"*account_action_plans": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// account action plans index caching
 		"*action_triggers": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// action triggers caching
 		"*shared_groups": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// shared groups caching
+		"*exchange_rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// exchange rate profiles caching
+		"*lookup_tables": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// lookup table entries caching
 		"*timings": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// timings caching
 		"*resource_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control resource profiles caching
 		"*resources": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control resources caching
@@ -288,6 +299,7 @@ const CGRATES_CFG_JSON = `
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control dispatcher load( in case of *ratio ConnParams is present)
 		"*dispatchers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 								// control dispatcher interface
 		"*diameter_messages": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// diameter messages caching
+		"*radius_packets": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},							// radius packets caching
 		"*rpc_responses": {"limit": 0, "ttl": "2s", "static_ttl": false, "replicate": false},							// RPC responses caching
 		"*closed_sessions": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},						// closed sessions cached for CDRs
 		"*event_charges": {"limit": 0, "ttl": "10s", "static_ttl": false, "replicate": false},							// events proccessed by ChargerS
@@ -308,6 +320,7 @@ const CGRATES_CFG_JSON = `
 	"stats_conns": [],						// connections to StatS for <*stats> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"resources_conns": [],					// connections to ResourceS for <*resources> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"apiers_conns": [],						// connections to RALs for <*accounts> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"geoip_db_paths": [],					// MaxMind format databases used by <*geoip> filters (ie. country and ASN ones)
 },
 
 
@@ -329,7 +342,14 @@ const CGRATES_CFG_JSON = `
 		"*any": "*zero1ns",
 		"*voice": "*zero1s"
 	},
-
+	"tiered_rating_plans": {				// RatingPlans selecting their rates based on cumulative usage instead of call duration
+		// "RP_WHOLESALE": {
+		//	"counter": "*account",			// usage counter the tier is selected on: <*account|*subject>
+		//	"cycle": "*monthly",			// counter reset cycle: <*daily|*weekly|*monthly|*yearly|*unlimited>
+		// },
+	},
+	"default_currency": "",					// currency of the RatingPlans and *monetary balances without one, empty disables the exchange between currencies
+	"balance_ledger": false,				// store each change of the balances into StorDB: <true|false>
 },
 
 
@@ -349,6 +369,14 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"invoices": {								// InvoiceS config
+	"enabled": false,						// start the Invoice service: <true|false>
+	"ees_conns": [],						// connections to EventExporter for invoice exports: <""|*internal|$rpc_conns_id>
+	"ees_ids": [],							// exporter IDs receiving the invoices, empty for all matching the filters
+	"run_ids": ["*default"],				// RunIDs of the CDRs invoiced, the other runs forked by ChargerS are not billed
+},
+
+
 "ers": {														// EventReaderService
 	"enabled": false,											// starts the EventReader service: <true|false>
 	"sessions_conns":["*internal"],								// RPC Connections IDs
@@ -607,6 +635,7 @@ const CGRATES_CFG_JSON = `
 	"session_indexes": [],					// index sessions based on these fields for GetActiveSessions API
 	"client_protocol": 1.0,					// version of protocol to use when acting as JSON-PRC client <"0","1.0">
 	"channel_sync_interval": "0",			// sync channels to detect stale sessions (0 to disable)
+	"backup_interval": "0",					// backup active sessions regularly to dataDB, restoring them on start (0 to disable, -1 to backup only on shutdown)
 	"terminate_attempts": 5,				// attempts to get the session before terminating it
 	"alterable_fields": [],					// the session fields that can be updated
 	//"min_dur_low_balance": "5s",			// threshold which will trigger low balance warnings for prepaid calls (needs to be lower than debit_interval)
@@ -625,8 +654,8 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,						// starts the Asterisk agent: <true|false>
 	"sessions_conns": ["*birpc_internal"],
 	"create_cdr": false,					// create CDR out of events and sends it to CDRS component
-	"asterisk_conns":[						// instantiate connections to multiple Asterisk servers
-		{"address": "127.0.0.1:8088", "user": "cgrates", "password": "CGRateS.org", "connect_attempts": 3,"reconnects": 5}
+	"asterisk_conns":[						// instantiate connections to multiple Asterisk servers, type: <*ari|*ami>
+		{"address": "127.0.0.1:8088", "user": "cgrates", "password": "CGRateS.org", "connect_attempts": 3,"reconnects": 5, "type": "*ari"}
 	],
 },
 
@@ -658,6 +687,18 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"opensips_agent": {
+	"enabled": false,						// starts OpenSIPS agent: <true|false>
+	"listen_udp": "127.0.0.1:2020",			// address where to listen for the event_datagram events (E_ACC_CDR, E_DLG_STATE_CHANGED)
+	"sessions_conns": ["*birpc_internal"],
+	"create_cdr": false,					// create CDR out of the dialog events and sends them to CDRS component
+	"timezone": "",							// timezone of the OpenSIPS server
+	"mi_conns":[							// instantiate connections to the MI JSON interface of multiple OpenSIPS servers
+		{"mi_addr": "http://127.0.0.1:8888/mi", "reconnects": 5}
+	],
+},
+
+
 "diameter_agent": {
 	"enabled": false,											// enables the diameter agent: <true|false>
 	"listen": "127.0.0.1:3868",									// address where to listen for diameter requests <x.y.z.y/x1.y1.z1.y1:1234>
@@ -673,6 +714,9 @@ const CGRATES_CFG_JSON = `
 	"asr_template": "",											// enable AbortSession message being sent to client on DisconnectSession
 	"rar_template": "",											// template used to build the Re-Auth-Request
 	"forced_disconnect": "*none",								// the request to send to diameter on DisconnectSession <*none|*asr|*rar>
+	"peers": [],												// outbound peers for relaying requests: [{"id","address","transport","watchdog_interval","reconnect_interval"}]
+	"routes": [],												// realm and application based routing towards peers, first matching wins: [{"realm","application_ids","peer_ids"}]
+	"relay_timeout": "2s",										// time to wait for the answer of a peer before failing over to the next one
 	"request_processors": [				// list of processors to be applied to diameter messages
 	],
 },
@@ -689,7 +733,19 @@ const CGRATES_CFG_JSON = `
 	"client_dictionaries": {									// per client path towards directory holding additional dictionaries to load (extra to RFC)
 		"*default": "/usr/share/cgrates/radius/dict/",			// key represents the client IP or catch-all <*default|$client_ip>
 	},
-	"sessions_conns": ["*internal"],
+	"client_da_addresses": {									// clients accepting Dynamic Authorization requests (RFC 5176) <$client_ip>
+		// "127.0.0.1": {
+		// 	"transport": "udp",									// transport used to send the requests <udp>
+		// 	"host": "",											// host of the client, empty to use the address it sent the requests from
+		// 	"port": 3799,										// port where the client listens for the requests
+		// 	"reply_timeout": "1s",								// time to wait for the ACK/NAK before retransmitting
+		// 	"retransmits": 2,									// number of retransmits when no reply is received
+		// },
+	},
+	"sessions_conns": ["*internal"],							// <*internal|*birpc_internal|$rpc_conns_id>, *birpc_internal needed for DisconnectSession/ReAuthorize
+	"requests_cache_key": "",									// key used to cache the requests needed for Dynamic Authorization, empty to disable caching
+	"dmr_template": "",											// template used to build the Disconnect-Request on DisconnectSession
+	"coa_template": "",											// template used to build the CoA-Request on ReAuthorize
 	"request_processors": [										// request processors to be applied to Radius messages
 	],
 },
@@ -702,7 +758,7 @@ const CGRATES_CFG_JSON = `
 "dns_agent": {
 	"enabled": false,											// enables the DNS agent: <true|false>
 	"listen": "127.0.0.1:2053",									// address where to listen for DNS requests <x.y.z.y:1234>
-	"listen_net": "udp",										// network to listen on <udp|tcp|tcp-tls>
+	"listen_net": "udp",										// network to listen on, https for DNS-over-HTTPS, tcp-tls and https using the certificate from tls section <udp|tcp|tcp-tls|https>
 	"sessions_conns": ["*internal"],
 	"timezone": "",												// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
 	"request_processors": [										// request processors to be applied to DNS messages
@@ -710,6 +766,32 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"chf_agent": {
+	"enabled": false,											// enables the 5G CHF agent: <true|false>
+	"listen": "127.0.0.1:2085",									// address where to listen for Nchf_ConvergedCharging requests <x.y.z.y:1234>
+	"listen_net": "tcp",										// network to listen on, HTTP/2 over cleartext or TLS <tcp|tcp-tls>
+	"api_root": "/nchf-convergedcharging/v3",					// API root of the chargingdata resources
+	"sessions_conns": ["*internal"],
+	"timezone": "",												// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"request_processors": [										// request processors to be applied to ChargingDataRequests
+	],
+},
+
+
+"smpp_agent": {
+	"enabled": false,											// enables the SMPP agent: <true|false>
+	"listen": "127.0.0.1:2775",									// address where to accept binds from ESMEs, empty to disable <""|x.y.z.y:1234>
+	"system_id": "CGRateS",										// system_id sent back in the bind responses
+	"client_passwords": {},										// passwords per system_id of the ESMEs, *default for any, empty to accept all
+	"smsc_conns": [],											// connections towards SMSCs, the agent binding as ESME: [{"id","address","system_id","password","system_type","reconnect_interval"}]
+	"reply_timeout": "5s",										// time to wait for the responses from SMSCs
+	"sessions_conns": ["*internal"],
+	"timezone": "",												// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"request_processors": [										// request processors to be applied to submit_sm and deliver_sm PDUs
+	],
+},
+
+
 "attributes": {								// AttributeS config
 	"enabled": false,						// starts attribute service: <true|false>
 	"stats_conns": [],						// connections to StatS, empty to disable: <""|*internal|$rpc_conns_id>
@@ -801,6 +883,7 @@ const CGRATES_CFG_JSON = `
 	"resources_conns": [],					// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"thresholds_conns": [],					// connections to ThresholdS for the *qos exclusion updates, empty to disable thresholds functionality: <""|*internal|$rpc_conns_id>
 	"default_ratio":1,						// default ratio used in case of *load strategy
 	"opts": {
 		"*context": "*routes",
@@ -889,6 +972,10 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.10"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.11"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.12"},
+					{"tag": "WindowType", "path": "WindowType", "type": "*variable", "value": "~*req.13"},
+					{"tag": "WindowSize", "path": "WindowSize", "type": "*variable", "value": "~*req.14"},
+					{"tag": "WindowSlide", "path": "WindowSlide", "type": "*variable", "value": "~*req.15"},
+					{"tag": "WindowCount", "path": "WindowCount", "type": "*variable", "value": "~*req.16"},
 				],
 			},
 			{
@@ -1031,7 +1118,8 @@ const CGRATES_CFG_JSON = `
 	"caches_conns":["*localhost"],
 	"scheduler_conns": ["*localhost"],
 	"gapi_credentials": ".gapi/credentials.json", 	// the path to the credentials for google API or the credentials.json file content
-	"gapi_token": ".gapi/token.json" 				// the path to the token for google API or the token.json file content
+	"gapi_token": ".gapi/token.json", 				// the path to the token for google API or the token.json file content
+	"rounding_method": "*up",						// rounding method of the DestinationRates imported out of rate decks <*up|*middle|*down>
 },
 
 
@@ -1073,6 +1161,7 @@ const CGRATES_CFG_JSON = `
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"attributes_conns": [],					// connections to AttributeS for API authorization, empty to disable auth functionality: <""|*internal|$rpc_conns_id>
 	"any_subsystem": true,					// if we match the *any subsystem
+	"health_check_interval": "0s",			// interval to ping the DispatcherHosts, taking the failed ones out of rotation: <""|$dur>
 },
 
 
@@ -1107,13 +1196,14 @@ const CGRATES_CFG_JSON = `
 	"scheduler_conns": [],					// connections to SchedulerS for reloads
 	"attributes_conns": [],					// connections to AttributeS for CDRExporter
 	"ees_conns": [],						// connections to EEs
+	"invoices_conns": [],					// connections to InvoiceS for re-issuing invoices
 },
 
 
-"sip_agent": {							// SIP Agents, only used for redirections
+"sip_agent": {							// SIP Agent, routing INVITEs and processing REGISTER, MESSAGE and OPTIONS
 	"enabled": false,					// enables the SIP agent: <true|false>
 	"listen": "127.0.0.1:5060",			// address where to listen for SIP requests <x.y.z.y:1234>
-	"listen_net": "udp",				// network to listen on <udp|tcp|tcp-tls>
+	"listen_net": "udp",				// network to listen on, tcp-tls using the certificate from tls section <udp|tcp|tcp-tls>
 	"sessions_conns": ["*internal"],
 	"timezone": "",						// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
 	"retransmission_timer": "1s",		// the duration to wait to receive an ACK before resending the reply
@@ -1147,6 +1237,54 @@ const CGRATES_CFG_JSON = `
 			{"tag": "CCRequestNumber", "path": "*rep.CC-Request-Number", "type": "*variable",
 				"value": "~*req.CC-Request-Number", "mandatory": true},
 	],
+	"*msccReq": [ // fans in one Multiple-Services-Credit-Control, used with *mscc flag in request_processors
+			{"tag": "RatingGroup", "path": "*cgreq.RatingGroup", "type": "*variable",
+				"value": "~*req.Rating-Group", "mandatory": true},
+			{"tag": "UsageTime", "path": "*cgreq.Usage", "type": "*variable",
+				"value": "~*req.Requested-Service-Unit.CC-Time:s/(.*)/${1}s/"},
+			{"tag": "UsageOctets", "path": "*cgreq.Usage", "type": "*variable",
+				"value": "~*req.Requested-Service-Unit.CC-Total-Octets"},
+			{"tag": "LastUsedTime", "path": "*cgreq.LastUsed", "type": "*variable",
+				"value": "~*req.Used-Service-Unit.CC-Time:s/(.*)/${1}s/"},
+			{"tag": "LastUsedOctets", "path": "*cgreq.LastUsed", "type": "*variable",
+				"value": "~*req.Used-Service-Unit.CC-Total-Octets"},
+	],
+	"*msccRep": [ // fans out one Multiple-Services-Credit-Control, used with *mscc flag in request_processors
+			{"tag": "RatingGroup", "path": "*rep.Multiple-Services-Credit-Control.Rating-Group", "type": "*group",
+				"value": "~*cgrep.RatingGroup", "new_branch": true, "mandatory": true},
+			{"tag": "GrantedTime", "path": "*rep.Multiple-Services-Credit-Control.Granted-Service-Unit.CC-Time", "type": "*group",
+				"filters": ["*exists:~*req.Requested-Service-Unit.CC-Time:"], "value": "~*cgrep.MaxUsage{*duration_seconds&*round:0}"},
+			{"tag": "GrantedOctets", "path": "*rep.Multiple-Services-Credit-Control.Granted-Service-Unit.CC-Total-Octets", "type": "*group",
+				"filters": ["*exists:~*req.Requested-Service-Unit.CC-Total-Octets:"], "value": "~*cgrep.MaxUsage{*duration_nanoseconds}"},
+			{"tag": "FinalUnitAction", "path": "*rep.Multiple-Services-Credit-Control.Final-Unit-Indication.Final-Unit-Action", "type": "*group",
+				"filters": ["*string:~*cgrep.FinalUnitIndication:true"], "value": "0"},
+			{"tag": "ResultCode", "path": "*rep.Multiple-Services-Credit-Control.Result-Code", "type": "*group",
+				"value": "2001"},
+	],
+	"*chfMsccReq": [ // fans in one multipleUnitUsage of the CHFAgent, used with *mscc flag in request_processors
+			{"tag": "RatingGroup", "path": "*cgreq.RatingGroup", "type": "*variable",
+				"value": "~*req.ratingGroup", "mandatory": true},
+			{"tag": "UsageTime", "path": "*cgreq.Usage", "type": "*variable",
+				"value": "~*req.requestedUnit.time:s/(.*)/${1}s/"},
+			{"tag": "UsageVolume", "path": "*cgreq.Usage", "type": "*variable",
+				"value": "~*req.requestedUnit.totalVolume"},
+			{"tag": "LastUsedTime", "path": "*cgreq.LastUsed", "type": "*variable",
+				"value": "~*req.usedUnitContainer[0].time:s/(.*)/${1}s/"},
+			{"tag": "LastUsedVolume", "path": "*cgreq.LastUsed", "type": "*variable",
+				"value": "~*req.usedUnitContainer[0].totalVolume"},
+	],
+	"*chfMsccRep": [ // fans out one multipleUnitInformation of the CHFAgent, used with *mscc flag in request_processors
+			{"tag": "RatingGroup", "path": "*rep.multipleUnitInformation[<~*vars.MSCCIndex>].ratingGroup", "type": "*variable",
+				"value": "~*cgrep.RatingGroup", "layout": "*integer", "mandatory": true},
+			{"tag": "GrantedTime", "path": "*rep.multipleUnitInformation[<~*vars.MSCCIndex>].grantedUnit.time", "type": "*variable",
+				"filters": ["*exists:~*req.requestedUnit.time:"], "value": "~*cgrep.MaxUsage{*duration_seconds&*round:0}", "layout": "*integer"},
+			{"tag": "GrantedVolume", "path": "*rep.multipleUnitInformation[<~*vars.MSCCIndex>].grantedUnit.totalVolume", "type": "*variable",
+				"filters": ["*exists:~*req.requestedUnit.totalVolume:"], "value": "~*cgrep.MaxUsage{*duration_nanoseconds}", "layout": "*integer"},
+			{"tag": "FinalUnitAction", "path": "*rep.multipleUnitInformation[<~*vars.MSCCIndex>].finalUnitIndication.finalUnitAction", "type": "*constant",
+				"filters": ["*string:~*cgrep.FinalUnitIndication:true"], "value": "TERMINATE"},
+			{"tag": "ResultCode", "path": "*rep.multipleUnitInformation[<~*vars.MSCCIndex>].resultCode", "type": "*constant",
+				"value": "SUCCESS"},
+	],
 	"*asr": [
 			{"tag": "SessionId", "path": "*diamreq.Session-Id", "type": "*variable",
 				"value": "~*req.Session-Id", "mandatory": true},
@@ -36,6 +36,7 @@ const (
 	SessionSJson       = "sessions"
 	FreeSWITCHAgentJSN = "freeswitch_agent"
 	KamailioAgentJSN   = "kamailio_agent"
+	OsipsAgentJSN      = "opensips_agent"
 	AsteriskAgentJSN   = "asterisk_agent"
 	DA_JSN             = "diameter_agent"
 	RA_JSN             = "radius_agent"
@@ -57,6 +58,8 @@ const (
 	AnalyzerCfgJson    = "analyzers"
 	ApierS             = "apiers"
 	DNSAgentJson       = "dns_agent"
+	CHFAgentJson       = "chf_agent"
+	SMPPAgentJson      = "smpp_agent"
 	ERsJson            = "ers"
 	EEsJson            = "ees"
 	RPCConnsJsonName   = "rpc_conns"
@@ -65,14 +68,16 @@ const (
 	ConfigSJson        = "configs"
 	APIBanCfgJson      = "apiban"
 	CoreSCfgJson       = "cores"
+	InvoiceSCfgJson    = "invoices"
 )
 
 var (
 	sortedCfgSections = []string{GENERAL_JSN, RPCConnsJsonName, DATADB_JSN, STORDB_JSN, LISTEN_JSN, TlsCfgJson, HTTP_JSN, SCHEDULER_JSN,
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN,
-		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
+		KamailioAgentJSN, OsipsAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, CHFAgentJson, SMPPAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
-		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson}
+		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson,
+		InvoiceSCfgJson}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -249,6 +254,18 @@ func (jsnCfg CgrJsonCfg) FreeswitchAgentJsonCfg() (*FreeswitchAgentJsonCfg, erro
 	return cfg, nil
 }
 
+func (jsnCfg CgrJsonCfg) OsipsAgentJsonCfg() (*OsipsAgentJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[OsipsAgentJSN]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(OsipsAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) KamAgentJsonCfg() (*KamAgentJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[KamailioAgentJSN]
 	if !hasKey {
@@ -319,6 +336,26 @@ func (jsnCfg CgrJsonCfg) DNSAgentJsonCfg() (da *DNSAgentJsonCfg, err error) {
 	return
 }
 
+func (jsnCfg CgrJsonCfg) CHFAgentJsonCfg() (ca *CHFAgentJsonCfg, err error) {
+	rawCfg, hasKey := jsnCfg[CHFAgentJson]
+	if !hasKey {
+		return
+	}
+	ca = new(CHFAgentJsonCfg)
+	err = json.Unmarshal(*rawCfg, ca)
+	return
+}
+
+func (jsnCfg CgrJsonCfg) SMPPAgentJsonCfg() (sa *SMPPAgentJsonCfg, err error) {
+	rawCfg, hasKey := jsnCfg[SMPPAgentJson]
+	if !hasKey {
+		return
+	}
+	sa = new(SMPPAgentJsonCfg)
+	err = json.Unmarshal(*rawCfg, sa)
+	return
+}
+
 func (cgrJsn CgrJsonCfg) AttributeServJsonCfg() (*AttributeSJsonCfg, error) {
 	rawCfg, hasKey := cgrJsn[ATTRIBUTE_JSN]
 	if !hasKey {
@@ -499,6 +536,18 @@ func (jsnCfg CgrJsonCfg) AnalyzerCfgJson() (*AnalyzerSJsonCfg, error) {
 	return cfg, nil
 }
 
+func (jsnCfg CgrJsonCfg) InvoiceSCfgJson() (*InvoiceSJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[InvoiceSCfgJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(InvoiceSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) ApierCfgJson() (*ApierJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[ApierS]
 	if !hasKey {
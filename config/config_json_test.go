@@ -112,6 +112,12 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheSharedGroups: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheExchangeRateProfiles: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheLookupTables: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheTimings: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -190,6 +196,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDiameterMessages: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheRadiusPackets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheRPCResponses: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer("2s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -355,6 +364,20 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheExchangeRateProfiles: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.CacheLookupTables: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.MetaTimings: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -453,6 +476,27 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.MetaTierCounters: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.MetaRerateJobs: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.MetaSessionsBackup: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheVersions: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -599,6 +643,20 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheTBLTPExchangeRates: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.CacheTBLTPLookupTables: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheTBLTPActions: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -655,6 +713,20 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheInvoicesTBL: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
+			utils.CacheBalanceLedgerTBL: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheTBLTPActionPlans: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -743,6 +815,9 @@ func TestDfRalsJsonCfg(t *testing.T) {
 			utils.MetaAny:   "*zero1ns",
 			utils.MetaVoice: "*zero1s",
 		},
+		Tiered_rating_plans: &map[string]*TierCounterJsonCfg{},
+		Default_currency:    utils.StringPointer(utils.EmptyString),
+		Balance_ledger:      utils.BoolPointer(false),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -821,6 +896,7 @@ func TestSmgJsonCfg(t *testing.T) {
 		Session_indexes:       &[]string{},
 		Client_protocol:       utils.Float64Pointer(1.0),
 		Channel_sync_interval: utils.StringPointer("0"),
+		Backup_interval:       utils.StringPointer("0"),
 		Terminate_attempts:    utils.IntPointer(5),
 		Alterable_fields:      &[]string{},
 		Default_usage: &map[string]string{
@@ -904,6 +980,32 @@ func TestKamAgentJsonCfg(t *testing.T) {
 	}
 }
 
+func TestOsipsAgentJsonCfg(t *testing.T) {
+	eCfg := &OsipsAgentJsonCfg{
+		Enabled:        utils.BoolPointer(false),
+		Listen_udp:     utils.StringPointer("127.0.0.1:2020"),
+		Sessions_conns: &[]string{rpcclient.BiRPCInternal},
+		Create_cdr:     utils.BoolPointer(false),
+		Mi_conns: &[]*OsipsConnJsonCfg{
+			{
+				Mi_addr:    utils.StringPointer("http://127.0.0.1:8888/mi"),
+				Reconnects: utils.IntPointer(5),
+			},
+		},
+		Timezone: utils.StringPointer(utils.EmptyString),
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.OsipsAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("Expecting: %s \n, received: %s: ",
+			utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
 func TestAsteriskAgentJsonCfg(t *testing.T) {
 	eCfg := &AsteriskAgentJsonCfg{
 		Enabled:        utils.BoolPointer(false),
@@ -916,6 +1018,7 @@ func TestAsteriskAgentJsonCfg(t *testing.T) {
 				Password:         utils.StringPointer("CGRateS.org"),
 				Connect_attempts: utils.IntPointer(3),
 				Reconnects:       utils.IntPointer(5),
+				Type:             utils.StringPointer(utils.MetaARI),
 			},
 		},
 	}
@@ -946,6 +1049,9 @@ func TestDiameterAgentJsonCfg(t *testing.T) {
 		Asr_template:         utils.StringPointer(""),
 		Rar_template:         utils.StringPointer(""),
 		Forced_disconnect:    utils.StringPointer(utils.MetaNone),
+		Peers:                &[]*DiameterPeerJsonCfg{},
+		Routes:               &[]*DiameterRouteJsonCfg{},
+		Relay_timeout:        utils.StringPointer("2s"),
 		Request_processors:   &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
@@ -971,8 +1077,12 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		Client_dictionaries: utils.MapStringStringPointer(map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		}),
-		Sessions_conns:     &[]string{utils.MetaInternal},
-		Request_processors: &[]*ReqProcessorJsnCfg{},
+		Client_da_addresses: &map[string]*DAClientOptsJson{},
+		Sessions_conns:      &[]string{utils.MetaInternal},
+		Requests_cache_key:  utils.StringPointer(""),
+		Dmr_template:        utils.StringPointer(""),
+		Coa_template:        utils.StringPointer(""),
+		Request_processors:  &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1019,6 +1129,50 @@ func TestDNSAgentJsonCfg(t *testing.T) {
 	}
 }
 
+func TestCHFAgentJsonCfg(t *testing.T) {
+	eCfg := &CHFAgentJsonCfg{
+		Enabled:            utils.BoolPointer(false),
+		Listen:             utils.StringPointer("127.0.0.1:2085"),
+		Listen_net:         utils.StringPointer("tcp"),
+		Api_root:           utils.StringPointer("/nchf-convergedcharging/v3"),
+		Sessions_conns:     &[]string{utils.MetaInternal},
+		Timezone:           utils.StringPointer(""),
+		Request_processors: &[]*ReqProcessorJsnCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.CHFAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
+func TestSMPPAgentJsonCfg(t *testing.T) {
+	eCfg := &SMPPAgentJsonCfg{
+		Enabled:            utils.BoolPointer(false),
+		Listen:             utils.StringPointer("127.0.0.1:2775"),
+		System_id:          utils.StringPointer("CGRateS"),
+		Client_passwords:   &map[string]string{},
+		Smsc_conns:         &[]*SMSCConnJsonCfg{},
+		Reply_timeout:      utils.StringPointer("5s"),
+		Sessions_conns:     &[]string{utils.MetaInternal},
+		Timezone:           utils.StringPointer(""),
+		Request_processors: &[]*ReqProcessorJsnCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.SMPPAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
 func TestDfAttributeServJsonCfg(t *testing.T) {
 	eCfg := &AttributeSJsonCfg{
 		Enabled:               utils.BoolPointer(false),
@@ -1075,6 +1229,7 @@ func TestDfFilterSJsonCfg(t *testing.T) {
 		Stats_conns:     &[]string{},
 		Resources_conns: &[]string{},
 		Apiers_conns:    &[]string{},
+		Geoip_db_paths:  &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1176,6 +1331,7 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Resources_conns:       &[]string{},
 		Stats_conns:           &[]string{},
 		Rals_conns:            &[]string{},
+		Thresholds_conns:      &[]string{},
 		Default_ratio:         utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
 		Opts: &RoutesOptsJson{
@@ -1404,6 +1560,22 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.12")},
+						{Tag: utils.StringPointer("WindowType"),
+							Path:  utils.StringPointer("WindowType"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.13")},
+						{Tag: utils.StringPointer("WindowSize"),
+							Path:  utils.StringPointer("WindowSize"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.14")},
+						{Tag: utils.StringPointer("WindowSlide"),
+							Path:  utils.StringPointer("WindowSlide"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("WindowCount"),
+							Path:  utils.StringPointer("WindowCount"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
 					},
 				},
 				{
@@ -1810,6 +1982,7 @@ func TestDfDispatcherSJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
 		Any_subsystem:         utils.BoolPointer(true),
+		Health_check_interval: utils.StringPointer("0s"),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1834,6 +2007,7 @@ func TestDfLoaderCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{utils.MetaLocalHost},
 		Gapi_credentials: &cred,
 		Gapi_token:       &tok,
+		Rounding_method:  utils.StringPointer(utils.MetaRoundingUp),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1933,6 +2107,7 @@ func TestDfApierCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{},
 		Attributes_conns: &[]string{},
 		Ees_conns:        &[]string{},
+		Invoices_conns:   &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -2126,6 +2301,128 @@ func TestDfTemplateSJsonCfg(t *testing.T) {
 				Value:     utils.StringPointer("~*req.CC-Request-Number"),
 				Mandatory: utils.BoolPointer(true)},
 		},
+		utils.MetaMSCCReq: {
+			{
+				Tag:       utils.StringPointer("RatingGroup"),
+				Path:      utils.StringPointer(fmt.Sprintf("%s.RatingGroup", utils.MetaCgreq)),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*req.Rating-Group"),
+				Mandatory: utils.BoolPointer(true)},
+			{
+				Tag:   utils.StringPointer("UsageTime"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.Usage", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Requested-Service-Unit.CC-Time:s/(.*)/${1}s/")},
+			{
+				Tag:   utils.StringPointer("UsageOctets"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.Usage", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Requested-Service-Unit.CC-Total-Octets")},
+			{
+				Tag:   utils.StringPointer("LastUsedTime"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.LastUsed", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Used-Service-Unit.CC-Time:s/(.*)/${1}s/")},
+			{
+				Tag:   utils.StringPointer("LastUsedOctets"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.LastUsed", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.Used-Service-Unit.CC-Total-Octets")},
+		},
+		utils.MetaMSCCRep: {
+			{
+				Tag:        utils.StringPointer("RatingGroup"),
+				Path:       utils.StringPointer(fmt.Sprintf("%s.Multiple-Services-Credit-Control.Rating-Group", utils.MetaRep)),
+				Type:       utils.StringPointer(utils.MetaGroup),
+				Value:      utils.StringPointer("~*cgrep.RatingGroup"),
+				New_branch: utils.BoolPointer(true),
+				Mandatory:  utils.BoolPointer(true)},
+			{
+				Tag:     utils.StringPointer("GrantedTime"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.Multiple-Services-Credit-Control.Granted-Service-Unit.CC-Time", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaGroup),
+				Filters: &[]string{"*exists:~*req.Requested-Service-Unit.CC-Time:"},
+				Value:   utils.StringPointer("~*cgrep.MaxUsage{*duration_seconds&*round:0}")},
+			{
+				Tag:     utils.StringPointer("GrantedOctets"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.Multiple-Services-Credit-Control.Granted-Service-Unit.CC-Total-Octets", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaGroup),
+				Filters: &[]string{"*exists:~*req.Requested-Service-Unit.CC-Total-Octets:"},
+				Value:   utils.StringPointer("~*cgrep.MaxUsage{*duration_nanoseconds}")},
+			{
+				Tag:     utils.StringPointer("FinalUnitAction"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.Multiple-Services-Credit-Control.Final-Unit-Indication.Final-Unit-Action", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaGroup),
+				Filters: &[]string{"*string:~*cgrep.FinalUnitIndication:true"},
+				Value:   utils.StringPointer("0")},
+			{
+				Tag:   utils.StringPointer("ResultCode"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.Multiple-Services-Credit-Control.Result-Code", utils.MetaRep)),
+				Type:  utils.StringPointer(utils.MetaGroup),
+				Value: utils.StringPointer("2001")},
+		},
+		utils.MetaCHFMSCCReq: {
+			{
+				Tag:       utils.StringPointer("RatingGroup"),
+				Path:      utils.StringPointer(fmt.Sprintf("%s.RatingGroup", utils.MetaCgreq)),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*req.ratingGroup"),
+				Mandatory: utils.BoolPointer(true)},
+			{
+				Tag:   utils.StringPointer("UsageTime"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.Usage", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.requestedUnit.time:s/(.*)/${1}s/")},
+			{
+				Tag:   utils.StringPointer("UsageVolume"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.Usage", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.requestedUnit.totalVolume")},
+			{
+				Tag:   utils.StringPointer("LastUsedTime"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.LastUsed", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.usedUnitContainer[0].time:s/(.*)/${1}s/")},
+			{
+				Tag:   utils.StringPointer("LastUsedVolume"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.LastUsed", utils.MetaCgreq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.usedUnitContainer[0].totalVolume")},
+		},
+		utils.MetaCHFMSCCRep: {
+			{
+				Tag:       utils.StringPointer("RatingGroup"),
+				Path:      utils.StringPointer(fmt.Sprintf("%s.multipleUnitInformation[<~*vars.MSCCIndex>].ratingGroup", utils.MetaRep)),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*cgrep.RatingGroup"),
+				Layout:    utils.StringPointer(utils.MetaInteger),
+				Mandatory: utils.BoolPointer(true)},
+			{
+				Tag:     utils.StringPointer("GrantedTime"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.multipleUnitInformation[<~*vars.MSCCIndex>].grantedUnit.time", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaVariable),
+				Filters: &[]string{"*exists:~*req.requestedUnit.time:"},
+				Value:   utils.StringPointer("~*cgrep.MaxUsage{*duration_seconds&*round:0}"),
+				Layout:  utils.StringPointer(utils.MetaInteger)},
+			{
+				Tag:     utils.StringPointer("GrantedVolume"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.multipleUnitInformation[<~*vars.MSCCIndex>].grantedUnit.totalVolume", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaVariable),
+				Filters: &[]string{"*exists:~*req.requestedUnit.totalVolume:"},
+				Value:   utils.StringPointer("~*cgrep.MaxUsage{*duration_nanoseconds}"),
+				Layout:  utils.StringPointer(utils.MetaInteger)},
+			{
+				Tag:     utils.StringPointer("FinalUnitAction"),
+				Path:    utils.StringPointer(fmt.Sprintf("%s.multipleUnitInformation[<~*vars.MSCCIndex>].finalUnitIndication.finalUnitAction", utils.MetaRep)),
+				Type:    utils.StringPointer(utils.MetaConstant),
+				Filters: &[]string{"*string:~*cgrep.FinalUnitIndication:true"},
+				Value:   utils.StringPointer("TERMINATE")},
+			{
+				Tag:   utils.StringPointer("ResultCode"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.multipleUnitInformation[<~*vars.MSCCIndex>].resultCode", utils.MetaRep)),
+				Type:  utils.StringPointer(utils.MetaConstant),
+				Value: utils.StringPointer("SUCCESS")},
+		},
 		utils.MetaASR: {
 			{
 				Tag:       utils.StringPointer("SessionId"),
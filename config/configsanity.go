@@ -52,6 +52,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RALService, connID)
 			}
 		}
+		for rpID, tc := range cfg.ralsCfg.TieredRatingPlans {
+			if tc.Counter != utils.MetaAccount && tc.Counter != utils.MetaSubject {
+				return fmt.Errorf("<%s> unsupported counter <%s> for tiered rating plan <%s>", utils.RALService, tc.Counter, rpID)
+			}
+			if !utils.IsSliceMember([]string{utils.MetaDaily, utils.MetaWeekly,
+				utils.MetaMonthly, utils.MetaYearly, utils.MetaUnlimited}, tc.Cycle) {
+				return fmt.Errorf("<%s> unsupported cycle <%s> for tiered rating plan <%s>", utils.RALService, tc.Cycle, rpID)
+			}
+		}
 	}
 	// CDRServer checks
 	if cfg.cdrsCfg.Enabled {
@@ -116,6 +125,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// InvoiceS checks
+	if cfg.invoiceSCfg.Enabled {
+		for _, connID := range cfg.invoiceSCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.InvoiceS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.InvoiceS, connID)
+			}
+		}
+	}
 	// Loaders sanity checks
 	for _, ldrSCfg := range cfg.loaderCfg {
 		if !ldrSCfg.Enabled {
@@ -161,6 +181,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// cgr-loader checks
+	if !utils.IsSliceMember([]string{utils.MetaRoundingUp, utils.MetaRoundingMiddle,
+		utils.MetaRoundingDown}, cfg.loaderCgrCfg.RoundingMethod) {
+		return fmt.Errorf("<%s> unsupported %s <%s>", CgrLoaderCfgJson, utils.RoundingMethodCfg, cfg.loaderCgrCfg.RoundingMethod)
+	}
 	// SessionS checks
 	if cfg.sessionSCfg.Enabled {
 		if cfg.sessionSCfg.TerminateAttempts < 1 {
@@ -278,6 +303,31 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// OpenSIPSAgent checks
+	if cfg.osipsAgentCfg.Enabled {
+		if len(cfg.osipsAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.OpenSIPSAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.osipsAgentCfg.SessionSConns {
+			isInternal := strings.HasPrefix(connID, utils.MetaInternal) || strings.HasPrefix(connID, rpcclient.BiRPCInternal)
+			if isInternal && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.OpenSIPSAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !isInternal {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.OpenSIPSAgent, connID)
+			}
+		}
+		if len(cfg.osipsAgentCfg.MiConns) == 0 {
+			return fmt.Errorf("<%s> no %s defined", utils.OpenSIPSAgent, utils.MiConnsCfg)
+		}
+		for _, conn := range cfg.osipsAgentCfg.MiConns {
+			if conn.MiAddr == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for %s", utils.OpenSIPSAgent,
+					utils.NewErrMandatoryIeMissing(utils.MiAddrCfg), utils.MiConnsCfg)
+			}
+		}
+	}
 	// AsteriskAgent checks
 	if cfg.asteriskAgentCfg.Enabled {
 		if len(cfg.asteriskAgentCfg.SessionSConns) == 0 {
@@ -293,6 +343,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.AsteriskAgent, connID)
 			}
 		}
+		for _, astConn := range cfg.asteriskAgentCfg.AsteriskConns {
+			if astConn.Type != utils.MetaARI && astConn.Type != utils.MetaAMI {
+				return fmt.Errorf("<%s> unsupported connection type <%s> for <%s>", utils.AsteriskAgent, astConn.Type, astConn.Address)
+			}
+		}
 	}
 	// DAgent checks
 	if cfg.diameterAgentCfg.Enabled {
@@ -309,6 +364,25 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DiameterAgent, connID)
 			}
 		}
+		peerIDs := make(utils.StringSet)
+		for _, peer := range cfg.diameterAgentCfg.Peers {
+			if peer.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for peer with id: <%s>",
+					utils.DiameterAgent, utils.NewErrMandatoryIeMissing(utils.AddressCfg), peer.ID)
+			}
+			peerIDs.Add(peer.ID)
+		}
+		for _, route := range cfg.diameterAgentCfg.Routes {
+			if len(route.PeerIDs) == 0 {
+				return fmt.Errorf("<%s> no peers defined for route with realm: <%s>",
+					utils.DiameterAgent, route.Realm)
+			}
+			for _, peerID := range route.PeerIDs {
+				if !peerIDs.Has(peerID) {
+					return fmt.Errorf("<%s> peer with id: <%s> not defined", utils.DiameterAgent, peerID)
+				}
+			}
+		}
 		for prf, tmp := range cfg.templates {
 			for _, field := range tmp {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -376,13 +450,39 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				utils.RadiusAgent, utils.SessionS)
 		}
 		for _, connID := range cfg.radiusAgentCfg.SessionSConns {
-			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+			isInternal := strings.HasPrefix(connID, utils.MetaInternal) || strings.HasPrefix(connID, rpcclient.BiRPCInternal)
+			if isInternal && !cfg.sessionSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.RadiusAgent)
 			}
-			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+			if _, has := cfg.rpcConns[connID]; !has && !isInternal {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RadiusAgent, connID)
 			}
 		}
+		for _, tplID := range []string{cfg.radiusAgentCfg.DMRTemplate, cfg.radiusAgentCfg.CoATemplate} {
+			if _, has := cfg.templates[tplID]; tplID != utils.EmptyString && !has {
+				return fmt.Errorf("<%s> template with ID <%s> has not been defined", utils.RadiusAgent, tplID)
+			}
+		}
+		if len(cfg.radiusAgentCfg.RequestsCacheKey) != 0 { // the cached requests are retrieved by the OriginID of the sessions
+			cacheKey := cfg.radiusAgentCfg.RequestsCacheKey.GetRule(utils.InfieldSep)
+			for _, req := range cfg.radiusAgentCfg.RequestProcessors {
+				if !req.Flags.Has(utils.MetaInitiate) &&
+					!req.Flags.Has(utils.MetaUpdate) &&
+					!req.Flags.Has(utils.MetaTerminate) {
+					continue
+				}
+				var originIDRules []string
+				for _, field := range req.RequestFields {
+					if field.Path == utils.MetaCgreq+utils.NestingSep+utils.OriginID {
+						originIDRules = append(originIDRules, field.Value.GetRule(utils.InfieldSep))
+					}
+				}
+				if originID := strings.Join(originIDRules, utils.InfieldSep); originID != cacheKey {
+					return fmt.Errorf("<%s> %s <%s> does not match the OriginID <%s> of the request processor <%s>",
+						utils.RadiusAgent, utils.RequestsCacheKeyCfg, cacheKey, originID, req.ID)
+				}
+			}
+		}
 		for _, req := range cfg.radiusAgentCfg.RequestProcessors {
 			for _, field := range req.RequestFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -473,6 +573,116 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	//CHF Agent
+	if cfg.chfAgentCfg.Enabled {
+		if len(cfg.chfAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.CHFAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.chfAgentCfg.SessionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.CHFAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CHFAgent, connID)
+			}
+		}
+		for _, req := range cfg.chfAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.CHFAgent, err, val.path, utils.Values, utils.RequestFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, err, field.Filters, utils.RequestFieldsCfg)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.CHFAgent, err, val.path, utils.Values, utils.ReplyFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, err, field.Filters, utils.ReplyFieldsCfg)
+				}
+			}
+			if err := utils.CheckInLineFilter(req.Filters); err != nil {
+				return fmt.Errorf("<%s> %s for %s at %s", utils.CHFAgent, err, req.Filters, utils.RequestProcessorsCfg)
+			}
+		}
+	}
+	//SMPP Agent
+	if cfg.smppAgentCfg.Enabled {
+		if len(cfg.smppAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.SMPPAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.smppAgentCfg.SessionSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.SMPPAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SMPPAgent, connID)
+			}
+		}
+		for _, conn := range cfg.smppAgentCfg.SMSCConns {
+			if conn.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for smsc connection with id: <%s>",
+					utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.AddressCfg), conn.ID)
+			}
+		}
+		for _, req := range cfg.smppAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.SMPPAgent, err, val.path, utils.Values, utils.RequestFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Filters, utils.RequestFieldsCfg)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.SMPPAgent, err, val.path, utils.Values, utils.ReplyFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, field.Filters, utils.ReplyFieldsCfg)
+				}
+			}
+			if err := utils.CheckInLineFilter(req.Filters); err != nil {
+				return fmt.Errorf("<%s> %s for %s at %s", utils.SMPPAgent, err, req.Filters, utils.RequestProcessorsCfg)
+			}
+		}
+	}
 	// HTTPAgent checks
 	for _, httpAgentCfg := range cfg.httpAgentCfg {
 		// httpAgent checks
@@ -654,6 +864,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		for _, connID := range cfg.routeSCfg.ThresholdSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.thresholdSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ThresholdS, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
 	}
 	// Scheduler check connection with CDR Server
 	if cfg.schedulerCfg.Enabled {
@@ -775,7 +993,8 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 					field.Type == utils.MetaDivide ||
 					field.Type == utils.MetaValueExponent ||
 					field.Type == utils.MetaUnixTimestamp ||
-					field.Type == utils.MetaSIPCID {
+					field.Type == utils.MetaSIPCID ||
+					field.Type == utils.MetaLookup {
 					for _, val := range field.Value {
 						if err := utils.IsPathValidForExporters(val.path); err != nil {
 							return fmt.Errorf("<%s> %s for %s at %s of %s", utils.ERs, err, val.path, utils.Values, utils.CacheDumpFieldsCfg)
@@ -804,7 +1023,8 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 					field.Type == utils.MetaDivide ||
 					field.Type == utils.MetaValueExponent ||
 					field.Type == utils.MetaUnixTimestamp ||
-					field.Type == utils.MetaSIPCID {
+					field.Type == utils.MetaSIPCID ||
+					field.Type == utils.MetaLookup {
 					for _, val := range field.Value {
 						if err := utils.IsPathValidForExporters(val.path); err != nil {
 							return fmt.Errorf("<%s> %s for %s at %s of %s", utils.ERs, err, val.path, utils.Values, utils.FieldsCfg)
@@ -874,7 +1094,8 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 					field.Type == utils.MetaDivide ||
 					field.Type == utils.MetaValueExponent ||
 					field.Type == utils.MetaUnixTimestamp ||
-					field.Type == utils.MetaSIPCID {
+					field.Type == utils.MetaSIPCID ||
+					field.Type == utils.MetaLookup {
 					for _, val := range field.Value {
 						if err := utils.IsPathValidForExporters(val.path); err != nil {
 							return fmt.Errorf("<%s> %s for %s at %s of %s", utils.EEs, err, val.path, utils.Values, utils.FieldsCfg)
@@ -962,6 +1183,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.APIerSv1, connID)
 		}
 	}
+	for _, connID := range cfg.apier.InvoiceSConns {
+		if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.invoiceSCfg.Enabled {
+			return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.InvoiceS, utils.APIerSv1)
+		}
+		if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.APIerSv1, connID)
+		}
+	}
 	// Dispatcher sanity check
 	if cfg.dispatcherSCfg.Enabled {
 		for _, connID := range cfg.dispatcherSCfg.AttributeSConns {
@@ -1019,6 +1248,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.FilterS, connID)
 		}
 	}
+	for _, dbPath := range cfg.filterSCfg.GeoIPDBPaths {
+		if _, err := os.Stat(dbPath); err != nil && os.IsNotExist(err) {
+			return fmt.Errorf("<%s> nonexistent GeoIP database: %s", utils.FilterS, dbPath)
+		}
+	}
 
 	if len(cfg.registrarCCfg.Dispatchers.RegistrarSConns) != 0 {
 		if len(cfg.registrarCCfg.Dispatchers.Hosts) == 0 {
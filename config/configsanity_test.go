@@ -50,6 +50,19 @@ func TestConfigSanityRater(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.ralsCfg.ThresholdSConns = []string{}
+	cfg.ralsCfg.TieredRatingPlans = map[string]*TierCounterCfg{
+		"RP_TIER": {Counter: "*destination", Cycle: utils.MetaMonthly},
+	}
+	expected = "<RALs> unsupported counter <*destination> for tiered rating plan <RP_TIER>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ralsCfg.TieredRatingPlans["RP_TIER"] = &TierCounterCfg{Counter: utils.MetaAccount, Cycle: "*hourly"}
+	expected = "<RALs> unsupported cycle <*hourly> for tiered rating plan <RP_TIER>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityCDRServer(t *testing.T) {
@@ -248,6 +261,19 @@ func TestConfigSanityLoaders(t *testing.T) {
 
 }
 
+func TestConfigSanityCgrLoader(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.loaderCgrCfg.RoundingMethod = "*nearest"
+	expected := "<loader> unsupported rounding_method <*nearest>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+q, received %+q", expected, err)
+	}
+	cfg.loaderCgrCfg.RoundingMethod = utils.MetaRoundingMiddle
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestConfigSanitySessionS(t *testing.T) {
 	cfg = NewDefaultCGRConfig()
 	cfg.sessionSCfg = &SessionSCfg{
@@ -431,6 +457,38 @@ func TestConfigSanityKamailioAgent(t *testing.T) {
 	}
 }
 
+func TestConfigSanityOsipsAgent(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.osipsAgentCfg = &OsipsAgentCfg{
+		Enabled: true,
+	}
+	expected := "<OpenSIPSAgent> no SessionS connections defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
+	cfg.osipsAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	expected = "<SessionS> not enabled but requested by <OpenSIPSAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.osipsAgentCfg.SessionSConns = []string{"test"}
+	expected = "<OpenSIPSAgent> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.rpcConns["test"] = nil
+	expected = "<OpenSIPSAgent> no mi_conns defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.osipsAgentCfg.MiConns = []*OsipsConnCfg{{}}
+	expected = "<OpenSIPSAgent> MANDATORY_IE_MISSING: [mi_addr] for mi_conns"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityAsteriskAgent(t *testing.T) {
 	cfg = NewDefaultCGRConfig()
 	cfg.asteriskAgentCfg = &AsteriskAgentCfg{
@@ -451,6 +509,12 @@ func TestConfigSanityAsteriskAgent(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.rpcConns["test"] = nil
+	cfg.asteriskAgentCfg.AsteriskConns = []*AsteriskConnCfg{{Address: "127.0.0.1:5038", Type: "*ws"}}
+	expected = "<AsteriskAgent> unsupported connection type <*ws> for <127.0.0.1:5038>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityDAgent(t *testing.T) {
@@ -497,6 +561,23 @@ func TestConfigSanityDAgent(t *testing.T) {
 	}
 
 	cfg.rpcConns["test"] = nil
+	cfg.diameterAgentCfg.Peers = []*DiameterPeerCfg{{ID: "dra1"}}
+	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [address] for peer with id: <dra1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers[0].Address = "127.0.0.1:3869"
+	cfg.diameterAgentCfg.Routes = []*DiameterRouteCfg{{Realm: "roaming.org"}}
+	expected = "<DiameterAgent> no peers defined for route with realm: <roaming.org>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].PeerIDs = []string{"dra2"}
+	expected = "<DiameterAgent> peer with id: <dra2> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Routes[0].PeerIDs = []string{"dra1"}
 	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [Path] for template *ees at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
@@ -624,10 +705,27 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	}
 
 	cfg.rpcConns["test"] = nil
+	cfg.radiusAgentCfg.DMRTemplate = "*dmr"
+	expected = "<RadiusAgent> template with ID <*dmr> has not been defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.DMRTemplate = utils.EmptyString
+
+	cfg.radiusAgentCfg.RequestsCacheKey = NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)
+	cfg.radiusAgentCfg.RequestProcessors[0].Flags = utils.FlagsWithParamsFromSlice([]string{utils.MetaInitiate})
+	expected = "<RadiusAgent> requests_cache_key <~*req.Acct-Session-Id> does not match the OriginID <> of the request processor <cgrates>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.RequestProcessors[0].RequestFields = append(cfg.radiusAgentCfg.RequestProcessors[0].RequestFields,
+		&FCTemplate{Tag: "OriginID", Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID, Type: utils.MetaVariable,
+			Value: NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)})
 	expected = "<RadiusAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.radiusAgentCfg.RequestProcessors[0].Flags = nil
 	cfg.radiusAgentCfg.RequestProcessors[0].RequestFields[0].Type = utils.MetaNone
 
 	cfg.radiusAgentCfg.RequestProcessors[0].RequestFields[0].Path = "~req."
@@ -689,6 +787,74 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	cfg.radiusAgentCfg.RequestProcessors[0].Filters = []string{"*string:~*req.Account:1001"}
 }
 
+func TestConfigSanityCHFAgent(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.chfAgentCfg = &CHFAgentCfg{
+		Enabled: true,
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID: "cgrates",
+				RequestFields: []*FCTemplate{
+					{Tag: "OriginID", Path: utils.EmptyString, Type: "*variable",
+						Value: NewRSRParsersMustCompile("~*vars.ChargingDataRef", utils.InfieldSep), Mandatory: true},
+				},
+			},
+		},
+	}
+	expected := "<CHFAgent> no SessionS connections defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.chfAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	expected = "<SessionS> not enabled but requested by <CHFAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sessionSCfg.Enabled = true
+	cfg.sessionSCfg.ChargerSConns = []string{}
+	expected = "<CHFAgent> MANDATORY_IE_MISSING: [Path] for cgrates at OriginID"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
+func TestConfigSanitySMPPAgent(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.smppAgentCfg = &SMPPAgentCfg{
+		Enabled:   true,
+		SMSCConns: []*SMSCConnCfg{{ID: "smsc1"}},
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID: "cgrates",
+				RequestFields: []*FCTemplate{
+					{Tag: "OriginID", Path: utils.EmptyString, Type: "*variable",
+						Value: NewRSRParsersMustCompile("~*req.sequence_number", utils.InfieldSep), Mandatory: true},
+				},
+			},
+		},
+	}
+	expected := "<SMPPAgent> no SessionS connections defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.smppAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	expected = "<SessionS> not enabled but requested by <SMPPAgent> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sessionSCfg.Enabled = true
+	cfg.sessionSCfg.ChargerSConns = []string{}
+	expected = "<SMPPAgent> MANDATORY_IE_MISSING: [address] for smsc connection with id: <smsc1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.smppAgentCfg.SMSCConns[0].Address = "127.0.0.1:2775"
+	expected = "<SMPPAgent> MANDATORY_IE_MISSING: [Path] for cgrates at OriginID"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
 func TestConfigSanityDNSAgent(t *testing.T) {
 	cfg = NewDefaultCGRConfig()
 	cfg.dnsAgentCfg = &DNSAgentCfg{
@@ -1841,6 +2007,13 @@ func TestConfigSanityAPIer(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.apier.SchedulerConns = []string{utils.MetaInternal}
+	cfg.schedulerCfg.Enabled = true
+	cfg.apier.InvoiceSConns = []string{utils.MetaInternal}
+	expected = "<InvoiceS> not enabled but requested by <APIerSv1> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityDispatcher(t *testing.T) {
@@ -1909,6 +2082,12 @@ func TestConfigSanityFilterS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.filterSCfg.ApierSConns = []string{}
+	cfg.filterSCfg.GeoIPDBPaths = []string{"/not/existing/GeoLite2-Country.mmdb"}
+	expected = "<FilterS> nonexistent GeoIP database: /not/existing/GeoLite2-Country.mmdb"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestCheckConfigSanity(t *testing.T) {
@@ -2032,3 +2211,18 @@ func TestConfigSanityErs(t *testing.T) {
 		t.Errorf("expected: <%v>,\n received: <%v>", expected, err)
 	}
 }
+
+func TestConfigSanityInvoiceS(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.invoiceSCfg.Enabled = true
+	cfg.invoiceSCfg.EEsConns = []string{utils.MetaInternal}
+	expected := "<EEs> not enabled but requested by <InvoiceS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.invoiceSCfg.EEsConns = []string{"test"}
+	expected = "<InvoiceS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 )
@@ -39,6 +41,9 @@ type DiameterAgentCfg struct {
 	ASRTemplate       string
 	RARTemplate       string
 	ForcedDisconnect  string
+	Peers             []*DiameterPeerCfg  // outbound peers used when relaying requests
+	Routes            []*DiameterRouteCfg // routing table towards peers, first matching route wins
+	RelayTimeout      time.Duration       // wait for the answer of one peer before failing over to the next
 	RequestProcessors []*RequestProcessor
 }
 
@@ -96,6 +101,38 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separa
 	if jsnCfg.Forced_disconnect != nil {
 		da.ForcedDisconnect = *jsnCfg.Forced_disconnect
 	}
+	if jsnCfg.Peers != nil {
+		for _, peerJsn := range *jsnCfg.Peers {
+			peer := NewDefaultDiameterPeerCfg()
+			var haveID bool
+			for _, peerSet := range da.Peers {
+				if peerJsn.ID != nil && peerSet.ID == *peerJsn.ID {
+					peer = peerSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = peer.loadFromJSONCfg(peerJsn); err != nil {
+				return
+			}
+			if !haveID {
+				da.Peers = append(da.Peers, peer)
+			}
+		}
+	}
+	if jsnCfg.Routes != nil {
+		da.Routes = nil // routes are order sensitive so they are replaced instead of merged
+		for _, routeJsn := range *jsnCfg.Routes {
+			route := new(DiameterRouteCfg)
+			route.loadFromJSONCfg(routeJsn)
+			da.Routes = append(da.Routes, route)
+		}
+	}
+	if jsnCfg.Relay_timeout != nil {
+		if da.RelayTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Relay_timeout); err != nil {
+			return
+		}
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -134,8 +171,21 @@ func (da *DiameterAgentCfg) AsMapInterface(separator string) (initialMP map[stri
 		utils.ASRTemplateCfg:        da.ASRTemplate,
 		utils.RARTemplateCfg:        da.RARTemplate,
 		utils.ForcedDisconnectCfg:   da.ForcedDisconnect,
+		utils.RelayTimeoutCfg:       da.RelayTimeout.String(),
 	}
 
+	peers := make([]map[string]interface{}, len(da.Peers))
+	for i, item := range da.Peers {
+		peers[i] = item.AsMapInterface()
+	}
+	initialMP[utils.PeersCfg] = peers
+
+	routes := make([]map[string]interface{}, len(da.Routes))
+	for i, item := range da.Routes {
+		routes[i] = item.AsMapInterface()
+	}
+	initialMP[utils.RoutesCfg] = routes
+
 	requestProcessors := make([]map[string]interface{}, len(da.RequestProcessors))
 	for i, item := range da.RequestProcessors {
 		requestProcessors[i] = item.AsMapInterface(separator)
@@ -173,6 +223,19 @@ func (da DiameterAgentCfg) Clone() (cln *DiameterAgentCfg) {
 		ASRTemplate:      da.ASRTemplate,
 		RARTemplate:      da.RARTemplate,
 		ForcedDisconnect: da.ForcedDisconnect,
+		RelayTimeout:     da.RelayTimeout,
+	}
+	if da.Peers != nil {
+		cln.Peers = make([]*DiameterPeerCfg, len(da.Peers))
+		for i, peer := range da.Peers {
+			cln.Peers[i] = peer.Clone()
+		}
+	}
+	if da.Routes != nil {
+		cln.Routes = make([]*DiameterRouteCfg, len(da.Routes))
+		for i, route := range da.Routes {
+			cln.Routes[i] = route.Clone()
+		}
 	}
 	if da.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(da.SessionSConns))
@@ -188,3 +251,103 @@ func (da DiameterAgentCfg) Clone() (cln *DiameterAgentCfg) {
 	}
 	return
 }
+
+// NewDefaultDiameterPeerCfg returns the diameter peer with the default values
+func NewDefaultDiameterPeerCfg() *DiameterPeerCfg {
+	return &DiameterPeerCfg{
+		Transport:         utils.TCP,
+		WatchdogInterval:  5 * time.Second,
+		ReconnectInterval: 5 * time.Second,
+	}
+}
+
+// DiameterPeerCfg is one outbound peer of the DiameterAgent, connected to with CER/CEA and monitored with DWR/DWA
+type DiameterPeerCfg struct {
+	ID                string
+	Address           string
+	Transport         string // tcp or sctp
+	WatchdogInterval  time.Duration
+	ReconnectInterval time.Duration
+}
+
+func (dp *DiameterPeerCfg) loadFromJSONCfg(jsnCfg *DiameterPeerJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.ID != nil {
+		dp.ID = *jsnCfg.ID
+	}
+	if jsnCfg.Address != nil {
+		dp.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Transport != nil {
+		dp.Transport = *jsnCfg.Transport
+	}
+	if jsnCfg.Watchdog_interval != nil {
+		if dp.WatchdogInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Watchdog_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reconnect_interval != nil {
+		if dp.ReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconnect_interval); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dp *DiameterPeerCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.IDCfg:                dp.ID,
+		utils.AddressCfg:           dp.Address,
+		utils.TransportCfg:         dp.Transport,
+		utils.WatchdogIntervalCfg:  dp.WatchdogInterval.String(),
+		utils.ReconnectIntervalCfg: dp.ReconnectInterval.String(),
+	}
+}
+
+// Clone returns a deep copy of DiameterPeerCfg
+func (dp DiameterPeerCfg) Clone() *DiameterPeerCfg {
+	return &dp
+}
+
+// DiameterRouteCfg routes the requests towards peers based on Destination-Realm and Application-Id
+type DiameterRouteCfg struct {
+	Realm          string // *any to match all realms
+	ApplicationIDs []int  // empty to match all applications
+	PeerIDs        []string
+}
+
+func (dr *DiameterRouteCfg) loadFromJSONCfg(jsnCfg *DiameterRouteJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Realm != nil {
+		dr.Realm = *jsnCfg.Realm
+	}
+	if jsnCfg.Application_ids != nil {
+		dr.ApplicationIDs = utils.CloneIntSlice(*jsnCfg.Application_ids)
+	}
+	if jsnCfg.Peer_ids != nil {
+		dr.PeerIDs = utils.CloneStringSlice(*jsnCfg.Peer_ids)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dr *DiameterRouteCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.RealmCfg:          dr.Realm,
+		utils.ApplicationIDsCfg: utils.CloneIntSlice(dr.ApplicationIDs),
+		utils.PeerIDsCfg:        utils.CloneStringSlice(dr.PeerIDs),
+	}
+}
+
+// Clone returns a deep copy of DiameterRouteCfg
+func (dr DiameterRouteCfg) Clone() *DiameterRouteCfg {
+	return &DiameterRouteCfg{
+		Realm:          dr.Realm,
+		ApplicationIDs: utils.CloneIntSlice(dr.ApplicationIDs),
+		PeerIDs:        utils.CloneStringSlice(dr.PeerIDs),
+	}
+}
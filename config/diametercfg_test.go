@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -41,6 +42,21 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		Asr_template:         utils.StringPointer("randomTemplate"),
 		Rar_template:         utils.StringPointer("randomTemplate"),
 		Forced_disconnect:    utils.StringPointer("forced"),
+		Peers: &[]*DiameterPeerJsonCfg{
+			{
+				ID:                 utils.StringPointer("dra1"),
+				Address:            utils.StringPointer("192.168.56.203:3868"),
+				Reconnect_interval: utils.StringPointer("1s"),
+			},
+		},
+		Routes: &[]*DiameterRouteJsonCfg{
+			{
+				Realm:           utils.StringPointer(utils.MetaAny),
+				Application_ids: &[]int{4},
+				Peer_ids:        &[]string{"dra1"},
+			},
+		},
+		Relay_timeout: utils.StringPointer("1s"),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:       utils.StringPointer(utils.CGRateSLwr),
@@ -63,6 +79,23 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		Peers: []*DiameterPeerCfg{
+			{
+				ID:                "dra1",
+				Address:           "192.168.56.203:3868",
+				Transport:         utils.TCP,
+				WatchdogInterval:  5 * time.Second,
+				ReconnectInterval: time.Second,
+			},
+		},
+		Routes: []*DiameterRouteCfg{
+			{
+				Realm:          utils.MetaAny,
+				ApplicationIDs: []int{4},
+				PeerIDs:        []string{"dra1"},
+			},
+		},
+		RelayTimeout: time.Second,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -129,6 +162,13 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 		"vendor_id": 0,												
 		"product_name": "CGRateS",									
 		"synced_conn_requests": true,
+		"peers": [
+			{"id": "dra1", "address": "192.168.56.203:3868", "watchdog_interval": "10s"},
+		],
+		"routes": [
+			{"realm": "roaming.org", "application_ids": [4], "peer_ids": ["dra1"]},
+		],
+		"relay_timeout": "1s",
 		"request_processors": [
                         {
                          "id": "cgrates", 
@@ -160,6 +200,23 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 		utils.SessionSConnsCfg:      []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
 		utils.SyncedConnReqsCfg:     true,
 		utils.VendorIDCfg:           0,
+		utils.PeersCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:                "dra1",
+				utils.AddressCfg:           "192.168.56.203:3868",
+				utils.TransportCfg:         utils.TCP,
+				utils.WatchdogIntervalCfg:  "10s",
+				utils.ReconnectIntervalCfg: "5s",
+			},
+		},
+		utils.RoutesCfg: []map[string]interface{}{
+			{
+				utils.RealmCfg:          "roaming.org",
+				utils.ApplicationIDsCfg: []int{4},
+				utils.PeerIDsCfg:        []string{"dra1"},
+			},
+		},
+		utils.RelayTimeoutCfg: "1s",
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:       utils.CGRateSLwr,
@@ -221,6 +278,9 @@ func TestDiameterAgentCfgAsMapInterface1(t *testing.T) {
 		utils.SessionSConnsCfg:      []string{rpcclient.BiRPCInternal},
 		utils.SyncedConnReqsCfg:     false,
 		utils.VendorIDCfg:           0,
+		utils.PeersCfg:              []map[string]interface{}{},
+		utils.RoutesCfg:             []map[string]interface{}{},
+		utils.RelayTimeoutCfg:       "2s",
 		utils.RequestProcessorsCfg:  []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -246,6 +306,23 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		Peers: []*DiameterPeerCfg{
+			{
+				ID:                "dra1",
+				Address:           "192.168.56.203:3868",
+				Transport:         utils.TCP,
+				WatchdogInterval:  5 * time.Second,
+				ReconnectInterval: time.Second,
+			},
+		},
+		Routes: []*DiameterRouteCfg{
+			{
+				Realm:          utils.MetaAny,
+				ApplicationIDs: []int{4},
+				PeerIDs:        []string{"dra1"},
+			},
+		},
+		RelayTimeout: time.Second,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -263,4 +340,10 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "cgrates" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.Peers[0].Address = ""; ban.Peers[0].Address != "192.168.56.203:3868" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.Routes[0].PeerIDs[0] = ""; ban.Routes[0].PeerIDs[0] != "dra1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
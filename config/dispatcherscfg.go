@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -32,6 +34,7 @@ type DispatcherSCfg struct {
 	AttributeSConns     []string
 	NestedFields        bool
 	AnySubsystem        bool
+	HealthCheckInterval time.Duration // 0 disables the health checks of the DispatcherHosts
 }
 
 func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -81,16 +84,22 @@ func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 	if jsnCfg.Any_subsystem != nil {
 		dps.AnySubsystem = *jsnCfg.Any_subsystem
 	}
+	if jsnCfg.Health_check_interval != nil {
+		if dps.HealthCheckInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Health_check_interval); err != nil {
+			return
+		}
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (dps *DispatcherSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        dps.Enabled,
-		utils.IndexedSelectsCfg: dps.IndexedSelects,
-		utils.NestedFieldsCfg:   dps.NestedFields,
-		utils.AnySubsystemCfg:   dps.AnySubsystem,
+		utils.EnabledCfg:             dps.Enabled,
+		utils.IndexedSelectsCfg:      dps.IndexedSelects,
+		utils.NestedFieldsCfg:        dps.NestedFields,
+		utils.AnySubsystemCfg:        dps.AnySubsystem,
+		utils.HealthCheckIntervalCfg: dps.HealthCheckInterval.String(),
 	}
 	if dps.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*dps.StringIndexedFields))
@@ -129,10 +138,11 @@ func (dps *DispatcherSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of DispatcherSCfg
 func (dps DispatcherSCfg) Clone() (cln *DispatcherSCfg) {
 	cln = &DispatcherSCfg{
-		Enabled:        dps.Enabled,
-		IndexedSelects: dps.IndexedSelects,
-		NestedFields:   dps.NestedFields,
-		AnySubsystem:   dps.AnySubsystem,
+		Enabled:             dps.Enabled,
+		IndexedSelects:      dps.IndexedSelects,
+		NestedFields:        dps.NestedFields,
+		AnySubsystem:        dps.AnySubsystem,
+		HealthCheckInterval: dps.HealthCheckInterval,
 	}
 
 	if dps.AttributeSConns != nil {
@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -35,6 +36,7 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Nested_fields:         utils.BoolPointer(true),
 		Any_subsystem:         utils.BoolPointer(true),
+		Health_check_interval: utils.StringPointer("10s"),
 	}
 	expected := &DispatcherSCfg{
 		Enabled:             true,
@@ -45,6 +47,7 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
 		AnySubsystem:        true,
+		HealthCheckInterval: 10 * time.Second,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.dispatcherSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -74,6 +77,7 @@ func TestDispatcherSCfgAsMapInterface(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -104,6 +108,7 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{"*internal", "*conn1"},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -124,6 +129,7 @@ func TestDispatcherSCfgAsMapInterface2(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -141,6 +147,7 @@ func TestDispatcherSCfgClone(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
 		AnySubsystem:        true,
+		HealthCheckInterval: time.Second,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -27,6 +27,7 @@ type FilterSCfg struct {
 	StatSConns     []string
 	ResourceSConns []string
 	ApierSConns    []string
+	GeoIPDBPaths   []string
 }
 
 func (fSCfg *FilterSCfg) loadFromJSONCfg(jsnCfg *FilterSJsonCfg) (err error) {
@@ -63,6 +64,12 @@ func (fSCfg *FilterSCfg) loadFromJSONCfg(jsnCfg *FilterSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Geoip_db_paths != nil {
+		fSCfg.GeoIPDBPaths = make([]string, len(*jsnCfg.Geoip_db_paths))
+		for i, path := range *jsnCfg.Geoip_db_paths {
+			fSCfg.GeoIPDBPaths[i] = path
+		}
+	}
 	return
 }
 
@@ -99,6 +106,13 @@ func (fSCfg *FilterSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.ApierSConnsCfg] = apierConns
 	}
+	if fSCfg.GeoIPDBPaths != nil {
+		geoIPDBPaths := make([]string, len(fSCfg.GeoIPDBPaths))
+		for i, path := range fSCfg.GeoIPDBPaths {
+			geoIPDBPaths[i] = path
+		}
+		initialMP[utils.GeoIPDBPathsCfg] = geoIPDBPaths
+	}
 	return
 }
 
@@ -123,5 +137,11 @@ func (fSCfg FilterSCfg) Clone() (cln *FilterSCfg) {
 			cln.ApierSConns[i] = con
 		}
 	}
+	if fSCfg.GeoIPDBPaths != nil {
+		cln.GeoIPDBPaths = make([]string, len(fSCfg.GeoIPDBPaths))
+		for i, path := range fSCfg.GeoIPDBPaths {
+			cln.GeoIPDBPaths[i] = path
+		}
+	}
 	return
 }
@@ -29,11 +29,13 @@ func TestFilterSCfgloadFromJsonCfg(t *testing.T) {
 		Stats_conns:     &[]string{utils.MetaInternal, "*conn1"},
 		Resources_conns: &[]string{utils.MetaInternal, "*conn1"},
 		Apiers_conns:    &[]string{utils.MetaInternal, "*conn1"},
+		Geoip_db_paths:  &[]string{"/usr/share/GeoIP/GeoLite2-Country.mmdb"},
 	}
 	expected := &FilterSCfg{
 		StatSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
 		ResourceSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "*conn1"},
 		ApierSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaApier), "*conn1"},
+		GeoIPDBPaths:   []string{"/usr/share/GeoIP/GeoLite2-Country.mmdb"},
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.filterSCfg.loadFromJSONCfg(cfgJSONS); err != nil {
@@ -49,12 +51,14 @@ func TestFilterSCfgAsMapInterface(t *testing.T) {
 			"stats_conns": ["*internal:*stats", "*conn1"],						
 			"resources_conns": ["*internal:*resources", "*conn1"],
             "apiers_conns": ["*internal:*apier", "*conn1"],
+			"geoip_db_paths": ["/usr/share/GeoIP/GeoLite2-ASN.mmdb"],
 	},
 }`
 	eMap := map[string]interface{}{
 		utils.StatSConnsCfg:     []string{utils.MetaInternal, "*conn1"},
 		utils.ResourceSConnsCfg: []string{utils.MetaInternal, "*conn1"},
 		utils.ApierSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
+		utils.GeoIPDBPathsCfg:   []string{"/usr/share/GeoIP/GeoLite2-ASN.mmdb"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -71,6 +75,7 @@ func TestFilterSCfgAsMapInterface2(t *testing.T) {
 		utils.StatSConnsCfg:     []string{},
 		utils.ResourceSConnsCfg: []string{},
 		utils.ApierSConnsCfg:    []string{},
+		utils.GeoIPDBPathsCfg:   []string{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -84,6 +89,7 @@ func TestFilterSCfgClone(t *testing.T) {
 		StatSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
 		ResourceSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "*conn1"},
 		ApierSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaApier), "*conn1"},
+		GeoIPDBPaths:   []string{"/usr/share/GeoIP/GeoLite2-Country.mmdb"},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -98,4 +104,7 @@ func TestFilterSCfgClone(t *testing.T) {
 	if rcv.ApierSConns[1] = ""; ban.ApierSConns[1] != "*conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.GeoIPDBPaths[0] = ""; ban.GeoIPDBPaths[0] != "/usr/share/GeoIP/GeoLite2-Country.mmdb" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -0,0 +1,107 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// InvoiceSCfg is the configuration of invoice service
+type InvoiceSCfg struct {
+	Enabled  bool
+	EEsConns []string // connections towards EEs used to export the invoices
+	EEsIDs   []string // exporters receiving the invoices, all if empty
+	RunIDs   []string // RunIDs of the CDRs invoiced
+}
+
+func (invCfg *InvoiceSCfg) loadFromJSONCfg(jsnCfg *InvoiceSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		invCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Ees_conns != nil {
+		invCfg.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for idx, connID := range *jsnCfg.Ees_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			invCfg.EEsConns[idx] = connID
+			if connID == utils.MetaInternal {
+				invCfg.EEsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			}
+		}
+	}
+	if jsnCfg.Ees_ids != nil {
+		invCfg.EEsIDs = make([]string, len(*jsnCfg.Ees_ids))
+		copy(invCfg.EEsIDs, *jsnCfg.Ees_ids)
+	}
+	if jsnCfg.Run_ids != nil {
+		invCfg.RunIDs = make([]string, len(*jsnCfg.Run_ids))
+		copy(invCfg.RunIDs, *jsnCfg.Run_ids)
+	}
+	return nil
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (invCfg *InvoiceSCfg) AsMapInterface() (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg: invCfg.Enabled,
+	}
+	if invCfg.EEsConns != nil {
+		eesConns := make([]string, len(invCfg.EEsConns))
+		for i, item := range invCfg.EEsConns {
+			eesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+				eesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.EEsConnsCfg] = eesConns
+	}
+	if invCfg.EEsIDs != nil {
+		eesIDs := make([]string, len(invCfg.EEsIDs))
+		copy(eesIDs, invCfg.EEsIDs)
+		initialMP[utils.EEsIDsCfg] = eesIDs
+	}
+	if invCfg.RunIDs != nil {
+		runIDs := make([]string, len(invCfg.RunIDs))
+		copy(runIDs, invCfg.RunIDs)
+		initialMP[utils.RunIDsCfg] = runIDs
+	}
+	return
+}
+
+// Clone returns a deep copy of InvoiceSCfg
+func (invCfg InvoiceSCfg) Clone() (cln *InvoiceSCfg) {
+	cln = &InvoiceSCfg{
+		Enabled: invCfg.Enabled,
+	}
+	if invCfg.EEsConns != nil {
+		cln.EEsConns = make([]string, len(invCfg.EEsConns))
+		copy(cln.EEsConns, invCfg.EEsConns)
+	}
+	if invCfg.EEsIDs != nil {
+		cln.EEsIDs = make([]string, len(invCfg.EEsIDs))
+		copy(cln.EEsIDs, invCfg.EEsIDs)
+	}
+	if invCfg.RunIDs != nil {
+		cln.RunIDs = make([]string, len(invCfg.RunIDs))
+		copy(cln.RunIDs, invCfg.RunIDs)
+	}
+	return
+}
@@ -0,0 +1,100 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInvoiceSCfgloadFromJsonCfg(t *testing.T) {
+	var invCfg, expected InvoiceSCfg
+	if err := invCfg.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(invCfg, expected) {
+		t.Errorf("Expected: %+v ,received: %+v", expected, invCfg)
+	}
+	cfgJSONStr := `{
+		"invoices":{
+			"enabled": true,
+			"ees_conns": ["*internal", "*conn1"],
+			"ees_ids": ["INVOICES_CSV"],
+			"run_ids": ["*default", "*raw"],
+		},
+}`
+	expected = InvoiceSCfg{
+		Enabled:  true,
+		EEsConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		EEsIDs:   []string{"INVOICES_CSV"},
+		RunIDs:   []string{utils.MetaDefault, utils.MetaRaw},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnInvCfg, err := jsnCfg.InvoiceSCfgJson(); err != nil {
+		t.Error(err)
+	} else if err = invCfg.loadFromJSONCfg(jsnInvCfg); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, invCfg) {
+		t.Errorf("Expected: %+v , received: %+v", utils.ToJSON(expected), utils.ToJSON(invCfg))
+	}
+}
+
+func TestInvoiceSCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+		"invoices":{
+			"enabled": true,
+			"ees_conns": ["*internal", "*conn1"],
+			"ees_ids": ["INVOICES_CSV"],
+		},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:  true,
+		utils.EEsConnsCfg: []string{utils.MetaInternal, "*conn1"},
+		utils.EEsIDsCfg:   []string{"INVOICES_CSV"},
+		utils.RunIDsCfg:   []string{utils.MetaDefault},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.InvoiceSCfg().AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestInvoiceSCfgClone(t *testing.T) {
+	invCfg := &InvoiceSCfg{
+		Enabled:  true,
+		EEsConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)},
+		EEsIDs:   []string{"INVOICES_CSV"},
+		RunIDs:   []string{utils.MetaDefault},
+	}
+	rcv := invCfg.Clone()
+	if !reflect.DeepEqual(invCfg, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(invCfg), utils.ToJSON(rcv))
+	}
+	if rcv.EEsConns[0] = ""; invCfg.EEsConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.EEsIDs[0] = ""; invCfg.EEsIDs[0] != "INVOICES_CSV" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.RunIDs[0] = ""; invCfg.RunIDs[0] != utils.MetaDefault {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
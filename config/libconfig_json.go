@@ -133,6 +133,7 @@ type FilterSJsonCfg struct {
 	Stats_conns     *[]string
 	Resources_conns *[]string
 	Apiers_conns    *[]string
+	Geoip_db_paths  *[]string
 }
 
 // Rater config section
@@ -145,6 +146,15 @@ type RalsJsonCfg struct {
 	Max_computed_usage         *map[string]string
 	Max_increments             *int
 	Balance_rating_subject     *map[string]string
+	Tiered_rating_plans        *map[string]*TierCounterJsonCfg
+	Default_currency           *string
+	Balance_ledger             *bool
+}
+
+// TierCounterJsonCfg is the counter definition of a tiered RatingPlan
+type TierCounterJsonCfg struct {
+	Counter *string
+	Cycle   *string
 }
 
 // Scheduler config section
@@ -362,6 +372,7 @@ type SessionSJsonCfg struct {
 	Session_indexes        *[]string
 	Client_protocol        *float64
 	Channel_sync_interval  *string
+	Backup_interval        *string
 	Terminate_attempts     *int
 	Alterable_fields       *[]string
 	Min_dur_low_balance    *string
@@ -421,6 +432,7 @@ type AstConnJsonCfg struct {
 	Password         *string
 	Connect_attempts *int
 	Reconnects       *int
+	Type             *string
 }
 
 type AsteriskAgentJsonCfg struct {
@@ -459,8 +471,19 @@ type KamConnJsonCfg struct {
 	Reconnects *int
 }
 
+// OsipsAgentJsonCfg OpenSIPSAgent config section
+type OsipsAgentJsonCfg struct {
+	Enabled        *bool
+	Listen_udp     *string
+	Sessions_conns *[]string
+	Create_cdr     *bool
+	Mi_conns       *[]*OsipsConnJsonCfg
+	Timezone       *string
+}
+
 // Represents one connection instance towards OpenSIPS
 type OsipsConnJsonCfg struct {
+	Alias      *string
 	Mi_addr    *string
 	Reconnects *int
 }
@@ -481,9 +504,28 @@ type DiameterAgentJsonCfg struct {
 	Asr_template         *string
 	Rar_template         *string
 	Forced_disconnect    *string
+	Peers                *[]*DiameterPeerJsonCfg
+	Routes               *[]*DiameterRouteJsonCfg
+	Relay_timeout        *string
 	Request_processors   *[]*ReqProcessorJsnCfg
 }
 
+// DiameterPeerJsonCfg
+type DiameterPeerJsonCfg struct {
+	ID                 *string
+	Address            *string
+	Transport          *string
+	Watchdog_interval  *string
+	Reconnect_interval *string
+}
+
+// DiameterRouteJsonCfg
+type DiameterRouteJsonCfg struct {
+	Realm           *string
+	Application_ids *[]int
+	Peer_ids        *[]string
+}
+
 // Radius Agent configuration section
 type RadiusAgentJsonCfg struct {
 	Enabled             *bool
@@ -492,11 +534,24 @@ type RadiusAgentJsonCfg struct {
 	Listen_acct         *string
 	Client_secrets      *map[string]string
 	Client_dictionaries *map[string]string
+	Client_da_addresses *map[string]*DAClientOptsJson
 	Sessions_conns      *[]string
 	Timezone            *string
+	Requests_cache_key  *string
+	Dmr_template        *string
+	Coa_template        *string
 	Request_processors  *[]*ReqProcessorJsnCfg
 }
 
+// DAClientOptsJson is the client configuration for the Dynamic Authorization requests
+type DAClientOptsJson struct {
+	Transport     *string
+	Host          *string
+	Port          *int
+	Reply_timeout *string
+	Retransmits   *int
+}
+
 // Conecto Agent configuration section
 type HttpAgentJsonCfg struct {
 	Id                 *string
@@ -507,6 +562,40 @@ type HttpAgentJsonCfg struct {
 	Request_processors *[]*ReqProcessorJsnCfg
 }
 
+// CHFAgentJsonCfg
+type CHFAgentJsonCfg struct {
+	Enabled            *bool
+	Listen             *string
+	Listen_net         *string
+	Api_root           *string
+	Sessions_conns     *[]string
+	Timezone           *string
+	Request_processors *[]*ReqProcessorJsnCfg
+}
+
+// SMPPAgentJsonCfg
+type SMPPAgentJsonCfg struct {
+	Enabled            *bool
+	Listen             *string
+	System_id          *string
+	Client_passwords   *map[string]string
+	Smsc_conns         *[]*SMSCConnJsonCfg
+	Reply_timeout      *string
+	Sessions_conns     *[]string
+	Timezone           *string
+	Request_processors *[]*ReqProcessorJsnCfg
+}
+
+// SMSCConnJsonCfg
+type SMSCConnJsonCfg struct {
+	ID                 *string
+	Address            *string
+	System_id          *string
+	Password           *string
+	System_type        *string
+	Reconnect_interval *string
+}
+
 // DNSAgentJsonCfg
 type DNSAgentJsonCfg struct {
 	Enabled            *bool
@@ -637,6 +726,7 @@ type RouteSJsonCfg struct {
 	Resources_conns       *[]string
 	Stats_conns           *[]string
 	Rals_conns            *[]string
+	Thresholds_conns      *[]string
 	Default_ratio         *int
 	Opts                  *RoutesOptsJson
 }
@@ -709,6 +799,7 @@ type DispatcherSJsonCfg struct {
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Attributes_conns      *[]string
 	Any_subsystem         *bool
+	Health_check_interval *string
 }
 
 type RegistrarCJsonCfg struct {
@@ -731,6 +822,7 @@ type LoaderCfgJson struct {
 	Scheduler_conns  *[]string
 	Gapi_credentials *json.RawMessage
 	Gapi_token       *json.RawMessage
+	Rounding_method  *string
 }
 
 type MigratorCfgJson struct {
@@ -782,12 +874,21 @@ type AnalyzerSJsonCfg struct {
 	Cleanup_interval *string
 }
 
+// Invoice service json config section
+type InvoiceSJsonCfg struct {
+	Enabled   *bool
+	Ees_conns *[]string
+	Ees_ids   *[]string
+	Run_ids   *[]string
+}
+
 type ApierJsonCfg struct {
 	Enabled          *bool
 	Caches_conns     *[]string
 	Scheduler_conns  *[]string
 	Attributes_conns *[]string
 	Ees_conns        *[]string
+	Invoices_conns   *[]string
 }
 
 type STIRJsonCfg struct {
@@ -34,6 +34,7 @@ type LoaderCgrCfg struct {
 	SchedulerConns  []string
 	GapiCredentials json.RawMessage
 	GapiToken       json.RawMessage
+	RoundingMethod  string // rounding method of the DestinationRates imported out of rate decks
 }
 
 func (ld *LoaderCgrCfg) loadFromJSONCfg(jsnCfg *LoaderCfgJson) (err error) {
@@ -79,6 +80,9 @@ func (ld *LoaderCgrCfg) loadFromJSONCfg(jsnCfg *LoaderCfgJson) (err error) {
 	if jsnCfg.Gapi_token != nil {
 		ld.GapiToken = *jsnCfg.Gapi_token
 	}
+	if jsnCfg.Rounding_method != nil {
+		ld.RoundingMethod = *jsnCfg.Rounding_method
+	}
 	return nil
 }
 
@@ -89,6 +93,7 @@ func (ld *LoaderCgrCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.DataPathCfg:       ld.DataPath,
 		utils.DisableReverseCfg: ld.DisableReverse,
 		utils.FieldSepCfg:       string(ld.FieldSeparator),
+		utils.RoundingMethodCfg: ld.RoundingMethod,
 	}
 	if ld.CachesConns != nil {
 		cacheSConns := make([]string, len(ld.CachesConns))
@@ -128,6 +133,7 @@ func (ld LoaderCgrCfg) Clone() (cln *LoaderCgrCfg) {
 		FieldSeparator:  ld.FieldSeparator,
 		GapiCredentials: json.RawMessage(string([]byte(ld.GapiCredentials))),
 		GapiToken:       json.RawMessage(string([]byte(ld.GapiToken))),
+		RoundingMethod:  ld.RoundingMethod,
 	}
 
 	if ld.CachesConns != nil {
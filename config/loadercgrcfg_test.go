@@ -35,6 +35,7 @@ func TestLoaderCgrCfgloadFromJsonCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{utils.MetaInternal},
 		Gapi_credentials: &json.RawMessage{12, 13, 60},
 		Gapi_token:       &json.RawMessage{13, 16},
+		Rounding_method:  utils.StringPointer(utils.MetaRoundingMiddle),
 	}
 	expected := &LoaderCgrCfg{
 		TpID:            "randomID",
@@ -45,6 +46,7 @@ func TestLoaderCgrCfgloadFromJsonCfg(t *testing.T) {
 		SchedulerConns:  []string{"*internal:*scheduler"},
 		GapiCredentials: json.RawMessage{12, 13, 60},
 		GapiToken:       json.RawMessage{13, 16},
+		RoundingMethod:  utils.MetaRoundingMiddle,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.loaderCgrCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -76,6 +78,7 @@ func TestLoaderCgrCfgAsMapInterface(t *testing.T) {
 		utils.SchedulerConnsCfg:  []string{"*internal", "*localhost"},
 		utils.GapiCredentialsCfg: json.RawMessage(`".gapi/credentials.json"`),
 		utils.GapiTokenCfg:       json.RawMessage(`".gapi/token.json"`),
+		utils.RoundingMethodCfg:  utils.MetaRoundingUp,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -94,6 +97,7 @@ func TestLoaderCgrCfgClone(t *testing.T) {
 		SchedulerConns:  []string{"*internal:*scheduler"},
 		GapiCredentials: json.RawMessage{12, 13, 60},
 		GapiToken:       json.RawMessage{13, 16},
+		RoundingMethod:  utils.MetaRoundingDown,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -0,0 +1,174 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewDfltOsipsConnConfig returns the first cached default value for a OsipsConnCfg connection
+func NewDfltOsipsConnConfig() *OsipsConnCfg {
+	if dfltOsipsConnConfig == nil {
+		return new(OsipsConnCfg) // No defaults, most probably we are building the defaults now
+	}
+	dfltVal := *dfltOsipsConnConfig
+	return &dfltVal
+}
+
+// OsipsConnCfg represents one connection instance towards the MI JSON interface of OpenSIPS
+type OsipsConnCfg struct {
+	Alias      string
+	MiAddr     string
+	Reconnects int
+}
+
+func (osCfg *OsipsConnCfg) loadFromJSONCfg(jsnCfg *OsipsConnJsonCfg) error {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Alias != nil {
+		osCfg.Alias = *jsnCfg.Alias
+	}
+	if jsnCfg.Mi_addr != nil {
+		osCfg.MiAddr = *jsnCfg.Mi_addr
+	}
+	if jsnCfg.Reconnects != nil {
+		osCfg.Reconnects = *jsnCfg.Reconnects
+	}
+	return nil
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (osCfg *OsipsConnCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.AliasCfg:      osCfg.Alias,
+		utils.MiAddrCfg:     osCfg.MiAddr,
+		utils.ReconnectsCfg: osCfg.Reconnects,
+	}
+}
+
+// Clone returns a deep copy of OsipsConnCfg
+func (osCfg OsipsConnCfg) Clone() *OsipsConnCfg {
+	return &OsipsConnCfg{
+		Alias:      osCfg.Alias,
+		MiAddr:     osCfg.MiAddr,
+		Reconnects: osCfg.Reconnects,
+	}
+}
+
+// OsipsAgentCfg is the OpenSIPS config section
+type OsipsAgentCfg struct {
+	Enabled       bool
+	ListenUDP     string // event_datagram socket
+	SessionSConns []string
+	CreateCdr     bool
+	MiConns       []*OsipsConnCfg
+	Timezone      string
+}
+
+func (oa *OsipsAgentCfg) loadFromJSONCfg(jsnCfg *OsipsAgentJsonCfg) error {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		oa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen_udp != nil {
+		oa.ListenUDP = *jsnCfg.Listen_udp
+	}
+	if jsnCfg.Sessions_conns != nil {
+		oa.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, attrConn := range *jsnCfg.Sessions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			oa.SessionSConns[idx] = attrConn
+			if attrConn == utils.MetaInternal ||
+				attrConn == rpcclient.BiRPCInternal {
+				oa.SessionSConns[idx] = utils.ConcatenatedKey(attrConn, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Create_cdr != nil {
+		oa.CreateCdr = *jsnCfg.Create_cdr
+	}
+	if jsnCfg.Mi_conns != nil {
+		oa.MiConns = make([]*OsipsConnCfg, len(*jsnCfg.Mi_conns))
+		for idx, jsnConnCfg := range *jsnCfg.Mi_conns {
+			oa.MiConns[idx] = NewDfltOsipsConnConfig()
+			oa.MiConns[idx].loadFromJSONCfg(jsnConnCfg)
+		}
+	}
+	if jsnCfg.Timezone != nil {
+		oa.Timezone = *jsnCfg.Timezone
+	}
+	return nil
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (oa *OsipsAgentCfg) AsMapInterface() (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:   oa.Enabled,
+		utils.ListenUDPCfg: oa.ListenUDP,
+		utils.CreateCdrCfg: oa.CreateCdr,
+		utils.TimezoneCfg:  oa.Timezone,
+	}
+	if oa.MiConns != nil {
+		miConns := make([]map[string]interface{}, len(oa.MiConns))
+		for i, item := range oa.MiConns {
+			miConns[i] = item.AsMapInterface()
+		}
+		initialMP[utils.MiConnsCfg] = miConns
+	}
+	if oa.SessionSConns != nil {
+		sessionSConns := make([]string, len(oa.SessionSConns))
+		for i, item := range oa.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			} else if item == utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS) {
+				sessionSConns[i] = rpcclient.BiRPCInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of OsipsAgentCfg
+func (oa OsipsAgentCfg) Clone() (cln *OsipsAgentCfg) {
+	cln = &OsipsAgentCfg{
+		Enabled:   oa.Enabled,
+		ListenUDP: oa.ListenUDP,
+		CreateCdr: oa.CreateCdr,
+		Timezone:  oa.Timezone,
+	}
+	if oa.SessionSConns != nil {
+		cln.SessionSConns = make([]string, len(oa.SessionSConns))
+		for i, con := range oa.SessionSConns {
+			cln.SessionSConns[i] = con
+		}
+	}
+	if oa.MiConns != nil {
+		cln.MiConns = make([]*OsipsConnCfg, len(oa.MiConns))
+		for i, req := range oa.MiConns {
+			cln.MiConns[i] = req.Clone()
+		}
+	}
+	return
+}
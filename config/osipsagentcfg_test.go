@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestOsipsAgentCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSON := &OsipsAgentJsonCfg{
+		Enabled:        utils.BoolPointer(true),
+		Listen_udp:     utils.StringPointer("127.0.0.1:2021"),
+		Sessions_conns: &[]string{"*internal"},
+		Create_cdr:     utils.BoolPointer(true),
+		Mi_conns: &[]*OsipsConnJsonCfg{
+			{
+				Alias:   utils.StringPointer("randomAlias"),
+				Mi_addr: utils.StringPointer("http://127.0.0.1:8889/mi"),
+			},
+		},
+		Timezone: utils.StringPointer("Local"),
+	}
+	expected := &OsipsAgentCfg{
+		Enabled:       true,
+		ListenUDP:     "127.0.0.1:2021",
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		CreateCdr:     true,
+		MiConns:       []*OsipsConnCfg{{MiAddr: "http://127.0.0.1:8889/mi", Reconnects: 5, Alias: "randomAlias"}},
+		Timezone:      "Local",
+	}
+	jsnCfg := NewDefaultCGRConfig()
+	if err = jsnCfg.osipsAgentCfg.loadFromJSONCfg(cfgJSON); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, jsnCfg.osipsAgentCfg) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsnCfg.osipsAgentCfg))
+	}
+}
+
+func TestOsipsAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+		"opensips_agent": {
+			"sessions_conns": ["*birpc_internal", "*conn1", "*internal"],
+			"create_cdr": true,
+			"timezone": "UTC",
+			"mi_conns":[
+				{"mi_addr": "http://127.0.0.1:8888/mi", "reconnects": 3, "alias": "osips1"}
+			],
+		},
+	}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:       false,
+		utils.ListenUDPCfg:     "127.0.0.1:2020",
+		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", utils.MetaInternal},
+		utils.CreateCdrCfg:     true,
+		utils.TimezoneCfg:      "UTC",
+		utils.MiConnsCfg: []map[string]interface{}{
+			{utils.MiAddrCfg: "http://127.0.0.1:8888/mi", utils.ReconnectsCfg: 3, utils.AliasCfg: "osips1"},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.osipsAgentCfg.AsMapInterface(); !reflect.DeepEqual(rcv, eMap) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestOsipsAgentCfgClone(t *testing.T) {
+	cS := &OsipsAgentCfg{
+		Enabled:       true,
+		ListenUDP:     "127.0.0.1:2020",
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		CreateCdr:     true,
+		MiConns:       []*OsipsConnCfg{{MiAddr: "http://127.0.0.1:8888/mi", Reconnects: 5, Alias: "osips1"}},
+		Timezone:      "Local",
+	}
+	rcv := cS.Clone()
+	if !reflect.DeepEqual(cS, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(cS), utils.ToJSON(rcv))
+	}
+	if rcv.SessionSConns[0] = ""; cS.SessionSConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.MiConns[0].Alias = ""; cS.MiConns[0].Alias != "osips1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
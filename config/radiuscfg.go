@@ -19,7 +19,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 // RadiusAgentCfg the config section that describes the Radius Agent
@@ -30,10 +33,63 @@ type RadiusAgentCfg struct {
 	ListenAcct         string
 	ClientSecrets      map[string]string
 	ClientDictionaries map[string]string
+	ClientDaAddresses  map[string]*DAClientOpts
 	SessionSConns      []string
+	RequestsCacheKey   RSRParsers
+	DMRTemplate        string
+	CoATemplate        string
 	RequestProcessors  []*RequestProcessor
 }
 
+// DAClientOpts contains the options used to send the Dynamic Authorization requests (RFC 5176) to a client
+type DAClientOpts struct {
+	Transport    string
+	Host         string // empty to use the address the client sent the requests from
+	Port         int
+	ReplyTimeout time.Duration
+	Retransmits  int
+}
+
+func (dao *DAClientOpts) loadFromJSONCfg(jsnCfg *DAClientOptsJson) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Transport != nil {
+		dao.Transport = *jsnCfg.Transport
+	}
+	if jsnCfg.Host != nil {
+		dao.Host = *jsnCfg.Host
+	}
+	if jsnCfg.Port != nil {
+		dao.Port = *jsnCfg.Port
+	}
+	if jsnCfg.Reply_timeout != nil {
+		if dao.ReplyTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reply_timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Retransmits != nil {
+		dao.Retransmits = *jsnCfg.Retransmits
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dao *DAClientOpts) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.TransportCfg:    dao.Transport,
+		utils.HostCfg:         dao.Host,
+		utils.PortCfg:         dao.Port,
+		utils.ReplyTimeoutCfg: dao.ReplyTimeout.String(),
+		utils.RetransmitsCfg:  dao.Retransmits,
+	}
+}
+
+// Clone returns a deep copy of DAClientOpts
+func (dao DAClientOpts) Clone() *DAClientOpts {
+	return &dao
+}
+
 func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator string) (err error) {
 	if jsnCfg == nil {
 		return nil
@@ -66,16 +122,48 @@ func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator
 			ra.ClientDictionaries[k] = v
 		}
 	}
+	if jsnCfg.Client_da_addresses != nil {
+		if ra.ClientDaAddresses == nil {
+			ra.ClientDaAddresses = make(map[string]*DAClientOpts)
+		}
+		for k, v := range *jsnCfg.Client_da_addresses {
+			dao, has := ra.ClientDaAddresses[k]
+			if !has {
+				dao = &DAClientOpts{ // defaults for a new client
+					Transport:    utils.UDP,
+					Port:         3799,
+					ReplyTimeout: time.Second,
+					Retransmits:  2,
+				}
+			}
+			if err = dao.loadFromJSONCfg(v); err != nil {
+				return
+			}
+			ra.ClientDaAddresses[k] = dao
+		}
+	}
 	if jsnCfg.Sessions_conns != nil {
 		ra.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
 		for idx, attrConn := range *jsnCfg.Sessions_conns {
 			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
 			ra.SessionSConns[idx] = attrConn
-			if attrConn == utils.MetaInternal {
-				ra.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			if attrConn == utils.MetaInternal ||
+				attrConn == rpcclient.BiRPCInternal {
+				ra.SessionSConns[idx] = utils.ConcatenatedKey(attrConn, utils.MetaSessionS)
 			}
 		}
 	}
+	if jsnCfg.Requests_cache_key != nil {
+		if ra.RequestsCacheKey, err = NewRSRParsers(*jsnCfg.Requests_cache_key, separator); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Dmr_template != nil {
+		ra.DMRTemplate = *jsnCfg.Dmr_template
+	}
+	if jsnCfg.Coa_template != nil {
+		ra.CoATemplate = *jsnCfg.Coa_template
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -101,10 +189,13 @@ func (ra *RadiusAgentCfg) loadFromJSONCfg(jsnCfg *RadiusAgentJsonCfg, separator
 // AsMapInterface returns the config as a map[string]interface{}
 func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:    ra.Enabled,
-		utils.ListenNetCfg:  ra.ListenNet,
-		utils.ListenAuthCfg: ra.ListenAuth,
-		utils.ListenAcctCfg: ra.ListenAcct,
+		utils.EnabledCfg:          ra.Enabled,
+		utils.ListenNetCfg:        ra.ListenNet,
+		utils.ListenAuthCfg:       ra.ListenAuth,
+		utils.ListenAcctCfg:       ra.ListenAcct,
+		utils.RequestsCacheKeyCfg: ra.RequestsCacheKey.GetRule(separator),
+		utils.DMRTemplateCfg:      ra.DMRTemplate,
+		utils.CoATemplateCfg:      ra.CoATemplate,
 	}
 
 	requestProcessors := make([]map[string]interface{}, len(ra.RequestProcessors))
@@ -119,6 +210,8 @@ func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string
 			sessionSConns[i] = item
 			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
 				sessionSConns[i] = utils.MetaInternal
+			} else if item == utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS) {
+				sessionSConns[i] = rpcclient.BiRPCInternal
 			}
 		}
 		initialMP[utils.SessionSConnsCfg] = sessionSConns
@@ -133,6 +226,11 @@ func (ra *RadiusAgentCfg) AsMapInterface(separator string) (initialMP map[string
 		clientDictionaries[k] = v
 	}
 	initialMP[utils.ClientDictionariesCfg] = clientDictionaries
+	clientDaAddresses := make(map[string]interface{})
+	for k, v := range ra.ClientDaAddresses {
+		clientDaAddresses[k] = v.AsMapInterface()
+	}
+	initialMP[utils.ClientDaAddressesCfg] = clientDaAddresses
 	return
 }
 
@@ -145,6 +243,10 @@ func (ra RadiusAgentCfg) Clone() (cln *RadiusAgentCfg) {
 		ListenAcct:         ra.ListenAcct,
 		ClientSecrets:      make(map[string]string),
 		ClientDictionaries: make(map[string]string),
+		ClientDaAddresses:  make(map[string]*DAClientOpts),
+		RequestsCacheKey:   ra.RequestsCacheKey.Clone(),
+		DMRTemplate:        ra.DMRTemplate,
+		CoATemplate:        ra.CoATemplate,
 	}
 	if ra.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(ra.SessionSConns))
@@ -158,6 +260,9 @@ func (ra RadiusAgentCfg) Clone() (cln *RadiusAgentCfg) {
 	for k, v := range ra.ClientDictionaries {
 		cln.ClientDictionaries[k] = v
 	}
+	for k, v := range ra.ClientDaAddresses {
+		cln.ClientDaAddresses[k] = v.Clone()
+	}
 	if ra.RequestProcessors != nil {
 		cln.RequestProcessors = make([]*RequestProcessor, len(ra.RequestProcessors))
 		for i, req := range ra.RequestProcessors {
@@ -34,7 +34,16 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		Listen_acct:         utils.StringPointer("127.0.0.1:1813"),
 		Client_secrets:      &map[string]string{utils.MetaDefault: "CGRateS.org"},
 		Client_dictionaries: &map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		Sessions_conns:      &[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Client_da_addresses: &map[string]*DAClientOptsJson{
+			"127.0.0.1": {
+				Port:          utils.IntPointer(1700),
+				Reply_timeout: utils.StringPointer("500ms"),
+			},
+		},
+		Sessions_conns:     &[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Requests_cache_key: utils.StringPointer("~*req.Acct-Session-Id"),
+		Dmr_template:       utils.StringPointer("*dmr"),
+		Coa_template:       utils.StringPointer("*coa"),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -63,7 +72,18 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		ClientDaAddresses: map[string]*DAClientOpts{
+			"127.0.0.1": {
+				Transport:    utils.UDP,
+				Port:         1700,
+				ReplyTimeout: 500 * time.Millisecond,
+				Retransmits:  2,
+			},
+		},
+		SessionSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		RequestsCacheKey: NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep),
+		DMRTemplate:      "*dmr",
+		CoATemplate:      "*coa",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -155,7 +175,12 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "client_dictionaries": {									
 	    	"*default": "/usr/share/cgrates/",			
 	     },
+	     "client_da_addresses": {
+	    	"127.0.0.1": {"host": "10.0.0.1"},
+	     },
 	     "sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
+	     "requests_cache_key": "~*req.Acct-Session-Id",
+	     "dmr_template": "*dmr",
          "request_processors": [
 			{
 				"id": "OutboundAUTHDryRun",
@@ -181,7 +206,19 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/",
 		},
-		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.ClientDaAddressesCfg: map[string]interface{}{
+			"127.0.0.1": map[string]interface{}{
+				utils.TransportCfg:    utils.UDP,
+				utils.HostCfg:         "10.0.0.1",
+				utils.PortCfg:         3799,
+				utils.ReplyTimeoutCfg: "1s",
+				utils.RetransmitsCfg:  2,
+			},
+		},
+		utils.SessionSConnsCfg:    []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.RequestsCacheKeyCfg: "~*req.Acct-Session-Id",
+		utils.DMRTemplateCfg:      "*dmr",
+		utils.CoATemplateCfg:      "",
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -218,7 +255,11 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		},
+		utils.ClientDaAddressesCfg: map[string]interface{}{},
 		utils.SessionSConnsCfg:     []string{"*internal"},
+		utils.RequestsCacheKeyCfg:  "",
+		utils.DMRTemplateCfg:       "",
+		utils.CoATemplateCfg:       "",
 		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -236,7 +277,12 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		ClientDaAddresses: map[string]*DAClientOpts{
+			"127.0.0.1": {Transport: utils.UDP, Port: 3799, ReplyTimeout: time.Second, Retransmits: 2},
+		},
+		SessionSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		RequestsCacheKey: NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep),
+		DMRTemplate:      "*dmr",
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -274,4 +320,7 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 	if rcv.ClientDictionaries[utils.MetaDefault] = ""; ban.ClientDictionaries[utils.MetaDefault] != "/usr/share/cgrates/radius/dict/" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ClientDaAddresses["127.0.0.1"].Port = 0; ban.ClientDaAddresses["127.0.0.1"].Port != 3799 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
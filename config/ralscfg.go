@@ -35,6 +35,43 @@ type RalsCfg struct {
 	MaxComputedUsage        map[string]time.Duration
 	BalanceRatingSubject    map[string]string
 	MaxIncrements           int
+	TieredRatingPlans       map[string]*TierCounterCfg // RatingPlanID: counter used for tier selection
+	DefaultCurrency         string                     // currency of the RatingPlans and *monetary balances not defining one
+	BalanceLedger           bool                       // store each change of the balances into StorDB
+}
+
+// TierCounterCfg defines the usage counter of a tiered RatingPlan
+type TierCounterCfg struct {
+	Counter string // *account or *subject
+	Cycle   string // *daily, *weekly, *monthly, *yearly or *unlimited
+}
+
+func (tcCfg *TierCounterCfg) loadFromJSONCfg(jsnCfg *TierCounterJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Counter != nil {
+		tcCfg.Counter = *jsnCfg.Counter
+	}
+	if jsnCfg.Cycle != nil {
+		tcCfg.Cycle = *jsnCfg.Cycle
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (tcCfg *TierCounterCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.CounterCfg: tcCfg.Counter,
+		utils.CycleCfg:   tcCfg.Cycle,
+	}
+}
+
+// Clone returns a deep copy of TierCounterCfg
+func (tcCfg TierCounterCfg) Clone() *TierCounterCfg {
+	return &TierCounterCfg{
+		Counter: tcCfg.Counter,
+		Cycle:   tcCfg.Cycle,
+	}
 }
 
 // loadFromJSONCfg loads Rals config from JsonCfg
@@ -86,7 +123,22 @@ func (ralsCfg *RalsCfg) loadFromJSONCfg(jsnRALsCfg *RalsJsonCfg) (err error) {
 			ralsCfg.BalanceRatingSubject[k] = v
 		}
 	}
-
+	if jsnRALsCfg.Tiered_rating_plans != nil {
+		for rpID, jsnTc := range *jsnRALsCfg.Tiered_rating_plans {
+			tc := &TierCounterCfg{Counter: utils.MetaAccount, Cycle: utils.MetaMonthly}
+			if oldTc, has := ralsCfg.TieredRatingPlans[rpID]; has {
+				tc = oldTc.Clone()
+			}
+			tc.loadFromJSONCfg(jsnTc)
+			ralsCfg.TieredRatingPlans[rpID] = tc
+		}
+	}
+	if jsnRALsCfg.Default_currency != nil {
+		ralsCfg.DefaultCurrency = *jsnRALsCfg.Default_currency
+	}
+	if jsnRALsCfg.Balance_ledger != nil {
+		ralsCfg.BalanceLedger = *jsnRALsCfg.Balance_ledger
+	}
 	return nil
 }
 
@@ -97,6 +149,8 @@ func (ralsCfg *RalsCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.RpSubjectPrefixMatchingCfg: ralsCfg.RpSubjectPrefixMatching,
 		utils.RemoveExpiredCfg:           ralsCfg.RemoveExpired,
 		utils.MaxIncrementsCfg:           ralsCfg.MaxIncrements,
+		utils.DefaultCurrencyCfg:         ralsCfg.DefaultCurrency,
+		utils.BalanceLedgerCfg:           ralsCfg.BalanceLedger,
 	}
 	if ralsCfg.ThresholdSConns != nil {
 		threSholds := make([]string, len(ralsCfg.ThresholdSConns))
@@ -132,6 +186,11 @@ func (ralsCfg *RalsCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		balanceRatSubj[k] = v
 	}
 	initialMP[utils.BalanceRatingSubjectCfg] = balanceRatSubj
+	tieredRPs := make(map[string]interface{})
+	for rpID, tc := range ralsCfg.TieredRatingPlans {
+		tieredRPs[rpID] = tc.AsMapInterface()
+	}
+	initialMP[utils.TieredRatingPlansCfg] = tieredRPs
 	return
 }
 
@@ -142,9 +201,12 @@ func (ralsCfg RalsCfg) Clone() (cln *RalsCfg) {
 		RpSubjectPrefixMatching: ralsCfg.RpSubjectPrefixMatching,
 		RemoveExpired:           ralsCfg.RemoveExpired,
 		MaxIncrements:           ralsCfg.MaxIncrements,
+		DefaultCurrency:         ralsCfg.DefaultCurrency,
+		BalanceLedger:           ralsCfg.BalanceLedger,
 
 		MaxComputedUsage:     make(map[string]time.Duration),
 		BalanceRatingSubject: make(map[string]string),
+		TieredRatingPlans:    make(map[string]*TierCounterCfg),
 	}
 	if ralsCfg.ThresholdSConns != nil {
 		cln.ThresholdSConns = make([]string, len(ralsCfg.ThresholdSConns))
@@ -165,5 +227,8 @@ func (ralsCfg RalsCfg) Clone() (cln *RalsCfg) {
 	for k, r := range ralsCfg.BalanceRatingSubject {
 		cln.BalanceRatingSubject[k] = r
 	}
+	for rpID, tc := range ralsCfg.TieredRatingPlans {
+		cln.TieredRatingPlans[rpID] = tc.Clone()
+	}
 	return
 }
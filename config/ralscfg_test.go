@@ -44,6 +44,16 @@ func TestRalsCfgFromJsonCfgCase1(t *testing.T) {
 			utils.MetaAny:   "*zero1ns",
 			utils.MetaVoice: "*zero1s",
 		},
+		Tiered_rating_plans: &map[string]*TierCounterJsonCfg{
+			"RP_WHOLESALE": {
+				Counter: utils.StringPointer(utils.MetaSubject),
+			},
+			"RP_RETAIL": {
+				Cycle: utils.StringPointer(utils.MetaDaily),
+			},
+		},
+		Default_currency: utils.StringPointer("EUR"),
+		Balance_ledger:   utils.BoolPointer(true),
 	}
 	expected := &RalsCfg{
 		Enabled:                 true,
@@ -63,6 +73,18 @@ func TestRalsCfgFromJsonCfgCase1(t *testing.T) {
 			utils.MetaAny:   "*zero1ns",
 			utils.MetaVoice: "*zero1s",
 		},
+		TieredRatingPlans: map[string]*TierCounterCfg{
+			"RP_WHOLESALE": {
+				Counter: utils.MetaSubject,
+				Cycle:   utils.MetaMonthly,
+			},
+			"RP_RETAIL": {
+				Counter: utils.MetaAccount,
+				Cycle:   utils.MetaDaily,
+			},
+		},
+		DefaultCurrency: "EUR",
+		BalanceLedger:   true,
 	}
 	cfg := NewDefaultCGRConfig()
 	if err = cfg.ralsCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -97,6 +119,11 @@ func TestRalsCfgAsMapInterfaceCase1(t *testing.T) {
 		   "*voice": "48h",
 		   "*sms": "5000"
         }, 
+	    "tiered_rating_plans": {
+		   "RP_WHOLESALE": {"cycle": "*weekly"},
+        },
+	    "default_currency": "USD",
+	    "balance_ledger": true,
     },
 }`
 	eMap := map[string]interface{}{
@@ -117,6 +144,14 @@ func TestRalsCfgAsMapInterfaceCase1(t *testing.T) {
 			"*any":   "*zero1ns",
 			"*voice": "*zero1s",
 		},
+		utils.TieredRatingPlansCfg: map[string]interface{}{
+			"RP_WHOLESALE": map[string]interface{}{
+				utils.CounterCfg: utils.MetaAccount,
+				utils.CycleCfg:   utils.MetaWeekly,
+			},
+		},
+		utils.DefaultCurrencyCfg: "USD",
+		utils.BalanceLedgerCfg:   true,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -150,6 +185,9 @@ func TestRalsCfgAsMapInterfaceCase2(t *testing.T) {
 			"*any":   "*zero1ns",
 			"*voice": "*zero1s",
 		},
+		utils.TieredRatingPlansCfg: map[string]interface{}{},
+		utils.DefaultCurrencyCfg:   "",
+		utils.BalanceLedgerCfg:     false,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -177,6 +215,14 @@ func TestRalsCfgClone(t *testing.T) {
 			utils.MetaAny:   "*zero1ns",
 			utils.MetaVoice: "*zero1s",
 		},
+		TieredRatingPlans: map[string]*TierCounterCfg{
+			"RP_WHOLESALE": {
+				Counter: utils.MetaAccount,
+				Cycle:   utils.MetaMonthly,
+			},
+		},
+		DefaultCurrency: "EUR",
+		BalanceLedger:   true,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -194,4 +240,7 @@ func TestRalsCfgClone(t *testing.T) {
 	if rcv.BalanceRatingSubject[utils.MetaAny] = ""; ban.BalanceRatingSubject[utils.MetaAny] != "*zero1ns" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.TieredRatingPlans["RP_WHOLESALE"].Cycle = ""; ban.TieredRatingPlans["RP_WHOLESALE"].Cycle != utils.MetaMonthly {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
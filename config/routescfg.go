@@ -42,6 +42,7 @@ type RouteSCfg struct {
 	ResourceSConns      []string
 	StatSConns          []string
 	RALsConns           []string
+	ThresholdSConns     []string
 	DefaultRatio        int
 	NestedFields        bool
 	Opts                *RoutesOpts
@@ -142,6 +143,16 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Thresholds_conns != nil {
+		rts.ThresholdSConns = make([]string, len(*jsnCfg.Thresholds_conns))
+		for idx, conn := range *jsnCfg.Thresholds_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rts.ThresholdSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rts.ThresholdSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)
+			}
+		}
+	}
 	if jsnCfg.Default_ratio != nil {
 		rts.DefaultRatio = *jsnCfg.Default_ratio
 	}
@@ -240,6 +251,16 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.StatSConnsCfg] = statSConns
 	}
+	if rts.ThresholdSConns != nil {
+		thresholdSConns := make([]string, len(rts.ThresholdSConns))
+		for i, item := range rts.ThresholdSConns {
+			thresholdSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds) {
+				thresholdSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.ThresholdSConnsCfg] = thresholdSConns
+	}
 	return
 }
 
@@ -294,6 +315,12 @@ func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 			cln.RALsConns[i] = con
 		}
 	}
+	if rts.ThresholdSConns != nil {
+		cln.ThresholdSConns = make([]string, len(rts.ThresholdSConns))
+		for i, con := range rts.ThresholdSConns {
+			cln.ThresholdSConns[i] = con
+		}
+	}
 	if rts.StringIndexedFields != nil {
 		idx := make([]string, len(*rts.StringIndexedFields))
 		for i, dx := range *rts.StringIndexedFields {
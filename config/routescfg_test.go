@@ -35,6 +35,7 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		Resources_conns:       &[]string{utils.MetaInternal, "conn1"},
 		Stats_conns:           &[]string{utils.MetaInternal, "conn1"},
 		Rals_conns:            &[]string{utils.MetaInternal, "conn1"},
+		Thresholds_conns:      &[]string{utils.MetaInternal, "conn1"},
 		Default_ratio:         utils.IntPointer(10),
 		Nested_fields:         utils.BoolPointer(true),
 	}
@@ -48,6 +49,7 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		ResourceSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "conn1"},
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "conn1"},
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
+		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "conn1"},
 		DefaultRatio:        10,
 		NestedFields:        true,
 		Opts: &RoutesOpts{
@@ -78,6 +80,7 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 		utils.ResourceSConnsCfg:      []string{},
 		utils.StatSConnsCfg:          []string{},
 		utils.RALsConnsCfg:           []string{},
+		utils.ThresholdSConnsCfg:     []string{},
 		utils.DefaultRatioCfg:        1,
 		utils.OptsCfg: map[string]interface{}{
 			utils.OptsContext:         utils.MetaRoutes,
@@ -105,6 +108,7 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 			"resources_conns": ["*internal:*resources", "conn1"],
 			"stats_conns": ["*internal:*stats", "conn1"],
 			"rals_conns": ["*internal:*responder", "conn1"],
+			"thresholds_conns": ["*internal:*thresholds", "conn1"],
 			"default_ratio":2,
 		},
 	}`
@@ -119,6 +123,7 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 		utils.ResourceSConnsCfg:      []string{utils.MetaInternal, "conn1"},
 		utils.StatSConnsCfg:          []string{utils.MetaInternal, "conn1"},
 		utils.RALsConnsCfg:           []string{utils.MetaInternal, "conn1"},
+		utils.ThresholdSConnsCfg:     []string{utils.MetaInternal, "conn1"},
 		utils.DefaultRatioCfg:        2,
 		utils.OptsCfg: map[string]interface{}{
 			utils.OptsContext:         utils.MetaRoutes,
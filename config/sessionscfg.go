@@ -109,6 +109,7 @@ type SessionSCfg struct {
 	SessionIndexes      utils.StringSet
 	ClientProtocol      float64
 	ChannelSyncInterval time.Duration
+	BackupInterval      time.Duration
 	TerminateAttempts   int
 	AlterableFields     utils.StringSet
 	MinDurLowBalance    time.Duration
@@ -271,6 +272,11 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Backup_interval != nil {
+		if scfg.BackupInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Backup_interval); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.Terminate_attempts != nil {
 		scfg.TerminateAttempts = *jsnCfg.Terminate_attempts
 	}
@@ -332,6 +338,7 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.STIRCfg:                scfg.STIRCfg.AsMapInterface(),
 		utils.MinDurLowBalanceCfg:    "0",
 		utils.ChannelSyncIntervalCfg: "0",
+		utils.BackupIntervalCfg:      "0",
 		utils.DebitIntervalCfg:       "0",
 		utils.SessionTTLCfg:          "0",
 		utils.DefaultUsageCfg:        maxComputed,
@@ -357,6 +364,9 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	if scfg.ChannelSyncInterval != 0 {
 		initialMP[utils.ChannelSyncIntervalCfg] = scfg.ChannelSyncInterval.String()
 	}
+	if scfg.BackupInterval != 0 {
+		initialMP[utils.BackupIntervalCfg] = scfg.BackupInterval.String()
+	}
 	if scfg.MinDurLowBalance != 0 {
 		initialMP[utils.MinDurLowBalanceCfg] = scfg.MinDurLowBalance.String()
 	}
@@ -463,6 +473,7 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		SessionTTL:          scfg.SessionTTL,
 		ClientProtocol:      scfg.ClientProtocol,
 		ChannelSyncInterval: scfg.ChannelSyncInterval,
+		BackupInterval:      scfg.BackupInterval,
 		TerminateAttempts:   scfg.TerminateAttempts,
 		MinDurLowBalance:    scfg.MinDurLowBalance,
 
@@ -705,6 +716,7 @@ type AsteriskConnCfg struct {
 	Password        string
 	ConnectAttempts int
 	Reconnects      int
+	Type            string // interface used towards Asterisk <*ari|*ami>
 }
 
 func (aConnCfg *AsteriskConnCfg) loadFromJSONCfg(jsnCfg *AstConnJsonCfg) error {
@@ -729,6 +741,9 @@ func (aConnCfg *AsteriskConnCfg) loadFromJSONCfg(jsnCfg *AstConnJsonCfg) error {
 	if jsnCfg.Reconnects != nil {
 		aConnCfg.Reconnects = *jsnCfg.Reconnects
 	}
+	if jsnCfg.Type != nil {
+		aConnCfg.Type = *jsnCfg.Type
+	}
 	return nil
 }
 
@@ -741,6 +756,7 @@ func (aConnCfg *AsteriskConnCfg) AsMapInterface() map[string]interface{} {
 		utils.Password:           aConnCfg.Password,
 		utils.ConnectAttemptsCfg: aConnCfg.ConnectAttempts,
 		utils.ReconnectsCfg:      aConnCfg.Reconnects,
+		utils.TypeCfg:            aConnCfg.Type,
 	}
 }
 
@@ -753,6 +769,7 @@ func (aConnCfg AsteriskConnCfg) Clone() *AsteriskConnCfg {
 		Password:        aConnCfg.Password,
 		ConnectAttempts: aConnCfg.ConnectAttempts,
 		Reconnects:      aConnCfg.Reconnects,
+		Type:            aConnCfg.Type,
 	}
 }
 
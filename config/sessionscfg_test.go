@@ -81,6 +81,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Session_indexes:       &[]string{},
 		Client_protocol:       utils.Float64Pointer(2.5),
 		Channel_sync_interval: utils.StringPointer("10"),
+		Backup_interval:       utils.StringPointer("-1"),
 		Terminate_attempts:    utils.IntPointer(6),
 		Alterable_fields:      &[]string{},
 		Min_dur_low_balance:   utils.StringPointer("1"),
@@ -111,6 +112,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		SessionIndexes:      utils.StringSet{},
 		ClientProtocol:      2.5,
 		ChannelSyncInterval: 10,
+		BackupInterval:      -1,
 		TerminateAttempts:   6,
 		AlterableFields:     utils.StringSet{},
 		MinDurLowBalance:    1,
@@ -329,6 +331,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 	cfgJSONStr := `{
 	"sessions": {
           "channel_sync_interval": "1s",
+          "backup_interval": "1m",
           "session_ttl_max_delay": "3h0m0s",
           "session_ttl_last_used": "0s",
           "session_ttl_usage": "1s",
@@ -363,6 +366,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.SessionIndexesCfg:      []string{},
 		utils.ClientProtocolCfg:      1.0,
 		utils.ChannelSyncIntervalCfg: "1s",
+		utils.BackupIntervalCfg:      "1m0s",
 		utils.TerminateAttemptsCfg:   5,
 		utils.MinDurLowBalanceCfg:    "0",
 		utils.AlterableFieldsCfg:     []string{},
@@ -438,6 +442,7 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.SessionIndexesCfg:      []string{},
 		utils.ClientProtocolCfg:      2.0,
 		utils.ChannelSyncIntervalCfg: "0",
+		utils.BackupIntervalCfg:      "0",
 		utils.TerminateAttemptsCfg:   10,
 		utils.AlterableFieldsCfg:     []string{},
 		utils.STIRCfg: map[string]interface{}{
@@ -715,6 +720,7 @@ func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 				Password:         utils.StringPointer("CGRateS.org"),
 				Connect_attempts: utils.IntPointer(3),
 				Reconnects:       utils.IntPointer(5),
+				Type:             utils.StringPointer(utils.MetaAMI),
 			},
 		},
 	}
@@ -729,6 +735,7 @@ func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 			Password:        "CGRateS.org",
 			ConnectAttempts: 3,
 			Reconnects:      5,
+			Type:            utils.MetaAMI,
 		}},
 	}
 	jsonCfg := NewDefaultCGRConfig()
@@ -750,7 +757,7 @@ func TestAsteriskAgentCfgAsMapInterface(t *testing.T) {
 		utils.SessionSConnsCfg: []string{utils.MetaInternal},
 		utils.CreateCdrCfg:     false,
 		utils.AsteriskConnsCfg: []map[string]interface{}{
-			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8088", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 3, utils.ReconnectsCfg: 5},
+			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8088", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 3, utils.ReconnectsCfg: 5, utils.TypeCfg: utils.MetaARI},
 		},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -767,7 +774,7 @@ func TestAsteriskAgentCfgAsMapInterface1(t *testing.T) {
 		"sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
 		"create_cdr": true,
 		"asterisk_conns":[
-			{"address": "127.0.0.1:8089","connect_attempts": 5,"reconnects": 8}
+			{"address": "127.0.0.1:8089","connect_attempts": 5,"reconnects": 8, "type": "*ami"}
 		],
 	},
 }`
@@ -776,7 +783,7 @@ func TestAsteriskAgentCfgAsMapInterface1(t *testing.T) {
 		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
 		utils.CreateCdrCfg:     true,
 		utils.AsteriskConnsCfg: []map[string]interface{}{
-			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8089", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 5, utils.ReconnectsCfg: 8},
+			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8089", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 5, utils.ReconnectsCfg: 8, utils.TypeCfg: utils.MetaAMI},
 		},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
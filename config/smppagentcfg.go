@@ -0,0 +1,253 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SMPPAgentCfg the config section that describes the SMPP Agent
+type SMPPAgentCfg struct {
+	Enabled           bool
+	Listen            string // empty to not accept binds
+	SystemID          string
+	ClientPasswords   map[string]string
+	SMSCConns         []*SMSCConnCfg
+	ReplyTimeout      time.Duration
+	SessionSConns     []string
+	Timezone          string
+	RequestProcessors []*RequestProcessor
+}
+
+func (sa *SMPPAgentCfg) loadFromJSONCfg(jsnCfg *SMPPAgentJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		sa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen != nil {
+		sa.Listen = *jsnCfg.Listen
+	}
+	if jsnCfg.System_id != nil {
+		sa.SystemID = *jsnCfg.System_id
+	}
+	if jsnCfg.Client_passwords != nil {
+		if sa.ClientPasswords == nil {
+			sa.ClientPasswords = make(map[string]string)
+		}
+		for k, v := range *jsnCfg.Client_passwords {
+			sa.ClientPasswords[k] = v
+		}
+	}
+	if jsnCfg.Smsc_conns != nil {
+		for _, connJsn := range *jsnCfg.Smsc_conns {
+			conn := NewDefaultSMSCConnCfg()
+			var haveID bool
+			for _, connSet := range sa.SMSCConns {
+				if connJsn.ID != nil && connSet.ID == *connJsn.ID {
+					conn = connSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = conn.loadFromJSONCfg(connJsn); err != nil {
+				return
+			}
+			if !haveID {
+				sa.SMSCConns = append(sa.SMSCConns, conn)
+			}
+		}
+	}
+	if jsnCfg.Reply_timeout != nil {
+		if sa.ReplyTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reply_timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Timezone != nil {
+		sa.Timezone = *jsnCfg.Timezone
+	}
+	if jsnCfg.Sessions_conns != nil {
+		sa.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, connID := range *jsnCfg.Sessions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			sa.SessionSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				sa.SessionSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Request_processors != nil {
+		for _, reqProcJsn := range *jsnCfg.Request_processors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range sa.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				sa.RequestProcessors = append(sa.RequestProcessors, rp)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (sa *SMPPAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:      sa.Enabled,
+		utils.ListenCfg:       sa.Listen,
+		utils.SystemIDCfg:     sa.SystemID,
+		utils.ReplyTimeoutCfg: sa.ReplyTimeout.String(),
+		utils.TimezoneCfg:     sa.Timezone,
+	}
+	clientPasswords := make(map[string]string)
+	for k, v := range sa.ClientPasswords {
+		clientPasswords[k] = v
+	}
+	initialMP[utils.ClientPasswordsCfg] = clientPasswords
+
+	smscConns := make([]map[string]interface{}, len(sa.SMSCConns))
+	for i, item := range sa.SMSCConns {
+		smscConns[i] = item.AsMapInterface()
+	}
+	initialMP[utils.SMSCConnsCfg] = smscConns
+
+	requestProcessors := make([]map[string]interface{}, len(sa.RequestProcessors))
+	for i, item := range sa.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
+	}
+	initialMP[utils.RequestProcessorsCfg] = requestProcessors
+
+	if sa.SessionSConns != nil {
+		sessionSConns := make([]string, len(sa.SessionSConns))
+		for i, item := range sa.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of SMPPAgentCfg
+func (sa SMPPAgentCfg) Clone() (cln *SMPPAgentCfg) {
+	cln = &SMPPAgentCfg{
+		Enabled:      sa.Enabled,
+		Listen:       sa.Listen,
+		SystemID:     sa.SystemID,
+		ReplyTimeout: sa.ReplyTimeout,
+		Timezone:     sa.Timezone,
+	}
+	if sa.ClientPasswords != nil {
+		cln.ClientPasswords = make(map[string]string)
+		for k, v := range sa.ClientPasswords {
+			cln.ClientPasswords[k] = v
+		}
+	}
+	if sa.SMSCConns != nil {
+		cln.SMSCConns = make([]*SMSCConnCfg, len(sa.SMSCConns))
+		for i, conn := range sa.SMSCConns {
+			cln.SMSCConns[i] = conn.Clone()
+		}
+	}
+	if sa.SessionSConns != nil {
+		cln.SessionSConns = make([]string, len(sa.SessionSConns))
+		for i, con := range sa.SessionSConns {
+			cln.SessionSConns[i] = con
+		}
+	}
+	if sa.RequestProcessors != nil {
+		cln.RequestProcessors = make([]*RequestProcessor, len(sa.RequestProcessors))
+		for i, req := range sa.RequestProcessors {
+			cln.RequestProcessors[i] = req.Clone()
+		}
+	}
+	return
+}
+
+// NewDefaultSMSCConnCfg returns the SMSC connection with the default values
+func NewDefaultSMSCConnCfg() *SMSCConnCfg {
+	return &SMSCConnCfg{ReconnectInterval: 5 * time.Second}
+}
+
+// SMSCConnCfg is the bind towards a SMSC, the agent acting as ESME
+type SMSCConnCfg struct {
+	ID                string
+	Address           string
+	SystemID          string
+	Password          string
+	SystemType        string
+	ReconnectInterval time.Duration
+}
+
+func (sc *SMSCConnCfg) loadFromJSONCfg(jsnCfg *SMSCConnJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.ID != nil {
+		sc.ID = *jsnCfg.ID
+	}
+	if jsnCfg.Address != nil {
+		sc.Address = *jsnCfg.Address
+	}
+	if jsnCfg.System_id != nil {
+		sc.SystemID = *jsnCfg.System_id
+	}
+	if jsnCfg.Password != nil {
+		sc.Password = *jsnCfg.Password
+	}
+	if jsnCfg.System_type != nil {
+		sc.SystemType = *jsnCfg.System_type
+	}
+	if jsnCfg.Reconnect_interval != nil {
+		if sc.ReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconnect_interval); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (sc *SMSCConnCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.IDCfg:                sc.ID,
+		utils.AddressCfg:           sc.Address,
+		utils.SystemIDCfg:          sc.SystemID,
+		utils.Password:             sc.Password,
+		utils.SystemTypeCfg:        sc.SystemType,
+		utils.ReconnectIntervalCfg: sc.ReconnectInterval.String(),
+	}
+}
+
+// Clone returns a deep copy of SMSCConnCfg
+func (sc SMSCConnCfg) Clone() *SMSCConnCfg {
+	return &sc
+}
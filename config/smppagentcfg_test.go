@@ -0,0 +1,192 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSMPPAgentCfgloadFromJsonCfg(t *testing.T) {
+	jsnCfg := &SMPPAgentJsonCfg{
+		Enabled:          utils.BoolPointer(true),
+		Listen:           utils.StringPointer("127.0.0.1:2776"),
+		System_id:        utils.StringPointer("SMSC1"),
+		Client_passwords: &map[string]string{"esme1": "secret"},
+		Smsc_conns: &[]*SMSCConnJsonCfg{
+			{
+				ID:        utils.StringPointer("smsc1"),
+				Address:   utils.StringPointer("127.0.0.1:2775"),
+				System_id: utils.StringPointer("cgrates"),
+				Password:  utils.StringPointer("pass"),
+			},
+		},
+		Reply_timeout:  utils.StringPointer("2s"),
+		Sessions_conns: &[]string{utils.MetaInternal, "*conn1"},
+		Timezone:       utils.StringPointer("UTC"),
+		Request_processors: &[]*ReqProcessorJsnCfg{
+			{
+				ID:             utils.StringPointer("SubmitSM"),
+				Filters:        &[]string{"*string:~*vars.*cmd:submit_sm"},
+				Flags:          &[]string{utils.MetaMessage, utils.MetaAccounts},
+				Request_fields: &[]*FcTemplateJsonCfg{},
+				Reply_fields:   &[]*FcTemplateJsonCfg{},
+			},
+		},
+	}
+	expected := &SMPPAgentCfg{
+		Enabled:         true,
+		Listen:          "127.0.0.1:2776",
+		SystemID:        "SMSC1",
+		ClientPasswords: map[string]string{"esme1": "secret"},
+		SMSCConns: []*SMSCConnCfg{
+			{
+				ID:                "smsc1",
+				Address:           "127.0.0.1:2775",
+				SystemID:          "cgrates",
+				Password:          "pass",
+				ReconnectInterval: 5 * time.Second,
+			},
+		},
+		ReplyTimeout:  2 * time.Second,
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:      "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "SubmitSM",
+				Filters:       []string{"*string:~*vars.*cmd:submit_sm"},
+				Flags:         utils.FlagsWithParamsFromSlice([]string{utils.MetaMessage, utils.MetaAccounts}),
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	jsonCfg := NewDefaultCGRConfig()
+	if err = jsonCfg.smppAgentCfg.loadFromJSONCfg(jsnCfg, jsonCfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(jsonCfg.smppAgentCfg, expected) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.smppAgentCfg))
+	}
+	jsnCfg = &SMPPAgentJsonCfg{
+		Smsc_conns: &[]*SMSCConnJsonCfg{
+			{
+				ID:                 utils.StringPointer("smsc1"),
+				Reconnect_interval: utils.StringPointer("1ss"),
+			},
+		},
+	}
+	if err = jsonCfg.smppAgentCfg.loadFromJSONCfg(jsnCfg, jsonCfg.generalCfg.RSRSep); err == nil {
+		t.Error("Expected error for invalid reconnect_interval")
+	}
+}
+
+func TestSMPPAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"smpp_agent": {
+		"enabled": true,
+		"client_passwords": {"*default": "secret"},
+		"smsc_conns": [
+			{"id": "smsc1", "address": "127.0.0.1:2775", "system_id": "cgrates"},
+		],
+		"sessions_conns": ["*internal", "*conn1"],
+		"request_processors": [
+			{
+				"id": "DeliverSM",
+				"filters": ["*string:~*vars.*cmd:deliver_sm"],
+				"flags": ["*message"],
+			},
+		],
+	},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:         true,
+		utils.ListenCfg:          "127.0.0.1:2775",
+		utils.SystemIDCfg:        "CGRateS",
+		utils.ClientPasswordsCfg: map[string]string{utils.MetaDefault: "secret"},
+		utils.SMSCConnsCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:                "smsc1",
+				utils.AddressCfg:           "127.0.0.1:2775",
+				utils.SystemIDCfg:          "cgrates",
+				utils.Password:             utils.EmptyString,
+				utils.SystemTypeCfg:        utils.EmptyString,
+				utils.ReconnectIntervalCfg: "5s",
+			},
+		},
+		utils.ReplyTimeoutCfg:  "5s",
+		utils.SessionSConnsCfg: []string{utils.MetaInternal, "*conn1"},
+		utils.TimezoneCfg:      utils.EmptyString,
+		utils.RequestProcessorsCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:       "DeliverSM",
+				utils.FiltersCfg:  []string{"*string:~*vars.*cmd:deliver_sm"},
+				utils.FlagsCfg:    []string{utils.MetaMessage},
+				utils.TimezoneCfg: utils.EmptyString,
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.smppAgentCfg.AsMapInterface(utils.EmptyString); !reflect.DeepEqual(rcv, eMap) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestSMPPAgentCfgClone(t *testing.T) {
+	ban := &SMPPAgentCfg{
+		Enabled:         true,
+		Listen:          "127.0.0.1:2775",
+		SystemID:        "CGRateS",
+		ClientPasswords: map[string]string{"esme1": "secret"},
+		SMSCConns: []*SMSCConnCfg{
+			{ID: "smsc1", Address: "127.0.0.1:2776", ReconnectInterval: time.Second},
+		},
+		ReplyTimeout:  time.Second,
+		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:      "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "SubmitSM",
+				Filters:       []string{"*string:~*vars.*cmd:submit_sm"},
+				Flags:         utils.FlagsWithParamsFromSlice([]string{utils.MetaMessage}),
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	rcv := ban.Clone()
+	if !reflect.DeepEqual(ban, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(ban), utils.ToJSON(rcv))
+	}
+	if rcv.ClientPasswords["esme1"] = utils.EmptyString; ban.ClientPasswords["esme1"] != "secret" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.SMSCConns[0].ID = utils.EmptyString; ban.SMSCConns[0].ID != "smsc1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.SessionSConns[1] = utils.EmptyString; ban.SessionSConns[1] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.RequestProcessors[0].ID = utils.EmptyString; ban.RequestProcessors[0].ID != "SubmitSM" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
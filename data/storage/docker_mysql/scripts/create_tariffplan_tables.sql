@@ -90,6 +90,7 @@ CREATE TABLE `tp_rating_plans` (
   `destrates_tag` varchar(64) NOT NULL,
   `timing_tag` varchar(64) NOT NULL,
   `weight` DECIMAL(8,2) NOT NULL,
+  `currency` varchar(8) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -476,6 +477,46 @@ CREATE TABLE tp_dispatcher_hosts (
     `id`,`address`)
 );
 
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS tp_exchange_rates;
+CREATE TABLE tp_exchange_rates (
+  `pk` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL,
+  `id` varchar(8) NOT NULL,
+  `currency` varchar(8) NOT NULL,
+  `activation_time` varchar(64) NOT NULL,
+  `rate` DECIMAL(20,8) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`pk`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_tp_exchange_rates` (`tpid`,`tenant`,
+    `id`,`currency`,`activation_time`)
+);
+
+--
+-- Table structure for table `tp_lookup_tables`
+--
+
+DROP TABLE IF EXISTS tp_lookup_tables;
+CREATE TABLE tp_lookup_tables (
+  `pk` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `tenant` varchar(64) NOT NULL,
+  `id` varchar(64) NOT NULL,
+  `key` varchar(128) NOT NULL,
+  `field_name` varchar(64) NOT NULL,
+  `value` varchar(256) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`pk`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `unique_tp_lookup_tables` (`tpid`,`tenant`,
+    `id`,`key`,`field_name`)
+);
+
 --
 -- Table structure for table `versions`
 --
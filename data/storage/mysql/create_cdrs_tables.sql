@@ -50,3 +50,39 @@ CREATE TABLE session_costs (
   KEY run_origin_idx (run_id, origin_id),
   KEY deleted_at_idx (deleted_at)
 );
+
+DROP TABLE IF EXISTS invoices;
+CREATE TABLE invoices (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  tenant varchar(64) NOT NULL,
+  invoice_id varchar(64) NOT NULL,
+  account varchar(128) NOT NULL,
+  period_start TIMESTAMP NOT NULL,
+  period_end TIMESTAMP NOT NULL,
+  status varchar(16) NOT NULL,
+  `lines` MEDIUMTEXT,
+  total DECIMAL(20,4) NOT NULL,
+  replaced_by varchar(64) NOT NULL,
+  created_at TIMESTAMP NULL,
+  updated_at TIMESTAMP NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY invoiceid (tenant, invoice_id),
+  KEY account_idx (tenant, account)
+);
+
+DROP TABLE IF EXISTS balance_ledger;
+CREATE TABLE balance_ledger (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  tenant varchar(64) NOT NULL,
+  account varchar(128) NOT NULL,
+  balance_uuid varchar(64) NOT NULL,
+  balance_id varchar(128) NOT NULL,
+  balance_type varchar(64) NOT NULL,
+  old_value DECIMAL(20,4) NOT NULL,
+  new_value DECIMAL(20,4) NOT NULL,
+  source varchar(64) NOT NULL,
+  ref_id varchar(128) NOT NULL,
+  created_at TIMESTAMP NULL,
+  PRIMARY KEY (`id`),
+  KEY account_time_idx (tenant, account, created_at)
+);
@@ -57,3 +57,39 @@ DROP INDEX IF EXISTS run_origin_sessionscost_idx;
 CREATE INDEX run_origin_sessionscost_idx ON session_costs (run_id, origin_id);
 DROP INDEX IF EXISTS deleted_at_sessionscost_idx;
 CREATE INDEX deleted_at_sessionscost_idx ON session_costs (deleted_at);
+
+DROP TABLE IF EXISTS invoices;
+CREATE TABLE invoices (
+  id SERIAL PRIMARY KEY,
+  tenant VARCHAR(64) NOT NULL,
+  invoice_id VARCHAR(64) NOT NULL,
+  account VARCHAR(128) NOT NULL,
+  period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+  period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  lines jsonb,
+  total NUMERIC(20,4) NOT NULL,
+  replaced_by VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  updated_at TIMESTAMP WITH TIME ZONE NULL,
+  UNIQUE (tenant, invoice_id)
+);
+DROP INDEX IF EXISTS account_invoices_idx;
+CREATE INDEX account_invoices_idx ON invoices (tenant, account);
+
+DROP TABLE IF EXISTS balance_ledger;
+CREATE TABLE balance_ledger (
+  id SERIAL PRIMARY KEY,
+  tenant VARCHAR(64) NOT NULL,
+  account VARCHAR(128) NOT NULL,
+  balance_uuid VARCHAR(64) NOT NULL,
+  balance_id VARCHAR(128) NOT NULL,
+  balance_type VARCHAR(64) NOT NULL,
+  old_value NUMERIC(20,4) NOT NULL,
+  new_value NUMERIC(20,4) NOT NULL,
+  source VARCHAR(64) NOT NULL,
+  ref_id VARCHAR(128) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE
+);
+DROP INDEX IF EXISTS account_time_ledger_idx;
+CREATE INDEX account_time_ledger_idx ON balance_ledger (tenant, account, created_at);
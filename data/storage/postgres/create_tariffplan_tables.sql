@@ -86,6 +86,7 @@ CREATE TABLE tp_rating_plans (
   destrates_tag VARCHAR(64) NOT NULL,
   timing_tag VARCHAR(64) NOT NULL,
   weight NUMERIC(8,2) NOT NULL,
+  currency VARCHAR(8) NOT NULL,
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE (tpid, tag, destrates_tag, timing_tag)
 );
@@ -284,6 +285,10 @@ CREATE TABLE tp_stats (
   "blocker" BOOLEAN NOT NULL,
   "weight" decimal(8,2) NOT NULL,
   "threshold_ids" varchar(64) NOT NULL,
+  "window_type" varchar(16) NOT NULL,
+  "window_size" varchar(32) NOT NULL,
+  "window_slide" varchar(32) NOT NULL,
+  "window_count" INTEGER NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
 );
 CREATE INDEX tp_stats_idx ON tp_stats (tpid);
@@ -462,6 +467,44 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
   CREATE INDEX tp_dispatcher_hosts_unique ON tp_dispatcher_hosts  ("tpid",  "tenant", "id",
     "address");
 
+--
+-- Table structure for table `tp_exchange_rates`
+--
+
+DROP TABLE IF EXISTS tp_exchange_rates;
+CREATE TABLE tp_exchange_rates (
+  "pk" SERIAL PRIMARY KEY,
+  "tpid" varchar(64) NOT NULL,
+  "tenant" varchar(64) NOT NULL,
+  "id" varchar(8) NOT NULL,
+  "currency" varchar(8) NOT NULL,
+  "activation_time" varchar(64) NOT NULL,
+  "rate" NUMERIC(20,8) NOT NULL,
+  "created_at" TIMESTAMP WITH TIME ZONE
+  );
+  CREATE INDEX tp_exchange_rates_ids ON tp_exchange_rates (tpid);
+  CREATE INDEX tp_exchange_rates_unique ON tp_exchange_rates  ("tpid",  "tenant", "id",
+    "currency", "activation_time");
+
+--
+-- Table structure for table `tp_lookup_tables`
+--
+
+DROP TABLE IF EXISTS tp_lookup_tables;
+CREATE TABLE tp_lookup_tables (
+  "pk" SERIAL PRIMARY KEY,
+  "tpid" varchar(64) NOT NULL,
+  "tenant" varchar(64) NOT NULL,
+  "id" varchar(64) NOT NULL,
+  "key" varchar(128) NOT NULL,
+  "field_name" varchar(64) NOT NULL,
+  "value" varchar(256) NOT NULL,
+  "created_at" TIMESTAMP WITH TIME ZONE
+  );
+  CREATE INDEX tp_lookup_tables_ids ON tp_lookup_tables (tpid);
+  CREATE INDEX tp_lookup_tables_unique ON tp_lookup_tables  ("tpid",  "tenant", "id",
+    "key", "field_name");
+
 
 
 --
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -39,6 +40,7 @@ func NewDispatcherService(dm *engine.DataManager,
 		cfg:     cfg,
 		fltrS:   fltrS,
 		connMgr: connMgr,
+		health:  newHostsHealth(),
 	}
 }
 
@@ -49,6 +51,75 @@ type DispatcherService struct {
 	cfg     *config.CGRConfig
 	fltrS   *engine.FilterS
 	connMgr *engine.ConnManager
+	health  *hostsHealth // status of the DispatcherHosts as found by the health checks
+}
+
+// ListenAndServe runs the health checks of the DispatcherHosts until the stopChan is closed
+func (dS *DispatcherService) ListenAndServe(stopChan chan struct{}) {
+	checkInterval := dS.cfg.DispatcherSCfg().HealthCheckInterval
+	if checkInterval <= 0 {
+		return
+	}
+	for {
+		dS.checkHosts()
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(checkInterval):
+		}
+	}
+}
+
+// checkHosts pings all the DispatcherHosts in parallel, taking out of rotation the ones not answering
+func (dS *DispatcherService) checkHosts() {
+	keys, err := dS.dm.DataDB().GetKeysForPrefix(utils.DispatcherHostPrefix)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed querying DispatcherHosts for health checks, error: %s",
+			utils.DispatcherS, err.Error()))
+		return
+	}
+	dHs := make([]*engine.DispatcherHost, 0, len(keys))
+	for _, key := range keys {
+		tntID := utils.NewTenantID(key[len(utils.DispatcherHostPrefix):])
+		dH, err := dS.dm.GetDispatcherHost(tntID.Tenant, tntID.ID, true, true, utils.NonTransactional)
+		if err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving DispatcherHost <%s> for health checks, error: %s",
+				utils.DispatcherS, tntID.TenantID(), err.Error()))
+			continue
+		}
+		dHs = append(dHs, dH)
+	}
+	statuses := make([]*HostStatus, len(dHs))
+	var wg sync.WaitGroup
+	for i, dH := range dHs {
+		wg.Add(1)
+		go func(i int, dH *engine.DispatcherHost) { // one unreachable host should not delay the others
+			defer wg.Done()
+			hs := &HostStatus{Alive: true, LastCheck: time.Now()}
+			var rply string
+			if err := dH.Call(utils.CoreSv1Ping, &utils.CGREvent{Tenant: dH.Tenant}, &rply); err != nil {
+				if dS.health.isAlive(dH.Tenant, dH.ID) {
+					utils.Logger.Warning(fmt.Sprintf("<%s> taking DispatcherHost <%s> out of rotation, error: %s",
+						utils.DispatcherS, dH.TenantID(), err.Error()))
+				}
+				hs.Alive = false
+				hs.Error = err.Error()
+			} else if !dS.health.isAlive(dH.Tenant, dH.ID) {
+				utils.Logger.Info(fmt.Sprintf("<%s> putting DispatcherHost <%s> back in rotation",
+					utils.DispatcherS, dH.TenantID()))
+			}
+			statuses[i] = hs
+		}(i, dH)
+	}
+	wg.Wait()
+	hosts := make(map[string]map[string]*HostStatus)
+	for i, dH := range dHs {
+		if _, has := hosts[dH.Tenant]; !has {
+			hosts[dH.Tenant] = make(map[string]*HostStatus)
+		}
+		hosts[dH.Tenant][dH.ID] = statuses[i]
+	}
+	dS.health.setHosts(hosts)
 }
 
 // Shutdown is called to shutdown the service
@@ -211,7 +282,7 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 		if err = engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil, true, utils.EmptyString); err != nil {
 			return utils.NewErrDispatcherS(err)
 		}
-		if err = d.Dispatch(dS.dm, dS.fltrS, evNm, tnt, utils.IfaceAsString(ev.APIOpts[utils.OptsRouteID]), subsys, serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
+		if err = d.Dispatch(dS.dm, dS.fltrS, dS.health, evNm, tnt, utils.IfaceAsString(ev.APIOpts[utils.OptsRouteID]), subsys, serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
 			return
 		}
 	}
@@ -407,3 +478,18 @@ func (dS *DispatcherService) DispatcherSv1RemotePing(args *utils.CGREvent, reply
 	}
 	return dS.Dispatch(args, utils.MetaCore, utils.CoreSv1Ping, args, reply)
 }
+
+// DispatcherSv1GetHostsStatus returns the status of the DispatcherHosts as found by the health checks
+func (dS *DispatcherService) DispatcherSv1GetHostsStatus(args *utils.TenantWithAPIOpts,
+	reply *map[string]*HostStatus) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	hosts := dS.health.getHosts(tnt)
+	if len(hosts) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = hosts
+	return
+}
@@ -1753,3 +1753,44 @@ func TestDispatchersdispatcherProfileForEventAnySStrueBothFound(t *testing.T) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", dsp1, rcv)
 	}
 }
+
+func TestDispatcherSv1GetHostsStatus(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	gCfg := config.CgrConfig().GeneralCfg()
+	connAttempts, reconnects := gCfg.ConnectAttempts, gCfg.Reconnects
+	gCfg.ConnectAttempts, gCfg.Reconnects = 1, 0
+	defer func() {
+		gCfg.ConnectAttempts, gCfg.Reconnects = connAttempts, reconnects
+	}()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	for _, id := range []string{"DSP_DOWN", "DSP_DOWN2"} {
+		if err := dm.SetDispatcherHost(&engine.DispatcherHost{
+			Tenant: "cgrates.org",
+			RemoteHost: &config.RemoteHost{
+				ID:        id,
+				Address:   "127.0.0.1:1",
+				Transport: utils.MetaJSON,
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dS := NewDispatcherService(dm, cfg, nil, nil)
+	var reply map[string]*HostStatus
+	if err := dS.DispatcherSv1GetHostsStatus(&utils.TenantWithAPIOpts{}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected error: %v, received: %v", utils.ErrNotFound, err)
+	}
+	dS.checkHosts()
+	if err := dS.DispatcherSv1GetHostsStatus(&utils.TenantWithAPIOpts{}, &reply); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, id := range []string{"DSP_DOWN", "DSP_DOWN2"} {
+			if hs, has := reply[id]; !has || hs.Alive || hs.Error == utils.EmptyString {
+				t.Errorf("Expected the host %s out of rotation, received: %s", id, utils.ToJSON(reply))
+			}
+		}
+	}
+	if dS.health.isAlive("cgrates.org", "DSP_DOWN") {
+		t.Error("Expected the host out of rotation")
+	}
+}
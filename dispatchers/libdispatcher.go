@@ -21,9 +21,13 @@ package dispatchers
 import (
 	"encoding/gob"
 	"fmt"
+	"hash/crc32"
 	"math/rand"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -40,7 +44,7 @@ func init() {
 // there will be different implementations based on strategy
 type Dispatcher interface {
 	// Dispatch is used to send the method over the connections given
-	Dispatch(dm *engine.DataManager, flts *engine.FilterS,
+	Dispatch(dm *engine.DataManager, flts *engine.FilterS, hh *hostsHealth,
 		ev utils.DataProvider, tnt, routeID, subsystem string,
 		serviceMethod string, args interface{}, reply interface{}) (err error)
 }
@@ -56,6 +60,10 @@ func newDispatcher(pfl *engine.DispatcherProfile) (d Dispatcher, err error) {
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(randomSort))
 	case utils.MetaRoundRobin:
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(roundRobinSort))
+	case utils.MetaWeightedRoundRobin:
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), newWeightedRoundRobinSort())
+	case utils.MetaHash:
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), newHashSort(hosts, pfl.StrategyParams))
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -124,6 +132,129 @@ func (rs *roundRobinSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt
 	return getDispatcherHosts(fltrs, ev, tnt, dh)
 }
 
+func newWeightedRoundRobinSort() *weightedRoundRobinSort {
+	return &weightedRoundRobinSort{currentWeights: make(map[string]float64)}
+}
+
+// weightedRoundRobinSort is the smooth weighted round robin
+// each host is selected first proportionally to its weight while the others are kept for failover
+// the hosts without a positive weight are used only for failover
+type weightedRoundRobinSort struct {
+	sync.Mutex
+	currentWeights map[string]float64
+}
+
+func (ws *weightedRoundRobinSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	selIdx := -1
+	var totalWeight float64
+	ws.Lock()
+	for i, host := range hosts {
+		if host.Weight <= 0 {
+			continue
+		}
+		ws.currentWeights[host.ID] += host.Weight
+		totalWeight += host.Weight
+		if selIdx == -1 ||
+			ws.currentWeights[host.ID] > ws.currentWeights[hosts[selIdx].ID] {
+			selIdx = i
+		}
+	}
+	if selIdx != -1 {
+		ws.currentWeights[hosts[selIdx].ID] -= totalWeight
+	}
+	ws.Unlock()
+	if selIdx == -1 { // no weights to balance on
+		return getDispatcherHosts(fltrs, ev, tnt, hosts)
+	}
+	dh := make(engine.DispatcherHostProfiles, 0, len(hosts))
+	dh = append(dh, hosts[selIdx])
+	dh = append(dh, hosts[:selIdx]...)
+	dh = append(dh, hosts[selIdx+1:]...)
+	return getDispatcherHosts(fltrs, ev, tnt, dh)
+}
+
+// hashRingReplicas is the number of virtual nodes each host has on the hash ring
+const hashRingReplicas = 100
+
+// hashRingNode is one virtual node on the hash ring
+type hashRingNode struct {
+	hash   uint32
+	hostID string
+}
+
+// newHashSort builds the hash ring out of the hosts
+// the hashed field is taken from the *hash_field strategy parameter
+// or from the first one if the profile was loaded from .csv
+func newHashSort(hosts engine.DispatcherHostProfiles, params map[string]interface{}) (hs *hashSort) {
+	fldPath := utils.MetaReq + utils.NestingSep + utils.OriginID
+	if fld, has := params[utils.MetaHashField]; has {
+		fldPath = utils.IfaceAsString(fld)
+	} else if fld, has := params["0"]; has {
+		fldPath = utils.IfaceAsString(fld)
+	}
+	fldPath = strings.TrimPrefix(fldPath, utils.DynamicDataPrefix)
+	if !strings.HasPrefix(fldPath, utils.Meta) { // plain field names are considered from the event
+		fldPath = utils.MetaReq + utils.NestingSep + fldPath
+	}
+	hs = &hashSort{
+		fldPath: strings.Split(fldPath, utils.NestingSep),
+		ring:    make([]*hashRingNode, 0, len(hosts)*hashRingReplicas),
+	}
+	for _, host := range hosts {
+		for i := 0; i < hashRingReplicas; i++ {
+			hs.ring = append(hs.ring, &hashRingNode{
+				hash:   crc32.ChecksumIEEE([]byte(host.ID + utils.InInFieldSep + strconv.Itoa(i))),
+				hostID: host.ID,
+			})
+		}
+	}
+	sort.Slice(hs.ring, func(i, j int) bool {
+		return hs.ring[i].hash < hs.ring[j].hash
+	})
+	return
+}
+
+// hashSort uses consistent hashing on an event field so the same value is sent to the same host
+// adding or removing a host moves only the keys of that host
+type hashSort struct {
+	fldPath []string
+	ring    []*hashRingNode // sorted by hash
+}
+
+func (hs *hashSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	var key string
+	if ev != nil {
+		key, _ = ev.FieldAsString(hs.fldPath)
+	}
+	if key == utils.EmptyString ||
+		len(hs.ring) == 0 { // nothing to hash on, keep the weight order
+		return getDispatcherHosts(fltrs, ev, tnt, hosts)
+	}
+	hostsByID := make(map[string]*engine.DispatcherHostProfile, len(hosts))
+	for _, host := range hosts {
+		hostsByID[host.ID] = host
+	}
+	dh := make(engine.DispatcherHostProfiles, 0, len(hosts))
+	keyHash := crc32.ChecksumIEEE([]byte(key))
+	startIdx := sort.Search(len(hs.ring), func(i int) bool {
+		return hs.ring[i].hash >= keyHash
+	})
+	// walk the ring clockwise so the failover order is consistent as well
+	for i := 0; i < len(hs.ring) && len(dh) != len(hostsByID); i++ {
+		hostID := hs.ring[(startIdx+i)%len(hs.ring)].hostID
+		if host, has := hostsByID[hostID]; has {
+			dh = append(dh, host)
+			delete(hostsByID, hostID)
+		}
+	}
+	for _, host := range hosts { // hosts not present on the ring are used last
+		if _, has := hostsByID[host.ID]; has {
+			dh = append(dh, host)
+		}
+	}
+	return getDispatcherHosts(fltrs, ev, tnt, dh)
+}
+
 func newSingleDispatcher(hosts engine.DispatcherHostProfiles, params map[string]interface{}, tntID string, sorter hostSorter) (_ Dispatcher, err error) {
 	if dflt, has := params[utils.MetaDefaultRatio]; has {
 		var ratio int64
@@ -158,7 +289,7 @@ type singleResultDispatcher struct {
 	hosts  engine.DispatcherHostProfiles
 }
 
-func (sd *singleResultDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
+func (sd *singleResultDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS, hh *hostsHealth,
 	ev utils.DataProvider, tnt, routeID, subsystem string,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	var dH *engine.DispatcherHost
@@ -169,13 +300,15 @@ func (sd *singleResultDispatcher) Dispatch(dm *engine.DataManager, flts *engine.
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			if err = dH.Call(serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
-				return
+			if hh.isAlive(tnt, dH.ID) { // do not stick to a host taken out of rotation
+				if err = dH.Call(serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
 	var hostIDs []string
-	if hostIDs, err = sd.sorter.Sort(flts, ev, tnt, sd.hosts); err != nil {
+	if hostIDs, err = sd.sorter.Sort(flts, ev, tnt, hh.aliveHosts(tnt, sd.hosts)); err != nil {
 		return
 	}
 	var called bool
@@ -214,11 +347,11 @@ type broadcastDispatcher struct {
 	hosts    engine.DispatcherHostProfiles
 }
 
-func (b *broadcastDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
+func (b *broadcastDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS, hh *hostsHealth,
 	ev utils.DataProvider, tnt, routeID, subsystem string,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	var hostIDs []string
-	if hostIDs, err = getDispatcherHosts(flts, ev, tnt, b.hosts); err != nil {
+	if hostIDs, err = getDispatcherHosts(flts, ev, tnt, hh.aliveHosts(tnt, b.hosts)); err != nil {
 		return
 	}
 	var hasHosts bool
@@ -250,7 +383,7 @@ type loadDispatcher struct {
 	hosts        engine.DispatcherHostProfiles
 }
 
-func (ld *loadDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
+func (ld *loadDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS, hh *hostsHealth,
 	ev utils.DataProvider, tnt, routeID, subsystem string,
 	serviceMethod string, args interface{}, reply interface{}) (err error) {
 	var dH *engine.DispatcherHost
@@ -271,16 +404,18 @@ func (ld *loadDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
 			routeID); ok && x != nil {
 			dH = x.(*engine.DispatcherHost)
-			lM.incrementLoad(dH.ID, ld.tntID)
-			err = dH.Call(serviceMethod, args, reply)
-			lM.decrementLoad(dH.ID, ld.tntID) // call ended
-			if !rpcclient.IsNetworkError(err) {
-				return
+			if hh.isAlive(tnt, dH.ID) { // do not stick to a host taken out of rotation
+				lM.incrementLoad(dH.ID, ld.tntID)
+				err = dH.Call(serviceMethod, args, reply)
+				lM.decrementLoad(dH.ID, ld.tntID) // call ended
+				if !rpcclient.IsNetworkError(err) {
+					return
+				}
 			}
 		}
 	}
 	var hostIDs []string
-	if hostIDs, err = ld.sorter.Sort(flts, ev, tnt, lM.getHosts(ld.hosts)); err != nil {
+	if hostIDs, err = ld.sorter.Sort(flts, ev, tnt, hh.aliveHosts(tnt, lM.getHosts(ld.hosts))); err != nil {
 		return
 	}
 	var called bool
@@ -390,3 +525,73 @@ func (lM *LoadMetrics) decrementLoad(hostID, tntID string) {
 	engine.Cache.ReplicateSet(utils.CacheDispatcherLoads, tntID, lM)
 	lM.mutex.Unlock()
 }
+
+// HostStatus is the status of one DispatcherHost as found by the health checks
+type HostStatus struct {
+	Alive     bool
+	LastCheck time.Time
+	Error     string
+}
+
+func newHostsHealth() *hostsHealth {
+	return &hostsHealth{hosts: make(map[string]map[string]*HostStatus)}
+}
+
+// hostsHealth is the registry of the health checks results indexed on tenant and host ID
+// a nil registry considers all the hosts alive
+type hostsHealth struct {
+	sync.RWMutex
+	hosts map[string]map[string]*HostStatus
+}
+
+// isAlive returns false only for the hosts that failed the last health check
+func (hh *hostsHealth) isAlive(tnt, hostID string) bool {
+	if hh == nil {
+		return true
+	}
+	hh.RLock()
+	defer hh.RUnlock()
+	hs, has := hh.hosts[tnt][hostID]
+	return !has || hs.Alive
+}
+
+// aliveHosts returns the hosts not taken out of rotation by the health checks
+func (hh *hostsHealth) aliveHosts(tnt string, hosts engine.DispatcherHostProfiles) engine.DispatcherHostProfiles {
+	if hh == nil {
+		return hosts
+	}
+	hh.RLock()
+	defer hh.RUnlock()
+	if len(hh.hosts[tnt]) == 0 {
+		return hosts
+	}
+	alive := make(engine.DispatcherHostProfiles, 0, len(hosts))
+	for _, host := range hosts {
+		if hs, has := hh.hosts[tnt][host.ID]; !has || hs.Alive {
+			alive = append(alive, host)
+		}
+	}
+	return alive
+}
+
+// setHosts replaces the status of all hosts after a round of health checks
+func (hh *hostsHealth) setHosts(hosts map[string]map[string]*HostStatus) {
+	hh.Lock()
+	hh.hosts = hosts
+	hh.Unlock()
+}
+
+// getHosts returns a copy of the hosts status for one tenant
+func (hh *hostsHealth) getHosts(tnt string) (hosts map[string]*HostStatus) {
+	if hh == nil {
+		return
+	}
+	hh.RLock()
+	defer hh.RUnlock()
+	hosts = make(map[string]*HostStatus, len(hh.hosts[tnt]))
+	for hostID, hs := range hh.hosts[tnt] {
+		hsCln := *hs
+		hosts[hostID] = &hsCln
+	}
+	return
+}
@@ -21,6 +21,7 @@ package dispatchers
 import (
 	"net/rpc"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/cgrates/cgrates/config"
@@ -388,7 +389,7 @@ func TestLibDispatcherSingleResultDispatcherDispatch(t *testing.T) {
 	wgDsp := &singleResultDispatcher{sorter: new(noSort)}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -399,7 +400,7 @@ func TestLibDispatcherSingleResultDispatcherDispatchRouteID(t *testing.T) {
 	wgDsp := &singleResultDispatcher{sorter: new(roundRobinSort)}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "routeID", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "routeID", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -410,7 +411,7 @@ func TestLibDispatcherBroadcastDispatcherDispatch(t *testing.T) {
 	wgDsp := &broadcastDispatcher{hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -421,7 +422,7 @@ func TestLibDispatcherBroadcastDispatcherDispatchRouteID(t *testing.T) {
 	wgDsp := &broadcastDispatcher{hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "routeID", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "routeID", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -432,7 +433,7 @@ func TestLibDispatcherLoadDispatcherDispatch(t *testing.T) {
 	wgDsp := &loadDispatcher{sorter: new(randomSort)}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -449,7 +450,7 @@ func TestLibDispatcherLoadDispatcherDispatchHostsID(t *testing.T) {
 	}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "routeID", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "routeID", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -474,7 +475,7 @@ func TestLibDispatcherLoadStrategyDispatchCaseHosts(t *testing.T) {
 	}
 	dataDB := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
 	dM := engine.NewDataManager(dataDB, config.CgrConfig().CacheCfg(), nil)
-	err := wgDsp.Dispatch(dM, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(dM, nil, nil, nil, "", "", "", "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -497,7 +498,7 @@ func TestLibDispatcherLoadStrategyDispatchCaseHostsError(t *testing.T) {
 		defaultRatio: 1,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(nil, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "", "", "", "", "", "")
 	expected := "DISPATCHER_ERROR:NO_DATABASE_CONNECTION"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -527,7 +528,7 @@ func TestLibDispatcherLoadStrategyDispatchCaseHostsCastError(t *testing.T) {
 		defaultRatio: 1,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(nil, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "", "", "", "", "", "")
 	expected := "cannot cast false to *LoadMetrics"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -552,7 +553,7 @@ func TestLibDispatcherLoadStrategyDispatchCaseHostsCastError2(t *testing.T) {
 		defaultRatio: 1,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(nil, nil, nil, "", "", "", "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "", "", "", "", "", "")
 	expected := "cannot convert field<bool>: false to int"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -577,7 +578,7 @@ func TestLibDispatcherSingleResultDispatcherCastError(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherRoutes, "testID:*attributes",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "", "testID", utils.MetaAttributes, "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "", "testID", utils.MetaAttributes, "", "", "")
 	expected := "DISPATCHER_ERROR:NO_DATABASE_CONNECTION"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -615,7 +616,7 @@ func TestLibDispatcherSingleResultDispatcherCastError2(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherRoutes, "testID:*attributes",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	expected := "UNSUPPORTED_SERVICE_METHOD"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -642,7 +643,7 @@ func TestLibDispatcherBroadcastDispatcherDispatchError1(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherRoutes, "testID:*attributes",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &broadcastDispatcher{hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
 	expected := "DISPATCHER_ERROR:NO_DATABASE_CONNECTION"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -660,7 +661,7 @@ func TestLibDispatcherBroadcastDispatcherDispatchError2(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
 		nil, nil, true, utils.NonTransactional)
 	wgDsp := &broadcastDispatcher{hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -686,7 +687,7 @@ func TestLibDispatcherBroadcastDispatcherDispatchError3(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &broadcastDispatcher{hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
 	if err != nil {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
 	}
@@ -711,7 +712,7 @@ func TestLibDispatcherLoadDispatcherCacheError(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherRoutes, "testID:*attributes",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &loadDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, "", "", "")
 	expected := "HOST_NOT_FOUND"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -743,7 +744,7 @@ func TestLibDispatcherLoadDispatcherCacheError2(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherRoutes, "testID:*attributes",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &loadDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	expected := "UNSUPPORTED_SERVICE_METHOD"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -800,7 +801,7 @@ func TestLibDispatcherLoadDispatcherCacheError3(t *testing.T) {
 		defaultRatio: 0,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(dm, nil, nil, "testTENANT", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(dm, nil, nil, nil, "testTENANT", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	if err != nil {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
 	}
@@ -868,7 +869,7 @@ func TestLibDispatcherLoadDispatcherCacheError4(t *testing.T) {
 		defaultRatio: 0,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(dm, nil, nil, "testTENANT", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(dm, nil, nil, nil, "testTENANT", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	expected := "DISCONNECTED"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -921,7 +922,7 @@ func TestLibDispatcherLoadDispatcherCacheError5(t *testing.T) {
 		defaultRatio: 0,
 		sorter:       new(noSort),
 	}
-	err := wgDsp.Dispatch(nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(nil, nil, nil, nil, "testTenant", "testID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	if err == nil {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", "connection is shut down", err)
 	}
@@ -951,7 +952,7 @@ func TestLibDispatcherSingleResultDispatcherCase1(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(dm, nil, nil, "testTenant", "", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(dm, nil, nil, nil, "testTenant", "", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	if err == nil {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", "connection is shut down", err)
 	}
@@ -988,7 +989,7 @@ func TestLibDispatcherSingleResultDispatcherCase2(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(dm, nil, nil, "testTenant", "routeID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(dm, nil, nil, nil, "testTenant", "routeID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	if err != nil {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
 	}
@@ -1035,7 +1036,7 @@ func TestLibDispatcherSingleResultDispatcherCase3(t *testing.T) {
 	engine.Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, "testTenant:testID",
 		value, nil, true, utils.NonTransactional)
 	wgDsp := &singleResultDispatcher{sorter: new(noSort), hosts: engine.DispatcherHostProfiles{{ID: "testID"}}}
-	err := wgDsp.Dispatch(dm, nil, nil, "testTenant", "routeID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
+	err := wgDsp.Dispatch(dm, nil, nil, nil, "testTenant", "routeID", utils.MetaAttributes, utils.AttributeSv1Ping, &utils.CGREvent{}, &wgDsp)
 	expected := "DISCONNECTED"
 	if err == nil || err.Error() != expected {
 		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", expected, err)
@@ -1055,7 +1056,7 @@ func TestLibDispatcherDispatchFilterError(t *testing.T) {
 		}},
 	}
 	expErrMsg := "inline parse error for string: <*wrongType>"
-	if err := dsp.Dispatch(nil, flts, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
+	if err := dsp.Dispatch(nil, flts, nil, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
 		t.Errorf("Expected error: %s received: %v", expErrMsg, err)
 	}
 	dsp = &loadDispatcher{
@@ -1066,7 +1067,7 @@ func TestLibDispatcherDispatchFilterError(t *testing.T) {
 		}},
 		defaultRatio: 1,
 	}
-	if err := dsp.Dispatch(nil, flts, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
+	if err := dsp.Dispatch(nil, flts, nil, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
 		t.Errorf("Expected error: %s received: %v", expErrMsg, err)
 	}
 	dsp = &broadcastDispatcher{
@@ -1075,7 +1076,7 @@ func TestLibDispatcherDispatchFilterError(t *testing.T) {
 			FilterIDs: []string{"*wrongType"},
 		}},
 	}
-	if err := dsp.Dispatch(nil, flts, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
+	if err := dsp.Dispatch(nil, flts, nil, nil, "", "", "", "", "", ""); err == nil || err.Error() != expErrMsg {
 		t.Errorf("Expected error: %s received: %v", expErrMsg, err)
 	}
 }
@@ -1090,7 +1091,7 @@ func TestLibDispatcherDispatchHostNotFound(t *testing.T) {
 			ID: "testID",
 		}},
 	}
-	if err := dsp.Dispatch(db, flts, nil, "", "", "", "", "", ""); err != utils.ErrHostNotFound {
+	if err := dsp.Dispatch(db, flts, nil, nil, "", "", "", "", "", ""); err != utils.ErrHostNotFound {
 		t.Errorf("Expected error: %s received: %v", utils.ErrHostNotFound, err)
 	}
 }
@@ -1136,3 +1137,161 @@ func TestLibDispatcherRoundRobinSort(t *testing.T) {
 		t.Errorf("Expected: %q, received: %q", expHostIDs2, hostIDs)
 	}
 }
+
+func TestLibDispatcherNewDispatcherMetaHash(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Hosts: engine.DispatcherHostProfiles{
+			{ID: "DSP_1"},
+		},
+		Strategy:       utils.MetaHash,
+		StrategyParams: map[string]interface{}{utils.MetaHashField: "~*req.Account"},
+	}
+	result, err := newDispatcher(pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorter, canCast := result.(*singleResultDispatcher).sorter.(*hashSort)
+	if !canCast {
+		t.Fatalf("Expected *hashSort, received: %T", result.(*singleResultDispatcher).sorter)
+	}
+	if exp := []string{utils.MetaReq, utils.AccountField}; !reflect.DeepEqual(exp, sorter.fldPath) {
+		t.Errorf("Expected: %q, received: %q", exp, sorter.fldPath)
+	}
+	if len(sorter.ring) != hashRingReplicas {
+		t.Errorf("Expected %d nodes on the ring, received: %d", hashRingReplicas, len(sorter.ring))
+	}
+	// field from the .csv strategy parameters
+	pfl.StrategyParams = map[string]interface{}{"0": "Subject"}
+	if result, err = newDispatcher(pfl); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{utils.MetaReq, utils.Subject}; !reflect.DeepEqual(exp,
+		result.(*singleResultDispatcher).sorter.(*hashSort).fldPath) {
+		t.Errorf("Expected: %q, received: %q", exp, result.(*singleResultDispatcher).sorter.(*hashSort).fldPath)
+	}
+}
+
+func TestLibDispatcherHashSort(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "DSP_1"},
+		{ID: "DSP_2"},
+		{ID: "DSP_3"},
+	}
+	sorter := newHashSort(hosts, nil)
+	routes := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.OriginID: "call" + strconv.Itoa(i)}}
+		hostIDs, err := sorter.Sort(flts, ev, "cgrates.org", hosts)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(hostIDs) != len(hosts) {
+			t.Fatalf("Expected all hosts for failover, received: %q", hostIDs)
+		}
+		routes["call"+strconv.Itoa(i)] = hostIDs[0]
+	}
+	perHost := make(map[string]int)
+	for _, hostID := range routes {
+		perHost[hostID]++
+	}
+	if len(perHost) != len(hosts) {
+		t.Errorf("Expected the keys spread on all hosts, received: %+v", perHost)
+	}
+	// adding a host should move keys only towards the new one
+	hostsNew := append(hosts.Clone(), &engine.DispatcherHostProfile{ID: "DSP_4"})
+	sorterNew := newHashSort(hostsNew, nil)
+	var moved int
+	for key, hostID := range routes {
+		ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.OriginID: key}}
+		hostIDs, err := sorterNew.Sort(flts, ev, "cgrates.org", hostsNew)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hostIDs[0] == hostID {
+			continue
+		}
+		if hostIDs[0] != "DSP_4" {
+			t.Fatalf("Key %q moved from %q to %q", key, hostID, hostIDs[0])
+		}
+		moved++
+	}
+	if moved == 0 || moved > len(routes)/2 {
+		t.Errorf("Unexpected number of moved keys: %d", moved)
+	}
+	// without the field the hosts are kept in their order
+	exp := engine.DispatcherHostIDs{"DSP_1", "DSP_2", "DSP_3"}
+	if hostIDs, err := sorter.Sort(flts, utils.MapStorage{}, "cgrates.org", hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", exp, hostIDs)
+	}
+}
+
+func TestLibDispatcherWeightedRoundRobinSort(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	sorter := newWeightedRoundRobinSort()
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1", Weight: 3},
+		{ID: "testID2", Weight: 1},
+		{ID: "testID3"},
+	}
+	expFirst := []string{"testID1", "testID1", "testID2", "testID1",
+		"testID1", "testID1", "testID2", "testID1"}
+	for i, exp := range expFirst {
+		if hostIDs, err := sorter.Sort(flts, nil, "", hosts); err != nil {
+			t.Fatal(err)
+		} else if len(hostIDs) != len(hosts) {
+			t.Errorf("Expected all hosts for failover, received: %q", hostIDs)
+		} else if hostIDs[0] != exp {
+			t.Errorf("Sort %d expected first: %q, received: %q", i, exp, hostIDs)
+		}
+	}
+	// the cycle starts again, the third host is used only for failover
+	sorter.Sort(flts, nil, "", hosts)
+	sorter.Sort(flts, nil, "", hosts)
+	expHostIDs := engine.DispatcherHostIDs{"testID2", "testID1", "testID3"}
+	if hostIDs, err := sorter.Sort(flts, nil, "", hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expHostIDs, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", expHostIDs, hostIDs)
+	}
+}
+
+func TestLibDispatcherHostsHealth(t *testing.T) {
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1", Blocker: true},
+		{ID: "testID2"},
+	}
+	hh := newHostsHealth()
+	hh.setHosts(map[string]map[string]*HostStatus{
+		"cgrates.org": {
+			"testID1": {Alive: false, Error: utils.ErrDisconnected.Error()},
+			"testID2": {Alive: true},
+		},
+	})
+	exp := engine.DispatcherHostProfiles{{ID: "testID2"}}
+	if rcv := hh.aliveHosts("cgrates.org", hosts); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	// the status is per tenant
+	if rcv := hh.aliveHosts("itsyscom.com", hosts); !reflect.DeepEqual(hosts, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(hosts), utils.ToJSON(rcv))
+	}
+	if hh.isAlive("cgrates.org", "testID1") || !hh.isAlive("itsyscom.com", "testID1") {
+		t.Error("Unexpected status for testID1")
+	}
+	expStatus := map[string]*HostStatus{
+		"testID1": {Alive: false, Error: utils.ErrDisconnected.Error()},
+		"testID2": {Alive: true},
+	}
+	if rcv := hh.getHosts("cgrates.org"); !reflect.DeepEqual(expStatus, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(expStatus), utils.ToJSON(rcv))
+	}
+	// without health checks all the hosts are in rotation
+	hh = nil
+	if rcv := hh.aliveHosts("cgrates.org", hosts); !reflect.DeepEqual(hosts, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(hosts), utils.ToJSON(rcv))
+	}
+}
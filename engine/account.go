@@ -39,9 +39,14 @@ type Account struct {
 	UnitCounters      UnitCounters
 	ActionTriggers    ActionTriggers
 	AllowNegative     bool
+	CreditLimits      map[string]float64 // how far under zero the balances can go, indexed on balance type (only *monetary)
 	Disabled          bool
 	UpdateTime        time.Time
+	Reservations      []*BalanceReservation // amounts held out of the balances
 	executingTriggers bool
+	ledgerSource      string // source of the balance changes recorded into the ledger
+	ledgerRefID       string
+	ledgerOld         *Account // balances as stored, the ledger entries are the changes against them
 }
 
 type AccountWithAPIOpts struct {
@@ -212,7 +217,11 @@ func (acc *Account) debitBalanceAction(a *Action, reset, resetIfNegative bool, f
 			if reset || (resetIfNegative && b.Value < 0) {
 				b.SetValue(0)
 			}
+			limitReached := acc.exceedsCreditLimit(balanceType, b.GetValue()-bClone.GetValue())
 			b.SubstractValue(bClone.GetValue())
+			if limitReached { // not a prepaid debit, only signal it
+				acc.publishCreditLimitReached(balanceType, b)
+			}
 			b.dirty = true
 			found = true
 			a.balanceValue = b.GetValue()
@@ -220,6 +229,7 @@ func (acc *Account) debitBalanceAction(a *Action, reset, resetIfNegative bool, f
 	}
 	// if it is not found then we add it to the list
 	if !found {
+		limitReached := acc.exceedsCreditLimit(balanceType, -bClone.GetValue())
 		// check if the Id is *default (user trying to create the default balance)
 		// use only it's value value
 		if bClone.ID == utils.MetaDefault {
@@ -235,6 +245,9 @@ func (acc *Account) debitBalanceAction(a *Action, reset, resetIfNegative bool, f
 		bClone.dirty = true // Mark the balance as dirty since we have modified and it should be checked by action triggers
 		a.balanceValue = bClone.GetValue()
 		bClone.Uuid = utils.GenUUID() // alway overwrite the uuid for consistency
+		if limitReached {
+			acc.publishCreditLimitReached(balanceType, bClone)
+		}
 		// load ValueFactor if defined in extra parametrs
 		if a.ExtraParameters != "" {
 			vf := ValueFactor{}
@@ -483,17 +496,24 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 
 		var debitedConnectFeeBalance Balance
 		var ok bool
+		var connectFeeRate float64
 
 		if initialLength == 0 {
 			// this is the first add, debit the connect fee
-			ok, debitedConnectFeeBalance = acc.DebitConnectionFee(cc, usefulMoneyBalances, count, true, fltrS)
+			if ok, debitedConnectFeeBalance, connectFeeRate, err = acc.DebitConnectionFee(cc, usefulMoneyBalances,
+				count, true, cd.exchangeTime(), fltrS); err != nil {
+				return nil, err
+			}
 		}
 		// get the default money balance
 		// and go negative on it with the amount still unpaid
-		if len(leftCC.Timespans) > 0 && leftCC.Cost > 0 && !acc.AllowNegative && !dryRun {
+		_, hasCreditLimit := acc.getCreditLimit(utils.MetaMonetary)
+		if len(leftCC.Timespans) > 0 && leftCC.Cost > 0 && !acc.AllowNegative && !hasCreditLimit && !dryRun {
 			utils.Logger.Warning(fmt.Sprintf("<Rater> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
 		}
+		var creditLimitReached bool
 		leftCC.Timespans.Decompress()
+	TIMESPANS:
 		for tsIndex, ts := range leftCC.Timespans {
 			if ts.Increments == nil {
 				ts.createIncrementsSlice()
@@ -506,9 +526,10 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 					Cost:     ts.RateInterval.Rating.ConnectFee,
 					BalanceInfo: &DebitInfo{
 						Monetary: &MonetaryInfo{
-							UUID:  debitedConnectFeeBalance.Uuid,
-							ID:    debitedConnectFeeBalance.ID,
-							Value: debitedConnectFeeBalance.Value,
+							UUID:         debitedConnectFeeBalance.Uuid,
+							ID:           debitedConnectFeeBalance.ID,
+							Value:        debitedConnectFeeBalance.Value,
+							ExchangeRate: connectFeeRate,
 						},
 						AccountID: acc.ID,
 					},
@@ -523,14 +544,37 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 				if tsIndex == 0 && incIndex == 0 && ts.RateInterval.Rating.ConnectFee > 0 && cc.deductConnectFee && ok {
 					continue
 				}
-				cost := increment.Cost
 				defaultBalance := acc.GetDefaultMoneyBalance()
+				cost, xRate, errX := defaultBalance.exchangeCost(increment.Cost,
+					ts.currency(), cd.Tenant, cd.exchangeTime())
+				if errX != nil { // cannot go negative with an amount in another currency
+					return nil, fmt.Errorf("cannot convert cost %v from <%s> for balance <%s>: %s",
+						increment.Cost, ts.currency(), defaultBalance.ID, errX.Error())
+				}
+				if acc.exceedsCreditLimit(utils.MetaMonetary, defaultBalance.GetValue()-cost) {
+					if dryRun { // computing the maximum usage, leave the rest of the increments unpaid
+						if incIndex == 0 {
+							leftCC.Timespans = leftCC.Timespans[:tsIndex]
+						} else {
+							ts.SplitByIncrement(incIndex)
+							leftCC.Timespans = leftCC.Timespans[:tsIndex+1]
+						}
+						cc.Timespans = append(cc.Timespans[:initialLength], leftCC.Timespans...)
+						break TIMESPANS
+					}
+					if cd.enforcesCreditLimit() { // refuse the debit instead of returning a partial CallCost
+						acc.publishCreditLimitReached(utils.MetaMonetary, defaultBalance)
+						return nil, utils.ErrInsufficientCredit
+					}
+					creditLimitReached = true // the postpaid usage is charged past the limit
+				}
 				defaultBalance.SubstractValue(cost)
 
 				increment.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  defaultBalance.Uuid,
-					ID:    defaultBalance.ID,
-					Value: defaultBalance.Value,
+					UUID:         defaultBalance.Uuid,
+					ID:           defaultBalance.ID,
+					Value:        defaultBalance.Value,
+					ExchangeRate: xRate,
 				}
 				increment.BalanceInfo.AccountID = acc.ID
 				if count {
@@ -546,8 +590,11 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 			}
 		}
 
+		if creditLimitReached {
+			acc.publishCreditLimitReached(utils.MetaMonetary, acc.GetDefaultMoneyBalance())
+		}
 		// in case of going to negative we send the default balance to thresholdS to be processed
-		if len(config.CgrConfig().RalsCfg().ThresholdSConns) != 0 {
+		if !dryRun && len(config.CgrConfig().RalsCfg().ThresholdSConns) != 0 {
 			defaultBalance := acc.GetDefaultMoneyBalance()
 			acntTnt := utils.NewTenantID(acc.ID)
 			thEv := &utils.CGREvent{
@@ -585,6 +632,69 @@ COMMIT:
 	return
 }
 
+// CheckCreditLimits validates the credit limits of an account
+// only the *monetary balances can go negative when rating so the other types are rejected
+func CheckCreditLimits(limits map[string]float64) error {
+	for blcType, limit := range limits {
+		if blcType != utils.MetaMonetary {
+			return fmt.Errorf("credit limit not supported for balance type <%s>", blcType)
+		}
+		if limit < 0 {
+			return fmt.Errorf("negative credit limit <%v> for balance type <%s>", limit, blcType)
+		}
+	}
+	return nil
+}
+
+// getCreditLimit returns the credit limit of the balance type
+// accounts allowed to go negative have no limit
+func (acc *Account) getCreditLimit(blcType string) (limit float64, has bool) {
+	if acc.AllowNegative {
+		return
+	}
+	limit, has = acc.CreditLimits[blcType]
+	return
+}
+
+// exceedsCreditLimit checks if a balance of the given type would go under the credit limit with the new value
+func (acc *Account) exceedsCreditLimit(blcType string, value float64) bool {
+	limit, has := acc.getCreditLimit(blcType)
+	return has && utils.Round(value, globalRoundingDecimals, utils.MetaRoundingMiddle) < -limit
+}
+
+// publishCreditLimitReached sends the balance to ThresholdS when a debit crossed the credit limit
+func (acc *Account) publishCreditLimitReached(blcType string, b *Balance) {
+	if len(config.CgrConfig().RalsCfg().ThresholdSConns) == 0 {
+		return
+	}
+	limit, _ := acc.getCreditLimit(blcType)
+	acntTnt := utils.NewTenantID(acc.ID)
+	thEv := &utils.CGREvent{
+		Tenant: acntTnt.Tenant,
+		ID:     utils.GenUUID(),
+		Event: map[string]interface{}{
+			utils.EventType:    utils.CreditLimitReached,
+			utils.EventSource:  utils.AccountService,
+			utils.AccountField: acntTnt.ID,
+			utils.BalanceType:  blcType,
+			utils.BalanceID:    b.ID,
+			utils.Units:        b.GetValue(),
+			utils.CreditLimit:  limit,
+		},
+		APIOpts: map[string]interface{}{
+			utils.MetaEventType: utils.CreditLimitReached,
+		},
+	}
+	var tIDs []string
+	if err := connMgr.Call(config.CgrConfig().RalsCfg().ThresholdSConns, nil,
+		utils.ThresholdSv1ProcessEvent, thEv, &tIDs); err != nil &&
+		err.Error() != utils.ErrNotFound.Error() {
+		utils.Logger.Warning(
+			fmt.Sprintf("<AccountS> error: <%s> processing credit limit event <%+v> with ThresholdS.",
+				err.Error(), utils.ToJSON(thEv)))
+	}
+}
+
 // GetDefaultMoneyBalance returns the defaultmoney balance
 func (acc *Account) GetDefaultMoneyBalance() *Balance {
 	for _, balance := range acc.BalanceMap[utils.MetaMonetary] {
@@ -852,46 +962,74 @@ func (acc *Account) Clone() *Account {
 			newAcc.ActionTriggers[key] = actionTrigger.Clone()
 		}
 	}
+	if acc.CreditLimits != nil {
+		newAcc.CreditLimits = make(map[string]float64, len(acc.CreditLimits))
+		for blcType, limit := range acc.CreditLimits {
+			newAcc.CreditLimits[blcType] = limit
+		}
+	}
+	if acc.Reservations != nil {
+		newAcc.Reservations = make([]*BalanceReservation, len(acc.Reservations))
+		for i, rsv := range acc.Reservations {
+			newAcc.Reservations[i] = rsv.Clone()
+		}
+	}
 	return newAcc
 }
 
 // DebitConnectionFee debits the connection fee
-func (acc *Account) DebitConnectionFee(cc *CallCost, ufMoneyBalances Balances, count bool, block bool, fltrS *FilterS) (bool, Balance) {
+// returning also the exchange rate used when the balance is in another currency
+// errors if the fee cannot be converted into the currency of the default balance when going negative
+func (acc *Account) DebitConnectionFee(cc *CallCost, ufMoneyBalances Balances, count bool, block bool,
+	xTime time.Time, fltrS *FilterS) (bool, Balance, float64, error) {
 	var debitedBalance Balance
 	if !cc.deductConnectFee {
-		return true, debitedBalance
+		return true, debitedBalance, 0, nil
 	}
 	connectFee := cc.GetConnectFee()
+	var currency string
+	if len(cc.Timespans) != 0 {
+		currency = cc.Timespans[0].currency()
+	}
 	//log.Print("CONNECT FEE: %f", connectFee)
 	var connectFeePaid bool
+	var xRate float64
 	for _, b := range ufMoneyBalances {
-		if b.GetValue() >= connectFee {
-			b.SubstractValue(connectFee)
+		bCost, bRate, errX := b.exchangeCost(connectFee, currency, cc.Tenant, xTime)
+		if errX == nil && b.GetValue() >= bCost {
+			b.SubstractValue(bCost)
 			// the conect fee is not refundable!
 			if count {
-				acc.countUnits(connectFee, utils.MetaMonetary, cc, b, fltrS)
+				acc.countUnits(bCost, utils.MetaMonetary, cc, b, fltrS)
 			}
 			connectFeePaid = true
 			debitedBalance = *b
+			xRate = bRate
 			break
 		}
 		if b.Blocker && block { // stop here
-			return false, debitedBalance
+			return false, debitedBalance, 0, nil
 		}
 	}
 	// debit connect fee
 	if connectFee > 0 && !connectFeePaid {
-		cc.negativeConnectFee = true
 		// there are no money for the connect fee; go negative
 		b := acc.GetDefaultMoneyBalance()
-		b.SubstractValue(connectFee)
+		bCost, bRate, err := b.exchangeCost(connectFee, currency, cc.Tenant, xTime)
+		if err != nil {
+			return false, debitedBalance, 0, fmt.Errorf("cannot convert connect fee %v from <%s> for balance <%s>: %s",
+				connectFee, currency, b.ID, err.Error())
+		}
+		cc.negativeConnectFee = true
+		xRate = bRate
+		b.SubstractValue(bCost)
 		debitedBalance = *b
 		// the conect fee is not refundable!
 		if count {
-			acc.countUnits(connectFee, utils.MetaMonetary, cc, b, fltrS)
+			acc.countUnits(bCost, utils.MetaMonetary, cc, b, fltrS)
 		}
 	}
-	return true, debitedBalance
+	return true, debitedBalance, xRate, nil
 }
 
 // GetID returns the account ID
@@ -1389,6 +1527,15 @@ func (acc *Account) FieldAsInterface(fldPath []string) (val interface{}, err err
 			return nil, utils.ErrNotFound
 		}
 		return acc.AllowNegative, nil
+	case utils.CreditLimits:
+		if len(fldPath) == 1 {
+			return acc.CreditLimits, nil
+		}
+		limit, has := acc.CreditLimits[fldPath[1]]
+		if !has || len(fldPath) != 2 {
+			return nil, utils.ErrNotFound
+		}
+		return limit, nil
 	case utils.Disabled:
 		if len(fldPath) != 1 {
 			return nil, utils.ErrNotFound
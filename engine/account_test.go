@@ -1048,6 +1048,99 @@ func TestAccountdebitBalanceExists(t *testing.T) {
 	}
 }
 
+func TestAccountCheckCreditLimits(t *testing.T) {
+	if err := CheckCreditLimits(map[string]float64{utils.MetaMonetary: 5}); err != nil {
+		t.Error(err)
+	}
+	if err := CheckCreditLimits(map[string]float64{utils.MetaVoice: 5}); err == nil {
+		t.Error("Expected error for non monetary credit limit")
+	}
+	if err := CheckCreditLimits(map[string]float64{utils.MetaMonetary: -5}); err == nil {
+		t.Error("Expected error for negative credit limit")
+	}
+}
+
+func TestAccountDebitBalanceCreditLimit(t *testing.T) {
+	ub := &Account{
+		ID:           "cgrates.org:rif",
+		CreditLimits: map[string]float64{utils.MetaMonetary: 5},
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {&Balance{ID: "MONEY", Value: 10}},
+			utils.MetaData:     {&Balance{ID: "DATA", Value: 10}}},
+	}
+	a := &Action{Balance: &BalanceFilter{
+		ID:    utils.StringPointer("MONEY"),
+		Type:  utils.StringPointer(utils.MetaMonetary),
+		Value: &utils.ValueFormula{Static: 15},
+	}}
+	if err := ub.debitBalanceAction(a, false, false, nil); err != nil {
+		t.Fatal(err)
+	} else if rcv := ub.BalanceMap[utils.MetaMonetary][0].GetValue(); rcv != -5 {
+		t.Errorf("Expected -5, received %v", rcv)
+	}
+	// not a prepaid debit, only the limit event is sent
+	if err := ub.debitBalanceAction(a, false, false, nil); err != nil {
+		t.Fatal(err)
+	} else if rcv := ub.BalanceMap[utils.MetaMonetary][0].GetValue(); rcv != -20 {
+		t.Errorf("Expected -20, received %v", rcv)
+	}
+	a.Balance.ID = utils.StringPointer("DATA")
+	a.Balance.Type = utils.StringPointer(utils.MetaData)
+	if err := ub.debitBalanceAction(a, false, false, nil); err != nil { // no limit for *data
+		t.Fatal(err)
+	} else if rcv := ub.BalanceMap[utils.MetaData][0].GetValue(); rcv != -5 {
+		t.Errorf("Expected -5, received %v", rcv)
+	}
+}
+
+func TestAccountDebitCreditBalanceCreditLimit(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2015, 07, 24, 13, 37, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, 07, 24, 16, 37, 0, 0, time.UTC),
+		Category:    "call",
+		Tenant:      "cgrates.org",
+		Subject:     "money",
+		Destination: "0723",
+		ToR:         utils.MetaVoice,
+	}
+	acc, err := dm.GetAccount("cgrates.org:money")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc.CreditLimits = map[string]float64{utils.MetaMonetary: 100}
+	// computing the maximum usage stops at the credit limit
+	cc, err := acc.Clone().debitCreditBalance(cd.Clone(), false, true, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := cc.GetDuration(); rcv != 10099*time.Second {
+		t.Errorf("Expected %v debited, received %v", 10099*time.Second, rcv)
+	}
+	// the prepaid debit is refused instead of returning a partial CallCost
+	prepaidCD := cd.Clone()
+	prepaidCD.RequestType = utils.MetaPrepaid
+	prepaidAcc := acc.Clone()
+	if _, err = prepaidAcc.debitCreditBalance(prepaidCD, false, false, true, nil); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	sessionCD := cd.Clone()
+	sessionCD.Source = utils.MetaSessionS
+	if _, err = acc.Clone().debitCreditBalance(sessionCD, false, false, true, nil); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	// the postpaid usage is charged past the limit
+	cd.RequestType = utils.MetaPostpaid
+	if cc, err = acc.debitCreditBalance(cd, false, false, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := cc.GetDuration(); rcv != 3*time.Hour {
+		t.Errorf("Expected %v debited, received %v", 3*time.Hour, rcv)
+	}
+	if rcv := acc.GetDefaultMoneyBalance().GetValue(); rcv >= -100 {
+		t.Errorf("Expected the balance under the credit limit, received %v", rcv)
+	}
+}
+
 func TestAccountAddMinuteNil(t *testing.T) {
 	ub := &Account{
 		ID:            "rif",
@@ -43,6 +43,8 @@ type ActionTiming struct {
 	accountIDs   utils.StringMap // copy of action plans accounts
 	actionPlanID string          // the id of the belonging action plan (info only)
 	stCache      time.Time       // cached time of the next start
+	ledgerSource string          // source of the balance changes, *actions if empty
+	ledgerRefID  string
 }
 
 // Tasks converts an ActionTiming into multiple Tasks
@@ -164,6 +166,11 @@ func (at *ActionTiming) SetAccountIDs(accIDs utils.StringMap) {
 	at.accountIDs = accIDs
 }
 
+// SetLedgerSource overwrites the source and reference recorded into the balance ledger
+func (at *ActionTiming) SetLedgerSource(source, refID string) {
+	at.ledgerSource, at.ledgerRefID = source, refID
+}
+
 func (at *ActionTiming) RemoveAccountID(acntID string) (found bool) {
 	if _, found = at.accountIDs[acntID]; found {
 		delete(at.accountIDs, acntID)
@@ -257,6 +264,11 @@ func (at *ActionTiming) Execute(fltrS *FilterS) (err error) {
 				}
 			}
 			if !transactionFailed && !removeAccountActionFound {
+				if at.ledgerSource != utils.EmptyString {
+					acc.SetLedgerSource(at.ledgerSource, at.ledgerRefID)
+				} else {
+					acc.SetLedgerSource(utils.MetaActions, at.ActionsID)
+				}
 				dm.SetAccount(acc)
 			}
 			return nil
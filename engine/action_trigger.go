@@ -107,7 +107,10 @@ func (at *ActionTrigger) Execute(ub *Account, fltrS *FilterS) (err error) {
 		at.Executed = false
 	}
 	if !transactionFailed && ub != nil && !removeAccountActionFound {
+		source, refID := ub.ledgerSource, ub.ledgerRefID
+		ub.SetLedgerSource(utils.MetaActions, at.ActionsID)
 		dm.SetAccount(ub)
+		ub.SetLedgerSource(source, refID) // restore the source of the operation triggering the actions
 	}
 	return
 }
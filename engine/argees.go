@@ -32,6 +32,19 @@ type CGREventWithEeIDs struct {
 	*utils.CGREvent
 }
 
+// RPCClone implements rpcclient.RPCCloner interface
+// without it the method promoted from CGREvent would drop the EeIDs on internal connections
+func (cgr *CGREventWithEeIDs) RPCClone() (interface{}, error) {
+	cln, err := cgr.CGREvent.RPCClone()
+	if err != nil {
+		return nil, err
+	}
+	return &CGREventWithEeIDs{
+		EeIDs:    cgr.EeIDs,
+		CGREvent: cln.(*utils.CGREvent),
+	}, nil
+}
+
 func (cgr *CGREventWithEeIDs) UnmarshalJSON(data []byte) (err error) {
 	// firstly, we will unamrshall the entire data into raw bytes
 	ids := make(map[string]json.RawMessage)
@@ -204,6 +204,10 @@ func (alS *AttributeService) processEvent(tnt string, args *utils.CGREvent, evNm
 		}
 		var out interface{}
 		if out, err = ParseAttribute(dynDP, utils.FirstNonEmpty(attribute.Type, utils.MetaVariable), utils.DynamicDataPrefix+attribute.Path, attribute.Value, alS.cgrcfg.GeneralCfg().RoundingDecimals, alS.cgrcfg.GeneralCfg().DefaultTimezone, time.RFC3339, alS.cgrcfg.GeneralCfg().RSRSep); err != nil {
+			if err == utils.ErrNotFound && attribute.Type == utils.MetaLookup { // key not in table, keep the field as it is
+				err = nil
+				continue
+			}
 			rply = nil
 			return
 		}
@@ -572,6 +576,24 @@ func ParseAttribute(dp utils.DataProvider, attrType, path string, value config.R
 
 		sort.Strings(values[1:])
 		out = strings.Join(values, utils.InfieldSep)
+	case utils.MetaLookup:
+		if len(value) != 3 {
+			return nil, fmt.Errorf("invalid arguments <%s> to %s",
+				utils.ToJSON(value), utils.MetaLookup)
+		}
+		var tableID string
+		if tableID, err = value[0].ParseDataProvider(dp); err != nil {
+			return
+		}
+		var key string
+		if key, err = value[1].ParseDataProvider(dp); err != nil {
+			return
+		}
+		var fldName string
+		if fldName, err = value[2].ParseDataProvider(dp); err != nil {
+			return
+		}
+		out, err = lookupTableValue(dp, tableID, key, fldName)
 	default:
 		return utils.EmptyString, fmt.Errorf("unsupported type: <%s>", attrType)
 	}
@@ -44,6 +44,7 @@ type BalanceFilter struct {
 	Disabled       *bool
 	Factor         *ValueFactor
 	Blocker        *bool
+	Currency       *string
 }
 
 // NewBalanceFilter creates a new BalanceFilter based on given filter
@@ -108,6 +109,9 @@ func NewBalanceFilter(filter map[string]interface{}, defaultTimezone string) (bf
 		}
 		bf.Blocker = utils.BoolPointer(value)
 	}
+	if cur, has := filter[utils.Currency]; has {
+		bf.Currency = utils.StringPointer(utils.IfaceAsString(cur))
+	}
 	return
 }
 
@@ -127,6 +131,7 @@ func (bp *BalanceFilter) CreateBalance() *Balance {
 		Disabled:       bp.GetDisabled(),
 		Factor:         bp.GetFactor(),
 		Blocker:        bp.GetBlocker(),
+		Currency:       bp.GetCurrency(),
 	}
 	return b.Clone()
 }
@@ -194,6 +199,10 @@ func (bf *BalanceFilter) Clone() *BalanceFilter {
 		result.Blocker = new(bool)
 		*result.Blocker = *bf.Blocker
 	}
+	if bf.Currency != nil {
+		result.Currency = new(string)
+		*result.Currency = *bf.Currency
+	}
 	return result
 }
 
@@ -243,6 +252,9 @@ func (bf *BalanceFilter) LoadFromBalance(b *Balance) *BalanceFilter {
 	if b.Blocker {
 		bf.Blocker = &b.Blocker
 	}
+	if b.Currency != "" {
+		bf.Currency = &b.Currency
+	}
 	bf.Timings = b.Timings
 	return bf
 }
@@ -339,6 +351,13 @@ func (bp *BalanceFilter) GetRatingSubject() string {
 	return *bp.RatingSubject
 }
 
+func (bp *BalanceFilter) GetCurrency() string {
+	if bp == nil || bp.Currency == nil {
+		return ""
+	}
+	return *bp.Currency
+}
+
 func (bp *BalanceFilter) GetDisabled() bool {
 	if bp == nil || bp.Disabled == nil {
 		return false
@@ -417,6 +436,9 @@ func (bf *BalanceFilter) ModifyBalance(b *Balance) {
 	if bf.Disabled != nil {
 		b.Disabled = *bf.Disabled
 	}
+	if bf.Currency != nil {
+		b.Currency = *bf.Currency
+	}
 	b.SetDirty() // Mark the balance as dirty since we have modified and it should be checked by action triggers
 }
 
@@ -558,6 +580,14 @@ func (bp *BalanceFilter) FieldAsInterface(fldPath []string) (val interface{}, er
 			return
 		}
 		return *bp.Blocker, nil
+	case utils.Currency:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		if bp.Currency == nil {
+			return
+		}
+		return *bp.Currency, nil
 	case utils.DestinationIDs:
 		if len(fldPath) == 1 {
 			return bp.DestinationIDs, nil
@@ -46,6 +46,7 @@ type Balance struct {
 	Disabled       bool
 	Factor         ValueFactor
 	Blocker        bool
+	Currency       string // currency of the *monetary balance, empty for the default one
 	precision      int
 	account        *Account // used to store ub reference for shared balances
 	dirty          bool
@@ -67,7 +68,8 @@ func (b *Balance) Equal(o *Balance) bool {
 		b.Categories.Equal(o.Categories) &&
 		b.SharedGroups.Equal(o.SharedGroups) &&
 		b.Disabled == o.Disabled &&
-		b.Blocker == o.Blocker
+		b.Blocker == o.Blocker &&
+		b.Currency == o.Currency
 }
 
 func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
@@ -92,7 +94,8 @@ func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
 		(o.Categories == nil || b.Categories.Includes(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Includes(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Includes(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
@@ -113,7 +116,8 @@ func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
 		(o.Categories == nil || b.Categories.Equal(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Equal(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Equal(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 // the default balance has standard Id
@@ -178,6 +182,7 @@ func (b *Balance) Clone() *Balance {
 		Timings:        b.Timings, // should not be a problem with aliasing
 		Blocker:        b.Blocker,
 		Disabled:       b.Disabled,
+		Currency:       b.Currency,
 		dirty:          b.dirty,
 	}
 	if b.DestinationIDs != nil {
@@ -387,7 +392,9 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		}
 		if debitConnectFee {
 			// this is the first add, debit the connect fee
-			if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true, fltrS); !ok {
+			if ok, debitedConnectFeeBalance, _, err = ub.DebitConnectionFee(cc, moneyBalances, count, true, cd.exchangeTime(), fltrS); err != nil {
+				return nil, err
+			} else if !ok {
 				// found blocker balance
 				return nil, nil
 			}
@@ -539,7 +546,9 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 	//log.Print("cc: " + utils.ToJSON(cc))
 	if debitConnectFee {
 		// this is the first add, debit the connect fee
-		if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true, fltrS); !ok {
+		if ok, debitedConnectFeeBalance, _, err = ub.DebitConnectionFee(cc, moneyBalances, count, true, cd.exchangeTime(), fltrS); err != nil {
+			return nil, err
+		} else if !ok {
 			// balance is blocker
 			return nil, nil
 		}
@@ -762,6 +771,7 @@ func (bc Balances) SaveDirtyBalances(acc *Account, initBal map[string]float64) {
 		}
 		savedAccounts.Add(b.account.ID)
 		if b.account != acc {
+			b.account.SetLedgerSource(acc.ledgerSource, acc.ledgerRefID)
 			dm.SetAccount(b.account)
 		}
 		b.account.Publish(initBal)
@@ -920,10 +930,13 @@ func (b *Balance) debit(cd *CallDescriptor, ub *Account, moneyBalances Balances,
 
 	var debitedConnectFeeBalance Balance
 	var connectFeeDebited bool
+	var connectFeeRate float64
 	//log.Print("cc: " + utils.ToJSON(cc))
 	if debitConnectFee {
 		// this is the first add, debit the connect fee
-		if connectFeeDebited, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true, fltrS); !connectFeeDebited {
+		if connectFeeDebited, debitedConnectFeeBalance, connectFeeRate, err = ub.DebitConnectionFee(cc, moneyBalances, count, true, cd.exchangeTime(), fltrS); err != nil {
+			return nil, err
+		} else if !connectFeeDebited {
 			// balance is blocker
 			return nil, nil
 		}
@@ -955,9 +968,10 @@ func (b *Balance) debit(cd *CallDescriptor, ub *Account, moneyBalances Balances,
 				Cost:     ts.RateInterval.Rating.ConnectFee,
 				BalanceInfo: &DebitInfo{
 					Monetary: &MonetaryInfo{
-						UUID:  debitedConnectFeeBalance.Uuid,
-						ID:    debitedConnectFeeBalance.ID,
-						Value: debitedConnectFeeBalance.Value,
+						UUID:         debitedConnectFeeBalance.Uuid,
+						ID:           debitedConnectFeeBalance.ID,
+						Value:        debitedConnectFeeBalance.Value,
+						ExchangeRate: connectFeeRate,
 					},
 					AccountID: ub.ID,
 				},
@@ -1014,24 +1028,48 @@ func (b *Balance) debit(cd *CallDescriptor, ub *Account, moneyBalances Balances,
 			// debit minutes and money
 			amount := float64(inc.Duration)
 			cost := inc.Cost
+			moneyCost := cost // the cost converted into the currency of the money balance
+			var xRate float64 // the exchange rate used for the conversion
 
-			canDebitCost := b.GetValue() >= cost
+			var canDebitCost bool
 			var moneyBal *Balance
 			if isUnitBal {
 				if b.Factor != nil {
 					amount = utils.Round(amount/b.Factor.GetValue(cd.ToR), globalRoundingDecimals, utils.MetaRoundingUp)
 				}
 				for _, mb := range moneyBalances {
-					if mb.GetValue() >= cost {
-						moneyBal = mb
+					mbCost, mbRate, errX := mb.exchangeCost(cost, ts.currency(), cd.Tenant, cd.exchangeTime())
+					if errX != nil { // no rate into the currency of this balance
+						continue
+					}
+					if mb.GetValue() >= mbCost {
+						moneyBal, moneyCost, xRate = mb, mbCost, mbRate
 						break
 					}
 				}
-				if cost != 0 && moneyBal == nil && (!dryRun || ub.AllowNegative) { // Fix for issue #685
-					utils.Logger.Warning(fmt.Sprintf("<RALs> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
+				_, hasCreditLimit := ub.getCreditLimit(utils.MetaMonetary)
+				if cost != 0 && moneyBal == nil && (!dryRun || ub.AllowNegative || hasCreditLimit) { // Fix for issue #685
+					if !hasCreditLimit {
+						utils.Logger.Warning(fmt.Sprintf("<RALs> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
+					}
 					moneyBal = ub.GetDefaultMoneyBalance()
+					var errX error
+					if moneyCost, xRate, errX = moneyBal.exchangeCost(cost, ts.currency(), cd.Tenant, cd.exchangeTime()); errX != nil {
+						utils.Logger.Warning(fmt.Sprintf("<%s> cannot convert cost %v from <%s> for balance <%s>, error: %s",
+							utils.RALService, cost, ts.currency(), moneyBal.ID, errX))
+						moneyBal = nil // skip the balance, the cost cannot be debited in another currency
+					} else if ub.exceedsCreditLimit(utils.MetaMonetary, moneyBal.GetValue()-moneyCost) {
+						moneyBal = nil
+					}
 				}
 				canDebitCost = b.GetValue() >= amount && (moneyBal != nil || cost == 0)
+			} else {
+				if moneyCost, xRate, err = b.exchangeCost(cost, ts.currency(), cd.Tenant, cd.exchangeTime()); err != nil &&
+					err != utils.ErrExchangeRateNotFound {
+					return nil, err
+				}
+				canDebitCost = err == nil && b.GetValue() >= moneyCost // balance not usable without an exchange rate
+				err = nil
 			}
 			if !canDebitCost {
 				// delete the rest of the unpaid increments/timespans
@@ -1061,34 +1099,36 @@ func (b *Balance) debit(cd *CallDescriptor, ub *Account, moneyBalances Balances,
 				}
 				inc.BalanceInfo.AccountID = ub.ID
 				if cost != 0 {
-					moneyBal.SubstractValue(cost)
+					moneyBal.SubstractValue(moneyCost)
 					inc.BalanceInfo.Monetary = &MonetaryInfo{
-						UUID:  moneyBal.Uuid,
-						ID:    moneyBal.ID,
-						Value: moneyBal.Value,
+						UUID:         moneyBal.Uuid,
+						ID:           moneyBal.ID,
+						Value:        moneyBal.Value,
+						ExchangeRate: xRate,
 					}
 					cd.MaxCostSoFar += cost
 				}
 				if count {
 					ub.countUnits(amount, cc.ToR, cc, b, fltrS)
 					if cost != 0 {
-						ub.countUnits(cost, utils.MetaMonetary, cc, moneyBal, fltrS)
+						ub.countUnits(moneyCost, utils.MetaMonetary, cc, moneyBal, fltrS)
 					}
 				}
 			} else { // monetary balance
-				b.SubstractValue(cost)
+				b.SubstractValue(moneyCost)
 				cd.MaxCostSoFar += cost
 				inc.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  b.Uuid,
-					ID:    b.ID,
-					Value: b.Value,
+					UUID:         b.Uuid,
+					ID:           b.ID,
+					Value:        b.Value,
+					ExchangeRate: xRate,
 				}
 				inc.BalanceInfo.AccountID = ub.ID
 				if b.RatingSubject != "" {
 					inc.BalanceInfo.Monetary.RateInterval = ts.RateInterval
 				}
 				if count {
-					ub.countUnits(cost, utils.MetaMonetary, cc, b, fltrS)
+					ub.countUnits(moneyCost, utils.MetaMonetary, cc, b, fltrS)
 				}
 			}
 		}
@@ -1239,6 +1279,11 @@ func (b *Balance) FieldAsInterface(fldPath []string) (val interface{}, err error
 			return nil, utils.ErrNotFound
 		}
 		return b.RatingSubject, nil
+	case utils.Currency:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		return b.Currency, nil
 	case utils.Categories:
 		if len(fldPath) == 1 {
 			return b.Categories, nil
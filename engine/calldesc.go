@@ -87,6 +87,7 @@ func NewCallDescriptorFromCGREvent(cgrEv *utils.CGREvent,
 		timezone); err != nil {
 		return nil, err
 	}
+	cd.SetupTime = cd.TimeStart
 	if _, has := cgrEv.Event[utils.AnswerTime]; has { // AnswerTime takes precendence for TimeStart
 		if aTime, err := cgrEv.FieldAsTime(utils.AnswerTime,
 			timezone); err != nil {
@@ -135,7 +136,10 @@ type CallDescriptor struct {
 	ForceDuration       bool // for Max debit if less than duration return err
 	PerformRounding     bool // flag for rating info rounding
 	DryRun              bool
-	DenyNegativeAccount bool // prevent account going on negative during debit
+	DenyNegativeAccount bool      // prevent account going on negative during debit
+	RequestType         string    // the *prepaid debits are refused under the credit limit
+	SetupTime           time.Time // selects the exchange rates used when debiting balances in other currencies
+	Source              string    // subsystem requesting the debit, recorded into the balance ledger, *rals if empty
 	account             *Account
 	testCallcost        *CallCost // testing purpose only!
 }
@@ -265,7 +269,7 @@ func (cd *CallDescriptor) LoadRatingPlans() (err error) {
 		utils.Logger.Err(fmt.Sprintf("Destination %s not authorized for account: %s, subject: %s", cd.Destination, cd.GetAccountKey(), cd.GetKey(cd.Subject)))
 		return utils.ErrUnauthorizedDestination
 	}
-	return
+	return cd.loadTierUsages()
 }
 
 // FIXME: this method is not exhaustive but will cover 99% of cases just good
@@ -634,8 +638,10 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account, fltrS *Fil
 
 	//use this to check what increment was payed with debt
 	initialDefaultBalanceValue := defaultBalance.GetValue()
+	// the credit limit allows the default balance to go negative
+	creditLimit, hasCreditLimit := account.getCreditLimit(utils.MetaMonetary)
 
-	cc, err := cd.debit(account, true, false, fltrS)
+	cc, err := cd.debit(account, true, hasCreditLimit, fltrS)
 	if err != nil {
 		return 0, err
 	}
@@ -662,7 +668,7 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account, fltrS *Fil
 			totalCost += incr.Cost
 			if incr.BalanceInfo.Monetary != nil && incr.BalanceInfo.Monetary.UUID == defaultBalance.Uuid {
 				initialDefaultBalanceValue -= incr.Cost
-				if initialDefaultBalanceValue < 0 {
+				if utils.Round(initialDefaultBalanceValue, globalRoundingDecimals, utils.MetaRoundingMiddle) < -creditLimit {
 					// this increment was payed with debt
 					// TODO: improve this check
 					return utils.MinDuration(initialDuration, totalDuration), nil
@@ -724,6 +730,9 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool,
 	if cd.ToR == "" {
 		cd.ToR = utils.MetaVoice
 	}
+	if !dryRun {
+		account.SetLedgerSource(cd.ledgerSource(), cd.CgrID)
+	}
 	//log.Printf("Debit CD: %+v", cd)
 	cc, err = account.debitCreditBalance(cd, !dryRun, dryRun, goNegative, fltrS)
 	//log.Printf("HERE: %+v %v", cc, err)
@@ -860,6 +869,7 @@ func (cd *CallDescriptor) refundIncrements(fltrS *FilterS) (acnt *Account, err e
 		if !found {
 			if acc, err := dm.GetAccount(increment.BalanceInfo.AccountID); err == nil && acc != nil {
 				account = acc
+				account.SetLedgerSource(cd.ledgerSource(), cd.CgrID)
 				accountsCache[increment.BalanceInfo.AccountID] = account
 				// will save the account only once at the end of the function
 				defer dm.SetAccount(account)
@@ -887,8 +897,13 @@ func (cd *CallDescriptor) refundIncrements(fltrS *FilterS) (acnt *Account, err e
 				utils.Logger.Warning(fmt.Sprintf("Could not get the balnce: <%s> to be refunded for account: <%s>", increment.BalanceInfo.Monetary.UUID, increment.BalanceInfo.AccountID))
 				continue
 			}
-			balance.AddValue(increment.Cost)
-			account.countUnits(-increment.Cost, utils.MetaMonetary, cc, balance, fltrS)
+			refund := increment.Cost
+			if increment.BalanceInfo.Monetary.ExchangeRate != 0 { // refund in the currency it was debited
+				refund = utils.Round(refund*increment.BalanceInfo.Monetary.ExchangeRate,
+					globalRoundingDecimals, utils.MetaRoundingMiddle)
+			}
+			balance.AddValue(refund)
+			account.countUnits(-refund, utils.MetaMonetary, cc, balance, fltrS)
 		}
 	}
 	acnt = accountsCache[utils.ConcatenatedKey(cd.Tenant, cd.Account)]
@@ -929,6 +944,7 @@ func (cd *CallDescriptor) refundRounding(old *Account, fltrS *FilterS) (accounts
 		if !found {
 			if acc, err := dm.GetAccount(increment.BalanceInfo.AccountID); err == nil && acc != nil {
 				account = acc
+				account.SetLedgerSource(cd.ledgerSource(), cd.CgrID)
 				accountsCache[increment.BalanceInfo.AccountID] = account
 				// will save the account only once at the end of the function
 				defer dm.SetAccount(account)
@@ -1001,10 +1017,33 @@ func (cd *CallDescriptor) Clone() *CallDescriptor {
 		DryRun:          cd.DryRun,
 		CgrID:           cd.CgrID,
 		RunID:           cd.RunID,
+		SetupTime:       cd.SetupTime,
+		Source:          cd.Source,
+		RequestType:     cd.RequestType,
 	}
 
 }
 
+// enforcesCreditLimit returns true for the debits refused under the credit limit,
+// the prepaid ones and the ones authorized by SessionS
+func (cd *CallDescriptor) enforcesCreditLimit() bool {
+	return cd.Source == utils.MetaSessionS ||
+		cd.RequestType == utils.MetaPrepaid || cd.RequestType == utils.MetaDynaprepaid
+}
+
+// ledgerSource returns the source of the balance changes recorded into the ledger
+func (cd *CallDescriptor) ledgerSource() string {
+	return utils.FirstNonEmpty(cd.Source, utils.MetaRALs)
+}
+
+// exchangeTime returns the time selecting the exchange rates, the SetupTime with fallback on TimeStart
+func (cd *CallDescriptor) exchangeTime() time.Time {
+	if cd.SetupTime.IsZero() {
+		return cd.TimeStart
+	}
+	return cd.SetupTime
+}
+
 // AccountSummary returns the AccountSummary for cached account
 func (cd *CallDescriptor) AccountSummary(initialAcnt *AccountSummary) *AccountSummary {
 	if cd.account == nil {
@@ -1375,6 +1375,30 @@ func TestMaxSesionTimeLongerThanMoney(t *testing.T) {
 	}
 }
 
+func TestMaxSesionTimeCreditLimit(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2015, 07, 24, 13, 37, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, 07, 24, 16, 37, 0, 0, time.UTC),
+		Category:    "call",
+		Tenant:      "cgrates.org",
+		Subject:     "money",
+		Destination: "0723",
+	}
+	acc, _ := dm.GetAccount("cgrates.org:money")
+	acc.CreditLimits = map[string]float64{utils.MetaMonetary: 100}
+	allowedTime, err := cd.getMaxSessionDuration(acc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 10099 * time.Second; allowedTime != expected { // 100 more with the credit limit
+		t.Errorf("Expected: %v got %v", expected, allowedTime)
+	}
+	acc.AllowNegative = true
+	if allowedTime, err = cd.getMaxSessionDuration(acc, nil); err != nil || allowedTime != -1 {
+		t.Errorf("Expected unlimited session, got %v with error %v", allowedTime, err)
+	}
+}
+
 func TestDebitFromShareAndNormal(t *testing.T) {
 	ap, _ := dm.GetActionPlan("TOPUP_SHARED10_AT", true, true, utils.NonTransactional)
 	for _, at := range ap.ActionTimings {
@@ -2193,6 +2217,7 @@ func TestCalldescNewCallDescriptorFromCGREvent(t *testing.T) {
 		TimeEnd:     time.Date(2021, 1, 5, 23, 59, 59, 100, time.UTC),
 		ToR:         utils.MetaVoice,
 		Tenant:      "cgrates.org",
+		SetupTime:   time.Date(2021, 1, 1, 23, 59, 59, 0, time.UTC),
 	}
 	rcv, err := NewCallDescriptorFromCGREvent(cgrEv, timezone)
 
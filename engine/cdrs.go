@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -101,6 +102,7 @@ func NewCDRServer(cgrCfg *config.CGRConfig, storDBChan chan StorDB, dm *DataMana
 		filterS:    filterS,
 		connMgr:    connMgr,
 		storDBChan: storDBChan,
+		rrJobs:     make(map[string]chan struct{}),
 	}
 }
 
@@ -113,13 +115,17 @@ type CDRServer struct {
 	filterS    *FilterS
 	connMgr    *ConnManager
 	storDBChan chan StorDB
+	rrJobs     map[string]chan struct{} // running rerate jobs, indexed by tenant and ID
+	rrJobsMux  sync.Mutex
 }
 
 // ListenAndServe listen for storbd reload
 func (cdrS *CDRServer) ListenAndServe(stopChan chan struct{}) {
+	cdrS.resumeRerateJobs()
 	for {
 		select {
 		case <-stopChan:
+			cdrS.stopRerateJobs()
 			return
 		case stordb, ok := <-cdrS.storDBChan:
 			if !ok { // the chanel was closed by the shutdown of stordbService
@@ -274,6 +280,8 @@ func (cdrS *CDRServer) getCostFromRater(cdr *CDRWithAPIOpts) (*CallCost, error)
 		TimeEnd:         timeStart.Add(cdr.Usage),
 		DurationIndex:   cdr.Usage,
 		PerformRounding: true,
+		SetupTime:       cdr.SetupTime,
+		RequestType:     cdr.RequestType,
 	}
 	if reqTypes.Has(cdr.RequestType) { // Prepaid - Cost can be recalculated in case of missing records from SM
 		err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().RaterConns, nil,
@@ -513,12 +521,21 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent,
 	}
 	if refund {
 		for i, cdr := range cdrs {
-			if rfnd, errRfd := cdrS.refundEventCost(cdr.CostDetails,
-				cdr.RequestType, cdr.ToR); errRfd != nil {
+			rfnd, errRfd := cdrS.refundEventCost(cdr.CostDetails,
+				cdr.RequestType, cdr.ToR)
+			if errRfd != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> error: <%s> refunding CDR %+v",
 						utils.CDRs, errRfd.Error(), utils.ToJSON(cdr)))
-			} else if rfnd {
+				continue
+			}
+			// the counters are reverted only after the refund succeeded
+			if errTc := cdrS.updateTierCounters(cdr, cdr.CostDetails, true); errTc != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> updating tier counters for CDR %+v",
+						utils.CDRs, errTc.Error(), utils.ToJSON(cdr)))
+			}
+			if rfnd {
 				cdr.CostDetails = nil
 				procFlgs[i].Add(utils.MetaRefund)
 			}
@@ -542,6 +559,13 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent,
 				}
 			}
 		}
+		for _, cdr := range cdrs {
+			if errTc := cdrS.updateTierCounters(cdr, cdr.CostDetails, false); errTc != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> updating tier counters for CDR %+v",
+						utils.CDRs, errTc.Error(), utils.ToJSON(cdr)))
+			}
+		}
 	}
 	if store {
 		refundCDRCosts := func() { // will be used to refund all CDRs on errors
@@ -551,6 +575,15 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent,
 					utils.Logger.Warning(
 						fmt.Sprintf("<%s> error: <%s> refunding CDR %+v",
 							utils.CDRs, errRfd.Error(), utils.ToJSON(cdr)))
+					continue
+				}
+				if !ralS { // the counters were not updated for this CDR
+					continue
+				}
+				if errTc := cdrS.updateTierCounters(cdr, cdr.CostDetails, true); errTc != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> error: <%s> reverting tier counters for CDR %+v",
+							utils.CDRs, errTc.Error(), utils.ToJSON(cdr)))
 				}
 			}
 		}
@@ -1053,47 +1086,51 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 	if cdrs, _, err = cdrS.cdrDb.GetCDRs(cdrFltr, false); err != nil {
 		return
 	}
-	flgs := utils.FlagsWithParamsFromSlice(arg.Flags)
-	store := cdrS.cgrCfg.CdrsCfg().StoreCdrs
+	chrgS, attrS, store, export, thdS, statS := cdrS.rateCDRsFlags(utils.FlagsWithParamsFromSlice(arg.Flags))
+	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
+		return utils.NewErrNotConnected(utils.ChargerS)
+	}
+	cgrEvs := make([]*utils.CGREvent, len(cdrs))
+	for i, cdr := range cdrs {
+		cdr.Cost = -1 // the cost will be recalculated
+		cgrEvs[i] = cdr.AsCGREvent()
+		cgrEvs[i].APIOpts = arg.APIOpts
+	}
+	if _, err = cdrS.processEvents(cgrEvs, chrgS, attrS, true,
+		true, store, true, export, thdS, statS); err != nil {
+		return utils.NewErrServerError(err)
+	}
+
+	*reply = utils.OK
+	return
+}
+
+// rateCDRsFlags returns the subsystems used when re-/rating stored CDRs
+func (cdrS *CDRServer) rateCDRsFlags(flgs utils.FlagsWithParams) (chrgS, attrS, store, export, thdS, statS bool) {
+	store = cdrS.cgrCfg.CdrsCfg().StoreCdrs
 	if flgs.Has(utils.MetaStore) {
 		store = flgs.GetBool(utils.MetaStore)
 	}
-	export := len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0
+	export = len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0
 	if flgs.Has(utils.MetaExport) {
 		export = flgs.GetBool(utils.MetaExport)
 	}
-	thdS := len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0
+	thdS = len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0
 	if flgs.Has(utils.MetaThresholds) {
 		thdS = flgs.GetBool(utils.MetaThresholds)
 	}
-	statS := len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0
+	statS = len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0
 	if flgs.Has(utils.MetaStats) {
 		statS = flgs.GetBool(utils.MetaStats)
 	}
-	chrgS := len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0
+	chrgS = len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) != 0
 	if flgs.Has(utils.MetaChargers) {
 		chrgS = flgs.GetBool(utils.MetaChargers)
 	}
-	attrS := len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0
+	attrS = len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0
 	if flgs.Has(utils.MetaAttributes) {
 		attrS = flgs.GetBool(utils.MetaAttributes)
 	}
-
-	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
-		return utils.NewErrNotConnected(utils.ChargerS)
-	}
-	cgrEvs := make([]*utils.CGREvent, len(cdrs))
-	for i, cdr := range cdrs {
-		cdr.Cost = -1 // the cost will be recalculated
-		cgrEvs[i] = cdr.AsCGREvent()
-		cgrEvs[i].APIOpts = arg.APIOpts
-	}
-	if _, err = cdrS.processEvents(cgrEvs, chrgS, attrS, true,
-		true, store, true, export, thdS, statS); err != nil {
-		return utils.NewErrServerError(err)
-	}
-
-	*reply = utils.OK
 	return
 }
 
@@ -442,6 +442,66 @@ func (dbM *DataDBMock) RemoveDispatcherHostDrv(string, string) error {
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetTierCounterDrv(string, string) (*TierCounter, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetTierCounterDrv(*TierCounter) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveTierCounterDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetRerateJobDrv(string, string) (*RerateJob, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRerateJobDrv(*RerateJob) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRerateJobDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetSessionsBackupDrv(string) ([]*StoredSession, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetSessionsBackupDrv(string, []*StoredSession) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveSessionsBackupDrv(string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetExchangeRateProfileDrv(string, string) (*ExchangeRateProfile, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetExchangeRateProfileDrv(*ExchangeRateProfile) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveExchangeRateProfileDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetLookupTableEntryDrv(string, string, string) (map[string]string, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetLookupTableDrv(*LookupTable) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveLookupTableDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 	return utils.ErrNotImplemented
 }
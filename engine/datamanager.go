@@ -42,37 +42,39 @@ var (
 		utils.FilterIndexPrfx:         {},
 	}
 	cachePrefixMap = utils.StringSet{
-		utils.DestinationPrefix:        {},
-		utils.ReverseDestinationPrefix: {},
-		utils.RatingPlanPrefix:         {},
-		utils.RatingProfilePrefix:      {},
-		utils.ActionPrefix:             {},
-		utils.ActionPlanPrefix:         {},
-		utils.AccountActionPlansPrefix: {},
-		utils.ActionTriggerPrefix:      {},
-		utils.SharedGroupPrefix:        {},
-		utils.ResourceProfilesPrefix:   {},
-		utils.TimingsPrefix:            {},
-		utils.ResourcesPrefix:          {},
-		utils.StatQueuePrefix:          {},
-		utils.StatQueueProfilePrefix:   {},
-		utils.ThresholdPrefix:          {},
-		utils.ThresholdProfilePrefix:   {},
-		utils.FilterPrefix:             {},
-		utils.RouteProfilePrefix:       {},
-		utils.AttributeProfilePrefix:   {},
-		utils.ChargerProfilePrefix:     {},
-		utils.DispatcherProfilePrefix:  {},
-		utils.DispatcherHostPrefix:     {},
-		utils.AttributeFilterIndexes:   {},
-		utils.ResourceFilterIndexes:    {},
-		utils.StatFilterIndexes:        {},
-		utils.ThresholdFilterIndexes:   {},
-		utils.RouteFilterIndexes:       {},
-		utils.ChargerFilterIndexes:     {},
-		utils.DispatcherFilterIndexes:  {},
-		utils.FilterIndexPrfx:          {},
-		utils.MetaAPIBan:               {}, // not realy a prefix as this is not stored in DB
+		utils.DestinationPrefix:         {},
+		utils.ReverseDestinationPrefix:  {},
+		utils.RatingPlanPrefix:          {},
+		utils.RatingProfilePrefix:       {},
+		utils.ActionPrefix:              {},
+		utils.ActionPlanPrefix:          {},
+		utils.AccountActionPlansPrefix:  {},
+		utils.ActionTriggerPrefix:       {},
+		utils.SharedGroupPrefix:         {},
+		utils.ResourceProfilesPrefix:    {},
+		utils.TimingsPrefix:             {},
+		utils.ResourcesPrefix:           {},
+		utils.StatQueuePrefix:           {},
+		utils.StatQueueProfilePrefix:    {},
+		utils.ThresholdPrefix:           {},
+		utils.ThresholdProfilePrefix:    {},
+		utils.FilterPrefix:              {},
+		utils.RouteProfilePrefix:        {},
+		utils.AttributeProfilePrefix:    {},
+		utils.ChargerProfilePrefix:      {},
+		utils.DispatcherProfilePrefix:   {},
+		utils.DispatcherHostPrefix:      {},
+		utils.ExchangeRateProfilePrefix: {},
+		utils.LookupTablePrefix:         {},
+		utils.AttributeFilterIndexes:    {},
+		utils.ResourceFilterIndexes:     {},
+		utils.StatFilterIndexes:         {},
+		utils.ThresholdFilterIndexes:    {},
+		utils.RouteFilterIndexes:        {},
+		utils.ChargerFilterIndexes:      {},
+		utils.DispatcherFilterIndexes:   {},
+		utils.FilterIndexPrfx:           {},
+		utils.MetaAPIBan:                {}, // not realy a prefix as this is not stored in DB
 	}
 )
 
@@ -213,6 +215,15 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.DispatcherHostPrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetDispatcherHost(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.ExchangeRateProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetExchangeRateProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.LookupTablePrefix:
+			var tnt, id, key string
+			if tnt, id, key, err = splitLookupEntryID(dataID); err != nil {
+				return
+			}
+			_, err = dm.GetLookupTableEntry(tnt, id, key, false, true, utils.NonTransactional)
 		case utils.AttributeFilterIndexes:
 			var tntCtx, idxKey string
 			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
@@ -580,6 +591,12 @@ func (dm *DataManager) GetAccount(id string) (acc *Account, err error) {
 			return nil, err
 		}
 	}
+	if config.CgrConfig().RalsCfg().BalanceLedger {
+		acc.setLedgerSnapshot()
+	}
+	if len(acc.removeExpiredReservations()) != 0 { // expired amounts are visible again
+		go dm.storeExpiredReservations(id)
+	}
 	return
 }
 
@@ -590,6 +607,9 @@ func (dm *DataManager) SetAccount(acc *Account) (err error) {
 	if err = dm.dataDB.SetAccountDrv(acc); err != nil {
 		return
 	}
+	if config.CgrConfig().RalsCfg().BalanceLedger {
+		storeLedgerEntries(acc) // the changes against the account as it was read with GetAccount
+	}
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccounts]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -624,6 +644,208 @@ func (dm *DataManager) RemoveAccount(id string) (err error) {
 	return
 }
 
+// GetTierCounter returns the TierCounter for the given tenant and ID
+func (dm *DataManager) GetTierCounter(tenant, id string) (tc *TierCounter, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetTierCounterDrv(tenant, id)
+}
+
+// SetTierCounter stores the TierCounter in DataDB
+func (dm *DataManager) SetTierCounter(tc *TierCounter) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetTierCounterDrv(tc)
+}
+
+// RemoveTierCounter removes the TierCounter from DataDB
+func (dm *DataManager) RemoveTierCounter(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.RemoveTierCounterDrv(tenant, id)
+}
+
+// GetRerateJob returns the RerateJob for the given tenant and ID
+func (dm *DataManager) GetRerateJob(tenant, id string) (job *RerateJob, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetRerateJobDrv(tenant, id)
+}
+
+// SetRerateJob stores the RerateJob in DataDB
+func (dm *DataManager) SetRerateJob(job *RerateJob) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetRerateJobDrv(job)
+}
+
+// RemoveRerateJob removes the RerateJob from DataDB
+func (dm *DataManager) RemoveRerateJob(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.RemoveRerateJobDrv(tenant, id)
+}
+
+// GetSessionsBackup returns the active sessions backed up by the given node
+func (dm *DataManager) GetSessionsBackup(nodeID string) (ss []*StoredSession, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetSessionsBackupDrv(nodeID)
+}
+
+// SetSessionsBackup overwrites the active sessions backed up by the given node
+func (dm *DataManager) SetSessionsBackup(nodeID string, ss []*StoredSession) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetSessionsBackupDrv(nodeID, ss)
+}
+
+// RemoveSessionsBackup removes the active sessions backed up by the given node
+func (dm *DataManager) RemoveSessionsBackup(nodeID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.RemoveSessionsBackupDrv(nodeID)
+}
+
+// GetExchangeRateProfile returns the ExchangeRateProfile converting from the currency given as id
+func (dm *DataManager) GetExchangeRateProfile(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (xrp *ExchangeRateProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheExchangeRateProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*ExchangeRateProfile), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if xrp, err = dm.dataDB.GetExchangeRateProfileDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheExchangeRateProfiles, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheExchangeRateProfiles, tntID, xrp, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// SetExchangeRateProfile stores the ExchangeRateProfile in DataDB with the rates sorted
+func (dm *DataManager) SetExchangeRateProfile(xrp *ExchangeRateProfile) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	xrp.Sort()
+	return dm.dataDB.SetExchangeRateProfileDrv(xrp)
+}
+
+// RemoveExchangeRateProfile removes the ExchangeRateProfile from DataDB
+func (dm *DataManager) RemoveExchangeRateProfile(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if _, err = dm.GetExchangeRateProfile(tenant, id, true, false, utils.NonTransactional); err != nil {
+		return
+	}
+	return dm.dataDB.RemoveExchangeRateProfileDrv(tenant, id)
+}
+
+// GetLookupTableEntry returns the fields stored for the key in the LookupTable
+func (dm *DataManager) GetLookupTableEntry(tenant, id, key string, cacheRead, cacheWrite bool,
+	transactionID string) (flds map[string]string, err error) {
+	entryID := utils.ConcatenatedKey(tenant, id, key)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheLookupTables, entryID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(map[string]string), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if flds, err = dm.dataDB.GetLookupTableEntryDrv(tenant, id, key); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheLookupTables, entryID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheLookupTables, entryID, flds, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// GetLookupTableEntryIDs returns the IDs of all entries stored for the LookupTable
+func (dm *DataManager) GetLookupTableEntryIDs(tenant, id string) (entryIDs []string, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	var keys []string
+	if keys, err = dm.dataDB.GetKeysForPrefix(utils.LookupTablePrefix +
+		utils.ConcatenatedKey(tenant, id, utils.EmptyString)); err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	entryIDs = make([]string, len(keys))
+	for i, key := range keys {
+		entryIDs[i] = key[len(utils.LookupTablePrefix):]
+	}
+	return
+}
+
+// SetLookupTable stores the entries of the LookupTable in DataDB
+// existing keys are overwritten while the ones not mentioned are kept
+func (dm *DataManager) SetLookupTable(lkt *LookupTable) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.dataDB.SetLookupTableDrv(lkt)
+}
+
+// RemoveLookupTable removes all the entries of the LookupTable from DataDB
+// returning their IDs so they can be removed from cache
+func (dm *DataManager) RemoveLookupTable(tenant, id string) (entryIDs []string, err error) {
+	if entryIDs, err = dm.GetLookupTableEntryIDs(tenant, id); err != nil {
+		return
+	}
+	err = dm.dataDB.RemoveLookupTableDrv(tenant, id)
+	return
+}
+
 // GetFilter returns a filter based on the given ID
 func (dm *DataManager) GetFilter(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (fltr *Filter, err error) {
@@ -1191,6 +1413,9 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 		oldSts.QueueLength != sqp.QueueLength ||
 		oldSts.TTL != sqp.TTL ||
 		oldSts.MinItems != sqp.MinItems ||
+		oldSts.WindowType != sqp.WindowType ||
+		oldSts.WindowSize != sqp.WindowSize ||
+		oldSts.WindowSlide != sqp.WindowSlide ||
 		(oldSts.Stored != sqp.Stored && oldSts.Stored) { // reset the stats queue if the profile changed this fields
 		guardian.Guardian.Guard(func() (_ error) { // we change the queue so lock it
 			var sq *StatQueue
@@ -57,6 +57,9 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 			utils.RatingPlanID:          ts.RatingPlanId,
 			utils.Subject:               ts.MatchedSubject,
 		}
+		if tier, has := ts.tier(); has {
+			rf[utils.Tier] = tier
+		}
 		isPause := ts.RatingPlanId == utils.MetaPause
 		cIl.RatingID = ec.ratingIDForRateInterval(ts.RateInterval, rf, isPause)
 		if len(ts.Increments) != 0 {
@@ -97,10 +100,11 @@ func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilte
 				rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause)
 			}
 			bc := &BalanceCharge{
-				AccountID:   incr.BalanceInfo.AccountID,
-				BalanceUUID: incr.BalanceInfo.Monetary.UUID,
-				Units:       incr.Cost,
-				RatingID:    rateID,
+				AccountID:    incr.BalanceInfo.AccountID,
+				BalanceUUID:  incr.BalanceInfo.Monetary.UUID,
+				Units:        incr.Cost,
+				RatingID:     rateID,
+				ExchangeRate: incr.BalanceInfo.Monetary.ExchangeRate,
 			}
 			if isPause {
 				ecUUID = utils.MetaPause
@@ -130,10 +134,11 @@ func (ec *EventCost) newChargingIncrement(incr *Increment, rf RatingMatchedFilte
 			rateID = ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf, isPause)
 		}
 		bc := &BalanceCharge{
-			AccountID:   incr.BalanceInfo.AccountID,
-			BalanceUUID: incr.BalanceInfo.Monetary.UUID,
-			Units:       incr.Cost,
-			RatingID:    rateID,
+			AccountID:    incr.BalanceInfo.AccountID,
+			BalanceUUID:  incr.BalanceInfo.Monetary.UUID,
+			Units:        incr.Cost,
+			RatingID:     rateID,
+			ExchangeRate: incr.BalanceInfo.Monetary.ExchangeRate,
 		}
 		if isPause {
 			cIt.AccountingID = utils.MetaPause
@@ -370,7 +375,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					}
 					blncSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(ec.Accounting[cIcrm.AccountingID].BalanceUUID)
 					if blncSmry.Type == utils.MetaMonetary {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID,
+							ExchangeRate: ec.Accounting[cIcrm.AccountingID].ExchangeRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: blncSmry.UUID}
 					}
@@ -383,7 +389,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					extraSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(
 						ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].BalanceUUID)
 					if extraSmry.Type == utils.MetaMonetary {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID,
+							ExchangeRate: ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].ExchangeRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: extraSmry.UUID}
 					}
@@ -483,7 +490,7 @@ func (ec *EventCost) newIntervalFromCharge(cInc *ChargingIncrement) (incr *Incre
 		}
 	}
 	if cBC.ExtraChargeID != utils.MetaNone {
-		incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID}
+		incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID, ExchangeRate: cBC.ExchangeRate}
 		incr.BalanceInfo.Monetary.RateInterval = ec.rateIntervalForRatingID(cBC.RatingID)
 	}
 	return
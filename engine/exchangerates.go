@@ -0,0 +1,133 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ExchangeRate is the rate applied starting with ActivationTime
+type ExchangeRate struct {
+	ActivationTime time.Time
+	Rate           float64
+}
+
+// ExchangeRateProfile holds the rates converting from the currency given as ID into other currencies
+type ExchangeRateProfile struct {
+	Tenant string
+	ID     string                     // the currency the rates are converting from
+	Rates  map[string][]*ExchangeRate // indexed on the currency converted into, sorted on ActivationTime
+}
+
+// ExchangeRateProfileWithAPIOpts is used by the API setting the ExchangeRateProfile
+type ExchangeRateProfileWithAPIOpts struct {
+	*ExchangeRateProfile
+	APIOpts map[string]interface{}
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (xrp *ExchangeRateProfile) TenantID() string {
+	return utils.ConcatenatedKey(xrp.Tenant, xrp.ID)
+}
+
+// Clone returns a deep copy of the ExchangeRateProfile
+func (xrp *ExchangeRateProfile) Clone() (cln *ExchangeRateProfile) {
+	if xrp == nil {
+		return
+	}
+	cln = &ExchangeRateProfile{
+		Tenant: xrp.Tenant,
+		ID:     xrp.ID,
+	}
+	if xrp.Rates != nil {
+		cln.Rates = make(map[string][]*ExchangeRate, len(xrp.Rates))
+		for currency, rates := range xrp.Rates {
+			cln.Rates[currency] = make([]*ExchangeRate, len(rates))
+			for i, xr := range rates {
+				cln.Rates[currency][i] = &ExchangeRate{
+					ActivationTime: xr.ActivationTime,
+					Rate:           xr.Rate,
+				}
+			}
+		}
+	}
+	return
+}
+
+// Sort orders the rates of each currency on ActivationTime
+func (xrp *ExchangeRateProfile) Sort() {
+	for _, rates := range xrp.Rates {
+		sort.Slice(rates, func(i, j int) bool {
+			return rates[i].ActivationTime.Before(rates[j].ActivationTime)
+		})
+	}
+}
+
+// RateAt returns the rate into the currency which was active at the given time
+func (xrp *ExchangeRateProfile) RateAt(currency string, t time.Time) (rate float64, has bool) {
+	for _, xr := range xrp.Rates[currency] {
+		if xr.ActivationTime.After(t) {
+			break
+		}
+		rate, has = xr.Rate, true
+	}
+	return
+}
+
+// getExchangeRate returns the rate converting between the two currencies at the given time
+// the inverse of the opposite rate is used when no direct one is defined
+func getExchangeRate(tnt, from, to string, t time.Time) (rate float64, err error) {
+	var xrp *ExchangeRateProfile
+	if xrp, err = dm.GetExchangeRateProfile(tnt, from, true, true, utils.NonTransactional); err == nil {
+		if rate, has := xrp.RateAt(to, t); has {
+			return rate, nil
+		}
+	} else if err != utils.ErrNotFound {
+		return
+	}
+	if xrp, err = dm.GetExchangeRateProfile(tnt, to, true, true, utils.NonTransactional); err == nil {
+		if rate, has := xrp.RateAt(from, t); has && rate != 0 {
+			return 1 / rate, nil
+		}
+	} else if err != utils.ErrNotFound {
+		return
+	}
+	return 0, utils.ErrExchangeRateNotFound
+}
+
+// exchangeCost converts the cost from the currency it was rated in into the currency of the balance
+// the rate returned is 0 if no conversion was needed
+func (b *Balance) exchangeCost(cost float64, currency, tnt string, setupTime time.Time) (amount, rate float64, err error) {
+	dfltCurrency := config.CgrConfig().RalsCfg().DefaultCurrency
+	balanceCurrency := utils.FirstNonEmpty(b.Currency, dfltCurrency)
+	if currency = utils.FirstNonEmpty(currency, dfltCurrency); cost == 0 ||
+		currency == balanceCurrency ||
+		currency == utils.EmptyString ||
+		balanceCurrency == utils.EmptyString {
+		return cost, 0, nil
+	}
+	if rate, err = getExchangeRate(tnt, currency, balanceCurrency, setupTime); err != nil {
+		return
+	}
+	return utils.Round(cost*rate, globalRoundingDecimals, utils.MetaRoundingMiddle), rate, nil
+}
@@ -0,0 +1,184 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestExchangeRateProfileRateAt(t *testing.T) {
+	xrp := &ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "EUR",
+		Rates: map[string][]*ExchangeRate{
+			"USD": {
+				{ActivationTime: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), Rate: 1.2},
+				{ActivationTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+			},
+		},
+	}
+	xrp.Sort()
+	if _, has := xrp.RateAt("USD", time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)); has {
+		t.Error("Expected no rate before the first activation")
+	}
+	if rate, has := xrp.RateAt("USD", time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)); !has || rate != 1.1 {
+		t.Errorf("Expected rate 1.1, received %v", rate)
+	}
+	if rate, has := xrp.RateAt("USD", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)); !has || rate != 1.2 {
+		t.Errorf("Expected rate 1.2, received %v", rate)
+	}
+	if _, has := xrp.RateAt("RON", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)); has {
+		t.Error("Expected no rate for RON")
+	}
+}
+
+func TestExchangeRateGetExchangeRate(t *testing.T) {
+	if err := dm.SetExchangeRateProfile(&ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "GBP",
+		Rates: map[string][]*ExchangeRate{
+			"CHF": {{Rate: 1.25}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tm := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+	if rate, err := getExchangeRate("cgrates.org", "GBP", "CHF", tm); err != nil {
+		t.Error(err)
+	} else if rate != 1.25 {
+		t.Errorf("Expected rate 1.25, received %v", rate)
+	}
+	if rate, err := getExchangeRate("cgrates.org", "CHF", "GBP", tm); err != nil {
+		t.Error(err)
+	} else if rate != 0.8 {
+		t.Errorf("Expected inverse rate 0.8, received %v", rate)
+	}
+	if _, err := getExchangeRate("cgrates.org", "GBP", "JPY", tm); err != utils.ErrExchangeRateNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrExchangeRateNotFound, err)
+	}
+}
+
+func TestExchangeRateDebitCreditBalance(t *testing.T) {
+	if err := dm.SetExchangeRateProfile(&ExchangeRateProfile{
+		Tenant: "xrate.org",
+		ID:     "EUR",
+		Rates: map[string][]*ExchangeRate{
+			"USD": {
+				{ActivationTime: time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+				{ActivationTime: time.Date(2013, 9, 24, 10, 0, 0, 0, time.UTC), Rate: 1.2},
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &CallCost{
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
+				DurationIndex: 0,
+				ratingInfo:    &RatingInfo{Currency: "EUR"},
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Rates: RateGroups{
+							&RGRate{GroupIntervalStart: 0,
+								Value:         1,
+								RateIncrement: 10 * time.Second,
+								RateUnit:      time.Second}}}},
+			},
+		},
+		ToR: utils.MetaVoice,
+	}
+	cd := &CallDescriptor{
+		Tenant:        "xrate.org",
+		SetupTime:     time.Date(2013, 9, 24, 9, 48, 0, 0, time.UTC), // rate 1.1 applies at setup
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Destination:   cc.Destination,
+		ToR:           cc.ToR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	acc := &Account{ID: "xrate.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {&Balance{Uuid: "money", Value: 50, Currency: "USD"}},
+		}}
+	var err error
+	if cc, err = acc.debitCreditBalance(cd, false, false, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := acc.BalanceMap[utils.MetaMonetary][0].GetValue(); rcv != 39 {
+		t.Errorf("Expected 39 left on the balance, received %v", rcv)
+	}
+	if inc := cc.Timespans[0].Increments[0]; inc.Cost != 10 ||
+		inc.BalanceInfo.Monetary.UUID != "money" ||
+		inc.BalanceInfo.Monetary.ExchangeRate != 1.1 {
+		t.Errorf("Unexpected increment: %s", utils.ToJSON(inc))
+	}
+	ec := NewEventCostFromCallCost(cc, "cgrid", utils.MetaDefault)
+	for _, bc := range ec.Accounting {
+		if bc.BalanceUUID == "money" && bc.ExchangeRate != 1.1 {
+			t.Errorf("Expected the exchange rate recorded in the EventCost, received %s", utils.ToJSON(bc))
+		}
+	}
+}
+
+func TestExchangeRateDebitCreditBalanceNoRate(t *testing.T) {
+	cc := &CallCost{
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 10, 0, time.UTC),
+				DurationIndex: 0,
+				ratingInfo:    &RatingInfo{Currency: "EUR"},
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Rates: RateGroups{
+							&RGRate{GroupIntervalStart: 0,
+								Value:         1,
+								RateIncrement: 10 * time.Second,
+								RateUnit:      time.Second}}}},
+			},
+		},
+		ToR: utils.MetaVoice,
+	}
+	cd := &CallDescriptor{
+		Tenant:        "norate.org",
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Destination:   cc.Destination,
+		ToR:           cc.ToR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	acc := &Account{ID: "norate.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {&Balance{Uuid: "dflt", ID: utils.MetaDefault, Value: 5, Currency: "USD"}},
+		}}
+	if _, err := acc.debitCreditBalance(cd, false, false, true, nil); err == nil {
+		t.Error("Expected error when the cost cannot be converted")
+	}
+	if rcv := acc.BalanceMap[utils.MetaMonetary][0].GetValue(); rcv != 5 {
+		t.Errorf("Expected the balance untouched, received %v", rcv)
+	}
+}
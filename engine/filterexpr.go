@@ -0,0 +1,437 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// operators and punctuation of the *expr filters
+const (
+	exprAnd          = "&&"
+	exprOr           = "||"
+	exprNot          = "!"
+	exprEqual        = "=="
+	exprNotEqual     = "!="
+	exprLessThan     = "<"
+	exprLessOrEqual  = "<="
+	exprGreaterThan  = ">"
+	exprGreaterOrEq  = ">="
+	exprMatch        = "=~"
+	exprNotMatch     = "!~"
+	exprParenOpen    = "("
+	exprParenClose   = ")"
+	exprOperatorSyms = "=!<>&|()"
+)
+
+// exprComparisons are the binary operators comparing two operands
+var exprComparisons = utils.NewStringSet([]string{exprEqual, exprNotEqual,
+	exprLessThan, exprLessOrEqual, exprGreaterThan, exprGreaterOrEq,
+	exprMatch, exprNotMatch})
+
+type exprTokenKind int
+
+const (
+	exprTknOperator exprTokenKind = iota
+	exprTknPath                   // ~*req.Usage
+	exprTknLiteral                // 60s or "^49"
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	val  string
+	pos  int
+}
+
+// tokenizeExpr splits the expression into tokens
+// the operators are recognized only after an operand so the paths can be negated (ie. !~*req.Flag)
+func tokenizeExpr(expr string) (tkns []*exprToken, err error) {
+	afterOperand := false // previous token ended an operand
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tkns = append(tkns, &exprToken{kind: exprTknOperator, val: string(c), pos: i})
+			afterOperand = c == ')'
+			i++
+		case c == '"' || c == '\'':
+			var val string
+			start := i
+			if val, i, err = readExprQuoted(expr, i); err != nil {
+				return
+			}
+			tkns = append(tkns, &exprToken{kind: exprTknLiteral, val: val, pos: start})
+			afterOperand = true
+		case afterOperand && strings.IndexByte(exprOperatorSyms, c) != -1:
+			op := string(c)
+			if i+1 < len(expr) {
+				switch two := expr[i : i+2]; two {
+				case exprAnd, exprOr, exprEqual, exprNotEqual,
+					exprLessOrEqual, exprGreaterOrEq, exprMatch, exprNotMatch:
+					op = two
+				}
+			}
+			if op == exprNot || op == "=" || op == "&" || op == "|" {
+				return nil, fmt.Errorf("unknown operator <%s> at position %d", op, i)
+			}
+			tkns = append(tkns, &exprToken{kind: exprTknOperator, val: op, pos: i})
+			afterOperand = false
+			i += len(op)
+		case c == '!':
+			tkns = append(tkns, &exprToken{kind: exprTknOperator, val: exprNot, pos: i})
+			i++
+		default:
+			start := i
+			i = readExprOperand(expr, i)
+			if i == start {
+				return nil, fmt.Errorf("unexpected <%c> at position %d", c, i)
+			}
+			kind := exprTknLiteral
+			if strings.HasPrefix(expr[start:i], utils.DynamicDataPrefix) {
+				kind = exprTknPath
+			}
+			tkns = append(tkns, &exprToken{kind: kind, val: expr[start:i], pos: start})
+			afterOperand = true
+		}
+	}
+	return
+}
+
+// readExprQuoted reads the quoted literal starting at idx, only the quote char can be escaped
+func readExprQuoted(expr string, idx int) (val string, end int, err error) {
+	quote := expr[idx]
+	var sb strings.Builder
+	for i := idx + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if i+1 < len(expr) && expr[i+1] == quote {
+				i++
+			}
+		case quote:
+			return sb.String(), i + 1, nil
+		}
+		sb.WriteByte(expr[i])
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", idx)
+}
+
+// readExprOperand returns the end of the unquoted operand starting at idx
+// the converters between curly brackets are kept within the operand
+func readExprOperand(expr string, idx int) int {
+	var depth int
+	for ; idx < len(expr); idx++ {
+		c := expr[idx]
+		switch {
+		case c == '{':
+			depth++
+		case c == '}' && depth != 0:
+			depth--
+		case depth != 0:
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' ||
+			strings.IndexByte(exprOperatorSyms, c) != -1:
+			return idx
+		}
+	}
+	return idx
+}
+
+// exprNode is one node of the compiled expression
+type exprNode interface {
+	pass(dDP utils.DataProvider) (bool, error)
+}
+
+// exprParser builds the tree out of tokens using recursive descent:
+// or := and ("||" and)*; and := unary ("&&" unary)*; unary := "!" unary | primary
+// primary := "(" or ")" | operand [comparison operand]
+type exprParser struct {
+	tkns []*exprToken
+	idx  int
+}
+
+func (p *exprParser) peek() *exprToken {
+	if p.idx < len(p.tkns) {
+		return p.tkns[p.idx]
+	}
+	return nil
+}
+
+func (p *exprParser) isOperator(op string) bool {
+	tkn := p.peek()
+	return tkn != nil && tkn.kind == exprTknOperator && tkn.val == op
+}
+
+func (p *exprParser) parseOr() (n exprNode, err error) {
+	if n, err = p.parseAnd(); err != nil {
+		return
+	}
+	for p.isOperator(exprOr) {
+		p.idx++
+		var right exprNode
+		if right, err = p.parseAnd(); err != nil {
+			return
+		}
+		n = &exprOrNode{left: n, right: right}
+	}
+	return
+}
+
+func (p *exprParser) parseAnd() (n exprNode, err error) {
+	if n, err = p.parseUnary(); err != nil {
+		return
+	}
+	for p.isOperator(exprAnd) {
+		p.idx++
+		var right exprNode
+		if right, err = p.parseUnary(); err != nil {
+			return
+		}
+		n = &exprAndNode{left: n, right: right}
+	}
+	return
+}
+
+func (p *exprParser) parseUnary() (n exprNode, err error) {
+	if p.isOperator(exprNot) {
+		p.idx++
+		if n, err = p.parseUnary(); err != nil {
+			return
+		}
+		return &exprNotNode{node: n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (n exprNode, err error) {
+	tkn := p.peek()
+	if tkn == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	if tkn.kind == exprTknOperator {
+		if tkn.val != exprParenOpen {
+			return nil, fmt.Errorf("unexpected <%s> at position %d", tkn.val, tkn.pos)
+		}
+		p.idx++
+		if n, err = p.parseOr(); err != nil {
+			return
+		}
+		if !p.isOperator(exprParenClose) {
+			return nil, fmt.Errorf("missing <%s> for <%s> at position %d", exprParenClose, exprParenOpen, tkn.pos)
+		}
+		p.idx++
+		return
+	}
+	p.idx++
+	var left *exprOperand
+	if left, err = newExprOperand(tkn); err != nil {
+		return
+	}
+	opTkn := p.peek()
+	if opTkn == nil || opTkn.kind != exprTknOperator ||
+		!exprComparisons.Has(opTkn.val) { // single operand evaluated as boolean
+		return &exprBoolNode{operand: left}, nil
+	}
+	p.idx++
+	rightTkn := p.peek()
+	if rightTkn == nil || rightTkn.kind == exprTknOperator {
+		return nil, fmt.Errorf("missing operand after <%s> at position %d", opTkn.val, opTkn.pos)
+	}
+	p.idx++
+	cmp := &exprCmpNode{op: opTkn.val, left: left}
+	if cmp.op == exprMatch || cmp.op == exprNotMatch {
+		if rightTkn.kind != exprTknLiteral {
+			return nil, fmt.Errorf("regular expression expected after <%s> at position %d", opTkn.val, opTkn.pos)
+		}
+		if cmp.regex, err = regexp.Compile(rightTkn.val); err != nil {
+			return nil, fmt.Errorf("invalid regular expression at position %d: %s", rightTkn.pos, err)
+		}
+		return cmp, nil
+	}
+	if cmp.right, err = newExprOperand(rightTkn); err != nil {
+		return
+	}
+	return cmp, nil
+}
+
+// newExprOperand compiles the operand out of token
+func newExprOperand(tkn *exprToken) (op *exprOperand, err error) {
+	op = &exprOperand{value: tkn.val}
+	if tkn.kind != exprTknPath {
+		return
+	}
+	if err = utils.IsPathValid(tkn.val); err != nil {
+		return nil, fmt.Errorf("invalid path <%s> at position %d: %s", tkn.val, tkn.pos, err)
+	}
+	if op.rsr, err = config.NewRSRParser(tkn.val); err != nil {
+		return nil, fmt.Errorf("invalid path <%s> at position %d: %s", tkn.val, tkn.pos, err)
+	}
+	return
+}
+
+// exprOperand is either a path in the DataProvider or a constant value
+type exprOperand struct {
+	value string
+	rsr   *config.RSRParser // populated for paths
+}
+
+// fieldValue returns the value of the operand, has is false for the paths not found in the DataProvider
+func (op *exprOperand) fieldValue(dDP utils.DataProvider) (val string, has bool, err error) {
+	if op.rsr == nil {
+		return op.value, true, nil
+	}
+	if val, err = op.rsr.ParseDataProviderWithInterfaces(dDP); err != nil {
+		if err == utils.ErrNotFound {
+			return utils.EmptyString, false, nil
+		}
+		return
+	}
+	return val, true, nil
+}
+
+type exprOrNode struct {
+	left, right exprNode
+}
+
+func (n *exprOrNode) pass(dDP utils.DataProvider) (pass bool, err error) {
+	if pass, err = n.left.pass(dDP); err != nil || pass {
+		return
+	}
+	return n.right.pass(dDP)
+}
+
+type exprAndNode struct {
+	left, right exprNode
+}
+
+func (n *exprAndNode) pass(dDP utils.DataProvider) (pass bool, err error) {
+	if pass, err = n.left.pass(dDP); err != nil || !pass {
+		return
+	}
+	return n.right.pass(dDP)
+}
+
+type exprNotNode struct {
+	node exprNode
+}
+
+func (n *exprNotNode) pass(dDP utils.DataProvider) (pass bool, err error) {
+	if pass, err = n.node.pass(dDP); err != nil {
+		return
+	}
+	return !pass, nil
+}
+
+// exprBoolNode passes if the operand is present and its value is true
+type exprBoolNode struct {
+	operand *exprOperand
+}
+
+func (n *exprBoolNode) pass(dDP utils.DataProvider) (pass bool, err error) {
+	var val string
+	var has bool
+	if val, has, err = n.operand.fieldValue(dDP); err != nil || !has {
+		return
+	}
+	if pass, err = utils.IfaceAsBool(val); err != nil {
+		return false, fmt.Errorf("cannot use <%s> as boolean: %s", n.operand.value, err)
+	}
+	return
+}
+
+// exprCmpNode compares two operands, failing if any of the paths is not found
+type exprCmpNode struct {
+	op          string
+	left, right *exprOperand
+	regex       *regexp.Regexp // compiled for =~ and !~
+}
+
+func (n *exprCmpNode) pass(dDP utils.DataProvider) (pass bool, err error) {
+	var lVal, rVal string
+	var has bool
+	if lVal, has, err = n.left.fieldValue(dDP); err != nil || !has {
+		return
+	}
+	if n.regex != nil {
+		return n.regex.MatchString(lVal) == (n.op == exprMatch), nil
+	}
+	if rVal, has, err = n.right.fieldValue(dDP); err != nil || !has {
+		return
+	}
+	lIf, rIf := utils.StringToInterface(lVal), utils.StringToInterface(rVal)
+	switch n.op {
+	case exprEqual, exprNotEqual:
+		var eq bool
+		if eq, err = utils.EqualTo(lIf, rIf); err != nil { // not comparable as types, compare the strings
+			eq, err = lVal == rVal, nil
+		}
+		return eq == (n.op == exprEqual), nil
+	case exprGreaterThan, exprLessOrEqual:
+		var gt bool
+		if gt, err = utils.GreaterThan(lIf, rIf, false); err != nil {
+			return
+		}
+		return gt == (n.op == exprGreaterThan), nil
+	default: // exprGreaterOrEq, exprLessThan
+		var gte bool
+		if gte, err = utils.GreaterThan(lIf, rIf, true); err != nil {
+			return
+		}
+		return gte == (n.op == exprGreaterOrEq), nil
+	}
+}
+
+// newFilterExpr compiles the expression used by the *expr filters
+func newFilterExpr(expr string) (fe *filterExpr, err error) {
+	var tkns []*exprToken
+	if tkns, err = tokenizeExpr(expr); err != nil {
+		return nil, fmt.Errorf("invalid expression <%s>: %s", expr, err)
+	}
+	p := &exprParser{tkns: tkns}
+	var root exprNode
+	if root, err = p.parseOr(); err != nil {
+		return nil, fmt.Errorf("invalid expression <%s>: %s", expr, err)
+	}
+	if tkn := p.peek(); tkn != nil {
+		return nil, fmt.Errorf("invalid expression <%s>: unexpected <%s> at position %d", expr, tkn.val, tkn.pos)
+	}
+	fe = &filterExpr{expr: expr, root: root}
+	for _, tkn := range tkns {
+		if tkn.kind == exprTknPath {
+			fe.paths = append(fe.paths, tkn.val)
+		}
+	}
+	return
+}
+
+// filterExpr is the compiled boolean expression of an *expr filter
+type filterExpr struct {
+	expr  string
+	root  exprNode
+	paths []string // the paths used within expression
+}
+
+// Pass evaluates the expression over the DataProvider
+func (fe *filterExpr) Pass(dDP utils.DataProvider) (bool, error) {
+	return fe.root.pass(dDP)
+}
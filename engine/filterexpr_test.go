@@ -0,0 +1,171 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFilterExprPass(t *testing.T) {
+	ev := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.AccountField: "1001",
+			utils.Destination:  "+4986517174963",
+			utils.Usage:        2 * time.Minute,
+			"Flag":             "true",
+		},
+		utils.MetaOpts: utils.MapStorage{
+			utils.OptsContext: "priority",
+		},
+	}
+	testCases := []struct {
+		expr string
+		pass bool
+	}{
+		{`~*req.Usage > 60s`, true},
+		{`~*req.Usage <= 60s`, false},
+		{`~*req.Usage >= 2m`, true},
+		{`~*req.Usage < 2m`, false},
+		{`~*req.Account == 1001`, true},
+		{`~*req.Account == "1001"`, true},
+		{`~*req.Account != '1001'`, false},
+		{`~*req.Destination =~ "^\+49"`, true},
+		{`~*req.Destination !~ "^\+49"`, false},
+		{`~*opts.*context == priority`, true},
+		{`~*req.Usage > 60s && (~*req.Destination =~ "^49" || ~*opts.*context == "priority")`, true},
+		{`~*req.Usage > 60s && (~*req.Destination =~ "^49" || ~*opts.*context == "low")`, false},
+		{`~*req.Usage > 60s && ~*req.Destination =~ "^49" || ~*opts.*context == "priority"`, true},
+		{`!(~*req.Account == 1002)`, true},
+		{`!~*req.Flag`, false},
+		{`~*req.Flag&&~*req.Account==1001`, true},
+		{`~*req.Missing == 1001`, false},
+		{`!(~*req.Missing == 1001)`, true},
+		{`~*req.Missing`, false},
+		{`~*req.Usage{*duration_seconds} == 120`, true},
+	}
+	for _, tc := range testCases {
+		fe, err := newFilterExpr(tc.expr)
+		if err != nil {
+			t.Errorf("expression <%s>: %v", tc.expr, err)
+			continue
+		}
+		if pass, err := fe.Pass(ev); err != nil {
+			t.Errorf("expression <%s>: %v", tc.expr, err)
+		} else if pass != tc.pass {
+			t.Errorf("expression <%s>: expected %v, received %v", tc.expr, tc.pass, pass)
+		}
+	}
+}
+
+func TestFilterExprCompileErrors(t *testing.T) {
+	testCases := []struct {
+		expr string
+		err  string
+	}{
+		{``, "invalid expression <>: unexpected end of expression"},
+		{`~*req.Usage >`, "invalid expression <~*req.Usage >>: missing operand after <>> at position 12"},
+		{`(~*req.Usage > 60s`, "invalid expression <(~*req.Usage > 60s>: missing <)> for <(> at position 0"},
+		{`~*req.Usage > 60s)`, "invalid expression <~*req.Usage > 60s)>: unexpected <)> at position 17"},
+		{`~*req.Usage = 60s`, "invalid expression <~*req.Usage = 60s>: unknown operator <=> at position 12"},
+		{`~*req.Usage > 60s & 1`, "invalid expression <~*req.Usage > 60s & 1>: unknown operator <&> at position 18"},
+		{`~*req.Account == "1001`, "invalid expression <~*req.Account == \"1001>: unterminated string starting at position 17"},
+		{`~*req.Account =~ "a(b"`, "invalid expression <~*req.Account =~ \"a(b\">: invalid regular expression at position 17: error parsing regexp: missing closing ): `a(b`"},
+		{`~*req.Account =~ ~*req.Subject`, "invalid expression <~*req.Account =~ ~*req.Subject>: regular expression expected after <=~> at position 14"},
+		{`~*req. == 1`, "invalid expression <~*req. == 1>: invalid path <~*req.> at position 0: Empty field path "},
+		{`&& ~*req.Flag`, "invalid expression <&& ~*req.Flag>: unexpected <&> at position 0"},
+	}
+	for _, tc := range testCases {
+		if _, err := newFilterExpr(tc.expr); err == nil || err.Error() != tc.err {
+			t.Errorf("expression <%s>: expected error <%s>, received <%v>", tc.expr, tc.err, err)
+		}
+	}
+}
+
+func TestFilterExprPassIncomparable(t *testing.T) {
+	fe, err := newFilterExpr(`~*req.Account > 10s`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: "dan"}}
+	if _, err := fe.Pass(ev); err == nil {
+		t.Error("expected incomparable error")
+	}
+}
+
+func TestFilterExprPaths(t *testing.T) {
+	fe, err := newFilterExpr(`~*req.Usage > 60s && (~*req.Destination =~ "^49" || ~*opts.*context == "priority")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{"~*req.Usage", "~*req.Destination", "~*opts.*context"}
+	if !reflect.DeepEqual(exp, fe.paths) {
+		t.Errorf("expected %q, received %q", exp, fe.paths)
+	}
+}
+
+func TestFilterPassExpr(t *testing.T) {
+	ev := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.AccountField: "1001",
+			utils.Usage:        "90s",
+		},
+	}
+	fltr, err := NewFilterFromInline("cgrates.org", `*expr::~*req.Usage > 60s && (~*req.Account == 1002 || ~*req.Account == 1001)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{`~*req.Usage > 60s && (~*req.Account == 1002 || ~*req.Account == 1001)`}; !reflect.DeepEqual(exp, fltr.Rules[0].Values) {
+		t.Errorf("expected %q, received %q", exp, fltr.Rules[0].Values)
+	}
+	if pass, err := fltr.Rules[0].Pass(ev); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("not passing")
+	}
+	rf, err := NewFilterRule(utils.MetaNotExpr, utils.EmptyString,
+		[]string{`~*req.Usage > 2m`, `~*req.Account == 1001`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pass, err := rf.Pass(ev); err != nil {
+		t.Error(err)
+	} else if pass {
+		t.Error("passing")
+	}
+	if _, err := NewFilterRule(utils.MetaExpr, utils.EmptyString, nil); err == nil ||
+		err.Error() != "Values is mandatory for Type: *expr" {
+		t.Errorf("received error: %v", err)
+	}
+	expErr := "invalid expression <~*req.Usage >>: missing operand after <>> at position 12 for filter"
+	if err := CheckFilter(&Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_EXPR",
+		Rules: []*FilterRule{{
+			Type:   utils.MetaExpr,
+			Values: []string{`~*req.Usage >`},
+		}},
+	}); err == nil || !strings.HasPrefix(err.Error(), expErr) {
+		t.Errorf("expected error: <%s>, received: <%v>", expErr, err)
+	}
+}
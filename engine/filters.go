@@ -23,6 +23,7 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -102,6 +103,21 @@ func verifyPrefixes(rule *FilterRule, prefixes []string) (hasPrefix bool) {
 			return
 		}
 	}
+	if rule.geoElement != nil { // both latitude and longitude need to be checked
+		for _, path := range strings.Split(rule.Element, utils.InfieldSep) {
+			if strings.HasPrefix(path, utils.DynamicDataPrefix) &&
+				!checkPrefix(path, prefixes) {
+				return false
+			}
+		}
+	}
+	for _, expr := range rule.exprValues { // all the paths within the expression need to be checked
+		for _, path := range expr.paths {
+			if !checkPrefix(path, prefixes) {
+				return false
+			}
+		}
+	}
 	for _, value := range rule.Values {
 		hasPrefix = false // reset hasPrefix
 		if strings.HasPrefix(value, utils.DynamicDataPrefix) {
@@ -176,7 +192,10 @@ func NewFilterFromInline(tenant, inlnRule string) (f *Filter, err error) {
 		return nil, fmt.Errorf("inline parse error for string: <%s>", inlnRule)
 	}
 	var vals []string
-	if ruleSplt[2] != utils.EmptyString {
+	if ruleSplt[0] == utils.MetaExpr ||
+		ruleSplt[0] == utils.MetaNotExpr { // the expression can contain the value separator
+		vals = []string{ruleSplt[2]}
+	} else if ruleSplt[2] != utils.EmptyString {
 		vals = splitDynFltrValues(ruleSplt[2], utils.PipeSep)
 	}
 	f = &Filter{
@@ -229,19 +248,22 @@ var supportedFiltersType utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
 	utils.MetaIPNet, utils.MetaAPIBan, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaExpr, utils.MetaGeoIP, utils.MetaGeoDistance,
+	utils.MetaTimeOfDay, utils.MetaDayOfWeek})
 var needsFieldName utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaString, utils.MetaPrefix, utils.MetaSuffix,
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
 	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan,
 	utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaGeoIP, utils.MetaGeoDistance, utils.MetaTimeOfDay,
+	utils.MetaDayOfWeek})
 var needsValues utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
 	utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaExpr, utils.MetaGeoIP, utils.MetaGeoDistance,
+	utils.MetaTimeOfDay, utils.MetaDayOfWeek})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -275,19 +297,61 @@ func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error)
 // FilterRule filters requests coming into various places
 // Pass rule: default negative, one matching rule should pass the filter
 type FilterRule struct {
-	Type        string            // Filter type (*string, *timing, *rsr_filters, *stats, *lt, *lte, *gt, *gte)
-	Element     string            // Name of the field providing us the Values to check (used in case of some )
-	Values      []string          // Filter definition
-	rsrValues   config.RSRParsers // Cache here the
-	rsrElement  *config.RSRParser // Cache here the
-	rsrFilters  utils.RSRFilters  // Cache here the RSRFilter Values
-	regexValues []*regexp.Regexp
-	negative    *bool
+	Type          string            // Filter type (*string, *timing, *rsr_filters, *stats, *lt, *lte, *gt, *gte)
+	Element       string            // Name of the field providing us the Values to check (used in case of some )
+	Values        []string          // Filter definition
+	rsrValues     config.RSRParsers // Cache here the
+	rsrElement    *config.RSRParser // Cache here the
+	rsrFilters    utils.RSRFilters  // Cache here the RSRFilter Values
+	regexValues   []*regexp.Regexp
+	exprValues    []*filterExpr      // compiled expressions for *expr
+	geoElement    config.RSRParsers  // latitude and longitude for *geo_distance
+	geoValues     []*geoCircle       // compiled values for *geo_distance
+	dayTimeValues []*dayTimeInterval // compiled values for *time_of_day and *day_of_week
+	negative      *bool
 }
 
 // CompileValues compiles RSR fields
 func (fltr *FilterRule) CompileValues() (err error) {
 	switch fltr.Type {
+	case utils.MetaExpr, utils.MetaNotExpr: // the paths are part of the expression so no element is needed
+		fltr.exprValues = make([]*filterExpr, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.exprValues[i], err = newFilterExpr(val); err != nil {
+				return
+			}
+		}
+		return
+	case utils.MetaGeoDistance, utils.MetaNotGeoDistance: // the element is composed of latitude and longitude
+		if fltr.geoElement, err = config.NewRSRParsers(fltr.Element, utils.InfieldSep); err != nil {
+			return
+		} else if len(fltr.geoElement) != 2 {
+			return fmt.Errorf("invalid element for %s filter: <%s>, expecting latitude%slongitude",
+				utils.MetaGeoDistance, fltr.Element, utils.InfieldSep)
+		}
+		fltr.geoValues = make([]*geoCircle, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.geoValues[i], err = newGeoCircle(val); err != nil {
+				return
+			}
+		}
+		return
+	case utils.MetaTimeOfDay, utils.MetaNotTimeOfDay:
+		fltr.dayTimeValues = make([]*dayTimeInterval, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.dayTimeValues[i], err = newTimeOfDayInterval(val,
+				config.CgrConfig().GeneralCfg().DefaultTimezone); err != nil {
+				return
+			}
+		}
+	case utils.MetaDayOfWeek, utils.MetaNotDayOfWeek:
+		fltr.dayTimeValues = make([]*dayTimeInterval, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.dayTimeValues[i], err = newDayOfWeekInterval(val,
+				config.CgrConfig().GeneralCfg().DefaultTimezone); err != nil {
+				return
+			}
+		}
 	case utils.MetaRegex, utils.MetaNotRegex:
 		fltr.regexValues = make([]*regexp.Regexp, len(fltr.Values))
 		for i, val := range fltr.Values {
@@ -355,6 +419,16 @@ func (fltr *FilterRule) Pass(dDP utils.DataProvider) (result bool, err error) {
 		result, err = fltr.passActivationInterval(dDP)
 	case utils.MetaRegex, utils.MetaNotRegex:
 		result, err = fltr.passRegex(dDP)
+	case utils.MetaExpr, utils.MetaNotExpr:
+		result, err = fltr.passExpr(dDP)
+	case utils.MetaGeoIP, utils.MetaNotGeoIP:
+		result, err = fltr.passGeoIP(dDP)
+	case utils.MetaGeoDistance, utils.MetaNotGeoDistance:
+		result, err = fltr.passGeoDistance(dDP)
+	case utils.MetaTimeOfDay, utils.MetaNotTimeOfDay:
+		result, err = fltr.passDayTime(dDP, (*dayTimeInterval).passTimeOfDay)
+	case utils.MetaDayOfWeek, utils.MetaNotDayOfWeek:
+		result, err = fltr.passDayTime(dDP, (*dayTimeInterval).passDayOfWeek)
 	default:
 		err = utils.ErrPrefixNotErrNotImplemented(fltr.Type)
 	}
@@ -697,6 +771,14 @@ func verifyInlineFilterS(fltrs []string) (err error) {
 
 func CheckFilter(fltr *Filter) (err error) {
 	for _, rls := range fltr.Rules {
+		if rls.Type == utils.MetaExpr || rls.Type == utils.MetaNotExpr {
+			for _, val := range rls.Values {
+				if _, err = newFilterExpr(val); err != nil {
+					return fmt.Errorf("%s for filter <%v>", err, fltr) //encapsulated error
+				}
+			}
+			continue
+		}
 		valFunc := utils.IsPathValid
 		if rls.Type == utils.MetaEmpty || rls.Type == utils.MetaExists {
 			valFunc = utils.IsPathValidForExporters
@@ -728,3 +810,90 @@ func (fltr *FilterRule) passRegex(dDP utils.DataProvider) (bool, error) {
 	}
 	return false, nil
 }
+
+// passExpr passes if any of the expressions evaluates to true
+func (fltr *FilterRule) passExpr(dDP utils.DataProvider) (bool, error) {
+	for _, expr := range fltr.exprValues {
+		if pass, err := expr.Pass(dDP); err != nil {
+			return false, err
+		} else if pass {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passGeoIP passes if the country or the ASN of the IP is one of the values
+func (fltr *FilterRule) passGeoIP(dDP utils.DataProvider) (bool, error) {
+	strVal, err := fltr.rsrElement.ParseDataProvider(dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	ip := net.ParseIP(strVal)
+	if ip == nil {
+		return false, nil
+	}
+	info, err := lookupGeoIP(config.CgrConfig().FilterSCfg().GeoIPDBPaths, ip)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	asn := geoIPASNPrefix + strconv.FormatUint(info.ASN, 10)
+	for _, val := range fltr.rsrValues {
+		sval, err := val.ParseDataProvider(dDP)
+		if err != nil {
+			continue
+		}
+		if (info.Country != utils.EmptyString && strings.EqualFold(sval, info.Country)) ||
+			(info.ASN != 0 && strings.EqualFold(sval, asn)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passGeoDistance passes if the coordinates are within the radius of one of the values
+func (fltr *FilterRule) passGeoDistance(dDP utils.DataProvider) (bool, error) {
+	var coords [2]float64
+	for i, rsr := range fltr.geoElement {
+		strVal, err := rsr.ParseDataProvider(dDP)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				return false, nil
+			}
+			return false, err
+		}
+		if coords[i], err = strconv.ParseFloat(strVal, 64); err != nil {
+			return false, nil
+		}
+	}
+	for _, gc := range fltr.geoValues {
+		if gc.contains(coords[0], coords[1]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passDayTime passes if the time matches one of the intervals
+func (fltr *FilterRule) passDayTime(dDP utils.DataProvider,
+	passFunc func(*dayTimeInterval, time.Time) bool) (bool, error) {
+	timeVal, err := parseTime(fltr.rsrElement, dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, dti := range fltr.dayTimeValues {
+		if passFunc(dti, timeVal) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
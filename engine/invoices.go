@@ -0,0 +1,441 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const invoiceCDRsBatchSize = 1000 // number of CDRs queried at once when closing a billing period
+
+// ArgsCloseBillingPeriod are the arguments passed to InvoiceSv1.CloseBillingPeriod
+type ArgsCloseBillingPeriod struct {
+	Tenant      string
+	Account     string
+	PeriodStart string // inclusive
+	PeriodEnd   string // exclusive
+	APIOpts     map[string]interface{}
+}
+
+// ArgsGetInvoices are the arguments passed to InvoiceSv1.GetInvoices
+// empty fields are not used for filtering
+type ArgsGetInvoices struct {
+	Tenant  string
+	Account string
+	ID      string
+	APIOpts map[string]interface{}
+}
+
+// InvoiceLine aggregates the charges of the same kind within the billing period
+type InvoiceLine struct {
+	Type          string // *usage or *recurring_fee
+	DestinationID string
+	Category      string
+	BalanceID     string
+	ActionPlanID  string // populated for *recurring_fee lines
+	Quantity      int64  // number of CDRs or of fee occurrences
+	Usage         time.Duration
+	Cost          float64
+}
+
+// Invoice is the document issued when closing the billing period of an account
+type Invoice struct {
+	Tenant      string
+	ID          string
+	Account     string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Status      string // *issued or *voided
+	Lines       []*InvoiceLine
+	Total       float64
+	ReplacedBy  string // ID of the invoice re-issued instead of this one
+	CreatedAt   time.Time
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (inv *Invoice) TenantID() string {
+	return utils.ConcatenatedKey(inv.Tenant, inv.ID)
+}
+
+// Clone returns a deep copy of the invoice
+func (inv *Invoice) Clone() (cln *Invoice) {
+	if inv == nil {
+		return
+	}
+	cln = new(Invoice)
+	*cln = *inv
+	if inv.Lines != nil {
+		cln.Lines = make([]*InvoiceLine, len(inv.Lines))
+		for i, line := range inv.Lines {
+			lnCln := *line
+			cln.Lines[i] = &lnCln
+		}
+	}
+	return
+}
+
+// overlaps returns true if the invoice is active and its period overlaps the given one
+func (inv *Invoice) overlaps(start, end time.Time) bool {
+	return inv.Status != utils.MetaVoided &&
+		inv.PeriodStart.Before(end) && start.Before(inv.PeriodEnd)
+}
+
+// AsCGREvents converts the invoice lines into events ready to be exported
+func (inv *Invoice) AsCGREvents(opts map[string]interface{}) (evs []*utils.CGREvent) {
+	evs = make([]*utils.CGREvent, len(inv.Lines))
+	for i, line := range inv.Lines {
+		evs[i] = &utils.CGREvent{
+			Tenant: inv.Tenant,
+			ID:     utils.ConcatenatedKey(inv.ID, utils.IfaceAsString(i)),
+			Time:   utils.TimePointer(inv.PeriodEnd),
+			Event: map[string]interface{}{
+				utils.InvoiceID:     inv.ID,
+				utils.AccountField:  inv.Account,
+				utils.PeriodStart:   inv.PeriodStart,
+				utils.PeriodEnd:     inv.PeriodEnd,
+				utils.Status:        inv.Status,
+				utils.Total:         inv.Total,
+				utils.Type:          line.Type,
+				utils.DestinationID: line.DestinationID,
+				utils.Category:      line.Category,
+				utils.BalanceID:     line.BalanceID,
+				utils.ActionPlanID:  line.ActionPlanID,
+				utils.Quantity:      line.Quantity,
+				utils.Usage:         line.Usage,
+				utils.Cost:          line.Cost,
+			},
+			APIOpts: opts,
+		}
+	}
+	return
+}
+
+// invoiceLines aggregates the lines of an invoice based on their type, destination, category, balance and ActionPlan
+type invoiceLines struct {
+	lines []*InvoiceLine
+	idx   map[string]*InvoiceLine
+}
+
+func (il *invoiceLines) get(lnType, dstID, category, balanceID, apID string) (line *InvoiceLine) {
+	key := utils.ConcatenatedKey(lnType, dstID, category, balanceID, apID)
+	if line = il.idx[key]; line != nil {
+		return
+	}
+	line = &InvoiceLine{
+		Type:          lnType,
+		DestinationID: dstID,
+		Category:      category,
+		BalanceID:     balanceID,
+		ActionPlanID:  apID,
+	}
+	if il.idx == nil {
+		il.idx = make(map[string]*InvoiceLine)
+	}
+	il.idx[key] = line
+	il.lines = append(il.lines, line)
+	return
+}
+
+// addCDR aggregates the usage and cost of a rated CDR
+// the charges of the EventCost are used if present, otherwise the CDR is added as a whole
+func (il *invoiceLines) addCDR(cdr *CDR) {
+	ec := cdr.CostDetails
+	if ec == nil || len(ec.Charges) == 0 {
+		line := il.get(utils.MetaUsage, utils.EmptyString, cdr.Category, utils.EmptyString, utils.EmptyString)
+		line.Quantity++
+		line.Usage += cdr.Usage
+		line.Cost += cdr.Cost
+		return
+	}
+	counted := make(map[*InvoiceLine]struct{}) // count the CDR once per line
+	for _, cIl := range ec.Charges {
+		var dstID string
+		if rating, has := ec.Rating[cIl.RatingID]; has {
+			dstID = utils.IfaceAsString(ec.RatingFilters[rating.RatingFiltersID][utils.DestinationID])
+		}
+		for _, incr := range cIl.Increments {
+			line := il.get(utils.MetaUsage, dstID, cdr.Category,
+				ec.balanceIDForAccounting(incr.AccountingID), utils.EmptyString)
+			if _, has := counted[line]; !has {
+				counted[line] = struct{}{}
+				line.Quantity++
+			}
+			cf := float64(incr.CompressFactor * cIl.CompressFactor)
+			line.Usage += time.Duration(float64(incr.Usage) * cf)
+			line.Cost += incr.Cost * cf
+		}
+	}
+}
+
+// balanceIDForAccounting returns the ID of the balance which paid for an increment
+// the monetary balance is considered when the increment was charged with an extra charge
+func (ec *EventCost) balanceIDForAccounting(accID string) string {
+	bc, has := ec.Accounting[accID]
+	if !has {
+		return utils.EmptyString
+	}
+	if extra, has := ec.Accounting[bc.ExtraChargeID]; has {
+		bc = extra
+	}
+	if ec.AccountSummary != nil {
+		if bs := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(bc.BalanceUUID); bs != nil &&
+			bs.ID != utils.EmptyString {
+			return bs.ID
+		}
+	}
+	return bc.BalanceUUID
+}
+
+// actionTimingOccurrences returns the number of times the ActionTiming is executed within [start, end)
+func actionTimingOccurrences(at *ActionTiming, start, end time.Time) (occurrences int64) {
+	if at.IsASAP() {
+		return
+	}
+	at = at.Clone() // GetNextStartTime caches the result and normalizes the timing
+	for t := start.Add(-time.Nanosecond); ; occurrences++ {
+		at.ResetStartTimeCache()
+		if t = at.GetNextStartTime(t); t.IsZero() || !t.Before(end) {
+			return
+		}
+	}
+}
+
+// NewInvoiceService constructs an InvoiceService
+func NewInvoiceService(cgrCfg *config.CGRConfig, storDBChan chan StorDB,
+	dm *DataManager, connMgr *ConnManager) *InvoiceService {
+	return &InvoiceService{
+		cgrCfg:     cgrCfg,
+		cdrDb:      <-storDBChan,
+		storDBChan: storDBChan,
+		dm:         dm,
+		connMgr:    connMgr,
+	}
+}
+
+// InvoiceService closes the billing periods of the accounts
+type InvoiceService struct {
+	cgrCfg     *config.CGRConfig
+	cdrDb      CdrStorage
+	storDBChan chan StorDB
+	dm         *DataManager
+	connMgr    *ConnManager
+}
+
+// ListenAndServe listen for storbd reload
+func (invS *InvoiceService) ListenAndServe(stopChan chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case stordb, ok := <-invS.storDBChan:
+			if !ok { // the chanel was closed by the shutdown of stordbService
+				return
+			}
+			invS.cdrDb = stordb
+		}
+	}
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (invS *InvoiceService) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(invS, serviceMethod, args, reply)
+}
+
+// V1Ping returns Pong
+func (invS *InvoiceService) V1Ping(ign *utils.CGREvent, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// usageLines aggregates the rated CDRs of the account answered within the period
+func (invS *InvoiceService) usageLines(lines *invoiceLines, tnt, account string, start, end time.Time) (err error) {
+	fltr := &utils.CDRsFilter{
+		Tenants:         []string{tnt},
+		Accounts:        []string{account},
+		RunIDs:          invS.cgrCfg.InvoiceSCfg().RunIDs, // only the billing runs
+		AnswerTimeStart: &start,
+		AnswerTimeEnd:   &end,
+		OrderBy:         utils.OrderID,
+		Paginator:       utils.Paginator{Limit: utils.IntPointer(invoiceCDRsBatchSize)},
+	}
+	for offset := 0; ; offset += invoiceCDRsBatchSize {
+		fltr.Offset = utils.IntPointer(offset)
+		var cdrs []*CDR
+		if cdrs, _, err = invS.cdrDb.GetCDRs(fltr, false); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+			}
+			return
+		}
+		for _, cdr := range cdrs {
+			if cdr.Cost < 0 { // not rated
+				continue
+			}
+			lines.addCDR(cdr)
+		}
+		if len(cdrs) < invoiceCDRsBatchSize {
+			return
+		}
+	}
+}
+
+// recurringFeeLines adds the monetary debits scheduled by the ActionPlans of the account within the period
+func (invS *InvoiceService) recurringFeeLines(lines *invoiceLines, tnt, account string, start, end time.Time) (err error) {
+	var apIDs []string
+	if apIDs, err = invS.dm.GetAccountActionPlans(utils.ConcatenatedKey(tnt, account),
+		true, true, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	for _, apID := range apIDs {
+		var ap *ActionPlan
+		if ap, err = invS.dm.GetActionPlan(apID, true, true, utils.NonTransactional); err != nil {
+			return
+		}
+		for _, at := range ap.ActionTimings {
+			occurrences := actionTimingOccurrences(at, start, end)
+			if occurrences == 0 {
+				continue
+			}
+			var acts Actions
+			if acts, err = invS.dm.GetActions(at.ActionsID, false, utils.NonTransactional); err != nil {
+				return
+			}
+			for _, act := range acts {
+				if (act.ActionType != utils.MetaDebit && act.ActionType != utils.MetaDebitReset) ||
+					act.Balance.GetType() != utils.MetaMonetary {
+					continue
+				}
+				line := lines.get(utils.MetaRecurringFee, utils.EmptyString, utils.EmptyString,
+					act.Balance.GetID(), apID)
+				line.Quantity += occurrences
+				line.Cost += act.Balance.GetValue() * float64(occurrences)
+			}
+		}
+	}
+	return
+}
+
+// exportInvoice sends the invoice lines to EEs
+func (invS *InvoiceService) exportInvoice(inv *Invoice, opts map[string]interface{}) (err error) {
+	for _, cgrEv := range inv.AsCGREvents(opts) {
+		var reply map[string]map[string]interface{}
+		if err = invS.connMgr.Call(invS.cgrCfg.InvoiceSCfg().EEsConns, nil,
+			utils.EeSv1ProcessEvent,
+			&CGREventWithEeIDs{
+				EeIDs:    invS.cgrCfg.InvoiceSCfg().EEsIDs,
+				CGREvent: cgrEv,
+			}, &reply); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() { // NotFound is not considered error
+			return
+		}
+		err = nil
+	}
+	return
+}
+
+// V1CloseBillingPeriod aggregates the charges of the account within the period into a new invoice
+func (invS *InvoiceService) V1CloseBillingPeriod(args *ArgsCloseBillingPeriod, reply *Invoice) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.AccountField,
+		utils.PeriodStart, utils.PeriodEnd}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, invS.cgrCfg.GeneralCfg().DefaultTenant)
+	var start, end time.Time
+	if start, err = utils.ParseTimeDetectLayout(args.PeriodStart,
+		invS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	if end, err = utils.ParseTimeDetectLayout(args.PeriodEnd,
+		invS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("PeriodEnd <%s> is not after PeriodStart <%s>", args.PeriodEnd, args.PeriodStart)
+	}
+	var inv *Invoice
+	if err = guardian.Guardian.Guard(func() (gErr error) {
+		var invs []*Invoice
+		if invs, gErr = invS.cdrDb.GetInvoices(tnt, args.Account, utils.EmptyString); gErr != nil &&
+			gErr != utils.ErrNotFound {
+			return
+		}
+		for _, exInv := range invs {
+			if exInv.overlaps(start, end) {
+				return utils.ErrExists
+			}
+		}
+		lines := new(invoiceLines)
+		if gErr = invS.usageLines(lines, tnt, args.Account, start, end); gErr != nil {
+			return
+		}
+		if gErr = invS.recurringFeeLines(lines, tnt, args.Account, start, end); gErr != nil {
+			return
+		}
+		inv = &Invoice{
+			Tenant:      tnt,
+			ID:          utils.UUIDSha1Prefix(),
+			Account:     args.Account,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Status:      utils.MetaIssued,
+			Lines:       lines.lines,
+			CreatedAt:   time.Now(),
+		}
+		roundDec := invS.cgrCfg.GeneralCfg().RoundingDecimals
+		for _, line := range inv.Lines {
+			line.Cost = utils.Round(line.Cost, roundDec, utils.MetaRoundingMiddle)
+			inv.Total += line.Cost
+		}
+		inv.Total = utils.Round(inv.Total, roundDec, utils.MetaRoundingMiddle)
+		return invS.cdrDb.SetInvoice(inv)
+	}, invS.cgrCfg.GeneralCfg().LockingTimeout,
+		utils.InvoicesTBL+utils.ConcatenatedKey(tnt, args.Account)); err != nil {
+		return
+	}
+	if len(invS.cgrCfg.InvoiceSCfg().EEsConns) != 0 {
+		if err = invS.exportInvoice(inv, args.APIOpts); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> exporting invoice <%s>",
+					utils.InvoiceS, err.Error(), inv.TenantID()))
+			return utils.ErrPartiallyExecuted
+		}
+	}
+	*reply = *inv
+	return
+}
+
+// V1GetInvoices returns the invoices matching the arguments
+func (invS *InvoiceService) V1GetInvoices(args *ArgsGetInvoices, reply *[]*Invoice) (err error) {
+	var invs []*Invoice
+	if invs, err = invS.cdrDb.GetInvoices(
+		utils.FirstNonEmpty(args.Tenant, invS.cgrCfg.GeneralCfg().DefaultTenant),
+		args.Account, args.ID); err != nil {
+		return
+	}
+	*reply = invs
+	return
+}
@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestInvoiceActionTimingOccurrences(t *testing.T) {
+	start := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)
+	monthly := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{
+		MonthDays: utils.MonthDays{1}, StartTime: "00:00:00"}}}
+	if rcv := actionTimingOccurrences(monthly, start, end); rcv != 1 {
+		t.Errorf("Expected 1 occurrence, received %d", rcv)
+	}
+	if monthly.Timing.Timing.StartTime != "00:00:00" || !monthly.stCache.IsZero() {
+		t.Error("Expected the ActionTiming to not be modified")
+	}
+	daily := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{StartTime: "12:00:00"}}}
+	if rcv := actionTimingOccurrences(daily, start, end); rcv != 31 {
+		t.Errorf("Expected 31 occurrences, received %d", rcv)
+	}
+	asap := &ActionTiming{Timing: &RateInterval{Timing: &RITiming{StartTime: utils.MetaASAP}}}
+	if rcv := actionTimingOccurrences(asap, start, end); rcv != 0 {
+		t.Errorf("Expected no occurrences, received %d", rcv)
+	}
+}
+
+func TestInvoiceV1CloseBillingPeriod(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	eesConn := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs, utils.MetaInvoices)
+	cfg.InvoiceSCfg().EEsConns = []string{eesConn}
+	cfg.InvoiceSCfg().EEsIDs = []string{"invoices_csv"}
+	var exported []*CGREventWithEeIDs
+	chanClnt := make(chan rpcclient.ClientConnector, 1)
+	chanClnt <- clMock(func(_ string, args interface{}, _ interface{}) error {
+		exported = append(exported, args.(*CGREventWithEeIDs))
+		return nil
+	})
+	connMngr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		eesConn: chanClnt,
+	})
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	dmInv := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), connMngr)
+	invS := NewInvoiceService(cfg, storDBChan, dmInv, connMngr)
+
+	ec := &EventCost{
+		CGRID:     "cgrid1",
+		RunID:     utils.MetaDefault,
+		StartTime: time.Date(2021, time.March, 10, 10, 0, 0, 0, time.UTC),
+		Charges: []*ChargingInterval{{
+			RatingID: "RATING1",
+			Increments: []*ChargingIncrement{
+				{Usage: 0, Cost: 0.2, AccountingID: "ACC1", CompressFactor: 1},
+				{Usage: time.Minute, Cost: 0.4, AccountingID: "ACC1", CompressFactor: 2},
+			},
+			CompressFactor: 1,
+		}},
+		Rating:        Rating{"RATING1": {RatingFiltersID: "RF1"}},
+		RatingFilters: RatingFilters{"RF1": {utils.DestinationID: "DST_1002"}},
+		Accounting:    Accounting{"ACC1": {BalanceUUID: "uuid1"}},
+		AccountSummary: &AccountSummary{
+			Tenant: "cgrates.org",
+			ID:     "1001",
+			BalanceSummaries: BalanceSummaries{{
+				UUID: "uuid1", ID: "MONETARY", Type: utils.MetaMonetary}},
+		},
+	}
+	for i, cdr := range []*CDR{
+		{CGRID: "cgrid1", Account: "1001", Cost: 1, CostDetails: ec, // aggregated from the EventCost
+			AnswerTime: time.Date(2021, time.March, 10, 10, 0, 0, 0, time.UTC)},
+		{CGRID: "cgrid2", Account: "1001", Cost: 1.5, Usage: time.Minute,
+			AnswerTime: time.Date(2021, time.March, 20, 10, 0, 0, 0, time.UTC)},
+		{CGRID: "cgrid3", Account: "1001", Cost: -1, Usage: time.Minute, // not rated
+			AnswerTime: time.Date(2021, time.March, 21, 10, 0, 0, 0, time.UTC)},
+		{CGRID: "cgrid4", Account: "1001", Cost: 5, Usage: time.Minute, // outside the period
+			AnswerTime: time.Date(2021, time.April, 1, 10, 0, 0, 0, time.UTC)},
+		{CGRID: "cgrid5", Account: "1002", Cost: 5, Usage: time.Minute, // different account
+			AnswerTime: time.Date(2021, time.March, 20, 10, 0, 0, 0, time.UTC)},
+		{CGRID: "cgrid2", RunID: "supplier", Account: "1001", Cost: 0.7, Usage: time.Minute, // not a billing run
+			AnswerTime: time.Date(2021, time.March, 20, 10, 0, 0, 0, time.UTC)},
+	} {
+		cdr.Tenant = "cgrates.org"
+		if cdr.RunID == utils.EmptyString {
+			cdr.RunID = utils.MetaDefault
+		}
+		cdr.OrderID = int64(i + 1)
+		cdr.Category = "call"
+		if err := invS.cdrDb.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := dmInv.SetActions("ACT_FEE", Actions{{
+		Id:         "ACT_FEE",
+		ActionType: utils.MetaDebit,
+		Balance: &BalanceFilter{
+			ID:    utils.StringPointer("MONETARY"),
+			Type:  utils.StringPointer(utils.MetaMonetary),
+			Value: &utils.ValueFormula{Static: 10},
+		},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmInv.SetActionPlan("AP_MONTHLY", &ActionPlan{
+		Id:         "AP_MONTHLY",
+		AccountIDs: utils.StringMap{"cgrates.org:1001": true},
+		ActionTimings: []*ActionTiming{{
+			ActionsID: "ACT_FEE",
+			Timing: &RateInterval{Timing: &RITiming{
+				MonthDays: utils.MonthDays{1}, StartTime: "00:00:00"}},
+		}},
+	}, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmInv.SetAccountActionPlans("cgrates.org:1001", []string{"AP_MONTHLY"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	args := &ArgsCloseBillingPeriod{
+		Tenant:      "cgrates.org",
+		Account:     "1001",
+		PeriodStart: "2021-03-01T00:00:00Z",
+		PeriodEnd:   "2021-04-01T00:00:00Z",
+	}
+	var inv Invoice
+	if err := invS.V1CloseBillingPeriod(args, &inv); err != nil {
+		t.Fatal(err)
+	}
+	expLines := []*InvoiceLine{
+		{Type: utils.MetaUsage, DestinationID: "DST_1002", Category: "call",
+			BalanceID: "MONETARY", Quantity: 1, Usage: 2 * time.Minute, Cost: 1},
+		{Type: utils.MetaUsage, Category: "call", Quantity: 1, Usage: time.Minute, Cost: 1.5},
+		{Type: utils.MetaRecurringFee, BalanceID: "MONETARY", ActionPlanID: "AP_MONTHLY",
+			Quantity: 1, Cost: 10},
+	}
+	if !reflect.DeepEqual(expLines, inv.Lines) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expLines), utils.ToJSON(inv.Lines))
+	}
+	if inv.Total != 12.5 || inv.Status != utils.MetaIssued {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(inv))
+	}
+	if len(exported) != 3 {
+		t.Fatalf("Expected 3 exported lines, received %d", len(exported))
+	}
+	if ev := exported[2]; !reflect.DeepEqual(ev.EeIDs, []string{"invoices_csv"}) ||
+		ev.Event[utils.InvoiceID] != inv.ID || ev.Event[utils.Type] != utils.MetaRecurringFee {
+		t.Errorf("Unexpected exported event: %s", utils.ToJSON(ev))
+	}
+
+	var rcv Invoice
+	if err := invS.V1CloseBillingPeriod(&ArgsCloseBillingPeriod{
+		Tenant:      "cgrates.org",
+		Account:     "1001",
+		PeriodStart: "2021-03-15T00:00:00Z",
+		PeriodEnd:   "2021-04-15T00:00:00Z",
+	}, &rcv); err != utils.ErrExists {
+		t.Errorf("Expected %v, received %v", utils.ErrExists, err)
+	}
+	var invs []*Invoice
+	if err := invS.V1GetInvoices(&ArgsGetInvoices{Tenant: "cgrates.org", Account: "1001"},
+		&invs); err != nil {
+		t.Fatal(err)
+	} else if len(invs) != 1 || invs[0].ID != inv.ID {
+		t.Errorf("Unexpected invoices: %s", utils.ToJSON(invs))
+	}
+
+	invs[0].Status = utils.MetaVoided
+	if err := invS.cdrDb.SetInvoice(invs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := invS.V1CloseBillingPeriod(args, &rcv); err != nil {
+		t.Fatal(err)
+	} else if rcv.ID == inv.ID || rcv.Total != inv.Total {
+		t.Errorf("Unexpected invoice: %s", utils.ToJSON(rcv))
+	}
+
+	if err := invS.V1CloseBillingPeriod(&ArgsCloseBillingPeriod{
+		Account:     "1001",
+		PeriodStart: "2021-04-01T00:00:00Z",
+		PeriodEnd:   "2021-03-01T00:00:00Z",
+	}, &rcv); err == nil {
+		t.Error("Expected error for reversed period")
+	}
+	if err := invS.V1CloseBillingPeriod(&ArgsCloseBillingPeriod{}, &rcv); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.AccountField,
+			utils.PeriodStart, utils.PeriodEnd).Error() {
+		t.Errorf("Expected mandatory error, received %v", err)
+	}
+}
+
+func TestInvoiceClone(t *testing.T) {
+	inv := &Invoice{
+		Tenant:  "cgrates.org",
+		ID:      "INV1",
+		Account: "1001",
+		Status:  utils.MetaIssued,
+		Lines:   []*InvoiceLine{{Type: utils.MetaUsage, Quantity: 1, Cost: 1.5}},
+		Total:   1.5,
+	}
+	cln := inv.Clone()
+	if !reflect.DeepEqual(inv, cln) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(inv), utils.ToJSON(cln))
+	}
+	cln.Status = utils.MetaVoided
+	cln.Lines[0].Cost = 2
+	if inv.Status != utils.MetaIssued || inv.Lines[0].Cost != 1.5 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if cln = (*Invoice)(nil).Clone(); cln != nil {
+		t.Errorf("Expected nil, received %s", utils.ToJSON(cln))
+	}
+}
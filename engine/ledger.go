@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LedgerEntry records one change of a balance value
+type LedgerEntry struct {
+	Tenant      string
+	Account     string
+	BalanceUUID string
+	BalanceID   string
+	BalanceType string
+	OldValue    float64
+	NewValue    float64
+	Source      string // *rals, *sessions, *actions, *apier, *expiry or *default if not set by the caller
+	RefID       string // CGRID of the debit, ID of the actions or the reservation
+	Time        time.Time
+}
+
+// LedgerFilter selects the ledger entries returned by StorDB
+type LedgerFilter struct {
+	Tenant       string
+	Accounts     []string
+	BalanceIDs   []string
+	BalanceTypes []string
+	Sources      []string
+	RefIDs       []string
+	TimeStart    time.Time // inclusive, ignored if zero
+	TimeEnd      time.Time // exclusive, ignored if zero
+	utils.Paginator
+}
+
+// Matches checks if the entry is selected by the filter, used by the internal StorDB
+func (lf *LedgerFilter) Matches(le *LedgerEntry) bool {
+	return le.Tenant == lf.Tenant &&
+		(len(lf.Accounts) == 0 || utils.IsSliceMember(lf.Accounts, le.Account)) &&
+		(len(lf.BalanceIDs) == 0 || utils.IsSliceMember(lf.BalanceIDs, le.BalanceID)) &&
+		(len(lf.BalanceTypes) == 0 || utils.IsSliceMember(lf.BalanceTypes, le.BalanceType)) &&
+		(len(lf.Sources) == 0 || utils.IsSliceMember(lf.Sources, le.Source)) &&
+		(len(lf.RefIDs) == 0 || utils.IsSliceMember(lf.RefIDs, le.RefID)) &&
+		(lf.TimeStart.IsZero() || !le.Time.Before(lf.TimeStart)) &&
+		(lf.TimeEnd.IsZero() || le.Time.Before(lf.TimeEnd))
+}
+
+// ArgsGetLedgerEntries is used by the API querying the balance ledger
+type ArgsGetLedgerEntries struct {
+	Tenant       string
+	Accounts     []string
+	BalanceIDs   []string
+	BalanceTypes []string
+	Sources      []string
+	RefIDs       []string
+	TimeStart    string
+	TimeEnd      string
+	utils.Paginator
+	APIOpts map[string]interface{}
+}
+
+// AsLedgerFilter converts the arguments into the filter used by StorDB
+func (args *ArgsGetLedgerEntries) AsLedgerFilter(timezone string) (lf *LedgerFilter, err error) {
+	lf = &LedgerFilter{
+		Tenant:       args.Tenant,
+		Accounts:     args.Accounts,
+		BalanceIDs:   args.BalanceIDs,
+		BalanceTypes: args.BalanceTypes,
+		Sources:      args.Sources,
+		RefIDs:       args.RefIDs,
+		Paginator:    args.Paginator,
+	}
+	if args.TimeStart != utils.EmptyString {
+		if lf.TimeStart, err = utils.ParseTimeDetectLayout(args.TimeStart, timezone); err != nil {
+			return
+		}
+	}
+	if args.TimeEnd != utils.EmptyString {
+		if lf.TimeEnd, err = utils.ParseTimeDetectLayout(args.TimeEnd, timezone); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// sortLedgerEntries orders the entries on time, used for pagination
+func sortLedgerEntries(les []*LedgerEntry) {
+	sort.SliceStable(les, func(i, j int) bool {
+		return les[i].Time.Before(les[j].Time)
+	})
+}
+
+// paginateLedgerEntries applies the limit and offset of the paginator on the sorted entries
+func paginateLedgerEntries(les []*LedgerEntry, pgnt utils.Paginator) []*LedgerEntry {
+	if pgnt.Offset != nil && *pgnt.Offset > 0 {
+		if *pgnt.Offset >= len(les) {
+			return nil
+		}
+		les = les[*pgnt.Offset:]
+	}
+	if pgnt.Limit != nil && *pgnt.Limit > 0 && *pgnt.Limit < len(les) {
+		les = les[:*pgnt.Limit]
+	}
+	return les
+}
+
+// SetLedgerSource sets the source and the reference of the balance changes
+// recorded into the ledger when the account is stored
+func (acc *Account) SetLedgerSource(source, refID string) {
+	acc.ledgerSource, acc.ledgerRefID = source, refID
+}
+
+// setLedgerSnapshot keeps the balances as stored so the ledger entries can be computed
+// when the account is written without reading it again
+func (acc *Account) setLedgerSnapshot() {
+	acc.ledgerOld = &Account{ID: acc.ID}
+	if acc.BalanceMap != nil {
+		acc.ledgerOld.BalanceMap = make(map[string]Balances, len(acc.BalanceMap))
+		for blcType, blcs := range acc.BalanceMap {
+			acc.ledgerOld.BalanceMap[blcType] = blcs.Clone()
+		}
+	}
+}
+
+// newLedgerEntries returns the balance changes between the stored account and the one replacing it
+func newLedgerEntries(oldAcc, acc *Account, tm time.Time) (les []*LedgerEntry) {
+	if oldAcc != nil && len(acc.BalanceMap) == 0 &&
+		!oldAcc.allBalancesExpired() { // the drivers keep the stored balances in this case
+		return
+	}
+	acntTnt := utils.NewTenantID(acc.ID)
+	ledgerSource := utils.FirstNonEmpty(acc.ledgerSource, utils.MetaDefault)
+	newLedgerEntry := func(b *Balance, blcType string, oldValue, newValue float64, source string) *LedgerEntry {
+		return &LedgerEntry{
+			Tenant:      acntTnt.Tenant,
+			Account:     acntTnt.ID,
+			BalanceUUID: b.Uuid,
+			BalanceID:   b.ID,
+			BalanceType: blcType,
+			OldValue:    oldValue,
+			NewValue:    newValue,
+			Source:      source,
+			RefID:       acc.ledgerRefID,
+			Time:        tm,
+		}
+	}
+	oldBals := make(map[string]*Balance)
+	if oldAcc != nil {
+		for _, bals := range oldAcc.BalanceMap {
+			for _, b := range bals {
+				oldBals[b.Uuid] = b
+			}
+		}
+	}
+	for _, blcType := range balanceTypes(acc.BalanceMap) {
+		for _, b := range acc.BalanceMap[blcType] {
+			var oldValue float64
+			if oldB, has := oldBals[b.Uuid]; has {
+				oldValue = oldB.GetValue()
+				delete(oldBals, b.Uuid)
+			}
+			if oldValue != b.GetValue() {
+				les = append(les, newLedgerEntry(b, blcType, oldValue, b.GetValue(), ledgerSource))
+			}
+		}
+	}
+	if oldAcc == nil {
+		return
+	}
+	for _, blcType := range balanceTypes(oldAcc.BalanceMap) {
+		for _, b := range oldAcc.BalanceMap[blcType] {
+			if _, removed := oldBals[b.Uuid]; !removed || b.GetValue() == 0 {
+				continue
+			}
+			source := ledgerSource
+			if b.IsExpiredAt(tm) {
+				source = utils.MetaExpiry
+			}
+			les = append(les, newLedgerEntry(b, blcType, b.GetValue(), 0, source))
+		}
+	}
+	return
+}
+
+// storeLedgerEntries writes the balance changes into StorDB
+// the account keeps the stored balances for the next update
+func storeLedgerEntries(acc *Account) {
+	oldAcc := acc.ledgerOld
+	if len(acc.BalanceMap) != 0 || oldAcc == nil ||
+		oldAcc.allBalancesExpired() { // otherwise the drivers keep the stored balances
+		acc.setLedgerSnapshot()
+	}
+	les := newLedgerEntries(oldAcc, acc, time.Now())
+	if len(les) == 0 {
+		return
+	}
+	if cdrStorage == nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> no StorDB to record the balance changes of account <%s>",
+			utils.RALService, acc.ID))
+		return
+	}
+	if err := cdrStorage.SetLedgerEntries(les); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> recording the balance changes of account <%s>",
+			utils.RALService, err.Error(), acc.ID))
+	}
+}
+
+// balanceTypes returns the sorted balance types so the entries are recorded in the same order
+func balanceTypes(bm map[string]Balances) (blcTypes []string) {
+	blcTypes = make([]string, 0, len(bm))
+	for blcType := range bm {
+		blcTypes = append(blcTypes, blcType)
+	}
+	sort.Strings(blcTypes)
+	return
+}
@@ -0,0 +1,174 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLedgerNewLedgerEntries(t *testing.T) {
+	tm := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	oldAcc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {
+				{Uuid: "uuid1", ID: "MONETARY", Value: 10},
+				{Uuid: "uuid2", ID: "BONUS", Value: 5,
+					ExpirationDate: tm.Add(-time.Hour)},
+			},
+			utils.MetaVoice: {
+				{Uuid: "uuid3", ID: "VOICE", Value: 60},
+			},
+		},
+	}
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {
+				{Uuid: "uuid1", ID: "MONETARY", Value: 7.5},
+				{Uuid: "uuid4", ID: "NEW", Value: 1},
+			},
+			utils.MetaVoice: {
+				{Uuid: "uuid3", ID: "VOICE", Value: 60},
+			},
+		},
+	}
+	acc.SetLedgerSource(utils.MetaRALs, "cgrid1")
+	exp := []*LedgerEntry{
+		{Tenant: "cgrates.org", Account: "1001", BalanceUUID: "uuid1", BalanceID: "MONETARY",
+			BalanceType: utils.MetaMonetary, OldValue: 10, NewValue: 7.5,
+			Source: utils.MetaRALs, RefID: "cgrid1", Time: tm},
+		{Tenant: "cgrates.org", Account: "1001", BalanceUUID: "uuid4", BalanceID: "NEW",
+			BalanceType: utils.MetaMonetary, OldValue: 0, NewValue: 1,
+			Source: utils.MetaRALs, RefID: "cgrid1", Time: tm},
+		{Tenant: "cgrates.org", Account: "1001", BalanceUUID: "uuid2", BalanceID: "BONUS",
+			BalanceType: utils.MetaMonetary, OldValue: 5, NewValue: 0,
+			Source: utils.MetaExpiry, RefID: "cgrid1", Time: tm},
+	}
+	if rcv := newLedgerEntries(oldAcc, acc, tm); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := newLedgerEntries(oldAcc, &Account{ID: "cgrates.org:1001"}, tm); len(rcv) != 0 {
+		t.Errorf("Expected no entries when the balances are kept, received %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestLedgerSetAccount(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RalsCfg().BalanceLedger = true
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	oldCfg, oldCdrStorage := config.CgrConfig(), cdrStorage
+	config.SetCgrConfig(cfg)
+	SetCdrStorage(storDB)
+	defer func() {
+		config.SetCgrConfig(oldCfg)
+		SetCdrStorage(oldCdrStorage)
+	}()
+	acc := &Account{
+		ID: "cgrates.org:ledger",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: "MONETARY", Value: 10}},
+		},
+	}
+	acc.SetLedgerSource(utils.MetaApier, utils.APIerSv1SetBalance)
+	err := dm.SetAccount(acc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc, err = dm.GetAccount(acc.ID); err != nil {
+		t.Fatal(err)
+	}
+	acc.BalanceMap[utils.MetaMonetary][0].AddValue(-3)
+	acc.SetLedgerSource(utils.MetaRALs, "cgrid1")
+	if err = dm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	les, err := storDB.GetLedgerEntries(&LedgerFilter{Tenant: "cgrates.org", Accounts: []string{"ledger"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(les) != 2 ||
+		les[0].Source != utils.MetaApier || les[0].OldValue != 0 || les[0].NewValue != 10 ||
+		les[1].Source != utils.MetaRALs || les[1].RefID != "cgrid1" ||
+		les[1].OldValue != 10 || les[1].NewValue != 7 {
+		t.Errorf("Unexpected entries: %s", utils.ToJSON(les))
+	}
+	if les, err = storDB.GetLedgerEntries(&LedgerFilter{Tenant: "cgrates.org",
+		Sources: []string{utils.MetaRALs}}); err != nil {
+		t.Fatal(err)
+	} else if len(les) != 1 || les[0].RefID != "cgrid1" {
+		t.Errorf("Unexpected entries: %s", utils.ToJSON(les))
+	}
+	if les, err = storDB.GetLedgerEntries(&LedgerFilter{Tenant: "cgrates.org",
+		Paginator: utils.Paginator{Limit: utils.IntPointer(1), Offset: utils.IntPointer(1)}}); err != nil {
+		t.Fatal(err)
+	} else if len(les) != 1 || les[0].Source != utils.MetaRALs {
+		t.Errorf("Unexpected entries: %s", utils.ToJSON(les))
+	}
+	if _, err = storDB.GetLedgerEntries(&LedgerFilter{Tenant: "cgrates.org",
+		TimeEnd: time.Now().Add(-time.Hour)}); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	acc.BalanceMap[utils.MetaMonetary][0].AddValue(-2)
+	acc.SetLedgerSource(utils.EmptyString, utils.EmptyString)
+	if err = dm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	if les, err = storDB.GetLedgerEntries(&LedgerFilter{Tenant: "cgrates.org",
+		Sources: []string{utils.MetaDefault}}); err != nil {
+		t.Fatal(err)
+	} else if len(les) != 1 || les[0].OldValue != 7 || les[0].NewValue != 5 {
+		t.Errorf("Unexpected entries: %s", utils.ToJSON(les))
+	}
+}
+
+func TestLedgerSnapshot(t *testing.T) {
+	tm := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	acc := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{Uuid: "uuid1", ID: "MONETARY", Value: 10}},
+		},
+	}
+	acc.setLedgerSnapshot()
+	acc.BalanceMap[utils.MetaMonetary][0].AddValue(-2)
+	if acc.ledgerOld.BalanceMap[utils.MetaMonetary][0].GetValue() != 10 {
+		t.Errorf("Expected the snapshot to keep the stored value, received %s", utils.ToJSON(acc.ledgerOld))
+	}
+	exp := []*LedgerEntry{
+		{Tenant: "cgrates.org", Account: "1001", BalanceUUID: "uuid1", BalanceID: "MONETARY",
+			BalanceType: utils.MetaMonetary, OldValue: 10, NewValue: 8,
+			Source: utils.MetaDefault, Time: tm},
+	}
+	if rcv := newLedgerEntries(acc.ledgerOld, acc, tm); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	cd := &CallDescriptor{}
+	if src := cd.ledgerSource(); src != utils.MetaRALs {
+		t.Errorf("Expected %s, received %s", utils.MetaRALs, src)
+	}
+	cd.Source = utils.MetaSessionS
+	if src := cd.Clone().ledgerSource(); src != utils.MetaSessionS {
+		t.Errorf("Expected %s, received %s", utils.MetaSessionS, src)
+	}
+}
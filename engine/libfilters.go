@@ -0,0 +1,214 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	earthRadiusKm   = 6371.0088 // mean Earth radius used by *geo_distance
+	geoUnitKm       = "km"
+	geoUnitM        = "m"
+	filterTZSep     = "@" // separates the timezone in *time_of_day and *day_of_week values
+	filterRangeSep  = "-"
+	weekDayNameSize = 3
+)
+
+// geoCircle is a compiled value of the *geo_distance filters
+type geoCircle struct {
+	lat, lon float64 // center in degrees
+	radius   float64 // km
+}
+
+// newGeoCircle parses the value in the form lat:lon:radius
+// the radius can have m or km as unit, km being the default one
+func newGeoCircle(val string) (gc *geoCircle, err error) {
+	vals := strings.Split(val, utils.InInFieldSep)
+	if len(vals) != 3 {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>", utils.MetaGeoDistance, val)
+	}
+	gc = new(geoCircle)
+	if gc.lat, err = strconv.ParseFloat(vals[0], 64); err != nil || math.Abs(gc.lat) > 90 {
+		return nil, fmt.Errorf("invalid latitude for %s filter: <%s>", utils.MetaGeoDistance, vals[0])
+	}
+	if gc.lon, err = strconv.ParseFloat(vals[1], 64); err != nil || math.Abs(gc.lon) > 180 {
+		return nil, fmt.Errorf("invalid longitude for %s filter: <%s>", utils.MetaGeoDistance, vals[1])
+	}
+	radius, unit := vals[2], 1.0
+	if strings.HasSuffix(radius, geoUnitKm) {
+		radius = strings.TrimSuffix(radius, geoUnitKm)
+	} else if strings.HasSuffix(radius, geoUnitM) {
+		radius, unit = strings.TrimSuffix(radius, geoUnitM), 0.001
+	}
+	if gc.radius, err = strconv.ParseFloat(radius, 64); err != nil || gc.radius < 0 {
+		return nil, fmt.Errorf("invalid radius for %s filter: <%s>", utils.MetaGeoDistance, vals[2])
+	}
+	gc.radius *= unit
+	return gc, nil
+}
+
+// contains returns true if the point is within the circle
+func (gc *geoCircle) contains(lat, lon float64) bool {
+	return haversineDistance(gc.lat, gc.lon, lat, lon) <= gc.radius
+}
+
+// haversineDistance returns the great-circle distance in km between two points given in degrees
+func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	rLat1, rLat2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dLat := rLat2 - rLat1
+	dLon := (lon2 - lon1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rLat1)*math.Cos(rLat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// dayTimeInterval is a compiled value of the *time_of_day and *day_of_week filters
+type dayTimeInterval struct {
+	start, end int     // seconds since midnight for *time_of_day, end exclusive
+	weekDays   [7]bool // indexed on time.Weekday for *day_of_week
+	loc        *time.Location
+}
+
+// splitFilterTZ splits the value from its optional timezone, using the default one if missing
+func splitFilterTZ(val, dfltTZ string) (rest string, loc *time.Location, err error) {
+	tz := dfltTZ
+	rest = val
+	if idx := strings.LastIndex(val, filterTZSep); idx != -1 {
+		rest, tz = val[:idx], val[idx+1:]
+	}
+	if loc, err = time.LoadLocation(tz); err != nil {
+		return "", nil, fmt.Errorf("invalid timezone <%s>: %s", tz, err)
+	}
+	return
+}
+
+// parseDayTime returns the seconds since midnight of HH:MM[:SS], 24:00 being accepted as end of day
+func parseDayTime(val string) (secs int, err error) {
+	parts := strings.Split(val, utils.InInFieldSep)
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time of day: <%s>", val)
+	}
+	var hms [3]int
+	for i, part := range parts {
+		if hms[i], err = strconv.Atoi(part); err != nil || hms[i] < 0 {
+			return 0, fmt.Errorf("invalid time of day: <%s>", val)
+		}
+	}
+	if hms[1] > 59 || hms[2] > 59 || hms[0] > 24 ||
+		(hms[0] == 24 && (hms[1] != 0 || hms[2] != 0)) {
+		return 0, fmt.Errorf("invalid time of day: <%s>", val)
+	}
+	return hms[0]*3600 + hms[1]*60 + hms[2], nil
+}
+
+// newTimeOfDayInterval parses the value in the form HH:MM[:SS]-HH:MM[:SS][@timezone]
+// the interval can go over midnight (ie. 22:00-06:00)
+func newTimeOfDayInterval(val, dfltTZ string) (dti *dayTimeInterval, err error) {
+	dti = new(dayTimeInterval)
+	var interval string
+	if interval, dti.loc, err = splitFilterTZ(val, dfltTZ); err != nil {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaTimeOfDay, val, err)
+	}
+	limits := strings.Split(interval, filterRangeSep)
+	if len(limits) != 2 {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>", utils.MetaTimeOfDay, val)
+	}
+	if dti.start, err = parseDayTime(limits[0]); err != nil {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaTimeOfDay, val, err)
+	}
+	if dti.end, err = parseDayTime(limits[1]); err != nil {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaTimeOfDay, val, err)
+	}
+	if dti.start == dti.end {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, empty interval", utils.MetaTimeOfDay, val)
+	}
+	return
+}
+
+// parseWeekDay accepts the day name (at least the first three letters) or the number with Sunday as 0
+func parseWeekDay(val string) (wd time.Weekday, err error) {
+	if nr, errNr := strconv.Atoi(val); errNr == nil {
+		if nr < 0 || nr > 6 {
+			return 0, fmt.Errorf("invalid day of week: <%s>", val)
+		}
+		return time.Weekday(nr), nil
+	}
+	if len(val) >= weekDayNameSize {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if dName := d.String(); len(val) <= len(dName) &&
+				strings.EqualFold(val, dName[:len(val)]) {
+				return d, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid day of week: <%s>", val)
+}
+
+// newDayOfWeekInterval parses the value in the form day[-day][@timezone] (ie. MON-FRI@Europe/Berlin)
+// the interval can go over the end of the week (ie. SAT-MON)
+func newDayOfWeekInterval(val, dfltTZ string) (dti *dayTimeInterval, err error) {
+	dti = new(dayTimeInterval)
+	var interval string
+	if interval, dti.loc, err = splitFilterTZ(val, dfltTZ); err != nil {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaDayOfWeek, val, err)
+	}
+	limits := strings.Split(interval, filterRangeSep)
+	if len(limits) > 2 {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>", utils.MetaDayOfWeek, val)
+	}
+	var start, end time.Weekday
+	if start, err = parseWeekDay(limits[0]); err != nil {
+		return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaDayOfWeek, val, err)
+	}
+	end = start
+	if len(limits) == 2 {
+		if end, err = parseWeekDay(limits[1]); err != nil {
+			return nil, fmt.Errorf("invalid value for %s filter: <%s>, %s", utils.MetaDayOfWeek, val, err)
+		}
+	}
+	for d := start; ; d = (d + 1) % 7 {
+		dti.weekDays[d] = true
+		if d == end {
+			break
+		}
+	}
+	return
+}
+
+// passTimeOfDay returns true if the time of day of t in the interval timezone is within interval
+func (dti *dayTimeInterval) passTimeOfDay(t time.Time) bool {
+	t = t.In(dti.loc)
+	secs := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	if dti.start < dti.end {
+		return secs >= dti.start && secs < dti.end
+	}
+	return secs >= dti.start || secs < dti.end // over midnight
+}
+
+// passDayOfWeek returns true if the day of t in the interval timezone is one of the interval days
+func (dti *dayTimeInterval) passDayOfWeek(t time.Time) bool {
+	return dti.weekDays[t.In(dti.loc).Weekday()]
+}
@@ -0,0 +1,195 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHaversineDistance(t *testing.T) {
+	// Paris to London is about 343.5 km
+	if dist := haversineDistance(48.8566, 2.3522, 51.5074, -0.1278); math.Abs(dist-343.5) > 1 {
+		t.Errorf("Expected about 343.5 km received %v", dist)
+	}
+	if dist := haversineDistance(10, 10, 10, 10); dist != 0 {
+		t.Errorf("Expected 0 received %v", dist)
+	}
+}
+
+func TestNewGeoCircle(t *testing.T) {
+	if gc, err := newGeoCircle("48.8566:2.3522:500m"); err != nil {
+		t.Error(err)
+	} else if gc.radius != 0.5 {
+		t.Errorf("Expected radius 0.5 received %v", gc.radius)
+	}
+	if gc, err := newGeoCircle("-33.86:151.2:10km"); err != nil {
+		t.Error(err)
+	} else if gc.radius != 10 {
+		t.Errorf("Expected radius 10 received %v", gc.radius)
+	}
+	if gc, err := newGeoCircle("0:0:7"); err != nil {
+		t.Error(err)
+	} else if gc.radius != 7 {
+		t.Errorf("Expected radius 7 received %v", gc.radius)
+	}
+	for _, val := range []string{"48.8566:2.3522", "91:0:1", "0:181:1",
+		"a:0:1", "0:0:-1", "0:0:1mi"} {
+		if _, err := newGeoCircle(val); err == nil {
+			t.Errorf("Expected error for <%s>", val)
+		}
+	}
+}
+
+func TestFilterPassGeoDistance(t *testing.T) {
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{
+		"Lat":    "48.8584",
+		"Lon":    2.2945, // Eiffel Tower
+		"BadLat": "north",
+	}}
+	for _, tc := range []struct {
+		fltr string
+		elem string
+		vals []string
+		exp  bool
+	}{
+		{utils.MetaGeoDistance, "~*req.Lat;~*req.Lon", []string{"48.8566:2.3522:5km"}, true},
+		{utils.MetaGeoDistance, "~*req.Lat;~*req.Lon", []string{"48.8566:2.3522:500m"}, false},
+		{utils.MetaGeoDistance, "~*req.Lat;~*req.Lon", []string{"51.5074:-0.1278:10", "48.8566:2.3522:5"}, true},
+		{utils.MetaGeoDistance, "~*req.Missing;~*req.Lon", []string{"48.8566:2.3522:5km"}, false},
+		{utils.MetaGeoDistance, "~*req.BadLat;~*req.Lon", []string{"48.8566:2.3522:5km"}, false},
+		{utils.MetaNotGeoDistance, "~*req.Lat;~*req.Lon", []string{"51.5074:-0.1278:100km"}, true},
+	} {
+		rf, err := NewFilterRule(tc.fltr, tc.elem, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.exp {
+			t.Errorf("Expected %v for %s:%s:%v received %v", tc.exp, tc.fltr, tc.elem, tc.vals, pass)
+		}
+	}
+	if _, err := NewFilterRule(utils.MetaGeoDistance, "~*req.Lat", []string{"48.8566:2.3522:5km"}); err == nil {
+		t.Error("Expected error for missing longitude")
+	}
+}
+
+func TestNewTimeOfDayInterval(t *testing.T) {
+	if dti, err := newTimeOfDayInterval("08:00-18:30:15@Europe/Bucharest", "UTC"); err != nil {
+		t.Error(err)
+	} else if dti.start != 8*3600 || dti.end != 18*3600+30*60+15 ||
+		dti.loc.String() != "Europe/Bucharest" {
+		t.Errorf("Unexpected interval: %+v", dti)
+	}
+	if dti, err := newTimeOfDayInterval("22:00-24:00", "UTC"); err != nil {
+		t.Error(err)
+	} else if dti.end != 24*3600 || dti.loc != time.UTC {
+		t.Errorf("Unexpected interval: %+v", dti)
+	}
+	for _, val := range []string{"08:00", "08:00-08:00", "8-18", "08:60-18:00",
+		"24:01-02:00", "08:00-18:00@Mars/Olympus"} {
+		if _, err := newTimeOfDayInterval(val, "UTC"); err == nil {
+			t.Errorf("Expected error for <%s>", val)
+		}
+	}
+}
+
+func TestNewDayOfWeekInterval(t *testing.T) {
+	for val, exp := range map[string][7]bool{
+		"MON-FRI":  {false, true, true, true, true, true, false},
+		"saturday": {false, false, false, false, false, false, true},
+		"FRI-MON":  {true, true, false, false, false, true, true},
+		"0":        {true, false, false, false, false, false, false},
+		"3-4":      {false, false, false, true, true, false, false},
+	} {
+		if dti, err := newDayOfWeekInterval(val, "UTC"); err != nil {
+			t.Error(err)
+		} else if dti.weekDays != exp {
+			t.Errorf("Expected %v for <%s> received %v", exp, val, dti.weekDays)
+		}
+	}
+	for _, val := range []string{"MO", "7", "MON-TUE-WED", "FUNDAY", "MON@Nowhere"} {
+		if _, err := newDayOfWeekInterval(val, "UTC"); err == nil {
+			t.Errorf("Expected error for <%s>", val)
+		}
+	}
+}
+
+func TestFilterPassDayTime(t *testing.T) {
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{
+		"Monday":   time.Date(2021, 3, 1, 9, 30, 0, 0, time.UTC),  // Monday
+		"Saturday": time.Date(2021, 3, 6, 23, 15, 0, 0, time.UTC), // Saturday, Sunday in Bucharest
+		"Invalid":  "not_a_time",
+	}}
+	for _, tc := range []struct {
+		fltr string
+		elem string
+		vals []string
+		exp  bool
+	}{
+		{utils.MetaTimeOfDay, "~*req.Monday", []string{"08:00-18:00@UTC"}, true},
+		{utils.MetaTimeOfDay, "~*req.Monday", []string{"09:30-10:00@UTC"}, true},
+		{utils.MetaTimeOfDay, "~*req.Monday", []string{"08:00-09:30@UTC"}, false},
+		{utils.MetaTimeOfDay, "~*req.Monday", []string{"08:00-10:00@Europe/Bucharest"}, false},
+		{utils.MetaTimeOfDay, "~*req.Saturday", []string{"22:00-06:00@UTC"}, true},
+		{utils.MetaTimeOfDay, "~*req.Missing", []string{"00:00-24:00@UTC"}, false},
+		{utils.MetaNotTimeOfDay, "~*req.Saturday", []string{"08:00-18:00@UTC"}, true},
+		{utils.MetaDayOfWeek, "~*req.Monday", []string{"MON-FRI@UTC"}, true},
+		{utils.MetaDayOfWeek, "~*req.Saturday", []string{"MON-FRI@UTC"}, false},
+		{utils.MetaDayOfWeek, "~*req.Saturday", []string{"SAT@UTC"}, true},
+		{utils.MetaDayOfWeek, "~*req.Saturday", []string{"SUN@Europe/Bucharest"}, true},
+		{utils.MetaDayOfWeek, "~*req.Saturday", []string{"MON@UTC", "SAT@UTC"}, true},
+		{utils.MetaNotDayOfWeek, "~*req.Saturday", []string{"SAT-SUN@UTC"}, false},
+	} {
+		rf, err := NewFilterRule(tc.fltr, tc.elem, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.exp {
+			t.Errorf("Expected %v for %s:%s:%v received %v", tc.exp, tc.fltr, tc.elem, tc.vals, pass)
+		}
+	}
+	rf, err := NewFilterRule(utils.MetaTimeOfDay, "~*req.Invalid", []string{"08:00-18:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rf.Pass(ev); err == nil {
+		t.Error("Expected error for invalid time")
+	}
+}
+
+func TestFilterFromInlineDayTime(t *testing.T) {
+	if _, err := NewFilterFromInline("cgrates.org",
+		"*time_of_day:~*req.AnswerTime:08:00-12:00@UTC|13:00-17:00@UTC"); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewFilterFromInline("cgrates.org",
+		"*geo_distance:~*req.Lat;~*req.Lon:48.8566:2.3522:5km"); err != nil {
+		t.Error(err)
+	}
+	if _, err := NewFilterFromInline("cgrates.org", "*day_of_week:~*req.AnswerTime:ODD"); err == nil {
+		t.Error("Expected error for invalid day")
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// MaxMind DB format constants, see https://maxmind.github.io/MaxMind-DB/
+const (
+	mmdbMetadataStart     = "\xAB\xCD\xEFMaxMind.com"
+	mmdbDataSeparatorSize = 16
+
+	mmdbTypeExtended  = 0
+	mmdbTypePointer   = 1
+	mmdbTypeString    = 2
+	mmdbTypeDouble    = 3
+	mmdbTypeBytes     = 4
+	mmdbTypeUint16    = 5
+	mmdbTypeUint32    = 6
+	mmdbTypeMap       = 7
+	mmdbTypeInt32     = 8
+	mmdbTypeUint64    = 9
+	mmdbTypeUint128   = 10
+	mmdbTypeArray     = 11
+	mmdbTypeContainer = 12
+	mmdbTypeEndMarker = 13
+	mmdbTypeBool      = 14
+	mmdbTypeFloat     = 15
+
+	// fields populated by the GeoIP2/GeoLite2 databases
+	geoIPCountry       = "country"
+	geoIPRegCountry    = "registered_country"
+	geoIPISOCode       = "iso_code"
+	geoIPASNumber      = "autonomous_system_number"
+	geoIPASNPrefix     = "AS"
+	mmdbNodeCount      = "node_count"
+	mmdbRecordSize     = "record_size"
+	mmdbIPVersion      = "ip_version"
+	mmdbMaxPointerJump = 32 // protection against the pointer loops of broken databases
+)
+
+var errMMDBInvalid = errors.New("invalid MaxMind database")
+
+// newMMDBReader loads in memory the MaxMind DB file
+func newMMDBReader(path string) (mr *mmdbReader, err error) {
+	var buf []byte
+	if buf, err = os.ReadFile(path); err != nil {
+		return
+	}
+	return newMMDBReaderFromBytes(buf)
+}
+
+// newMMDBReaderFromBytes parses the metadata and prepares the reader for lookups
+func newMMDBReaderFromBytes(buf []byte) (mr *mmdbReader, err error) {
+	mdStart := bytes.LastIndex(buf, []byte(mmdbMetadataStart))
+	if mdStart == -1 {
+		return nil, fmt.Errorf("%w: metadata not found", errMMDBInvalid)
+	}
+	mdDec := &mmdbDecoder{buf: buf[mdStart+len(mmdbMetadataStart):]}
+	var mdIface interface{}
+	if mdIface, _, err = mdDec.decode(0, 0); err != nil {
+		return
+	}
+	md, canCast := mdIface.(map[string]interface{})
+	if !canCast {
+		return nil, fmt.Errorf("%w: metadata is not a map", errMMDBInvalid)
+	}
+	mr = new(mmdbReader)
+	var nodeCount, recordSize, ipVersion uint64
+	if nodeCount, err = mmdbMetaUint(md, mmdbNodeCount); err != nil {
+		return nil, err
+	}
+	if recordSize, err = mmdbMetaUint(md, mmdbRecordSize); err != nil {
+		return nil, err
+	}
+	if ipVersion, err = mmdbMetaUint(md, mmdbIPVersion); err != nil {
+		return nil, err
+	}
+	if recordSize != 24 && recordSize != 28 && recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size: %d", errMMDBInvalid, recordSize)
+	}
+	mr.nodeCount = uint(nodeCount)
+	mr.recordSize = uint(recordSize)
+	mr.ipVersion = uint(ipVersion)
+	mr.nodeSize = mr.recordSize / 4 // two records per node
+	treeSize := mr.nodeCount * mr.nodeSize
+	if treeSize+mmdbDataSeparatorSize > uint(mdStart) {
+		return nil, fmt.Errorf("%w: search tree bigger than the file", errMMDBInvalid)
+	}
+	mr.tree = buf[:treeSize]
+	mr.data = &mmdbDecoder{buf: buf[treeSize+mmdbDataSeparatorSize : mdStart]}
+	if mr.ipVersion == 6 { // IPv4 addresses are found after the first 96 zero bits
+		for i := 0; i < 96 && mr.ipv4Start < mr.nodeCount; i++ {
+			mr.ipv4Start = mr.readRecord(mr.ipv4Start, 0)
+		}
+	}
+	return
+}
+
+// mmdbMetaUint returns the unsigned integer field of the metadata
+func mmdbMetaUint(md map[string]interface{}, fld string) (uint64, error) {
+	val, canCast := md[fld].(uint64)
+	if !canCast {
+		return 0, fmt.Errorf("%w: invalid metadata field <%s>: %v", errMMDBInvalid, fld, md[fld])
+	}
+	return val, nil
+}
+
+// mmdbReader does the lookups in a MaxMind DB loaded in memory
+type mmdbReader struct {
+	tree       []byte
+	data       *mmdbDecoder
+	nodeCount  uint
+	recordSize uint
+	nodeSize   uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+// readRecord returns the left(0) or right(1) record of the node
+func (mr *mmdbReader) readRecord(node, bit uint) uint {
+	b := mr.tree[node*mr.nodeSize : (node+1)*mr.nodeSize]
+	switch mr.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default: // 32
+		return uint(binary.BigEndian.Uint32(b[bit*4:]))
+	}
+}
+
+// Lookup returns the record of the network containing the IP or utils.ErrNotFound
+func (mr *mmdbReader) Lookup(ip net.IP) (rcrd interface{}, err error) {
+	node := uint(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		node = mr.ipv4Start
+	} else if mr.ipVersion == 4 {
+		return nil, utils.ErrNotFound
+	}
+	bitCount := uint(len(ip) * 8)
+	for i := uint(0); i < bitCount && node < mr.nodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-(i&7))) & 1
+		node = mr.readRecord(node, bit)
+	}
+	if node <= mr.nodeCount { // empty record or tree not finished
+		return nil, utils.ErrNotFound
+	}
+	offset := node - mr.nodeCount - mmdbDataSeparatorSize
+	if offset >= uint(len(mr.data.buf)) {
+		return nil, fmt.Errorf("%w: data pointer out of range", errMMDBInvalid)
+	}
+	rcrd, _, err = mr.data.decode(offset, 0)
+	return
+}
+
+// mmdbDecoder decodes the data section of MaxMind DB
+type mmdbDecoder struct {
+	buf []byte
+}
+
+// bytesAt returns size bytes from offset checking the bounds
+func (d *mmdbDecoder) bytesAt(offset, size uint) ([]byte, error) {
+	if offset+size > uint(len(d.buf)) {
+		return nil, fmt.Errorf("%w: unexpected end of data", errMMDBInvalid)
+	}
+	return d.buf[offset : offset+size], nil
+}
+
+// uintFromBytes decodes the big endian unsigned integer
+func uintFromBytes(prefix uint64, b []byte) uint64 {
+	for _, c := range b {
+		prefix = prefix<<8 | uint64(c)
+	}
+	return prefix
+}
+
+// decode returns the value found at offset and the offset of the next field
+func (d *mmdbDecoder) decode(offset uint, depth int) (val interface{}, next uint, err error) {
+	var ctrl []byte
+	if ctrl, err = d.bytesAt(offset, 1); err != nil {
+		return
+	}
+	offset++
+	typ := uint(ctrl[0] >> 5)
+	if typ == mmdbTypePointer {
+		if depth >= mmdbMaxPointerJump {
+			return nil, 0, fmt.Errorf("%w: too many pointers", errMMDBInvalid)
+		}
+		ptrSize := uint((ctrl[0]>>3)&0x3) + 1
+		var b []byte
+		if b, err = d.bytesAt(offset, ptrSize); err != nil {
+			return
+		}
+		var ptr uint64
+		switch ptrSize {
+		case 1:
+			ptr = uintFromBytes(uint64(ctrl[0]&0x7), b)
+		case 2:
+			ptr = uintFromBytes(uint64(ctrl[0]&0x7), b) + 2048
+		case 3:
+			ptr = uintFromBytes(uint64(ctrl[0]&0x7), b) + 526336
+		default:
+			ptr = uintFromBytes(0, b)
+		}
+		if val, _, err = d.decode(uint(ptr), depth+1); err != nil {
+			return
+		}
+		return val, offset + ptrSize, nil
+	}
+	if typ == mmdbTypeExtended {
+		var ext []byte
+		if ext, err = d.bytesAt(offset, 1); err != nil {
+			return
+		}
+		offset++
+		typ = 7 + uint(ext[0])
+	}
+	size := uint(ctrl[0] & 0x1f)
+	if size >= 29 {
+		extBytes := size - 28
+		var b []byte
+		if b, err = d.bytesAt(offset, extBytes); err != nil {
+			return
+		}
+		offset += extBytes
+		switch extBytes {
+		case 1:
+			size = 29 + uint(b[0])
+		case 2:
+			size = 285 + uint(uintFromBytes(0, b))
+		default:
+			size = 65821 + uint(uintFromBytes(0, b))
+		}
+	}
+	return d.decodeValue(typ, size, offset, depth)
+}
+
+// decodeValue decodes the value of type and size starting at offset
+func (d *mmdbDecoder) decodeValue(typ, size, offset uint, depth int) (val interface{}, next uint, err error) {
+	switch typ {
+	case mmdbTypeMap:
+		mp := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			var key, itm interface{}
+			if key, offset, err = d.decode(offset, depth); err != nil {
+				return
+			}
+			keyStr, canCast := key.(string)
+			if !canCast {
+				return nil, 0, fmt.Errorf("%w: map key is not a string", errMMDBInvalid)
+			}
+			if itm, offset, err = d.decode(offset, depth); err != nil {
+				return
+			}
+			mp[keyStr] = itm
+		}
+		return mp, offset, nil
+	case mmdbTypeArray:
+		arr := make([]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			if arr[i], offset, err = d.decode(offset, depth); err != nil {
+				return
+			}
+		}
+		return arr, offset, nil
+	case mmdbTypeBool:
+		return size != 0, offset, nil
+	case mmdbTypeContainer, mmdbTypeEndMarker:
+		return nil, offset, nil
+	}
+	var b []byte
+	if b, err = d.bytesAt(offset, size); err != nil {
+		return
+	}
+	next = offset + size
+	switch typ {
+	case mmdbTypeString:
+		val = string(b)
+	case mmdbTypeBytes:
+		val = append([]byte(nil), b...)
+	case mmdbTypeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: invalid double size: %d", errMMDBInvalid, size)
+		}
+		val = math.Float64frombits(binary.BigEndian.Uint64(b))
+	case mmdbTypeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: invalid float size: %d", errMMDBInvalid, size)
+		}
+		val = float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case mmdbTypeUint16, mmdbTypeUint32, mmdbTypeUint64:
+		val = uintFromBytes(0, b)
+	case mmdbTypeInt32:
+		val = int64(int32(uint32(uintFromBytes(0, b))))
+	case mmdbTypeUint128: // not used by the GeoIP fields we are interested in
+		val = append([]byte(nil), b...)
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown data type: %d", errMMDBInvalid, typ)
+	}
+	return
+}
+
+// geoIPInfo is the information about an IP used by the *geoip filters
+type geoIPInfo struct {
+	Country string // ISO code
+	ASN     uint64
+}
+
+// geoIPDBs are the MaxMind databases opened from the filters config
+var geoIPDBs = new(geoIPReaders)
+
+// geoIPReaders keeps the opened databases, reopening them when the configured paths change
+type geoIPReaders struct {
+	sync.RWMutex
+	paths   []string
+	readers []*mmdbReader
+}
+
+// getReaders returns the readers for the paths, opening the databases on first use
+func (gr *geoIPReaders) getReaders(paths []string) (readers []*mmdbReader, err error) {
+	gr.RLock()
+	if utils.SliceStringEqual(gr.paths, paths) {
+		readers = gr.readers
+		gr.RUnlock()
+		return
+	}
+	gr.RUnlock()
+	gr.Lock()
+	defer gr.Unlock()
+	if utils.SliceStringEqual(gr.paths, paths) { // opened meanwhile
+		return gr.readers, nil
+	}
+	readers = make([]*mmdbReader, len(paths))
+	for i, path := range paths {
+		if readers[i], err = newMMDBReader(path); err != nil {
+			return nil, fmt.Errorf("cannot open GeoIP database <%s>: %s", path, err)
+		}
+	}
+	gr.paths = utils.CloneStringSlice(paths)
+	gr.readers = readers
+	return
+}
+
+// lookupGeoIP merges the information about IP from all the configured databases
+func lookupGeoIP(paths []string, ip net.IP) (info *geoIPInfo, err error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no %s configured", utils.GeoIPDBPathsCfg)
+	}
+	var readers []*mmdbReader
+	if readers, err = geoIPDBs.getReaders(paths); err != nil {
+		return
+	}
+	info = new(geoIPInfo)
+	var found bool
+	for _, rdr := range readers {
+		rcrd, err := rdr.Lookup(ip)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		mp, canCast := rcrd.(map[string]interface{})
+		if !canCast {
+			continue
+		}
+		found = true
+		if info.Country == utils.EmptyString {
+			info.Country = geoIPISOCodeFromRecord(mp, geoIPCountry)
+		}
+		if info.Country == utils.EmptyString {
+			info.Country = geoIPISOCodeFromRecord(mp, geoIPRegCountry)
+		}
+		if asn, has := mp[geoIPASNumber]; has && info.ASN == 0 {
+			info.ASN, _ = asn.(uint64)
+		}
+	}
+	if !found {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// geoIPISOCodeFromRecord returns the iso_code of the country field
+func geoIPISOCodeFromRecord(rcrd map[string]interface{}, fld string) (isoCode string) {
+	if country, canCast := rcrd[fld].(map[string]interface{}); canCast {
+		isoCode, _ = country[geoIPISOCode].(string)
+	}
+	return
+}
@@ -0,0 +1,232 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// mmdbTestEncode encodes the value in the MaxMind DB data format
+func mmdbTestEncode(val interface{}) (b []byte) {
+	ctrl := func(typ byte, size int) []byte {
+		if typ > 7 {
+			return []byte{byte(size), typ - 7}
+		}
+		return []byte{typ<<5 | byte(size)}
+	}
+	switch v := val.(type) {
+	case string:
+		return append(ctrl(mmdbTypeString, len(v)), v...)
+	case uint32:
+		b = ctrl(mmdbTypeUint32, 4)
+		return binary.BigEndian.AppendUint32(b, v)
+	case uint64:
+		b = ctrl(mmdbTypeUint64, 8)
+		return binary.BigEndian.AppendUint64(b, v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b = ctrl(mmdbTypeMap, len(v))
+		for _, k := range keys {
+			b = append(b, mmdbTestEncode(k)...)
+			b = append(b, mmdbTestEncode(v[k])...)
+		}
+	}
+	return
+}
+
+// mmdbTestBuild builds an IPv4 MaxMind DB with 24 bits records for the given networks
+func mmdbTestBuild(t *testing.T, nets map[string]map[string]interface{}) []byte {
+	type node struct{ rcrds [2]int } // negative values are data offsets, 0 is empty
+	nodes := []*node{{}}
+	var data []byte
+	dataOffsets := make(map[string]int)
+	for cidr, rcrd := range nets {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dataOffsets[cidr] = len(data)
+		data = append(data, mmdbTestEncode(rcrd)...)
+		ones, _ := ipNet.Mask.Size()
+		ip := ipNet.IP.To4()
+		crt := 0
+		for i := 0; i < ones; i++ {
+			bit := int(ip[i>>3]>>(7-(i&7))) & 1
+			if i == ones-1 {
+				nodes[crt].rcrds[bit] = -(dataOffsets[cidr] + 1)
+				break
+			}
+			if nodes[crt].rcrds[bit] <= 0 {
+				nodes = append(nodes, &node{})
+				nodes[crt].rcrds[bit] = len(nodes) - 1
+			}
+			crt = nodes[crt].rcrds[bit]
+		}
+	}
+	nodeCount := len(nodes)
+	var buf []byte
+	for _, n := range nodes {
+		for _, rcrd := range n.rcrds {
+			val := nodeCount // empty
+			if rcrd > 0 {
+				val = rcrd
+			} else if rcrd < 0 {
+				val = nodeCount + mmdbDataSeparatorSize - rcrd - 1
+			}
+			buf = append(buf, byte(val>>16), byte(val>>8), byte(val))
+		}
+	}
+	buf = append(buf, make([]byte, mmdbDataSeparatorSize)...)
+	buf = append(buf, data...)
+	buf = append(buf, mmdbMetadataStart...)
+	return append(buf, mmdbTestEncode(map[string]interface{}{
+		mmdbNodeCount:  uint32(nodeCount),
+		mmdbRecordSize: uint32(24),
+		mmdbIPVersion:  uint32(4),
+	})...)
+}
+
+var mmdbTestNets = map[string]map[string]interface{}{
+	"1.2.3.0/24": {
+		geoIPCountry: map[string]interface{}{geoIPISOCode: "DE"},
+	},
+	"8.8.8.0/24": {
+		geoIPASNumber: uint64(15169),
+	},
+	"10.0.0.0/8": {
+		geoIPRegCountry: map[string]interface{}{geoIPISOCode: "RO"},
+	},
+}
+
+func TestMMDBReaderLookup(t *testing.T) {
+	mr, err := newMMDBReaderFromBytes(mmdbTestBuild(t, mmdbTestNets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{
+		geoIPCountry: map[string]interface{}{geoIPISOCode: "DE"},
+	}
+	if rcv, err := mr.Lookup(net.ParseIP("1.2.3.4")); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	exp = map[string]interface{}{geoIPASNumber: uint64(15169)}
+	if rcv, err := mr.Lookup(net.ParseIP("8.8.8.8")); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, err := mr.Lookup(net.ParseIP("1.2.4.4")); err != utils.ErrNotFound {
+		t.Errorf("Expected %v received %v", utils.ErrNotFound, err)
+	}
+	if _, err := mr.Lookup(net.ParseIP("2001:db8::1")); err != utils.ErrNotFound {
+		t.Errorf("Expected %v received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestMMDBReaderInvalid(t *testing.T) {
+	if _, err := newMMDBReaderFromBytes([]byte("not a database")); !errors.Is(err, errMMDBInvalid) {
+		t.Errorf("Expected %v received %v", errMMDBInvalid, err)
+	}
+	buf := append([]byte(mmdbMetadataStart), mmdbTestEncode(map[string]interface{}{
+		mmdbNodeCount:  uint32(1),
+		mmdbRecordSize: uint32(20),
+		mmdbIPVersion:  uint32(4),
+	})...)
+	if _, err := newMMDBReaderFromBytes(buf); !errors.Is(err, errMMDBInvalid) {
+		t.Errorf("Expected %v received %v", errMMDBInvalid, err)
+	}
+}
+
+func TestLookupGeoIP(t *testing.T) {
+	if _, err := lookupGeoIP(nil, net.ParseIP("1.2.3.4")); err == nil {
+		t.Error("Expected error for missing databases")
+	}
+	dbPath := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(dbPath, mmdbTestBuild(t, mmdbTestNets), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := lookupGeoIP([]string{dbPath}, net.ParseIP("10.1.1.1")); err != nil {
+		t.Error(err)
+	} else if exp := (&geoIPInfo{Country: "RO"}); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v received %+v", exp, rcv)
+	}
+	if _, err := lookupGeoIP([]string{dbPath}, net.ParseIP("192.168.1.1")); err != utils.ErrNotFound {
+		t.Errorf("Expected %v received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestFilterPassGeoIP(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.mmdb")
+	if err := os.WriteFile(dbPath, mmdbTestBuild(t, mmdbTestNets), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dfltPaths := config.CgrConfig().FilterSCfg().GeoIPDBPaths
+	config.CgrConfig().FilterSCfg().GeoIPDBPaths = []string{dbPath}
+	defer func() {
+		config.CgrConfig().FilterSCfg().GeoIPDBPaths = dfltPaths
+	}()
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{
+		"SrcIP": "1.2.3.4",
+		"DNS":   "8.8.8.8",
+		"LAN":   "192.168.1.1",
+		"Bad":   "not_an_ip",
+	}}
+	for _, tc := range []struct {
+		fltr string
+		elem string
+		vals []string
+		exp  bool
+	}{
+		{utils.MetaGeoIP, "~*req.SrcIP", []string{"FR", "de"}, true},
+		{utils.MetaGeoIP, "~*req.SrcIP", []string{"FR"}, false},
+		{utils.MetaGeoIP, "~*req.DNS", []string{"AS15169"}, true},
+		{utils.MetaGeoIP, "~*req.DNS", []string{"US"}, false},
+		{utils.MetaGeoIP, "~*req.LAN", []string{"DE"}, false},
+		{utils.MetaGeoIP, "~*req.Bad", []string{"DE"}, false},
+		{utils.MetaGeoIP, "~*req.Missing", []string{"DE"}, false},
+		{utils.MetaNotGeoIP, "~*req.SrcIP", []string{"DE"}, false},
+		{utils.MetaNotGeoIP, "~*req.LAN", []string{"DE"}, true},
+	} {
+		rf, err := NewFilterRule(tc.fltr, tc.elem, tc.vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := rf.Pass(ev); err != nil {
+			t.Error(err)
+		} else if pass != tc.exp {
+			t.Errorf("Expected %v for %s:%s:%v received %v", tc.exp, tc.fltr, tc.elem, tc.vals, pass)
+		}
+	}
+}
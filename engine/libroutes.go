@@ -36,9 +36,10 @@ type SortedRoute struct {
 
 // SortedRoutes is returned as part of GetRoutes call
 type SortedRoutes struct {
-	ProfileID string         // Profile matched
-	Sorting   string         // Sorting algorithm
-	Routes    []*SortedRoute // list of route IDs and SortingData data
+	ProfileID string            // Profile matched
+	Sorting   string            // Sorting algorithm
+	Routes    []*SortedRoute    // list of route IDs and SortingData data
+	Blacklist []*RouteQOSStatus // routes blacklisted by the *qos exclusion rules
 }
 
 // RouteIDs returns a list of route IDs
@@ -39,6 +39,10 @@ type StatQueueProfile struct {
 	QueueLength        int
 	TTL                time.Duration
 	MinItems           int
+	WindowType         string               // *tumbling or *sliding, no windows are kept if empty
+	WindowSize         time.Duration        // time covered by the metrics of one window
+	WindowSlide        time.Duration        // interval between the *sliding windows, defaults to WindowSize
+	WindowCount        int                  // number of closed windows kept
 	Metrics            []*MetricWithFilters // list of metrics to build
 	Stored             bool
 	Blocker            bool // blocker flag to stop processing on filters matched
@@ -88,6 +92,36 @@ func (sqp *StatQueueProfile) isLocked() bool {
 	return sqp.lkID != utils.EmptyString
 }
 
+// CheckWindows validates the time windows of the profile
+func (sqp *StatQueueProfile) CheckWindows() error {
+	switch sqp.WindowType {
+	case utils.EmptyString:
+		return nil
+	case utils.MetaTumbling, utils.MetaSliding:
+	default:
+		return fmt.Errorf("unsupported WindowType: <%s>", sqp.WindowType)
+	}
+	if sqp.WindowSize <= 0 {
+		return fmt.Errorf("WindowSize must be positive for WindowType: <%s>", sqp.WindowType)
+	}
+	if sqp.WindowSlide < 0 ||
+		(sqp.WindowType == utils.MetaTumbling && sqp.WindowSlide != 0) {
+		return fmt.Errorf("invalid WindowSlide: <%s> for WindowType: <%s>", sqp.WindowSlide, sqp.WindowType)
+	}
+	if sqp.WindowCount <= 0 {
+		return fmt.Errorf("WindowCount must be positive for WindowType: <%s>", sqp.WindowType)
+	}
+	return nil
+}
+
+// windowStep returns the interval at which the windows are closed
+func (sqp *StatQueueProfile) windowStep() time.Duration {
+	if sqp.WindowType == utils.MetaSliding && sqp.WindowSlide > 0 {
+		return sqp.WindowSlide
+	}
+	return sqp.WindowSize
+}
+
 type MetricWithFilters struct {
 	FilterIDs []string
 	MetricID  string
@@ -102,6 +136,8 @@ func NewStoredStatQueue(sq *StatQueue, ms Marshaler) (sSQ *StoredStatQueue, err
 			config.CgrConfig().GeneralCfg().RoundingDecimals),
 		SQItems:   make([]SQItem, len(sq.SQItems)),
 		SQMetrics: make(map[string][]byte, len(sq.SQMetrics)),
+		Windows:   sq.Windows,
+		WindowEnd: sq.WindowEnd,
 	}
 	for i, sqItm := range sq.SQItems {
 		sSQ.SQItems[i] = sqItm
@@ -123,6 +159,8 @@ type StoredStatQueue struct {
 	SQItems    []SQItem
 	SQMetrics  map[string][]byte
 	Compressed bool
+	Windows    []*StatWindow
+	WindowEnd  *time.Time
 }
 
 type StatQueueWithAPIOpts struct {
@@ -145,6 +183,8 @@ func (ssq *StoredStatQueue) AsStatQueue(ms Marshaler) (sq *StatQueue, err error)
 		ID:        ssq.ID,
 		SQItems:   make([]SQItem, len(ssq.SQItems)),
 		SQMetrics: make(map[string]StatMetric, len(ssq.SQMetrics)),
+		Windows:   ssq.Windows,
+		WindowEnd: ssq.WindowEnd,
 	}
 	for i, sqItm := range ssq.SQItems {
 		sq.SQItems[i] = sqItm
@@ -169,6 +209,26 @@ type SQItem struct {
 	ExpiryTime *time.Time // Used to auto-expire events
 }
 
+// StatWindow holds the values of the metrics for a closed window
+type StatWindow struct {
+	Start   time.Time
+	End     time.Time
+	Metrics map[string]string // map[metricID]value
+}
+
+// Clone returns a copy of the window
+func (wnd *StatWindow) Clone() (cln *StatWindow) {
+	cln = &StatWindow{
+		Start:   wnd.Start,
+		End:     wnd.End,
+		Metrics: make(map[string]string, len(wnd.Metrics)),
+	}
+	for metricID, val := range wnd.Metrics {
+		cln.Metrics[metricID] = val
+	}
+	return
+}
+
 func NewStatQueue(tnt, id string, metrics []*MetricWithFilters, minItems int) (sq *StatQueue, err error) {
 	sq = &StatQueue{
 		Tenant:    tnt,
@@ -191,7 +251,9 @@ type StatQueue struct {
 	ID        string
 	SQItems   []SQItem
 	SQMetrics map[string]StatMetric
-	lkID      string // ID of the lock used when matching the stat
+	Windows   []*StatWindow // closed windows, oldest first
+	WindowEnd *time.Time    // end of the current window
+	lkID      string        // ID of the lock used when matching the stat
 	sqPrfl    *StatQueueProfile
 	dirty     *bool          // needs save
 	ttl       *time.Duration // timeToLeave, picked on each init
@@ -234,6 +296,10 @@ func (sq *StatQueue) TenantID() string {
 
 // ProcessEvent processes a utils.CGREvent, returns true if processed
 func (sq *StatQueue) ProcessEvent(tnt, evID string, filterS *FilterS, evNm utils.MapStorage) (err error) {
+	if _, err = sq.rollWindows(sq.sqPrfl, time.Now(),
+		config.CgrConfig().GeneralCfg().RoundingDecimals); err != nil {
+		return
+	}
 	if _, err = sq.remExpired(); err != nil {
 		return
 	}
@@ -260,12 +326,17 @@ func (sq *StatQueue) remEventWithID(evID string) (err error) {
 
 // remExpired expires items in queue
 func (sq *StatQueue) remExpired() (removed int, err error) {
+	return sq.remExpiredAt(time.Now())
+}
+
+// remExpiredAt expires the items in queue which are expired at the given time
+func (sq *StatQueue) remExpiredAt(t time.Time) (removed int, err error) {
 	var expIdx *int // index of last item to be expired
 	for i, item := range sq.SQItems {
 		if item.ExpiryTime == nil {
 			break // items are ordered, so no need to look further
 		}
-		if item.ExpiryTime.After(time.Now()) {
+		if item.ExpiryTime.After(t) {
 			break
 		}
 		if err = sq.remEventWithID(item.EventID); err != nil {
@@ -296,6 +367,73 @@ func (sq *StatQueue) remOnQueueLength() (err error) {
 	return
 }
 
+// reset removes all the events out of the queue and its metrics
+func (sq *StatQueue) reset() (err error) {
+	sq.SQItems = make([]SQItem, 0)
+	metrics := sq.SQMetrics
+	sq.SQMetrics = make(map[string]StatMetric)
+	for id, m := range metrics {
+		var metric StatMetric
+		if metric, err = NewStatMetric(id,
+			m.GetMinItems(), m.GetFilterIDs()); err != nil {
+			return
+		}
+		sq.SQMetrics[id] = metric
+	}
+	return
+}
+
+// stringMetrics returns the values of the metrics as strings
+func (sq *StatQueue) stringMetrics(roundingDecimals int) (metrics map[string]string) {
+	metrics = make(map[string]string, len(sq.SQMetrics))
+	for metricID, metric := range sq.SQMetrics {
+		metrics[metricID] = metric.GetStringValue(roundingDecimals)
+	}
+	return
+}
+
+// rollWindows closes the windows ended until now, recording the values of the metrics
+// *tumbling windows start with empty metrics while *sliding ones expire the events older than WindowSize
+func (sq *StatQueue) rollWindows(sqPrfl *StatQueueProfile, now time.Time, roundingDecimals int) (rolled bool, err error) {
+	if sqPrfl == nil || sqPrfl.WindowType == utils.EmptyString || sqPrfl.WindowSize <= 0 {
+		return
+	}
+	step := sqPrfl.windowStep()
+	crntEnd := now.Truncate(step).Add(step) // end of the window including now
+	if sq.WindowEnd == nil {
+		sq.WindowEnd = &crntEnd
+		return true, nil
+	}
+	for !now.Before(*sq.WindowEnd) {
+		end := *sq.WindowEnd
+		if sqPrfl.WindowType == utils.MetaSliding {
+			if _, err = sq.remExpiredAt(end); err != nil {
+				return
+			}
+		}
+		sq.Windows = append(sq.Windows, &StatWindow{
+			Start:   end.Add(-sqPrfl.WindowSize),
+			End:     end,
+			Metrics: sq.stringMetrics(roundingDecimals),
+		})
+		if sqPrfl.WindowType == utils.MetaTumbling {
+			if err = sq.reset(); err != nil {
+				return
+			}
+		}
+		nextEnd := end.Add(step)
+		if firstKept := crntEnd.Add(-time.Duration(sqPrfl.WindowCount) * step); nextEnd.Before(firstKept) {
+			nextEnd = firstKept // skip the windows which would not be kept
+		}
+		sq.WindowEnd = &nextEnd
+		rolled = true
+	}
+	if len(sq.Windows) > sqPrfl.WindowCount {
+		sq.Windows = sq.Windows[len(sq.Windows)-sqPrfl.WindowCount:]
+	}
+	return
+}
+
 // addStatEvent computes metrics for an event
 func (sq *StatQueue) addStatEvent(tnt, evID string, filterS *FilterS, evNm utils.MapStorage) (err error) {
 	var expTime *time.Time
@@ -418,6 +556,8 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 		ID        string
 		SQItems   []SQItem
 		SQMetrics map[string]json.RawMessage
+		Windows   []*StatWindow
+		WindowEnd *time.Time
 	}
 	if err = json.Unmarshal(data, &tmp); err != nil {
 		return
@@ -425,6 +565,8 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 	sq.Tenant = tmp.Tenant
 	sq.ID = tmp.ID
 	sq.SQItems = tmp.SQItems
+	sq.Windows = tmp.Windows
+	sq.WindowEnd = tmp.WindowEnd
 	sq.SQMetrics = make(map[string]StatMetric)
 	for metricID, val := range tmp.SQMetrics {
 		metricSplit := strings.Split(metricID, utils.HashtagSep)
@@ -450,6 +592,8 @@ func (sq *StatQueue) UnmarshalJSON(data []byte) (err error) {
 			metric = new(StatAverage)
 		case utils.MetaDistinct:
 			metric = new(StatDistinct)
+		case utils.MetaPercentile, utils.MetaMedian:
+			metric = new(StatPercentile)
 		default:
 			return fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 		}
@@ -1333,3 +1333,164 @@ func TestStatQueueLockUnlockStatQueues(t *testing.T) {
 		t.Fatal("expected struct field \"lkID\" to be empty")
 	}
 }
+
+func TestStatQueueRollWindowsTumbling(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		WindowType:  utils.MetaTumbling,
+		WindowSize:  5 * time.Minute,
+		WindowCount: 2,
+	}
+	sq, err := NewStatQueue("cgrates.org", "STS_TUMBLING", []*MetricWithFilters{
+		{MetricID: "*sum#~*req.Cost"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addEv := func(evID string, cost float64) {
+		sq.SQItems = append(sq.SQItems, SQItem{EventID: evID})
+		if err := sq.SQMetrics["*sum#~*req.Cost"].AddEvent(evID,
+			utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: cost}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tm := time.Date(2021, 3, 1, 10, 2, 0, 0, time.UTC)
+	if rolled, err := sq.rollWindows(sqPrfl, tm, 4); err != nil || !rolled {
+		t.Fatalf("Expected the first window to start, received %v, %v", rolled, err)
+	} else if exp := tm.Add(3 * time.Minute); !sq.WindowEnd.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, sq.WindowEnd)
+	}
+	addEv("EV1", 2)
+	if _, err := sq.rollWindows(sqPrfl, tm.Add(4*time.Minute), 4); err != nil {
+		t.Fatal(err)
+	}
+	addEv("EV2", 3)
+	if _, err := sq.rollWindows(sqPrfl, tm.Add(9*time.Minute), 4); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*StatWindow{
+		{Start: tm.Add(-2 * time.Minute), End: tm.Add(3 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": "2"}},
+		{Start: tm.Add(3 * time.Minute), End: tm.Add(8 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": "3"}},
+	}
+	if !reflect.DeepEqual(exp, sq.Windows) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(sq.Windows))
+	}
+	if len(sq.SQItems) != 0 {
+		t.Errorf("Expected the queue to be reset, received %s", utils.ToJSON(sq.SQItems))
+	}
+
+	ssq, err := NewStoredStatQueue(sq, &jMarshaler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := ssq.AsStatQueue(&jMarshaler); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(sq.Windows, rcv.Windows) || !rcv.WindowEnd.Equal(*sq.WindowEnd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(sq), utils.ToJSON(rcv))
+	}
+
+	if _, err := sq.rollWindows(sqPrfl, tm.Add(58*time.Minute), 4); err != nil {
+		t.Fatal(err)
+	}
+	exp = []*StatWindow{
+		{Start: tm.Add(48 * time.Minute), End: tm.Add(53 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": utils.NotAvailable}},
+		{Start: tm.Add(53 * time.Minute), End: tm.Add(58 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": utils.NotAvailable}},
+	}
+	if !reflect.DeepEqual(exp, sq.Windows) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(sq.Windows))
+	} else if exp := tm.Add(63 * time.Minute); !sq.WindowEnd.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, sq.WindowEnd)
+	}
+}
+
+func TestStatQueueRollWindowsSliding(t *testing.T) {
+	sqPrfl := &StatQueueProfile{
+		WindowType:  utils.MetaSliding,
+		WindowSize:  10 * time.Minute,
+		WindowSlide: 5 * time.Minute,
+		WindowCount: 3,
+	}
+	sq, err := NewStatQueue("cgrates.org", "STS_SLIDING", []*MetricWithFilters{
+		{MetricID: "*sum#~*req.Cost"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addEv := func(evID string, cost float64, tm time.Time) {
+		sq.SQItems = append(sq.SQItems, SQItem{EventID: evID,
+			ExpiryTime: utils.TimePointer(tm.Add(sqPrfl.WindowSize))})
+		if err := sq.SQMetrics["*sum#~*req.Cost"].AddEvent(evID,
+			utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: cost}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tm := time.Date(2021, 3, 1, 10, 1, 0, 0, time.UTC)
+	for _, ev := range []struct {
+		id   string
+		cost float64
+		tm   time.Time
+	}{
+		{"EV1", 1, tm},
+		{"EV2", 2, tm.Add(6 * time.Minute)},
+	} {
+		if _, err := sq.rollWindows(sqPrfl, ev.tm, 4); err != nil {
+			t.Fatal(err)
+		}
+		addEv(ev.id, ev.cost, ev.tm)
+	}
+	if _, err := sq.rollWindows(sqPrfl, tm.Add(11*time.Minute), 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sq.rollWindows(sqPrfl, tm.Add(15*time.Minute), 4); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*StatWindow{
+		{Start: tm.Add(-6 * time.Minute), End: tm.Add(4 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": "1"}},
+		{Start: tm.Add(-1 * time.Minute), End: tm.Add(9 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": "3"}},
+		{Start: tm.Add(4 * time.Minute), End: tm.Add(14 * time.Minute),
+			Metrics: map[string]string{"*sum#~*req.Cost": "2"}},
+	}
+	if !reflect.DeepEqual(exp, sq.Windows) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(sq.Windows))
+	}
+}
+
+func TestStatQueueProfileCheckWindows(t *testing.T) {
+	sqp := &StatQueueProfile{}
+	if err := sqp.CheckWindows(); err != nil {
+		t.Error(err)
+	}
+	sqp = &StatQueueProfile{
+		WindowType:  utils.MetaSliding,
+		WindowSize:  5 * time.Minute,
+		WindowSlide: time.Minute,
+		WindowCount: 12,
+	}
+	if err := sqp.CheckWindows(); err != nil {
+		t.Error(err)
+	}
+	sqp.WindowType = utils.MetaTumbling
+	if err := sqp.CheckWindows(); err == nil ||
+		err.Error() != "invalid WindowSlide: <1m0s> for WindowType: <*tumbling>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	sqp.WindowSlide = 0
+	sqp.WindowCount = 0
+	if err := sqp.CheckWindows(); err == nil ||
+		err.Error() != "WindowCount must be positive for WindowType: <*tumbling>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	sqp.WindowSize = 0
+	if err := sqp.CheckWindows(); err == nil ||
+		err.Error() != "WindowSize must be positive for WindowType: <*tumbling>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	sqp.WindowType = "*hopping"
+	if err := sqp.CheckWindows(); err == nil ||
+		err.Error() != "unsupported WindowType: <*hopping>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
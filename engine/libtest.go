@@ -107,29 +107,29 @@ RT_DY,RET,DY,*up,2,0,
 RT_DY,EU_LANDLINE,CF,*middle,4,0,
 `
 	RatingPlansCSVContent = `
-STANDARD,RT_STANDARD,WORKDAYS_00,10
-STANDARD,RT_STD_WEEKEND,WORKDAYS_18,10
-STANDARD,RT_STD_WEEKEND,WEEKENDS,10
-STANDARD,RT_URG,*any,20
-PREMIUM,RT_STANDARD,WORKDAYS_00,10
-PREMIUM,RT_STD_WEEKEND,WORKDAYS_18,10
-PREMIUM,RT_STD_WEEKEND,WEEKENDS,10
-DEFAULT,RT_DEFAULT,WORKDAYS_00,10
-EVENING,P1,WORKDAYS_00,10
-EVENING,P2,WORKDAYS_18,10
-EVENING,P2,WEEKENDS,10
-TDRT,T1,WORKDAYS_00,10
-TDRT,T2,WORKDAYS_00,10
-G,RT_STANDARD,WORKDAYS_00,10
-R,P1,WORKDAYS_00,10
-RP_UK_Mobile_BIG5_PKG,DR_UK_Mobile_BIG5_PKG,*any,10
-RP_UK,DR_UK_Mobile_BIG5,*any,10
-RP_DATA,DATA_RATE,*any,10
-RP_MX,MX_DISC,WORKDAYS_00,10
-RP_MX,MX_FREE,WORKDAYS_18,10
-GER_ONLY,GER,*any,10
-ANY_PLAN,DATA_RATE,*any,10
-DY_PLAN,RT_DY,*any,10
+STANDARD,RT_STANDARD,WORKDAYS_00,10,
+STANDARD,RT_STD_WEEKEND,WORKDAYS_18,10,
+STANDARD,RT_STD_WEEKEND,WEEKENDS,10,
+STANDARD,RT_URG,*any,20,
+PREMIUM,RT_STANDARD,WORKDAYS_00,10,
+PREMIUM,RT_STD_WEEKEND,WORKDAYS_18,10,
+PREMIUM,RT_STD_WEEKEND,WEEKENDS,10,
+DEFAULT,RT_DEFAULT,WORKDAYS_00,10,
+EVENING,P1,WORKDAYS_00,10,
+EVENING,P2,WORKDAYS_18,10,
+EVENING,P2,WEEKENDS,10,
+TDRT,T1,WORKDAYS_00,10,
+TDRT,T2,WORKDAYS_00,10,
+G,RT_STANDARD,WORKDAYS_00,10,
+R,P1,WORKDAYS_00,10,
+RP_UK_Mobile_BIG5_PKG,DR_UK_Mobile_BIG5_PKG,*any,10,
+RP_UK,DR_UK_Mobile_BIG5,*any,10,
+RP_DATA,DATA_RATE,*any,10,
+RP_MX,MX_DISC,WORKDAYS_00,10,
+RP_MX,MX_FREE,WORKDAYS_18,10,
+GER_ONLY,GER,*any,10,
+ANY_PLAN,DATA_RATE,*any,10,
+DY_PLAN,RT_DY,*any,10,
 `
 	RatingProfilesCSVContent = `
 CUSTOMER_1,0,rif:from:tm,2012-01-01T00:00:00Z,PREMIUM,danb
@@ -280,6 +280,18 @@ cgrates.org,D1,,,,*first,,C2,*lt:~*req.Usage:10,10,false,192.168.56.204,
 	DispatcherHostCSVContent = `
 #Tenant[0],ID[1],Address[2],Transport[3],ConnectAttempts[4],Reconnects[5],ConnectTimeout[6],ReplyTimeout[7],Tls[8],ClientKey[9],ClientCertificate[10],CaCertificate[11]
 cgrates.org,ALL,127.0.0.1:6012,*json,1,3,1m,2m,false,,,
+`
+	ExchangeRatesCSVContent = `
+#Tenant[0],ID[1],Currency[2],ActivationTime[3],Rate[4]
+cgrates.org,EUR,USD,2014-07-29T15:00:00Z,1.1
+cgrates.org,EUR,USD,2014-08-29T15:00:00Z,1.2
+cgrates.org,EUR,RON,2014-07-29T15:00:00Z,4.9
+`
+	LookupTablesCSVContent = `
+#Tenant[0],ID[1],Key[2],FieldName[3],Value[4]
+cgrates.org,LRN,+4986517174963,LRN,+4986517174960
+cgrates.org,LRN,+4986517174963,Carrier,CARRIER_1
+cgrates.org,LRN,+4986517174964,LRN,+4986517174960
 `
 )
 
@@ -523,6 +535,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheThresholds:              {},
 		utils.CacheTimings:                 {},
 		utils.CacheDiameterMessages:        {},
+		utils.CacheRadiusPackets:           {},
 		utils.CacheClosedSessions:          {},
 		utils.CacheLoadIDs:                 {},
 		utils.CacheRPCConnections:          {},
@@ -42,7 +42,7 @@ func init() {
 		ActionsCSVContent, ActionPlansCSVContent, ActionTriggersCSVContent, AccountActionsCSVContent,
 		ResourcesCSVContent, StatsCSVContent, ThresholdsCSVContent, FiltersCSVContent,
 		RoutesCSVContent, AttributesCSVContent, ChargersCSVContent, DispatcherCSVContent,
-		DispatcherHostCSVContent), testTPID, "", nil, nil, false)
+		DispatcherHostCSVContent, ExchangeRatesCSVContent, LookupTablesCSVContent), testTPID, "", nil, nil, false)
 	if err != nil {
 		log.Print("error when creating TpReader:", err)
 	}
@@ -106,6 +106,12 @@ func init() {
 	if err := csvr.LoadDispatcherHosts(); err != nil {
 		log.Print("error in LoadDispatcherHosts:", err)
 	}
+	if err := csvr.LoadExchangeRates(); err != nil {
+		log.Print("error in LoadExchangeRates:", err)
+	}
+	if err := csvr.LoadLookupTables(); err != nil {
+		log.Print("error in LoadLookupTables:", err)
+	}
 	if err := csvr.WriteToDatabase(false, false); err != nil {
 		log.Print("error when writing into database ", err)
 	}
@@ -1416,3 +1422,56 @@ func TestLoadDispatcherHosts(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eDispatcherHosts), utils.ToJSON(csvr.dispatcherHosts[dphKey]))
 	}
 }
+
+func TestLoadExchangeRates(t *testing.T) {
+	eXRP := &ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "EUR",
+		Rates: map[string][]*ExchangeRate{
+			"USD": {
+				{ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC), Rate: 1.1},
+				{ActivationTime: time.Date(2014, 8, 29, 15, 0, 0, 0, time.UTC), Rate: 1.2},
+			},
+			"RON": {
+				{ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC), Rate: 4.9},
+			},
+		},
+	}
+	xrpKey := utils.TenantID{Tenant: "cgrates.org", ID: "EUR"}
+	if len(csvr.exchangeRates) != 1 {
+		t.Fatalf("Failed to load ExchangeRates: %v", len(csvr.exchangeRates))
+	}
+	if xrp, err := APItoExchangeRateProfile(csvr.exchangeRates[xrpKey], utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eXRP, xrp) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eXRP), utils.ToJSON(xrp))
+	}
+	if xrp, err := dm.GetExchangeRateProfile("cgrates.org", "EUR", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eXRP, xrp) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eXRP), utils.ToJSON(xrp))
+	}
+}
+
+func TestLoadLookupTables(t *testing.T) {
+	eLkt := &LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "LRN",
+		Entries: map[string]map[string]string{
+			"+4986517174963": {"LRN": "+4986517174960", "Carrier": "CARRIER_1"},
+			"+4986517174964": {"LRN": "+4986517174960"},
+		},
+	}
+	lktKey := utils.TenantID{Tenant: "cgrates.org", ID: "LRN"}
+	if len(csvr.lookupTables) != 1 {
+		t.Fatalf("Failed to load LookupTables: %v", len(csvr.lookupTables))
+	}
+	if lkt := APItoLookupTable(csvr.lookupTables[lktKey]); !reflect.DeepEqual(eLkt, lkt) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eLkt), utils.ToJSON(lkt))
+	}
+	if flds, err := dm.GetLookupTableEntry("cgrates.org", "LRN", "+4986517174963", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eLkt.Entries["+4986517174963"], flds) {
+		t.Errorf("Expecting: %+v, received: %+v", eLkt.Entries["+4986517174963"], flds)
+	}
+}
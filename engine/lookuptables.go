@@ -0,0 +1,120 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LookupTable maps keys into the values of one or more fields
+// the entries are stored and cached individually so the table can grow to millions of keys
+type LookupTable struct {
+	Tenant  string
+	ID      string
+	Entries map[string]map[string]string // indexed on key, then on field name
+}
+
+// LookupTableWithAPIOpts is used by the API setting the LookupTable
+type LookupTableWithAPIOpts struct {
+	*LookupTable
+	APIOpts map[string]interface{}
+}
+
+// LookupTableEntryArgs is used by the API querying one entry of a LookupTable
+type LookupTableEntryArgs struct {
+	Tenant string
+	ID     string
+	Key    string
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (lkt *LookupTable) TenantID() string {
+	return utils.ConcatenatedKey(lkt.Tenant, lkt.ID)
+}
+
+// EntryIDs returns the IDs under which the entries are stored and cached
+func (lkt *LookupTable) EntryIDs() (ids []string) {
+	ids = make([]string, 0, len(lkt.Entries))
+	for key := range lkt.Entries {
+		ids = append(ids, utils.ConcatenatedKey(lkt.Tenant, lkt.ID, key))
+	}
+	return
+}
+
+// Clone returns a deep copy of the LookupTable
+func (lkt *LookupTable) Clone() (cln *LookupTable) {
+	if lkt == nil {
+		return
+	}
+	cln = &LookupTable{
+		Tenant: lkt.Tenant,
+		ID:     lkt.ID,
+	}
+	if lkt.Entries != nil {
+		cln.Entries = make(map[string]map[string]string, len(lkt.Entries))
+		for key, flds := range lkt.Entries {
+			cln.Entries[key] = cloneLookupEntry(flds)
+		}
+	}
+	return
+}
+
+func cloneLookupEntry(flds map[string]string) (cln map[string]string) {
+	cln = make(map[string]string, len(flds))
+	for fldName, val := range flds {
+		cln[fldName] = val
+	}
+	return
+}
+
+// splitLookupEntryID splits the entry ID into tenant, table ID and key
+// the key is kept intact even if it contains the separator
+func splitLookupEntryID(entryID string) (tnt, id, key string, err error) {
+	splt := strings.SplitN(entryID, utils.ConcatenatedKeySep, 3)
+	if len(splt) != 3 {
+		err = fmt.Errorf("malformed lookup entry ID: <%s>", entryID)
+		return
+	}
+	return splt[0], splt[1], splt[2], nil
+}
+
+// lookupTableValue returns the value of the field for the key out of the table
+// the table can be given as tenant:ID, otherwise the tenant is taken from the event
+func lookupTableValue(dp utils.DataProvider, tableID, key, fldName string) (val string, err error) {
+	tnt := config.CgrConfig().GeneralCfg().DefaultTenant
+	if tntID := strings.SplitN(tableID, utils.ConcatenatedKeySep, 2); len(tntID) == 2 {
+		tnt, tableID = tntID[0], tntID[1]
+	} else if evTnt, errTnt := dp.FieldAsString([]string{utils.MetaTenant}); errTnt == nil &&
+		evTnt != utils.EmptyString {
+		tnt = evTnt
+	}
+	var flds map[string]string
+	if flds, err = dm.GetLookupTableEntry(tnt, tableID, key, true, true, utils.NonTransactional); err != nil {
+		return
+	}
+	var has bool
+	if val, has = flds[fldName]; !has {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	return
+}
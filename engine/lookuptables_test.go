@@ -0,0 +1,138 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLookupTableSetGetRemove(t *testing.T) {
+	lkt := &LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "TAGS",
+		Entries: map[string]map[string]string{
+			"1001": {"Tag": "GOLD", "Segment": "ENTERPRISE"},
+			"1002": {"Tag": "SILVER"},
+		},
+	}
+	if err := dm.SetLookupTable(lkt); err != nil {
+		t.Fatal(err)
+	}
+	if flds, err := dm.GetLookupTableEntry("cgrates.org", "TAGS", "1001", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(lkt.Entries["1001"], flds) {
+		t.Errorf("Expecting: %+v, received: %+v", lkt.Entries["1001"], flds)
+	}
+	// setting again only overwrites the keys given
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "TAGS",
+		Entries: map[string]map[string]string{"1002": {"Tag": "GOLD"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	eIDs := []string{"cgrates.org:TAGS:1001", "cgrates.org:TAGS:1002"}
+	if entryIDs, err := dm.GetLookupTableEntryIDs("cgrates.org", "TAGS"); err != nil {
+		t.Error(err)
+	} else if sort.Strings(entryIDs); !reflect.DeepEqual(eIDs, entryIDs) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, entryIDs)
+	}
+	if flds, err := dm.GetLookupTableEntry("cgrates.org", "TAGS", "1002", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if flds["Tag"] != "GOLD" {
+		t.Errorf("Expecting GOLD, received: %+v", flds)
+	}
+	if entryIDs, err := dm.RemoveLookupTable("cgrates.org", "TAGS"); err != nil {
+		t.Error(err)
+	} else if sort.Strings(entryIDs); !reflect.DeepEqual(eIDs, entryIDs) {
+		t.Errorf("Expecting: %+v, received: %+v", eIDs, entryIDs)
+	}
+	if _, err := dm.GetLookupTableEntry("cgrates.org", "TAGS", "1001", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := dm.RemoveLookupTable("cgrates.org", "TAGS"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLookupTableLookupTableValue(t *testing.T) {
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant: "itsyscom.com",
+		ID:     "PORTED",
+		Entries: map[string]map[string]string{
+			"+4986517174963": {"LRN": "+4986517174960", "Carrier": "CARRIER_2"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	dp := utils.MapStorage{
+		utils.MetaReq:    utils.MapStorage{utils.Destination: "+4986517174963"},
+		utils.MetaTenant: "itsyscom.com",
+	}
+	if val, err := lookupTableValue(dp, "PORTED", "+4986517174963", "Carrier"); err != nil {
+		t.Error(err)
+	} else if val != "CARRIER_2" {
+		t.Errorf("Expected CARRIER_2, received %q", val)
+	}
+	// explicit tenant wins over the one of the event
+	if val, err := lookupTableValue(utils.MapStorage{}, "itsyscom.com:PORTED", "+4986517174963", "LRN"); err != nil {
+		t.Error(err)
+	} else if val != "+4986517174960" {
+		t.Errorf("Expected +4986517174960, received %q", val)
+	}
+	if _, err := lookupTableValue(dp, "PORTED", "+4986517174963", "Unknown"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := lookupTableValue(dp, "PORTED", "+4986517174999", "LRN"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestAttributesParseAttributeLookup(t *testing.T) {
+	if err := dm.SetLookupTable(&LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "NP",
+		Entries: map[string]map[string]string{
+			"+4986517174963": {"LRN": "+4986517174960"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	dp := utils.MapStorage{
+		utils.MetaReq:    utils.MapStorage{utils.Destination: "+4986517174963"},
+		utils.MetaTenant: "cgrates.org",
+	}
+	if out, err := ParseAttribute(dp, utils.MetaLookup, utils.EmptyString,
+		config.NewRSRParsersMustCompile("NP;~*req.Destination;LRN", utils.InfieldSep),
+		0, utils.EmptyString, utils.EmptyString, utils.InfieldSep); err != nil {
+		t.Error(err)
+	} else if out != "+4986517174960" {
+		t.Errorf("Expected +4986517174960, received %q", out)
+	}
+	expErr := `invalid arguments <[{"Rules":"NP"},{"Rules":"~*req.Destination"}]> to *lookup`
+	if _, err := ParseAttribute(dp, utils.MetaLookup, utils.EmptyString,
+		config.NewRSRParsersMustCompile("NP;~*req.Destination", utils.InfieldSep),
+		0, utils.EmptyString, utils.EmptyString, utils.InfieldSep); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %q, received %v", expErr, err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,10 @@ func csvLoad(s interface{}, values []string) (interface{}, error) {
 		index := field.Tag.Get("index")
 		if index != utils.EmptyString {
 			idx, err := strconv.Atoi(index)
+			if err == nil && len(values) <= idx &&
+				field.Tag.Get("optional") == "true" { // column missing from the file
+				continue
+			}
 			if err != nil || len(values) <= idx {
 				return nil, fmt.Errorf("invalid %v.%v index %v", st.Name(), field.Name, index)
 			}
@@ -146,6 +151,21 @@ func getColumnCount(s interface{}) int {
 	return count
 }
 
+// getRequiredColumnCount returns the number of columns without the optional ones
+func getRequiredColumnCount(s interface{}) int {
+	st := reflect.TypeOf(s)
+	numFields := st.NumField()
+	count := 0
+	for i := 0; i < numFields; i++ {
+		field := st.Field(i)
+		if field.Tag.Get("index") != utils.EmptyString &&
+			field.Tag.Get("optional") != "true" {
+			count++
+		}
+	}
+	return count
+}
+
 type DestinationMdls []DestinationMdl
 
 func (tps DestinationMdls) AsMapDestinations() (map[string]*Destination, error) {
@@ -429,8 +449,9 @@ func (tps RatingPlanMdls) AsMapTPRatingPlans() (result map[string]*utils.TPRatin
 	result = make(map[string]*utils.TPRatingPlan)
 	for _, tp := range tps {
 		rp := &utils.TPRatingPlan{
-			TPid: tp.Tpid,
-			ID:   tp.Tag,
+			TPid:     tp.Tpid,
+			ID:       tp.Tag,
+			Currency: tp.Currency,
 		}
 		rpb := &utils.TPRatingPlanBinding{
 			DestinationRatesId: tp.DestratesTag,
@@ -442,6 +463,9 @@ func (tps RatingPlanMdls) AsMapTPRatingPlans() (result map[string]*utils.TPRatin
 			result[rp.ID] = rp
 		} else {
 			existing.RatingPlanBindings = append(existing.RatingPlanBindings, rpb)
+			if existing.Currency == utils.EmptyString {
+				existing.Currency = tp.Currency
+			}
 		}
 	}
 	return
@@ -500,6 +524,17 @@ func MapTPRatingPlanBindings(s []*utils.TPRatingPlan) map[string][]*utils.TPRati
 	return result
 }
 
+// MapTPRatingPlanCurrencies returns the currency of each RatingPlan indexed on its ID
+func MapTPRatingPlanCurrencies(s []*utils.TPRatingPlan) map[string]string {
+	result := make(map[string]string)
+	for _, e := range s {
+		if e.Currency != utils.EmptyString {
+			result[e.ID] = e.Currency
+		}
+	}
+	return result
+}
+
 func APItoModelRatingPlan(rp *utils.TPRatingPlan) (result RatingPlanMdls) {
 	if rp != nil {
 		for _, rpb := range rp.RatingPlanBindings {
@@ -509,12 +544,14 @@ func APItoModelRatingPlan(rp *utils.TPRatingPlan) (result RatingPlanMdls) {
 				DestratesTag: rpb.DestinationRatesId,
 				TimingTag:    rpb.TimingId,
 				Weight:       rpb.Weight,
+				Currency:     rp.Currency,
 			})
 		}
 		if len(rp.RatingPlanBindings) == 0 {
 			result = append(result, RatingPlanMdl{
-				Tpid: rp.TPid,
-				Tag:  rp.ID,
+				Tpid:     rp.TPid,
+				Tag:      rp.ID,
+				Currency: rp.Currency,
 			})
 		}
 	}
@@ -1281,6 +1318,18 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		if model.QueueLength != 0 {
 			st.QueueLength = model.QueueLength
 		}
+		if model.WindowType != utils.EmptyString {
+			st.WindowType = model.WindowType
+		}
+		if model.WindowSize != utils.EmptyString {
+			st.WindowSize = model.WindowSize
+		}
+		if model.WindowSlide != utils.EmptyString {
+			st.WindowSlide = model.WindowSlide
+		}
+		if model.WindowCount != 0 {
+			st.WindowCount = model.WindowCount
+		}
 		if model.ThresholdIDs != utils.EmptyString {
 			if _, has := thresholdMap[key.TenantID()]; !has {
 				thresholdMap[key.TenantID()] = make(utils.StringSet)
@@ -1373,6 +1422,10 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.WindowType = st.WindowType
+				mdl.WindowSize = st.WindowSize
+				mdl.WindowSlide = st.WindowSlide
+				mdl.WindowCount = st.WindowCount
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1399,12 +1452,27 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 		Blocker:      tpST.Blocker,
 		Weight:       tpST.Weight,
 		ThresholdIDs: make([]string, len(tpST.ThresholdIDs)),
+		WindowType:   tpST.WindowType,
+		WindowCount:  tpST.WindowCount,
 	}
 	if tpST.TTL != utils.EmptyString {
 		if st.TTL, err = utils.ParseDurationWithNanosecs(tpST.TTL); err != nil {
 			return nil, err
 		}
 	}
+	if tpST.WindowSize != utils.EmptyString {
+		if st.WindowSize, err = utils.ParseDurationWithNanosecs(tpST.WindowSize); err != nil {
+			return nil, err
+		}
+	}
+	if tpST.WindowSlide != utils.EmptyString {
+		if st.WindowSlide, err = utils.ParseDurationWithNanosecs(tpST.WindowSlide); err != nil {
+			return nil, err
+		}
+	}
+	if err = st.CheckWindows(); err != nil {
+		return nil, err
+	}
 	for i, metric := range tpST.Metrics {
 		st.Metrics[i] = &MetricWithFilters{
 			MetricID:  metric.MetricID,
@@ -1438,6 +1506,8 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 		Weight:             st.Weight,
 		MinItems:           st.MinItems,
 		ThresholdIDs:       make([]string, len(st.ThresholdIDs)),
+		WindowType:         st.WindowType,
+		WindowCount:        st.WindowCount,
 	}
 	for i, metric := range st.Metrics {
 		tpST.Metrics[i] = &utils.MetricWithFilters{
@@ -1454,6 +1524,12 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	if st.TTL != time.Duration(0) {
 		tpST.TTL = st.TTL.String()
 	}
+	if st.WindowSize != time.Duration(0) {
+		tpST.WindowSize = st.WindowSize.String()
+	}
+	if st.WindowSlide != time.Duration(0) {
+		tpST.WindowSlide = st.WindowSlide.String()
+	}
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
@@ -2911,3 +2987,209 @@ func DispatcherHostToAPI(dph *DispatcherHost) (tpDPH *utils.TPDispatcherHost) {
 		},
 	}
 }
+
+type ExchangeRateMdls []*ExchangeRateMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (tps ExchangeRateMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.Currency, utils.ActivationTime, utils.Rate}
+}
+
+func (tps ExchangeRateMdls) AsTPExchangeRateProfiles() (result []*utils.TPExchangeRateProfile) {
+	mxrp := make(map[string]*utils.TPExchangeRateProfile)
+	for _, tp := range tps {
+		tntID := utils.ConcatenatedKey(tp.Tenant, tp.ID)
+		xrp, found := mxrp[tntID]
+		if !found {
+			xrp = &utils.TPExchangeRateProfile{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			mxrp[tntID] = xrp
+		}
+		if tp.Currency == utils.EmptyString { // profile without rates
+			continue
+		}
+		xrp.Rates = append(xrp.Rates, &utils.TPExchangeRate{
+			Currency:       tp.Currency,
+			ActivationTime: tp.ActivationTime,
+			Rate:           tp.Rate,
+		})
+	}
+	result = make([]*utils.TPExchangeRateProfile, 0, len(mxrp))
+	for _, xrp := range mxrp {
+		result = append(result, xrp)
+	}
+	return
+}
+
+func APItoModelTPExchangeRateProfile(tpXRP *utils.TPExchangeRateProfile) (mdls ExchangeRateMdls) {
+	if tpXRP == nil {
+		return
+	}
+	if len(tpXRP.Rates) == 0 {
+		return ExchangeRateMdls{{
+			Tpid:   tpXRP.TPid,
+			Tenant: tpXRP.Tenant,
+			ID:     tpXRP.ID,
+		}}
+	}
+	for _, xr := range tpXRP.Rates {
+		mdls = append(mdls, &ExchangeRateMdl{
+			Tpid:           tpXRP.TPid,
+			Tenant:         tpXRP.Tenant,
+			ID:             tpXRP.ID,
+			Currency:       xr.Currency,
+			ActivationTime: xr.ActivationTime,
+			Rate:           xr.Rate,
+		})
+	}
+	return
+}
+
+func APItoExchangeRateProfile(tpXRP *utils.TPExchangeRateProfile, timezone string) (xrp *ExchangeRateProfile, err error) {
+	xrp = &ExchangeRateProfile{
+		Tenant: tpXRP.Tenant,
+		ID:     tpXRP.ID,
+		Rates:  make(map[string][]*ExchangeRate),
+	}
+	for _, tpXR := range tpXRP.Rates {
+		xr := &ExchangeRate{Rate: tpXR.Rate}
+		if tpXR.ActivationTime != utils.EmptyString {
+			if xr.ActivationTime, err = utils.ParseTimeDetectLayout(tpXR.ActivationTime, timezone); err != nil {
+				return nil, err
+			}
+		}
+		xrp.Rates[tpXR.Currency] = append(xrp.Rates[tpXR.Currency], xr)
+	}
+	xrp.Sort()
+	return
+}
+
+func ExchangeRateProfileToAPI(xrp *ExchangeRateProfile) (tpXRP *utils.TPExchangeRateProfile) {
+	tpXRP = &utils.TPExchangeRateProfile{
+		Tenant: xrp.Tenant,
+		ID:     xrp.ID,
+	}
+	currencies := make([]string, 0, len(xrp.Rates))
+	for currency := range xrp.Rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		for _, xr := range xrp.Rates[currency] {
+			tpXR := &utils.TPExchangeRate{
+				Currency: currency,
+				Rate:     xr.Rate,
+			}
+			if !xr.ActivationTime.IsZero() {
+				tpXR.ActivationTime = xr.ActivationTime.Format(time.RFC3339)
+			}
+			tpXRP.Rates = append(tpXRP.Rates, tpXR)
+		}
+	}
+	return
+}
+
+type LookupTableMdls []*LookupTableMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (tps LookupTableMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.Key, utils.FieldName, utils.Value}
+}
+
+func (tps LookupTableMdls) AsTPLookupTables() (result []*utils.TPLookupTable) {
+	mlkt := make(map[string]*utils.TPLookupTable)
+	for _, tp := range tps {
+		tntID := utils.ConcatenatedKey(tp.Tenant, tp.ID)
+		lkt, found := mlkt[tntID]
+		if !found {
+			lkt = &utils.TPLookupTable{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			mlkt[tntID] = lkt
+		}
+		if tp.Key == utils.EmptyString { // table without entries
+			continue
+		}
+		lkt.Entries = append(lkt.Entries, &utils.TPLookupEntry{
+			Key:       tp.Key,
+			FieldName: tp.FieldName,
+			Value:     tp.Value,
+		})
+	}
+	result = make([]*utils.TPLookupTable, 0, len(mlkt))
+	for _, lkt := range mlkt {
+		result = append(result, lkt)
+	}
+	return
+}
+
+func APItoModelTPLookupTable(tpLkt *utils.TPLookupTable) (mdls LookupTableMdls) {
+	if tpLkt == nil {
+		return
+	}
+	if len(tpLkt.Entries) == 0 {
+		return LookupTableMdls{{
+			Tpid:   tpLkt.TPid,
+			Tenant: tpLkt.Tenant,
+			ID:     tpLkt.ID,
+		}}
+	}
+	for _, le := range tpLkt.Entries {
+		mdls = append(mdls, &LookupTableMdl{
+			Tpid:      tpLkt.TPid,
+			Tenant:    tpLkt.Tenant,
+			ID:        tpLkt.ID,
+			Key:       le.Key,
+			FieldName: le.FieldName,
+			Value:     le.Value,
+		})
+	}
+	return
+}
+
+func APItoLookupTable(tpLkt *utils.TPLookupTable) (lkt *LookupTable) {
+	lkt = &LookupTable{
+		Tenant:  tpLkt.Tenant,
+		ID:      tpLkt.ID,
+		Entries: make(map[string]map[string]string),
+	}
+	for _, tpLE := range tpLkt.Entries {
+		if _, has := lkt.Entries[tpLE.Key]; !has {
+			lkt.Entries[tpLE.Key] = make(map[string]string)
+		}
+		lkt.Entries[tpLE.Key][tpLE.FieldName] = tpLE.Value
+	}
+	return
+}
+
+func LookupTableToAPI(lkt *LookupTable) (tpLkt *utils.TPLookupTable) {
+	tpLkt = &utils.TPLookupTable{
+		Tenant: lkt.Tenant,
+		ID:     lkt.ID,
+	}
+	keys := make([]string, 0, len(lkt.Entries))
+	for key := range lkt.Entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fldNames := make([]string, 0, len(lkt.Entries[key]))
+		for fldName := range lkt.Entries[key] {
+			fldNames = append(fldNames, fldName)
+		}
+		sort.Strings(fldNames)
+		for _, fldName := range fldNames {
+			tpLkt.Entries = append(tpLkt.Entries, &utils.TPLookupEntry{
+				Key:       key,
+				FieldName: fldName,
+				Value:     lkt.Entries[key][fldName],
+			})
+		}
+	}
+	return
+}
@@ -37,6 +37,85 @@ func TestModelHelperCsvLoad(t *testing.T) {
 	}
 }
 
+func TestModelHelperCsvLoadOptional(t *testing.T) {
+	l, err := csvLoad(RatingPlanMdl{}, []string{"RP_1", "DR_1", "*any", "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp := l.(RatingPlanMdl); rp.Tag != "RP_1" || rp.Weight != 10 || rp.Currency != utils.EmptyString {
+		t.Errorf("model load failed: %+v", rp)
+	}
+	if l, err = csvLoad(RatingPlanMdl{}, []string{"RP_1", "DR_1", "*any", "10", "EUR"}); err != nil {
+		t.Fatal(err)
+	} else if rp := l.(RatingPlanMdl); rp.Currency != "EUR" {
+		t.Errorf("model load failed: %+v", rp)
+	}
+	if _, err = csvLoad(RatingPlanMdl{}, []string{"RP_1", "DR_1", "*any"}); err == nil {
+		t.Error("Expected error for missing mandatory column")
+	}
+}
+
+func TestCSVStorageRatingPlansWithoutCurrency(t *testing.T) {
+	csvs := NewStringCSVStorage(utils.CSVSep, "", "", "", "",
+		`#Tag,DestinationRatesTag,TimingTag,Weight
+RP_OLD,DR_1,*any,10
+RP_NEW,DR_1,*any,10,EUR
+`, "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "")
+	rps, err := csvs.GetTPRatingPlans("", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rps) != 2 {
+		t.Fatalf("Expected 2 RatingPlans, received %s", utils.ToJSON(rps))
+	}
+	for _, rp := range rps {
+		if exp := map[string]string{"RP_OLD": "", "RP_NEW": "EUR"}[rp.ID]; rp.Currency != exp {
+			t.Errorf("Expected currency %q for %s, received %q", exp, rp.ID, rp.Currency)
+		}
+	}
+	csvs = NewStringCSVStorage(utils.CSVSep, "", "", "", "",
+		"RP_BAD,DR_1,*any,10,EUR,extra\n", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "")
+	if _, err = csvs.GetTPRatingPlans("", "", nil); err == nil {
+		t.Error("Expected error for extra columns")
+	}
+}
+
+func TestCSVStorageStatsWindows(t *testing.T) {
+	csvs := NewStringCSVStorage(utils.CSVSep, "", "", "", "", "", "", "", "", "", "", "", "",
+		`#Tenant[0],Id[1],FilterIDs[2],ActivationInterval[3],QueueLength[4],TTL[5],MinItems[6],Metrics[7],MetricFilterIDs[8],Stored[9],Blocker[10],Weight[11],ThresholdIDs[12],WindowType[13],WindowSize[14],WindowSlide[15],WindowCount[16]
+cgrates.org,SQ_OLD,,,100,-1,0,*asr,,false,false,10,*none
+cgrates.org,SQ_WND,,,100,-1,0,*asr,,false,false,10,*none,*sliding,5m,1m,12
+`, "", "", "", "", "", "", "", "", "")
+	tpSts, err := csvs.GetTPStats("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqps := make(map[string]*StatQueueProfile)
+	for _, tpSt := range tpSts {
+		sqp, err := APItoStats(tpSt, utils.EmptyString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sqps[sqp.ID] = sqp
+		if rcv := APItoModelStats(StatQueueProfileToAPI(sqp)).AsTPStats()[0]; rcv.WindowType != tpSt.WindowType ||
+			rcv.WindowCount != tpSt.WindowCount {
+			t.Errorf("Expected %s, received %s", utils.ToJSON(tpSt), utils.ToJSON(rcv))
+		}
+	}
+	if len(sqps) != 2 || sqps["SQ_OLD"].WindowType != utils.EmptyString {
+		t.Fatalf("Unexpected profiles: %s", utils.ToJSON(sqps))
+	}
+	if sqp := sqps["SQ_WND"]; sqp.WindowType != utils.MetaSliding || sqp.WindowSize != 5*time.Minute ||
+		sqp.WindowSlide != time.Minute || sqp.WindowCount != 12 {
+		t.Errorf("Unexpected profile: %s", utils.ToJSON(sqp))
+	}
+	if _, err = APItoStats(&utils.TPStatProfile{Tenant: "cgrates.org", ID: "SQ_BAD",
+		WindowType: "*hopping"}, utils.EmptyString); err == nil ||
+		err.Error() != "unsupported WindowType: <*hopping>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
 func TestModelHelperCsvDump(t *testing.T) {
 	tpd := DestinationMdl{
 		Tag:    "TEST_DEST",
@@ -766,8 +845,8 @@ func TestTPRatingPlanAsExportSlice(t *testing.T) {
 				Weight:             20.0},
 		}}
 	expectedSlc := [][]string{
-		{"TEST_RPLAN", "TEST_DSTRATE1", "TEST_TIMING1", "10"},
-		{"TEST_RPLAN", "TEST_DSTRATE2", "TEST_TIMING2", "20"},
+		{"TEST_RPLAN", "TEST_DSTRATE1", "TEST_TIMING1", "10", ""},
+		{"TEST_RPLAN", "TEST_DSTRATE2", "TEST_TIMING2", "20", ""},
 	}
 
 	ms := APItoModelRatingPlan(tpRpln)
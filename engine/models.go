@@ -97,6 +97,7 @@ type RatingPlanMdl struct {
 	DestratesTag string  `index:"1" re:"\w+\s*,\s*|\*any"`
 	TimingTag    string  `index:"2" re:"\w+\s*,\s*|\*any"`
 	Weight       float64 `index:"3" re:"\d+.?\d*"`
+	Currency     string  `index:"4" optional:"true"` // missing in the files without currency, defaults to the account one
 	CreatedAt    time.Time
 }
 
@@ -276,6 +277,10 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	WindowType         string  `index:"13" re:"" optional:"true"`
+	WindowSize         string  `index:"14" re:"" optional:"true"`
+	WindowSlide        string  `index:"15" re:"" optional:"true"`
+	WindowCount        int     `index:"16" re:"" optional:"true"`
 	CreatedAt          time.Time
 }
 
@@ -398,6 +403,43 @@ func (t SessionCostsSQL) TableName() string {
 	return utils.SessionCostsTBL
 }
 
+type InvoiceSQL struct {
+	ID          int64
+	Tenant      string
+	InvoiceID   string
+	Account     string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Status      string
+	Lines       string
+	Total       float64
+	ReplacedBy  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (t InvoiceSQL) TableName() string {
+	return utils.InvoicesTBL
+}
+
+type BalanceLedgerSQL struct {
+	ID          int64
+	Tenant      string
+	Account     string
+	BalanceUUID string
+	BalanceID   string
+	BalanceType string
+	OldValue    float64
+	NewValue    float64
+	Source      string
+	RefID       string
+	CreatedAt   time.Time
+}
+
+func (t BalanceLedgerSQL) TableName() string {
+	return utils.BalanceLedgerTBL
+}
+
 type TBLVersion struct {
 	ID      uint
 	Item    string
@@ -516,3 +558,33 @@ type DispatcherHostMdl struct {
 func (DispatcherHostMdl) TableName() string {
 	return utils.TBLTPDispatcherHosts
 }
+
+type ExchangeRateMdl struct {
+	PK             uint    `gorm:"primary_key"`
+	Tpid           string  //
+	Tenant         string  `index:"0" re:""`
+	ID             string  `index:"1" re:""`
+	Currency       string  `index:"2" re:""`
+	ActivationTime string  `index:"3" re:""`
+	Rate           float64 `index:"4" re:""`
+	CreatedAt      time.Time
+}
+
+func (ExchangeRateMdl) TableName() string {
+	return utils.TBLTPExchangeRates
+}
+
+type LookupTableMdl struct {
+	PK        uint   `gorm:"primary_key"`
+	Tpid      string //
+	Tenant    string `index:"0" re:""`
+	ID        string `index:"1" re:""`
+	Key       string `index:"2" re:""`
+	FieldName string `index:"3" re:""`
+	Value     string `index:"4" re:""`
+	CreatedAt time.Time
+}
+
+func (LookupTableMdl) TableName() string {
+	return utils.TBLTPLookupTables
+}
@@ -0,0 +1,519 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	rateDeckRateUnit      = "1m0s" // the deck rates are per minute unless the template sets the RateUnit
+	rateDeckRateIncrement = "1s"
+	rateDeckVersionSep    = "_v"
+)
+
+// excelEpoch is the day 0 of the dates stored as serial numbers in spreadsheets
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// RateDeckRow is one line of a carrier rate deck
+type RateDeckRow struct {
+	Prefix        string
+	Rate          float64
+	ConnectFee    float64
+	RateUnit      string
+	RateIncrement string
+	EffectiveDate time.Time // zero if the rate is already active
+}
+
+// RateDeck holds the rows of a carrier rate deck
+type RateDeck []*RateDeckRow
+
+// RateDeckChange is one difference between the rate deck and the current tariff plan
+type RateDeckChange struct {
+	Type             string // *new, *increased, *decreased, *changed or *removed
+	Prefix           string
+	OldRate          float64
+	NewRate          float64
+	OldConnectFee    float64
+	NewConnectFee    float64
+	OldRateUnit      string
+	NewRateUnit      string
+	OldRateIncrement string
+	NewRateIncrement string
+}
+
+// RateDeckChangeCSVHeader is the header of the csv records returned by RateDeckChange.AsCSV
+var RateDeckChangeCSVHeader = []string{utils.Type, utils.Prefix,
+	"OldRate", "NewRate", "OldConnectFee", "NewConnectFee",
+	"OldRateUnit", "NewRateUnit", "OldRateIncrement", "NewRateIncrement"}
+
+// newRateDeckChange builds the change out of the old and new rows, any of them can be nil
+func newRateDeckChange(chngType, prefix string, oldRow, newRow *RateDeckRow) (rdc *RateDeckChange) {
+	rdc = &RateDeckChange{Type: chngType, Prefix: prefix}
+	if oldRow != nil {
+		rdc.OldRate = oldRow.Rate
+		rdc.OldConnectFee = oldRow.ConnectFee
+		rdc.OldRateUnit = oldRow.RateUnit
+		rdc.OldRateIncrement = oldRow.RateIncrement
+	}
+	if newRow != nil {
+		rdc.NewRate = newRow.Rate
+		rdc.NewConnectFee = newRow.ConnectFee
+		rdc.NewRateUnit = newRow.RateUnit
+		rdc.NewRateIncrement = newRow.RateIncrement
+	}
+	return
+}
+
+// AsCSV returns the change as a csv record
+func (rdc *RateDeckChange) AsCSV() []string {
+	return []string{rdc.Type, rdc.Prefix,
+		strconv.FormatFloat(rdc.OldRate, 'f', -1, 64),
+		strconv.FormatFloat(rdc.NewRate, 'f', -1, 64),
+		strconv.FormatFloat(rdc.OldConnectFee, 'f', -1, 64),
+		strconv.FormatFloat(rdc.NewConnectFee, 'f', -1, 64),
+		rdc.OldRateUnit, rdc.NewRateUnit,
+		rdc.OldRateIncrement, rdc.NewRateIncrement}
+}
+
+// defaultRateDeckTemplate is used when no template is configured for the rate deck
+func defaultRateDeckTemplate() []*config.FCTemplate {
+	return []*config.FCTemplate{
+		{Tag: utils.Prefix, Path: utils.Prefix, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep), Mandatory: true},
+		{Tag: utils.Rate, Path: utils.Rate, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep), Mandatory: true},
+		{Tag: utils.EffectiveDate, Path: utils.EffectiveDate, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+		{Tag: utils.RateIncrement, Path: utils.RateIncrement, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+	}
+}
+
+// ReadRateDeck reads the .csv or .xlsx rate deck using the template
+func ReadRateDeck(path string, sep rune, tpl []*config.FCTemplate, timezone string) (rd RateDeck, err error) {
+	var records [][]string
+	if strings.EqualFold(filepath.Ext(path), utils.XLSXSuffix) {
+		if records, err = utils.ReadXLSXRecords(path); err != nil {
+			return
+		}
+	} else {
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return
+		}
+		defer f.Close()
+		csvReader := csv.NewReader(f)
+		csvReader.Comma = sep
+		csvReader.FieldsPerRecord = -1
+		if records, err = csvReader.ReadAll(); err != nil {
+			return
+		}
+	}
+	return NewRateDeck(records, tpl, timezone)
+}
+
+// NewRateDeck parses the records of a rate deck, the first one being the header
+// the template can refer the columns by index (~*req.0) or by header name (~*req.Prefix)
+func NewRateDeck(records [][]string, tpl []*config.FCTemplate, timezone string) (rd RateDeck, err error) {
+	if len(tpl) == 0 {
+		tpl = defaultRateDeckTemplate()
+	}
+	if len(records) == 0 {
+		return
+	}
+	hdrIdx := make(map[string]int, len(records[0]))
+	for i, hdr := range records[0] {
+		hdrIdx[strings.TrimSpace(hdr)] = i
+	}
+	rd = make(RateDeck, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if len(record) == 0 { // blank row
+			continue
+		}
+		var row *RateDeckRow
+		if row, err = newRateDeckRow(record, hdrIdx, tpl, timezone); err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+2, err.Error())
+		}
+		rd = append(rd, row)
+	}
+	return
+}
+
+// newRateDeckRow populates the row out of the record based on the template paths
+func newRateDeckRow(record []string, hdrIdx map[string]int, tpl []*config.FCTemplate, timezone string) (row *RateDeckRow, err error) {
+	row = &RateDeckRow{
+		RateUnit:      rateDeckRateUnit,
+		RateIncrement: rateDeckRateIncrement,
+	}
+	dP := utils.MapStorage{utils.MetaReq: config.NewSliceDP(record, hdrIdx)}
+	for _, fld := range tpl {
+		var out string
+		if out, err = fld.Value.ParseDataProvider(dP); err != nil && err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+		if out = strings.TrimSpace(out); out == utils.EmptyString {
+			if fld.Mandatory {
+				return nil, utils.NewErrMandatoryIeMissing(fld.Tag)
+			}
+			continue
+		}
+		switch fld.Path {
+		case utils.Prefix:
+			row.Prefix = out
+		case utils.Rate:
+			row.Rate, err = strconv.ParseFloat(out, 64)
+		case utils.ConnectFee:
+			row.ConnectFee, err = strconv.ParseFloat(out, 64)
+		case utils.RateUnit:
+			row.RateUnit, err = parseRateDeckDuration(out)
+		case utils.RateIncrement:
+			row.RateIncrement, err = parseRateDeckDuration(out)
+		case utils.EffectiveDate:
+			row.EffectiveDate, err = parseRateDeckDate(out, timezone)
+		default:
+			return nil, fmt.Errorf("unsupported path <%s>", fld.Path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field <%s>: %s", fld.Tag, err.Error())
+		}
+	}
+	if row.Prefix == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.Prefix)
+	}
+	return
+}
+
+// parseRateDeckDuration considers the numbers as seconds, the way the decks define the increments
+func parseRateDeckDuration(durStr string) (string, error) {
+	dur, err := utils.ParseDurationWithSecs(durStr)
+	if err != nil {
+		return utils.EmptyString, err
+	}
+	return dur.String(), nil
+}
+
+// sameRateDeckDuration compares the durations independent of their format (ie: 60s and 1m0s)
+func sameRateDeckDuration(dur1, dur2 string) bool {
+	d1, err1 := utils.ParseDurationWithSecs(dur1)
+	d2, err2 := utils.ParseDurationWithSecs(dur2)
+	if err1 != nil || err2 != nil {
+		return dur1 == dur2
+	}
+	return d1 == d2
+}
+
+// parseRateDeckDate also accepts the serial numbers used by spreadsheets for dates
+func parseRateDeckDate(dateStr, timezone string) (time.Time, error) {
+	if days, err := strconv.ParseFloat(dateStr, 64); err == nil &&
+		days > 0 && days < 2958466 { // up to the year 9999
+		return excelEpoch.Add(time.Duration(days * float64(24*time.Hour))), nil
+	}
+	return utils.ParseTimeDetectLayout(dateStr, timezone)
+}
+
+// latestRows returns the row with the latest effective date for each prefix
+// only the rows effective until the given time are considered if it is not zero
+func (rd RateDeck) latestRows(until time.Time) (rows map[string]*RateDeckRow) {
+	rows = make(map[string]*RateDeckRow)
+	for _, row := range rd {
+		if !until.IsZero() && row.EffectiveDate.After(until) {
+			continue
+		}
+		if crnt, has := rows[row.Prefix]; !has ||
+			!row.EffectiveDate.Before(crnt.EffectiveDate) {
+			rows[row.Prefix] = row
+		}
+	}
+	return
+}
+
+// ratePerSecond normalizes the rate so rows with different rate units can be compared
+func (row *RateDeckRow) ratePerSecond() float64 {
+	unit, err := utils.ParseDurationWithNanosecs(row.RateUnit)
+	if err != nil || unit <= 0 {
+		return row.Rate
+	}
+	return row.Rate / unit.Seconds()
+}
+
+// changeType returns the type of change from the old row or empty if the rows are charging the same way
+// the rate decides over the connect fee, the unit and increment changes alone are reported as *changed
+func (row *RateDeckRow) changeType(oldRow *RateDeckRow) string {
+	rate, oldRate := row.ratePerSecond(), oldRow.ratePerSecond()
+	switch {
+	case rate > oldRate,
+		rate == oldRate && row.ConnectFee > oldRow.ConnectFee:
+		return utils.MetaIncreased
+	case rate < oldRate,
+		rate == oldRate && row.ConnectFee < oldRow.ConnectFee:
+		return utils.MetaDecreased
+	case !sameRateDeckDuration(row.RateIncrement, oldRow.RateIncrement),
+		!sameRateDeckDuration(row.RateUnit, oldRow.RateUnit):
+		return utils.MetaChanged
+	}
+	return utils.EmptyString
+}
+
+// Diff compares the latest rates within the deck with the ones of the current tariff plan
+func (rd RateDeck) Diff(oldRows map[string]*RateDeckRow) (chngs []*RateDeckChange) {
+	rows := rd.latestRows(time.Time{})
+	for prefix, row := range rows {
+		oldRow, has := oldRows[prefix]
+		if !has {
+			chngs = append(chngs, newRateDeckChange(utils.MetaNew, prefix, nil, row))
+			continue
+		}
+		if chngType := row.changeType(oldRow); chngType != utils.EmptyString {
+			chngs = append(chngs, newRateDeckChange(chngType, prefix, oldRow, row))
+		}
+	}
+	for prefix, oldRow := range oldRows {
+		if _, has := rows[prefix]; !has {
+			chngs = append(chngs, newRateDeckChange(utils.MetaRemoved, prefix, oldRow, nil))
+		}
+	}
+	sort.Slice(chngs, func(i, j int) bool {
+		return chngs[i].Prefix < chngs[j].Prefix
+	})
+	return
+}
+
+// RateDeckTP is the tariff plan built out of a rate deck
+type RateDeckTP struct {
+	Destinations     []*utils.TPDestination
+	Rates            []*utils.TPRateRALs
+	DestinationRates []*utils.TPDestinationRate
+	RatingPlans      []*utils.TPRatingPlan
+	RatingProfile    *utils.TPRatingProfile
+}
+
+// AsTP builds the tariff plan with one RatingPlan for each effective date of the deck,
+// activated for the category and subject at that date; the rows without effective date are activated at now
+func (rd RateDeck) AsTP(tpid, tenant, category, subject, roundingMethod string, now time.Time) (tp *RateDeckTP) {
+	effDate := func(row *RateDeckRow) time.Time {
+		if row.EffectiveDate.IsZero() {
+			return now
+		}
+		return row.EffectiveDate
+	}
+	dates := make([]time.Time, 0)
+	dstIDs := make(utils.StringSet)
+	tp = &RateDeckTP{
+		RatingProfile: &utils.TPRatingProfile{
+			TPid:     tpid,
+			LoadId:   tpid,
+			Tenant:   tenant,
+			Category: category,
+			Subject:  subject,
+		},
+	}
+	rateIDs := make(map[*RateDeckRow]string, len(rd))
+	for _, row := range rd {
+		if dstID := "DST_" + row.Prefix; !dstIDs.Has(dstID) {
+			dstIDs.Add(dstID)
+			tp.Destinations = append(tp.Destinations, &utils.TPDestination{
+				TPid:     tpid,
+				ID:       dstID,
+				Prefixes: []string{row.Prefix},
+			})
+		}
+		date := effDate(row)
+		rateIDs[row] = strings.Join([]string{"RT", subject, row.Prefix, rateDeckDateTag(date)}, utils.Underline)
+		tp.Rates = append(tp.Rates, &utils.TPRateRALs{
+			TPid: tpid,
+			ID:   rateIDs[row],
+			RateSlots: []*utils.RateSlot{{
+				ConnectFee:         row.ConnectFee,
+				Rate:               row.Rate,
+				RateUnit:           row.RateUnit,
+				RateIncrement:      row.RateIncrement,
+				GroupIntervalStart: "0s",
+			}},
+		})
+		if idx := sort.Search(len(dates), func(i int) bool {
+			return !dates[i].Before(date)
+		}); idx == len(dates) || !dates[idx].Equal(date) {
+			dates = append(dates, time.Time{})
+			copy(dates[idx+1:], dates[idx:])
+			dates[idx] = date
+		}
+	}
+	for _, date := range dates {
+		dateTag := rateDeckDateTag(date)
+		drID := strings.Join([]string{"DR", subject, dateTag}, utils.Underline)
+		rpID := strings.Join([]string{"RP", subject, dateTag}, utils.Underline)
+		rows := make(map[string]*RateDeckRow) // the rates active at this date
+		for _, row := range rd {
+			if rowDate := effDate(row); !rowDate.After(date) {
+				if crnt, has := rows[row.Prefix]; !has || !rowDate.Before(effDate(crnt)) {
+					rows[row.Prefix] = row
+				}
+			}
+		}
+		prefixes := make([]string, 0, len(rows))
+		for prefix := range rows {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		dr := &utils.TPDestinationRate{
+			TPid:             tpid,
+			ID:               drID,
+			DestinationRates: make([]*utils.DestinationRate, len(prefixes)),
+		}
+		for i, prefix := range prefixes {
+			dr.DestinationRates[i] = &utils.DestinationRate{
+				DestinationId:    "DST_" + prefix,
+				RateId:           rateIDs[rows[prefix]],
+				RoundingMethod:   roundingMethod,
+				RoundingDecimals: config.CgrConfig().GeneralCfg().RoundingDecimals,
+			}
+		}
+		tp.DestinationRates = append(tp.DestinationRates, dr)
+		tp.RatingPlans = append(tp.RatingPlans, &utils.TPRatingPlan{
+			TPid: tpid,
+			ID:   rpID,
+			RatingPlanBindings: []*utils.TPRatingPlanBinding{{
+				DestinationRatesId: drID,
+				TimingId:           utils.MetaAny,
+				Weight:             10,
+			}},
+		})
+		tp.RatingProfile.RatingPlanActivations = append(tp.RatingProfile.RatingPlanActivations,
+			&utils.TPRatingActivation{
+				ActivationTime: date.Format(time.RFC3339),
+				RatingPlanId:   rpID,
+			})
+	}
+	return
+}
+
+// rateDeckDateTag is used in the IDs of the items depending on the effective date
+func rateDeckDateTag(date time.Time) string {
+	return date.UTC().Format("20060102150405")
+}
+
+// SetTP writes the tariff plan into StorDB
+func (tp *RateDeckTP) SetTP(storDB LoadWriter) (err error) {
+	if err = storDB.SetTPDestinations(tp.Destinations); err != nil {
+		return
+	}
+	if err = storDB.SetTPRates(tp.Rates); err != nil {
+		return
+	}
+	if err = storDB.SetTPDestinationRates(tp.DestinationRates); err != nil {
+		return
+	}
+	if err = storDB.SetTPRatingPlans(tp.RatingPlans); err != nil {
+		return
+	}
+	return storDB.SetTPRatingProfiles([]*utils.TPRatingProfile{tp.RatingProfile})
+}
+
+// NextRateDeckTPid returns the next version of the tariff plan within StorDB, as <tpid>_v<version>
+func NextRateDeckTPid(storDB LoadReader, tpid string) (nextTPid string, err error) {
+	var tpids []string
+	if tpids, err = storDB.GetTpIds(utils.TBLTPDestinationRates); err != nil {
+		return
+	}
+	var lastVersion int
+	for _, id := range tpids {
+		if !strings.HasPrefix(id, tpid+rateDeckVersionSep) {
+			continue
+		}
+		if version, err := strconv.Atoi(strings.TrimPrefix(id, tpid+rateDeckVersionSep)); err == nil &&
+			version > lastVersion {
+			lastVersion = version
+		}
+	}
+	return tpid + rateDeckVersionSep + strconv.Itoa(lastVersion+1), nil
+}
+
+// TPPrefixRates returns the first rate slot of each prefix within the tariff plan
+// the DestinationRates are applied in the order of their IDs, the later ones overwriting the rates
+func TPPrefixRates(storDB LoadReader, tpid string) (rows map[string]*RateDeckRow, err error) {
+	rows = make(map[string]*RateDeckRow)
+	var tpDsts []*utils.TPDestination
+	if tpDsts, err = storDB.GetTPDestinations(tpid, utils.EmptyString); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	var tpRts []*utils.TPRateRALs
+	if tpRts, err = storDB.GetTPRates(tpid, utils.EmptyString); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	var tpDrs []*utils.TPDestinationRate
+	if tpDrs, err = storDB.GetTPDestinationRates(tpid, utils.EmptyString, nil); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	err = nil
+	dsts := make(map[string][]string, len(tpDsts))
+	for _, tpDst := range tpDsts {
+		dsts[tpDst.ID] = tpDst.Prefixes
+	}
+	rts := make(map[string]*RateDeckRow, len(tpRts))
+	for _, tpRt := range tpRts {
+		if len(tpRt.RateSlots) == 0 {
+			continue
+		}
+		rs := tpRt.RateSlots[0]
+		row := &RateDeckRow{Rate: rs.Rate, ConnectFee: rs.ConnectFee}
+		if row.RateUnit, err = parseRateDeckDuration(rs.RateUnit); err != nil {
+			return nil, fmt.Errorf("rate <%s>: %s", tpRt.ID, err.Error())
+		}
+		if row.RateIncrement, err = parseRateDeckDuration(rs.RateIncrement); err != nil {
+			return nil, fmt.Errorf("rate <%s>: %s", tpRt.ID, err.Error())
+		}
+		rts[tpRt.ID] = row
+	}
+	sort.Slice(tpDrs, func(i, j int) bool {
+		return tpDrs[i].ID < tpDrs[j].ID
+	})
+	for _, tpDr := range tpDrs {
+		for _, dr := range tpDr.DestinationRates {
+			rt, has := rts[dr.RateId]
+			if !has {
+				continue
+			}
+			for _, prefix := range dsts[dr.DestinationId] {
+				row := *rt
+				row.Prefix = prefix
+				rows[prefix] = &row
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,199 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewRateDeck(t *testing.T) {
+	tpl := []*config.FCTemplate{
+		{Tag: "Prefix", Path: utils.Prefix, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Code", utils.InfieldSep), Mandatory: true},
+		{Tag: "Rate", Path: utils.Rate, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Price", utils.InfieldSep), Mandatory: true},
+		{Tag: "EffectiveDate", Path: utils.EffectiveDate, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Effective", utils.InfieldSep)},
+		{Tag: "Increment", Path: utils.RateIncrement, Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Increment", utils.InfieldSep)},
+	}
+	records := [][]string{
+		{"Code", "Destination", "Price", "Effective", "Increment"},
+		{"4420", "UK London", "0.02", "2021-03-01T00:00:00Z", "60"},
+		{"4475", "UK Mobile", "0.09", "44256", ""},
+	}
+	exp := RateDeck{
+		{Prefix: "4420", Rate: 0.02, RateUnit: rateDeckRateUnit, RateIncrement: "1m0s",
+			EffectiveDate: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Prefix: "4475", Rate: 0.09, RateUnit: rateDeckRateUnit, RateIncrement: rateDeckRateIncrement,
+			EffectiveDate: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if rd, err := NewRateDeck(records, tpl, "UTC"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rd))
+	}
+
+	records = append(records, []string{"4477", "UK Mobile", "", "", ""})
+	if _, err := NewRateDeck(records, tpl, "UTC"); err == nil ||
+		err.Error() != "line 4: MANDATORY_IE_MISSING: [Rate]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRateDeckDiff(t *testing.T) {
+	rd := RateDeck{
+		{Prefix: "4420", Rate: 0.01, RateUnit: "1m0s", RateIncrement: "1s"},
+		{Prefix: "4420", Rate: 0.02, RateUnit: "1m0s", RateIncrement: "1s",
+			EffectiveDate: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Prefix: "4421", Rate: 0.01, RateUnit: "1m0s", RateIncrement: "1s"},
+		{Prefix: "4422", Rate: 0.01, ConnectFee: 0.1, RateUnit: "1m0s", RateIncrement: "1s"},
+		{Prefix: "4423", Rate: 0.01, RateUnit: "1m0s", RateIncrement: "1m0s"},
+		{Prefix: "4424", Rate: 0.01, RateUnit: "1m0s", RateIncrement: "1s"},
+		{Prefix: "4474", Rate: 0.05, RateUnit: "1m0s", RateIncrement: "1s"},
+		{Prefix: "4475", Rate: 0.09, RateUnit: "1m0s", RateIncrement: "1s"},
+	}
+	oldRows := map[string]*RateDeckRow{
+		"4420": {Prefix: "4420", Rate: 0.015, RateUnit: "1m0s", RateIncrement: "1s"},
+		"4421": {Prefix: "4421", Rate: 0.0005, RateUnit: "3s", RateIncrement: "1s"},
+		"4422": {Prefix: "4422", Rate: 0.01, RateUnit: "1m0s", RateIncrement: "1s"},
+		"4423": {Prefix: "4423", Rate: 0.01, RateUnit: "60s", RateIncrement: "1s"},
+		"4424": {Prefix: "4424", Rate: 0.01, RateUnit: "60s", RateIncrement: "1s"},
+		"4474": {Prefix: "4474", Rate: 0.1, RateUnit: "1m0s", RateIncrement: "1s"},
+		"4477": {Prefix: "4477", Rate: 0.1, RateUnit: "1m0s", RateIncrement: "1s"},
+	}
+	exp := []*RateDeckChange{
+		{Type: utils.MetaIncreased, Prefix: "4420", OldRate: 0.015, NewRate: 0.02,
+			OldRateUnit: "1m0s", NewRateUnit: "1m0s", OldRateIncrement: "1s", NewRateIncrement: "1s"},
+		{Type: utils.MetaChanged, Prefix: "4421", OldRate: 0.0005, NewRate: 0.01,
+			OldRateUnit: "3s", NewRateUnit: "1m0s", OldRateIncrement: "1s", NewRateIncrement: "1s"},
+		{Type: utils.MetaIncreased, Prefix: "4422", OldRate: 0.01, NewRate: 0.01, NewConnectFee: 0.1,
+			OldRateUnit: "1m0s", NewRateUnit: "1m0s", OldRateIncrement: "1s", NewRateIncrement: "1s"},
+		{Type: utils.MetaChanged, Prefix: "4423", OldRate: 0.01, NewRate: 0.01,
+			OldRateUnit: "60s", NewRateUnit: "1m0s", OldRateIncrement: "1s", NewRateIncrement: "1m0s"},
+		{Type: utils.MetaDecreased, Prefix: "4474", OldRate: 0.1, NewRate: 0.05,
+			OldRateUnit: "1m0s", NewRateUnit: "1m0s", OldRateIncrement: "1s", NewRateIncrement: "1s"},
+		{Type: utils.MetaNew, Prefix: "4475", NewRate: 0.09, NewRateUnit: "1m0s", NewRateIncrement: "1s"},
+		{Type: utils.MetaRemoved, Prefix: "4477", OldRate: 0.1, OldRateUnit: "1m0s", OldRateIncrement: "1s"},
+	}
+	if rcv := rd.Diff(oldRows); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := exp[2].AsCSV(); !reflect.DeepEqual(rcv, []string{utils.MetaIncreased, "4422",
+		"0.01", "0.01", "0", "0.1", "1m0s", "1m0s", "1s", "1s"}) {
+		t.Errorf("Unexpected csv: %+v", rcv)
+	}
+}
+
+func TestRateDeckAsTP(t *testing.T) {
+	now := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	rd := RateDeck{
+		{Prefix: "4420", Rate: 0.01, RateUnit: "60s", RateIncrement: "1s"},
+		{Prefix: "4475", Rate: 0.09, RateUnit: "60s", RateIncrement: "1s"},
+		{Prefix: "4420", Rate: 0.02, RateUnit: "60s", RateIncrement: "1s",
+			EffectiveDate: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	tp := rd.AsTP("CARRIER_v2", "cgrates.org", "sms", "CARRIER", utils.MetaRoundingMiddle, now)
+	if len(tp.Destinations) != 2 || len(tp.Rates) != 3 {
+		t.Fatalf("Unexpected TP: %s", utils.ToJSON(tp))
+	}
+	expDRs := []*utils.TPDestinationRate{
+		{
+			TPid: "CARRIER_v2",
+			ID:   "DR_CARRIER_20210201000000",
+			DestinationRates: []*utils.DestinationRate{
+				{DestinationId: "DST_4420", RateId: "RT_CARRIER_4420_20210201000000",
+					RoundingMethod: utils.MetaRoundingMiddle, RoundingDecimals: config.CgrConfig().GeneralCfg().RoundingDecimals},
+				{DestinationId: "DST_4475", RateId: "RT_CARRIER_4475_20210201000000",
+					RoundingMethod: utils.MetaRoundingMiddle, RoundingDecimals: config.CgrConfig().GeneralCfg().RoundingDecimals},
+			},
+		},
+		{
+			TPid: "CARRIER_v2",
+			ID:   "DR_CARRIER_20210301000000",
+			DestinationRates: []*utils.DestinationRate{
+				{DestinationId: "DST_4420", RateId: "RT_CARRIER_4420_20210301000000",
+					RoundingMethod: utils.MetaRoundingMiddle, RoundingDecimals: config.CgrConfig().GeneralCfg().RoundingDecimals},
+				{DestinationId: "DST_4475", RateId: "RT_CARRIER_4475_20210201000000",
+					RoundingMethod: utils.MetaRoundingMiddle, RoundingDecimals: config.CgrConfig().GeneralCfg().RoundingDecimals},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expDRs, tp.DestinationRates) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expDRs), utils.ToJSON(tp.DestinationRates))
+	}
+	expRPrf := &utils.TPRatingProfile{
+		TPid:     "CARRIER_v2",
+		LoadId:   "CARRIER_v2",
+		Tenant:   "cgrates.org",
+		Category: "sms",
+		Subject:  "CARRIER",
+		RatingPlanActivations: []*utils.TPRatingActivation{
+			{ActivationTime: "2021-02-01T00:00:00Z", RatingPlanId: "RP_CARRIER_20210201000000"},
+			{ActivationTime: "2021-03-01T00:00:00Z", RatingPlanId: "RP_CARRIER_20210301000000"},
+		},
+	}
+	if !reflect.DeepEqual(expRPrf, tp.RatingProfile) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expRPrf), utils.ToJSON(tp.RatingProfile))
+	}
+}
+
+func TestRateDeckStorDB(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	storDB := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	if nextTPid, err := NextRateDeckTPid(storDB, "CARRIER"); err != nil {
+		t.Error(err)
+	} else if nextTPid != "CARRIER_v1" {
+		t.Errorf("Unexpected tpid: <%s>", nextTPid)
+	}
+	rd := RateDeck{
+		{Prefix: "4420", Rate: 0.01, RateUnit: "60s", RateIncrement: "1s"},
+		{Prefix: "4420", Rate: 0.02, ConnectFee: 0.1, RateUnit: "60s", RateIncrement: "1s",
+			EffectiveDate: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Prefix: "4475", Rate: 0.09, RateUnit: "60s", RateIncrement: "60s"},
+	}
+	if err := rd.AsTP("CARRIER_v1", "cgrates.org", utils.Call, "CARRIER", utils.MetaRoundingUp,
+		time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)).SetTP(storDB); err != nil {
+		t.Fatal(err)
+	}
+	if nextTPid, err := NextRateDeckTPid(storDB, "CARRIER"); err != nil {
+		t.Error(err)
+	} else if nextTPid != "CARRIER_v2" {
+		t.Errorf("Unexpected tpid: <%s>", nextTPid)
+	}
+	exp := map[string]*RateDeckRow{
+		"4420": {Prefix: "4420", Rate: 0.02, ConnectFee: 0.1, RateUnit: "1m0s", RateIncrement: "1s"},
+		"4475": {Prefix: "4475", Rate: 0.09, RateUnit: "1m0s", RateIncrement: "1m0s"},
+	}
+	if rows, err := TPPrefixRates(storDB, "CARRIER_v1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rows) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rows))
+	}
+	if rows, err := TPPrefixRates(storDB, "CARRIER_v5"); err != nil {
+		t.Error(err)
+	} else if len(rows) != 0 {
+		t.Errorf("Unexpected rates: %s", utils.ToJSON(rows))
+	}
+}
@@ -30,6 +30,7 @@ type RatingPlan struct {
 	Timings          map[string]*RITiming
 	Ratings          map[string]*RIRate
 	DestinationRates map[string]RPRateList
+	Currency         string // currency of the costs, empty for the default one
 }
 
 // RatingPlanWithOpts is used in replicatorV1 for dispatcher
@@ -93,6 +93,8 @@ type RatingInfo struct {
 	ActivationTime time.Time
 	RateIntervals  RateIntervalList
 	FallbackKeys   []string
+	TierUsage      *time.Duration // usage counted before the call, populated only for tiered RatingPlans
+	Currency       string         // currency of the RatingPlan
 }
 
 // SelectRatingIntevalsForTimespan orders rate intervals in time preserving only those which aply to the specified timestamp
@@ -233,7 +235,8 @@ func (rpf *RatingProfile) GetRatingPlansForPrefix(cd *CallDescriptor) (err error
 				MatchedDestId:  destinationID,
 				ActivationTime: rpa.ActivationTime,
 				RateIntervals:  rps,
-				FallbackKeys:   rpa.FallbackKeys})
+				FallbackKeys:   rpa.FallbackKeys,
+				Currency:       rpl.Currency})
 		} else {
 			// add for fallback information
 			if len(rpa.FallbackKeys) > 0 {
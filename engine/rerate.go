@@ -0,0 +1,484 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	rerateBatchSize = 100 // default number of CDRs rerated in one batch
+	rerateMaxErrors = 10  // number of errors kept within the job
+)
+
+// ArgsStartRerate are the arguments passed to CDRsV1.StartRerate
+type ArgsStartRerate struct {
+	Tenant string
+	ID     string // generated if missing
+	utils.RPCCDRsFilter
+	ActivationTime string // activation time of the new RatingPlan, CDRs answered before it are not rerated
+	RatingPlanID   string // activated at ActivationTime on the RatingProfiles of the rerated CDRs
+	RunID          string // with *chargers only the CDRs of this run are rerated, the others being forked out of them; defaults to *default
+	Flags          []string
+	BatchSize      int
+	APIOpts        map[string]interface{}
+}
+
+// RerateJob tracks the progress of a bulk CDR rerate
+type RerateJob struct {
+	Tenant         string
+	ID             string
+	Filter         *utils.CDRsFilter
+	ActivationTime time.Time
+	RatingPlanID   string
+	Flags          []string
+	APIOpts        map[string]interface{}
+	BatchSize      int
+	Status         string   // one of *running, *completed, *canceled or *failed
+	Total          int64    // number of CDRs matching the filter when the job was started
+	Processed      int64    // number of CDRs processed
+	LastOrderID    int64    // OrderID of the last processed CDR, the job resumes after it
+	Failed         int64    // number of CDRs which could not be rerated
+	Errors         []string // last errors encountered
+	CostDelta      float64  // difference between the new and the old cost of the rerated CDRs
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (job *RerateJob) TenantID() string {
+	return utils.ConcatenatedKey(job.Tenant, job.ID)
+}
+
+// Clone returns a copy of the job
+// the Filter is shared since it is not modified after the job is created
+func (job *RerateJob) Clone() (cln *RerateJob) {
+	cln = new(RerateJob)
+	*cln = *job
+	if job.Flags != nil {
+		cln.Flags = make([]string, len(job.Flags))
+		copy(cln.Flags, job.Flags)
+	}
+	if job.APIOpts != nil {
+		cln.APIOpts = make(map[string]interface{}, len(job.APIOpts))
+		for k, v := range job.APIOpts {
+			cln.APIOpts[k] = v
+		}
+	}
+	if job.Errors != nil {
+		cln.Errors = make([]string, len(job.Errors))
+		copy(cln.Errors, job.Errors)
+	}
+	return
+}
+
+// addError records the error keeping only the last rerateMaxErrors
+func (job *RerateJob) addError(err string) {
+	if job.Errors = append(job.Errors, err); len(job.Errors) > rerateMaxErrors {
+		job.Errors = job.Errors[len(job.Errors)-rerateMaxErrors:]
+	}
+}
+
+// startRerateJob starts processing the job in background
+func (cdrS *CDRServer) startRerateJob(job *RerateJob) {
+	stop := make(chan struct{})
+	cdrS.rrJobsMux.Lock()
+	if cdrS.rrJobs == nil {
+		cdrS.rrJobs = make(map[string]chan struct{})
+	}
+	cdrS.rrJobs[job.TenantID()] = stop
+	cdrS.rrJobsMux.Unlock()
+	go cdrS.runRerateJob(job, stop)
+}
+
+// stopRerateJob stops the job if it runs on this server
+func (cdrS *CDRServer) stopRerateJob(tntID string) {
+	cdrS.rrJobsMux.Lock()
+	if stop, has := cdrS.rrJobs[tntID]; has {
+		close(stop)
+		delete(cdrS.rrJobs, tntID)
+	}
+	cdrS.rrJobsMux.Unlock()
+}
+
+// stopRerateJobs stops all the jobs running on this server
+// their status is not changed so they are resumed at the next start
+func (cdrS *CDRServer) stopRerateJobs() {
+	cdrS.rrJobsMux.Lock()
+	for tntID, stop := range cdrS.rrJobs {
+		close(stop)
+		delete(cdrS.rrJobs, tntID)
+	}
+	cdrS.rrJobsMux.Unlock()
+}
+
+// resumeRerateJobs restarts the jobs left running before a shutdown
+func (cdrS *CDRServer) resumeRerateJobs() {
+	if cdrS.dm == nil {
+		return
+	}
+	keys, err := cdrS.dm.DataDB().GetKeysForPrefix(utils.RerateJobPrefix)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: <%s> querying rerate jobs", utils.CDRs, err.Error()))
+		return
+	}
+	for _, key := range keys {
+		tntID := utils.NewTenantID(key[len(utils.RerateJobPrefix):])
+		job, err := cdrS.dm.GetRerateJob(tntID.Tenant, tntID.ID)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> querying rerate job <%s>",
+					utils.CDRs, err.Error(), tntID.TenantID()))
+			continue
+		}
+		if job.Status != utils.MetaRunning {
+			continue
+		}
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> resuming rerate job <%s> after OrderID %d",
+				utils.CDRs, job.TenantID(), job.LastOrderID))
+		cdrS.startRerateJob(job)
+	}
+}
+
+// saveRerateJob stores the job state unless it was stopped or canceled meanwhile
+func (cdrS *CDRServer) saveRerateJob(job *RerateJob, stop chan struct{}) (saved bool) {
+	cdrS.guard.Guard(func() (_ error) {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if stored, err := cdrS.dm.GetRerateJob(job.Tenant, job.ID); err == nil &&
+			stored.Status != utils.MetaRunning { // canceled on another server
+			return
+		}
+		if err := cdrS.dm.SetRerateJob(job); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> storing rerate job <%s>",
+					utils.CDRs, err.Error(), job.TenantID()))
+		}
+		saved = true
+		return
+	}, cdrS.cgrCfg.GeneralCfg().LockingTimeout, utils.RerateJobPrefix+job.TenantID())
+	return
+}
+
+// runRerateJob rerates in batches the CDRs matching the job filter
+func (cdrS *CDRServer) runRerateJob(job *RerateJob, stop chan struct{}) {
+	defer cdrS.stopRerateJob(job.TenantID())
+	chrgS, attrS, store, export, thdS, stS := cdrS.rateCDRsFlags(utils.FlagsWithParamsFromSlice(job.Flags))
+	for {
+		fltr := *job.Filter
+		fltr.OrderBy = utils.OrderID
+		// page with a cursor since the rerated CDRs are stored back and can change the result set
+		if fltr.OrderIDStart == nil || *fltr.OrderIDStart <= job.LastOrderID {
+			fltr.OrderIDStart = utils.Int64Pointer(job.LastOrderID + 1)
+		}
+		fltr.Paginator = utils.Paginator{
+			Limit: utils.IntPointer(job.BatchSize),
+		}
+		cdrs, _, err := cdrS.cdrDb.GetCDRs(&fltr, false)
+		if err != nil && err != utils.ErrNotFound {
+			job.Status = utils.MetaFailed
+			job.addError(err.Error())
+			job.EndTime = time.Now()
+			cdrS.saveRerateJob(job, stop)
+			return
+		}
+		if len(cdrs) == 0 {
+			job.Status = utils.MetaCompleted
+			job.EndTime = time.Now()
+			cdrS.saveRerateJob(job, stop)
+			return
+		}
+		for _, cdr := range cdrs {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var costDelta float64
+			var err error
+			if job.RatingPlanID != utils.EmptyString {
+				err = cdrS.activateRatingPlan(cdr, job.RatingPlanID, job.ActivationTime)
+			}
+			if err == nil {
+				costDelta, err = cdrS.rerateCDR(cdr, job.APIOpts,
+					chrgS, attrS, store, export, thdS, stS)
+			}
+			if err != nil {
+				job.Failed++
+				job.addError(fmt.Sprintf("CGRID: %s, RunID: %s, error: %s",
+					cdr.CGRID, cdr.RunID, err.Error()))
+			}
+			job.CostDelta = utils.Round(job.CostDelta+costDelta,
+				globalRoundingDecimals, utils.MetaRoundingMiddle)
+			job.Processed++
+			job.LastOrderID = cdr.OrderID
+		}
+		if !cdrS.saveRerateJob(job, stop) {
+			return
+		}
+	}
+}
+
+// activateRatingPlan adds the RatingPlan activation to the RatingProfile the CDR is rated on
+func (cdrS *CDRServer) activateRatingPlan(cdr *CDR, rpID string, aTime time.Time) (err error) {
+	subject := cdr.Subject
+	if subject == utils.EmptyString {
+		subject = cdr.Account
+	}
+	rpfKey := utils.ConcatenatedKey(utils.MetaOut, cdr.Tenant, cdr.Category, subject)
+	return cdrS.guard.Guard(func() (gErr error) {
+		var rpf *RatingProfile
+		if rpf, gErr = cdrS.dm.GetRatingProfile(rpfKey, true, utils.NonTransactional); gErr != nil {
+			return fmt.Errorf("RatingProfile <%s>: %s", rpfKey, gErr.Error())
+		}
+		rpa := &RatingPlanActivation{
+			ActivationTime: aTime,
+			RatingPlanId:   rpID,
+		}
+		for _, actv := range rpf.RatingPlanActivations {
+			if actv.Equal(rpa) { // already activated
+				return
+			}
+		}
+		if len(rpf.RatingPlanActivations) != 0 { // keep the fallback of the previous activations
+			rpa.FallbackKeys = rpf.RatingPlanActivations[len(rpf.RatingPlanActivations)-1].FallbackKeys
+		}
+		rpf.RatingPlanActivations = append(rpf.RatingPlanActivations, rpa)
+		if gErr = cdrS.dm.SetRatingProfile(rpf); gErr != nil {
+			return
+		}
+		if gErr = cdrS.dm.SetLoadIDs(map[string]int64{utils.CacheRatingProfiles: time.Now().UnixNano()}); gErr != nil {
+			return
+		}
+		return Cache.Remove(utils.CacheRatingProfiles, rpfKey, true, utils.NonTransactional)
+	}, cdrS.cgrCfg.GeneralCfg().LockingTimeout, utils.RatingProfilePrefix+rpfKey)
+}
+
+// rerateCDR refunds and rates again the CDR, returning the cost difference
+// with ChargerS the runs are forked again out of the CDR so all the stored runs of the call
+// are refunded here, each run being debited only once
+func (cdrS *CDRServer) rerateCDR(cdr *CDR, opts map[string]interface{},
+	chrgS, attrS, store, export, thdS, stS bool) (costDelta float64, err error) {
+	oldCDRs := []*CDR{cdr}
+	if chrgS {
+		if oldCDRs, _, err = cdrS.cdrDb.GetCDRs(
+			&utils.CDRsFilter{CGRIDs: []string{cdr.CGRID}}, false); err != nil {
+			return
+		}
+		for _, oldCDR := range oldCDRs {
+			if err = cdrS.refundCDR(oldCDR); err != nil {
+				return
+			}
+		}
+	}
+	var oldCost float64
+	for _, oldCDR := range oldCDRs {
+		if oldCDR.Cost > 0 {
+			oldCost += oldCDR.Cost
+		}
+	}
+	if chrgS {
+		cdr.CostDetails = nil // refunded already, not to be refunded by each forked run
+	}
+	runID := cdr.RunID
+	cdr.Cost = -1 // the cost will be recalculated
+	cgrEv := cdr.AsCGREvent()
+	cgrEv.APIOpts = opts
+	var outEvs []*utils.EventWithFlags
+	if outEvs, err = cdrS.processEvents([]*utils.CGREvent{cgrEv}, chrgS, attrS, !chrgS,
+		true, store, true, export, thdS, stS); err != nil && len(outEvs) == 0 {
+		return
+	}
+	rated := utils.NewStringSet(nil) // the first cost of each run is considered
+	for _, ev := range outEvs {
+		evRunID := utils.IfaceAsString(ev.Event[utils.RunID])
+		if rated.Has(evRunID) ||
+			!chrgS && evRunID != runID {
+			continue
+		}
+		newCost, errCast := utils.IfaceAsFloat64(ev.Event[utils.Cost])
+		if errCast != nil || newCost < 0 {
+			continue
+		}
+		rated.Add(evRunID)
+		costDelta += newCost
+	}
+	if rated.Size() != 0 {
+		costDelta -= oldCost
+	}
+	return
+}
+
+// refundCDR refunds the cost of one stored CDR, reverting the tier counters
+func (cdrS *CDRServer) refundCDR(cdr *CDR) (err error) {
+	if _, err = cdrS.refundEventCost(cdr.CostDetails,
+		cdr.RequestType, cdr.ToR); err != nil {
+		return
+	}
+	if errTc := cdrS.updateTierCounters(cdr, cdr.CostDetails, true); errTc != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: <%s> updating tier counters for CDR %+v",
+				utils.CDRs, errTc.Error(), utils.ToJSON(cdr)))
+	}
+	return
+}
+
+// V1StartRerate starts a background job rerating the CDRs matching the filter
+// replies with the job ID
+func (cdrS *CDRServer) V1StartRerate(args *ArgsStartRerate, reply *string) (err error) {
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	if args.ID == utils.EmptyString {
+		args.ID = utils.GenUUID()
+	}
+	var cdrFltr *utils.CDRsFilter
+	if cdrFltr, err = args.RPCCDRsFilter.AsCDRsFilter(cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	job := &RerateJob{
+		Tenant:       args.Tenant,
+		ID:           args.ID,
+		Filter:       cdrFltr,
+		RatingPlanID: args.RatingPlanID,
+		Flags:        args.Flags,
+		APIOpts:      args.APIOpts,
+		BatchSize:    args.BatchSize,
+		Status:       utils.MetaRunning,
+		StartTime:    time.Now(),
+	}
+	if job.BatchSize <= 0 {
+		job.BatchSize = rerateBatchSize
+	}
+	if args.RatingPlanID != utils.EmptyString {
+		if args.ActivationTime == utils.EmptyString {
+			return utils.NewErrMandatoryIeMissing("ActivationTime")
+		}
+		var has bool
+		if has, err = cdrS.dm.HasData(utils.RatingPlanPrefix, args.RatingPlanID, utils.EmptyString); err != nil {
+			return utils.NewErrServerError(err)
+		} else if !has {
+			return fmt.Errorf("%s:RatingPlanID:%s", utils.ErrNotFound.Error(), args.RatingPlanID)
+		}
+	}
+	if args.ActivationTime != utils.EmptyString {
+		if job.ActivationTime, err = utils.ParseTimeDetectLayout(args.ActivationTime,
+			cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+			return
+		}
+		if cdrFltr.AnswerTimeStart == nil || cdrFltr.AnswerTimeStart.Before(job.ActivationTime) {
+			cdrFltr.AnswerTimeStart = utils.TimePointer(job.ActivationTime)
+		}
+	}
+	if chrgS, _, _, _, _, _ := cdrS.rateCDRsFlags(utils.FlagsWithParamsFromSlice(args.Flags)); chrgS {
+		if len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
+			return utils.NewErrNotConnected(utils.ChargerS)
+		}
+		// the other runs are forked again by ChargerS
+		cdrFltr.RunIDs = []string{utils.FirstNonEmpty(args.RunID, utils.MetaDefault)}
+	}
+	cntFltr := *cdrFltr
+	cntFltr.Count = true
+	if _, job.Total, err = cdrS.cdrDb.GetCDRs(&cntFltr, false); err != nil &&
+		err != utils.ErrNotFound {
+		return utils.NewErrServerError(err)
+	}
+	// limit the job to the CDRs existing now so the rerated ones stored back
+	// with a new OrderID are not processed again
+	lastFltr := *cdrFltr
+	lastFltr.OrderBy = utils.OrderID + utils.InfieldSep + "desc"
+	lastFltr.Paginator = utils.Paginator{Limit: utils.IntPointer(1)}
+	var lastCDRs []*CDR
+	if lastCDRs, _, err = cdrS.cdrDb.GetCDRs(&lastFltr, false); err != nil &&
+		err != utils.ErrNotFound {
+		return utils.NewErrServerError(err)
+	}
+	err = nil
+	if len(lastCDRs) != 0 &&
+		(cdrFltr.OrderIDEnd == nil || *cdrFltr.OrderIDEnd > lastCDRs[0].OrderID+1) {
+		cdrFltr.OrderIDEnd = utils.Int64Pointer(lastCDRs[0].OrderID + 1)
+	}
+	if err = cdrS.guard.Guard(func() (gErr error) {
+		if _, gErr = cdrS.dm.GetRerateJob(job.Tenant, job.ID); gErr == nil {
+			return utils.ErrExists
+		} else if gErr != utils.ErrNotFound {
+			return
+		}
+		return cdrS.dm.SetRerateJob(job)
+	}, cdrS.cgrCfg.GeneralCfg().LockingTimeout, utils.RerateJobPrefix+job.TenantID()); err != nil {
+		return
+	}
+	cdrS.startRerateJob(job.Clone())
+	*reply = job.ID
+	return
+}
+
+// V1GetRerateStatus returns the rerate job with its progress
+func (cdrS *CDRServer) V1GetRerateStatus(args *utils.TenantIDWithAPIOpts, reply *RerateJob) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	var job *RerateJob
+	if job, err = cdrS.dm.GetRerateJob(tnt, args.ID); err != nil {
+		return
+	}
+	*reply = *job
+	return
+}
+
+// V1CancelRerate stops a running rerate job
+func (cdrS *CDRServer) V1CancelRerate(args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	if args.TenantID == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	tntID := utils.ConcatenatedKey(tnt, args.ID)
+	if err = cdrS.guard.Guard(func() (gErr error) {
+		var job *RerateJob
+		if job, gErr = cdrS.dm.GetRerateJob(tnt, args.ID); gErr != nil {
+			return
+		}
+		if job.Status != utils.MetaRunning {
+			return utils.ErrJobNotRunning
+		}
+		cdrS.stopRerateJob(tntID)
+		job.Status = utils.MetaCanceled
+		job.EndTime = time.Now()
+		return cdrS.dm.SetRerateJob(job)
+	}, cdrS.cgrCfg.GeneralCfg().LockingTimeout, utils.RerateJobPrefix+tntID); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
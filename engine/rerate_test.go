@@ -0,0 +1,286 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestRerateJobClone(t *testing.T) {
+	job := &RerateJob{
+		Tenant:  "cgrates.org",
+		ID:      "RERATE1",
+		Flags:   []string{utils.MetaStore},
+		APIOpts: map[string]interface{}{"opt": 1},
+		Errors:  []string{"err1"},
+		Status:  utils.MetaRunning,
+	}
+	cln := job.Clone()
+	if !reflect.DeepEqual(job, cln) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(job), utils.ToJSON(cln))
+	}
+	cln.Flags[0] = utils.MetaExport
+	cln.APIOpts["opt"] = 2
+	cln.Errors[0] = "err2"
+	if job.Flags[0] != utils.MetaStore || job.APIOpts["opt"] != 1 || job.Errors[0] != "err1" {
+		t.Errorf("Clone modified the original job: %s", utils.ToJSON(job))
+	}
+}
+
+func TestRerateJobAddError(t *testing.T) {
+	job := new(RerateJob)
+	for i := 0; i < rerateMaxErrors+2; i++ {
+		job.addError(fmt.Sprintf("err%d", i))
+	}
+	if len(job.Errors) != rerateMaxErrors {
+		t.Fatalf("Expected %d errors, received %d", rerateMaxErrors, len(job.Errors))
+	}
+	if job.Errors[0] != "err2" || job.Errors[rerateMaxErrors-1] != fmt.Sprintf("err%d", rerateMaxErrors+1) {
+		t.Errorf("Unexpected errors: %v", job.Errors)
+	}
+}
+
+func testRerateCDRServer(t *testing.T) *CDRServer {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().RaterConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	clMock := clMock(func(_ string, args interface{}, reply interface{}) error {
+		if chrgRply, canCast := reply.(*[]*ChrgSProcessEventReply); canCast { // fork *default and run2
+			ev := args.(*utils.CGREvent)
+			for _, runID := range []string{utils.MetaDefault, "run2"} {
+				runEv := ev.Clone()
+				runEv.Event[utils.RunID] = runID
+				*chrgRply = append(*chrgRply, &ChrgSProcessEventReply{CGREvent: runEv})
+			}
+			return nil
+		}
+		rply, canCast := reply.(*CallCost)
+		if !canCast {
+			return fmt.Errorf("can't cast")
+		}
+		*rply = CallCost{Cost: 3}
+		return nil
+	})
+	chanClnt := make(chan rpcclient.ClientConnector, 1)
+	chanClnt <- clMock
+	connMngr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): chanClnt,
+	})
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		connMgr: connMngr,
+		cdrDb:   NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		dm:      NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), connMngr),
+		guard:   guardian.Guardian,
+		rrJobs:  make(map[string]chan struct{}),
+	}
+	for i, answTime := range []time.Time{
+		time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, time.March, 10, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, time.March, 20, 10, 0, 0, 0, time.UTC),
+	} {
+		cdr := &CDR{
+			CGRID:       utils.Sha1(fmt.Sprintf("rerate%d", i)),
+			RunID:       utils.MetaDefault,
+			OrderID:     int64(i + 1),
+			OriginID:    fmt.Sprintf("rerate%d", i),
+			ToR:         utils.MetaVoice,
+			RequestType: utils.MetaRated,
+			Tenant:      "cgrates.org",
+			Category:    "call",
+			Account:     "1001",
+			Subject:     "1001",
+			Destination: "1002",
+			SetupTime:   answTime,
+			AnswerTime:  answTime,
+			Usage:       time.Minute,
+			Cost:        1,
+		}
+		if err := cdrS.cdrDb.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cdrS
+}
+
+func testRerateWaitJob(t *testing.T, cdrS *CDRServer, id string) (job *RerateJob) {
+	job = new(RerateJob)
+	for i := 0; i < 100; i++ {
+		if err := cdrS.V1GetRerateStatus(&utils.TenantIDWithAPIOpts{
+			TenantID: &utils.TenantID{ID: id}}, job); err != nil {
+			t.Fatal(err)
+		}
+		if job.Status != utils.MetaRunning {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %s did not finish: %s", id, utils.ToJSON(job))
+	return
+}
+
+func TestCDRsV1StartRerate(t *testing.T) {
+	cdrS := testRerateCDRServer(t)
+	var reply string
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{
+		ID:             "RERATE1",
+		ActivationTime: "2021-03-05T00:00:00Z",
+		BatchSize:      1,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != "RERATE1" {
+		t.Errorf("Expected RERATE1, received %s", reply)
+	}
+	job := testRerateWaitJob(t, cdrS, reply)
+	if job.Status != utils.MetaCompleted {
+		t.Errorf("Expected status %s, received %s", utils.MetaCompleted, job.Status)
+	}
+	if job.Total != 2 || job.Processed != 2 || job.Failed != 0 || job.LastOrderID != 3 {
+		t.Errorf("Unexpected progress: %s", utils.ToJSON(job))
+	}
+	if job.CostDelta != 4 {
+		t.Errorf("Expected cost delta 4, received %v", job.CostDelta)
+	}
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{ID: "RERATE1"},
+		&reply); err != utils.ErrExists {
+		t.Errorf("Expected %v, received %v", utils.ErrExists, err)
+	}
+	if err := cdrS.V1CancelRerate(&utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{ID: "RERATE1"}}, &reply); err != utils.ErrJobNotRunning {
+		t.Errorf("Expected %v, received %v", utils.ErrJobNotRunning, err)
+	}
+	if err := cdrS.V1CancelRerate(&utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{}}, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.ID).Error() {
+		t.Errorf("Expected mandatory error, received %v", err)
+	}
+}
+
+func TestCDRsResumeRerateJobs(t *testing.T) {
+	cdrS := testRerateCDRServer(t)
+	job := &RerateJob{
+		Tenant:      "cgrates.org",
+		ID:          "RERATE2",
+		Filter:      &utils.CDRsFilter{},
+		BatchSize:   2,
+		Status:      utils.MetaRunning,
+		Total:       3,
+		Processed:   2, // stopped after the first batch
+		LastOrderID: 2,
+	}
+	if err := cdrS.dm.SetRerateJob(job); err != nil {
+		t.Fatal(err)
+	}
+	cdrS.resumeRerateJobs()
+	job = testRerateWaitJob(t, cdrS, "RERATE2")
+	if job.Status != utils.MetaCompleted || job.Processed != 3 ||
+		job.LastOrderID != 3 || job.CostDelta != 2 {
+		t.Errorf("Unexpected job: %s", utils.ToJSON(job))
+	}
+}
+
+func TestCDRsV1StartRerateRatingPlan(t *testing.T) {
+	cdrS := testRerateCDRServer(t)
+	var reply string
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{ID: "RERATE3",
+		RatingPlanID: "RP_NEW"}, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing("ActivationTime").Error() {
+		t.Errorf("Expected mandatory error, received %v", err)
+	}
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{ID: "RERATE3",
+		RatingPlanID: "RP_NEW", ActivationTime: "2021-03-05T00:00:00Z"}, &reply); err == nil ||
+		err.Error() != "NOT_FOUND:RatingPlanID:RP_NEW" {
+		t.Errorf("Expected not found error, received %v", err)
+	}
+	if err := cdrS.dm.SetRatingPlan(&RatingPlan{Id: "RP_NEW"}); err != nil {
+		t.Fatal(err)
+	}
+	rpfKey := utils.ConcatenatedKey(utils.MetaOut, "cgrates.org", "call", "1001")
+	oldActv := &RatingPlanActivation{
+		ActivationTime: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		RatingPlanId:   "RP_OLD",
+		FallbackKeys:   []string{utils.ConcatenatedKey(utils.MetaOut, "cgrates.org", "call", utils.MetaAny)},
+	}
+	if err := cdrS.dm.SetRatingProfile(&RatingProfile{Id: rpfKey,
+		RatingPlanActivations: RatingPlanActivations{oldActv}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{ID: "RERATE3",
+		RatingPlanID: "RP_NEW", ActivationTime: "2021-03-05T00:00:00Z"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if job := testRerateWaitJob(t, cdrS, reply); job.Status != utils.MetaCompleted ||
+		job.Processed != 2 || job.Failed != 0 {
+		t.Errorf("Unexpected job: %s", utils.ToJSON(job))
+	}
+	exp := RatingPlanActivations{oldActv, {
+		ActivationTime: time.Date(2021, time.March, 5, 0, 0, 0, 0, time.UTC),
+		RatingPlanId:   "RP_NEW",
+		FallbackKeys:   oldActv.FallbackKeys,
+	}}
+	if rpf, err := cdrS.dm.GetRatingProfile(rpfKey, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rpf.RatingPlanActivations) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rpf.RatingPlanActivations))
+	}
+}
+
+func TestCDRsV1StartRerateChargers(t *testing.T) {
+	cdrS := testRerateCDRServer(t)
+	cdrS.cgrCfg.CdrsCfg().ChargerSConns = cdrS.cgrCfg.CdrsCfg().RaterConns
+	cdrS.cgrCfg.CdrsCfg().StoreCdrs = false
+	// the second run of the first call, forked out of *default by ChargerS
+	if err := cdrS.cdrDb.SetCDR(&CDR{
+		CGRID:       utils.Sha1("rerate0"),
+		RunID:       "run2",
+		OrderID:     4,
+		OriginID:    "rerate0",
+		ToR:         utils.MetaVoice,
+		RequestType: utils.MetaRated,
+		Tenant:      "cgrates.org",
+		Category:    "call",
+		Account:     "1001",
+		Subject:     "1001",
+		Destination: "1002",
+		SetupTime:   time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC),
+		AnswerTime:  time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC),
+		Usage:       time.Minute,
+		Cost:        2,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	if err := cdrS.V1StartRerate(&ArgsStartRerate{ID: "RERATE4"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	job := testRerateWaitJob(t, cdrS, reply)
+	// each call is rerated once, out of its *default run
+	if job.Status != utils.MetaCompleted || job.Total != 3 || job.Processed != 3 || job.Failed != 0 {
+		t.Errorf("Unexpected job: %s", utils.ToJSON(job))
+	}
+	if job.CostDelta != 13 { // 3 calls with 2 runs of 3 each, out of an old cost of 1+2, 1 and 1
+		t.Errorf("Expected cost delta 13, received %v", job.CostDelta)
+	}
+}
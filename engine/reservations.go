@@ -0,0 +1,214 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// BalanceReservation is an amount held out of the account balances until it is committed, released or expires
+type BalanceReservation struct {
+	ID          string
+	BalanceType string
+	Amount      float64
+	Balances    map[string]float64 // amount held out of each balance, indexed on balance Uuid
+	ExpiryTime  time.Time
+}
+
+// isExpired returns true if the reservation expired at the given time
+func (rsv *BalanceReservation) isExpired(t time.Time) bool {
+	return !rsv.ExpiryTime.IsZero() && !rsv.ExpiryTime.After(t)
+}
+
+// Clone returns a copy of the reservation
+func (rsv *BalanceReservation) Clone() (cln *BalanceReservation) {
+	if rsv == nil {
+		return
+	}
+	cln = &BalanceReservation{
+		ID:          rsv.ID,
+		BalanceType: rsv.BalanceType,
+		Amount:      rsv.Amount,
+		ExpiryTime:  rsv.ExpiryTime,
+	}
+	if rsv.Balances != nil {
+		cln.Balances = make(map[string]float64, len(rsv.Balances))
+		for uuid, amount := range rsv.Balances {
+			cln.Balances[uuid] = amount
+		}
+	}
+	return
+}
+
+// reservationIndex returns the index of the reservation with the given ID or -1 if not found
+func (acc *Account) reservationIndex(rsvID string) int {
+	for i, rsv := range acc.Reservations {
+		if rsv.ID == rsvID {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReserveBalance holds the amount out of the active balances of the given type
+// the balances are used in the order they would be debited, restricted to blcID if not empty
+func (acc *Account) ReserveBalance(rsvID, blcType, blcID string, amount float64, expiryTime time.Time) (err error) {
+	if amount <= 0 {
+		return utils.ErrNegative
+	}
+	acc.removeExpiredReservations()
+	if acc.reservationIndex(rsvID) != -1 {
+		return utils.ErrExists
+	}
+	now := time.Now()
+	var available float64
+	var blcs Balances
+	for _, b := range acc.BalanceMap[blcType] {
+		if (blcID != utils.EmptyString && b.ID != blcID) ||
+			!b.IsActiveAt(now) || b.GetValue() <= 0 {
+			continue
+		}
+		blcs = append(blcs, b)
+		available += b.GetValue()
+	}
+	if available < amount {
+		return utils.ErrInsufficientCredit
+	}
+	blcs.Sort()
+	rsv := &BalanceReservation{
+		ID:          rsvID,
+		BalanceType: blcType,
+		Amount:      amount,
+		Balances:    make(map[string]float64),
+		ExpiryTime:  expiryTime,
+	}
+	for _, b := range blcs {
+		held := math.Min(b.GetValue(), amount)
+		b.SubstractValue(held)
+		rsv.Balances[b.Uuid] = held
+		if amount = utils.Round(amount-held, globalRoundingDecimals, utils.MetaRoundingMiddle); amount <= 0 {
+			break
+		}
+	}
+	acc.Reservations = append(acc.Reservations, rsv)
+	return
+}
+
+// CommitReservation debits the amount out of the reservation returning the rest of it to the balances
+// the whole reserved amount is debited if amount is nil
+func (acc *Account) CommitReservation(rsvID string, amount *float64) (err error) {
+	if amount != nil && *amount < 0 {
+		return utils.ErrNegative
+	}
+	acc.removeExpiredReservations()
+	idx := acc.reservationIndex(rsvID)
+	if idx == -1 {
+		return utils.ErrNotFound
+	}
+	var rest float64
+	if amount != nil {
+		if *amount > acc.Reservations[idx].Amount {
+			return utils.ErrInsufficientCredit
+		}
+		rest = acc.Reservations[idx].Amount - *amount
+	}
+	acc.releaseReservation(idx, rest)
+	return
+}
+
+// ReleaseReservation returns the whole reserved amount to the balances
+func (acc *Account) ReleaseReservation(rsvID string) (err error) {
+	acc.removeExpiredReservations()
+	idx := acc.reservationIndex(rsvID)
+	if idx == -1 {
+		return utils.ErrNotFound
+	}
+	acc.releaseReservation(idx, acc.Reservations[idx].Amount)
+	return
+}
+
+// removeExpiredReservations releases the reservations which expired returning their IDs
+func (acc *Account) removeExpiredReservations() (rsvIDs []string) {
+	now := time.Now()
+	for i := 0; i < len(acc.Reservations); {
+		if !acc.Reservations[i].isExpired(now) {
+			i++
+			continue
+		}
+		rsvIDs = append(rsvIDs, acc.Reservations[i].ID)
+		acc.releaseReservation(i, acc.Reservations[i].Amount)
+	}
+	return
+}
+
+// storeExpiredReservations releases and stores the expired reservations of the account
+// runs in its own goroutine since the account lock can be held by the one reading the account
+func (dm *DataManager) storeExpiredReservations(accID string) {
+	if err := guardian.Guardian.Guard(func() (err error) {
+		var acc *Account
+		if acc, err = dm.dataDB.GetAccountDrv(accID); err != nil {
+			return
+		}
+		if config.CgrConfig().RalsCfg().BalanceLedger {
+			acc.setLedgerSnapshot()
+		}
+		rsvIDs := acc.removeExpiredReservations()
+		if len(rsvIDs) == 0 { // already stored by the lock holder
+			return
+		}
+		acc.SetLedgerSource(utils.MetaRALs, strings.Join(rsvIDs, utils.InfieldSep))
+		return dm.SetAccount(acc)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> storing the expired reservations of account <%s>",
+			utils.RALService, err.Error(), accID))
+	}
+}
+
+// releaseReservation removes the reservation at index returning up to amount to its balances
+// the balances debited last get their amount back first
+func (acc *Account) releaseReservation(idx int, amount float64) {
+	rsv := acc.Reservations[idx]
+	acc.Reservations = append(acc.Reservations[:idx], acc.Reservations[idx+1:]...)
+	if len(acc.Reservations) == 0 {
+		acc.Reservations = nil
+	}
+	blcs := make(Balances, 0, len(rsv.Balances))
+	for _, b := range acc.BalanceMap[rsv.BalanceType] {
+		if _, has := rsv.Balances[b.Uuid]; has {
+			blcs = append(blcs, b)
+		}
+	}
+	blcs.Sort()
+	for i := len(blcs) - 1; i >= 0 && amount > 0; i-- {
+		held := math.Min(rsv.Balances[blcs[i].Uuid], amount)
+		blcs[i].AddValue(held)
+		amount = utils.Round(amount-held, globalRoundingDecimals, utils.MetaRoundingMiddle)
+	}
+	if amount > 0 {
+		utils.Logger.Warning(fmt.Sprintf("<%s> could not return %v out of reservation <%s> on account <%s>, balances removed",
+			utils.RALService, amount, rsv.ID, acc.ID))
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAccountReserveBalance(t *testing.T) {
+	acc := &Account{
+		ID: "cgrates.org:rsv1",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {
+				&Balance{Uuid: "uuid1", ID: "B1", Value: 5, Weight: 20},
+				&Balance{Uuid: "uuid2", ID: "B2", Value: 10, Weight: 10},
+				&Balance{Uuid: "uuid3", ID: "B3", Value: 100, Disabled: true},
+			},
+		},
+	}
+	if err := acc.ReserveBalance("RSV1", utils.MetaMonetary, utils.EmptyString, 20,
+		time.Now().Add(time.Hour)); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	if err := acc.ReserveBalance("RSV1", utils.MetaMonetary, utils.EmptyString, 8,
+		time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.ReserveBalance("RSV1", utils.MetaMonetary, utils.EmptyString, 1,
+		time.Now().Add(time.Hour)); err != utils.ErrExists {
+		t.Errorf("Expected %v, received %v", utils.ErrExists, err)
+	}
+	blcs := acc.BalanceMap[utils.MetaMonetary]
+	if blcs[0].GetValue() != 0 || blcs[1].GetValue() != 7 {
+		t.Errorf("Unexpected balances: %s", utils.ToJSON(blcs))
+	}
+	if rsv := acc.Reservations[0]; rsv.Balances["uuid1"] != 5 || rsv.Balances["uuid2"] != 3 {
+		t.Errorf("Unexpected reservation: %s", utils.ToJSON(rsv))
+	}
+	// the rest of the commit goes back to the balance debited last
+	if err := acc.CommitReservation("RSV1", utils.Float64Pointer(6)); err != nil {
+		t.Fatal(err)
+	}
+	if blcs[0].GetValue() != 0 || blcs[1].GetValue() != 9 || acc.Reservations != nil {
+		t.Errorf("Unexpected account: %s", utils.ToJSON(acc))
+	}
+	if err := acc.CommitReservation("RSV1", nil); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	if err := acc.ReserveBalance("RSV2", utils.MetaMonetary, "B2", 4,
+		time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.CommitReservation("RSV2", utils.Float64Pointer(-1)); err != utils.ErrNegative {
+		t.Errorf("Expected %v, received %v", utils.ErrNegative, err)
+	}
+	if err := acc.ReserveBalance("RSV3", utils.MetaMonetary, utils.EmptyString, -1,
+		time.Now().Add(time.Hour)); err != utils.ErrNegative {
+		t.Errorf("Expected %v, received %v", utils.ErrNegative, err)
+	}
+	if err := acc.CommitReservation("RSV2", utils.Float64Pointer(5)); err != utils.ErrInsufficientCredit {
+		t.Errorf("Expected %v, received %v", utils.ErrInsufficientCredit, err)
+	}
+	if err := acc.ReleaseReservation("RSV2"); err != nil {
+		t.Fatal(err)
+	}
+	if blcs[1].GetValue() != 9 || acc.Reservations != nil {
+		t.Errorf("Unexpected account: %s", utils.ToJSON(acc))
+	}
+}
+
+func TestAccountReservationExpired(t *testing.T) {
+	acc := &Account{
+		ID: "cgrates.org:rsv2",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {&Balance{Uuid: "uuid1", ID: "B1", Value: 10}},
+		},
+	}
+	if err := acc.ReserveBalance("RSV2", utils.MetaMonetary, utils.EmptyString, 3,
+		time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.ReserveBalance("RSV1", utils.MetaMonetary, utils.EmptyString, 4,
+		time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := dm.GetAccount(acc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rcv.Reservations) != 1 || rcv.Reservations[0].ID != "RSV2" {
+		t.Errorf("Unexpected reservations: %s", utils.ToJSON(rcv.Reservations))
+	}
+	if val := rcv.BalanceMap[utils.MetaMonetary][0].GetValue(); val != 7 {
+		t.Errorf("Expected 7 available, received %v", val)
+	}
+	if cln := rcv.Clone(); !cln.Reservations[0].ExpiryTime.Equal(rcv.Reservations[0].ExpiryTime) ||
+		cln.Reservations[0].Balances["uuid1"] != 3 {
+		t.Errorf("Unexpected clone: %s", utils.ToJSON(cln))
+	}
+	// the release is stored in background
+	for i := 0; i < 100; i++ {
+		if stored, err := dm.DataDB().GetAccountDrv(acc.ID); err != nil {
+			t.Fatal(err)
+		} else if len(stored.Reservations) == 1 {
+			if val := stored.BalanceMap[utils.MetaMonetary][0].GetValue(); val != 7 {
+				t.Errorf("Expected 7 stored, received %v", val)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expired reservation release was not stored")
+}
@@ -19,6 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -45,6 +52,261 @@ func (qos *QOSRouteSorter) SortRoutes(prflID string, routes map[string]*Route,
 			sortedRoutes.Routes = append(sortedRoutes.Routes, srtSpl)
 		}
 	}
+	if qe := extraOpts.qosExclusion; qe != nil {
+		updates := qos.rS.qosStatus.applyExclusion(qe, sortedRoutes, time.Now())
+		qos.rS.processThresholds(qe.tenant, prflID, updates)
+	}
 	sortedRoutes.SortQOS(extraOpts.sortingParameters)
 	return
 }
+
+// RouteQOSStatus is the status of a route blacklisted by the *qos exclusion rules
+type RouteQOSStatus struct {
+	RouteID  string
+	Status   string    // *blacklisted, *probing or *restored
+	Since    time.Time // time of the last status change
+	MetricID string    // the metric which excluded the route
+	Value    float64   // the value of the metric when the route was excluded
+}
+
+// Clone returns a copy of the RouteQOSStatus
+func (rs *RouteQOSStatus) Clone() *RouteQOSStatus {
+	cln := *rs
+	return &cln
+}
+
+// qosExclusionRule blacklists a route when its metric crosses the exclude threshold,
+// the route being restored only once the metric is past the restore threshold (hysteresis)
+type qosExclusionRule struct {
+	metricID string
+	operator string // one of *lt, *lte, *gt or *gte
+	exclude  float64
+	restore  float64
+}
+
+// excludes checks if the metric value crossed the exclude threshold
+func (er *qosExclusionRule) excludes(val float64) bool {
+	return compareQOSMetric(er.operator, val, er.exclude)
+}
+
+// restores checks if the metric value is past the restore threshold
+func (er *qosExclusionRule) restores(val float64) bool {
+	return !compareQOSMetric(er.operator, val, er.restore)
+}
+
+func compareQOSMetric(operator string, val, threshold float64) bool {
+	switch operator {
+	case utils.MetaLessThan:
+		return val < threshold
+	case utils.MetaLessOrEqual:
+		return val <= threshold
+	case utils.MetaGreaterThan:
+		return val > threshold
+	default: // *gte
+		return val >= threshold
+	}
+}
+
+// newQOSExclusionRule parses the rule out of *exclude:MetricID:Operator:ExcludeThreshold[:RestoreThreshold]
+func newQOSExclusionRule(param string) (er *qosExclusionRule, err error) {
+	splt := strings.Split(param, utils.ConcatenatedKeySep)
+	if len(splt) != 4 && len(splt) != 5 {
+		return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaExclude, param)
+	}
+	er = &qosExclusionRule{
+		metricID: splt[1],
+		operator: splt[2],
+	}
+	switch er.operator {
+	case utils.MetaLessThan, utils.MetaLessOrEqual,
+		utils.MetaGreaterThan, utils.MetaGreaterOrEqual:
+	default:
+		return nil, fmt.Errorf("unsupported operator <%s> for %s sorting parameter: <%s>",
+			er.operator, utils.MetaExclude, param)
+	}
+	if er.exclude, err = strconv.ParseFloat(splt[3], 64); err != nil {
+		return nil, err
+	}
+	er.restore = er.exclude
+	if len(splt) == 5 {
+		if er.restore, err = strconv.ParseFloat(splt[4], 64); err != nil {
+			return nil, err
+		}
+	}
+	if (er.operator == utils.MetaLessThan || er.operator == utils.MetaLessOrEqual) &&
+		er.restore < er.exclude ||
+		(er.operator == utils.MetaGreaterThan || er.operator == utils.MetaGreaterOrEqual) &&
+			er.restore > er.exclude {
+		return nil, fmt.Errorf("restore threshold inside the excluded interval for %s sorting parameter: <%s>",
+			utils.MetaExclude, param)
+	}
+	return
+}
+
+// qosExclusion holds the exclusion rules of a *qos RouteProfile
+type qosExclusion struct {
+	tenant            string
+	sortingParameters []string // the metrics used for sorting, without the exclusion parameters
+	rules             []*qosExclusionRule
+	cooldown          time.Duration // 0 keeps the route blacklisted until its metrics recover or become unavailable
+	probeRatio        float64       // share of the queries returning a route after the cooldown
+}
+
+// newQOSExclusion parses the exclusion parameters out of the *qos SortingParameters
+// returns nil if the profile has no exclusion rules defined
+func newQOSExclusion(tnt string, sortingParams []string) (qe *qosExclusion, err error) {
+	qe = &qosExclusion{
+		tenant:            tnt,
+		sortingParameters: make([]string, 0, len(sortingParams)),
+		probeRatio:        1,
+	}
+	var hasOpts bool
+	for _, param := range sortingParams {
+		switch {
+		case strings.HasPrefix(param, utils.MetaExclude+utils.ConcatenatedKeySep):
+			var er *qosExclusionRule
+			if er, err = newQOSExclusionRule(param); err != nil {
+				return nil, err
+			}
+			qe.rules = append(qe.rules, er)
+		case strings.HasPrefix(param, utils.MetaCooldown+utils.ConcatenatedKeySep):
+			if qe.cooldown, err = utils.ParseDurationWithNanosecs(
+				strings.TrimPrefix(param, utils.MetaCooldown+utils.ConcatenatedKeySep)); err != nil {
+				return nil, err
+			}
+			hasOpts = true
+		case strings.HasPrefix(param, utils.MetaProbe+utils.ConcatenatedKeySep):
+			if qe.probeRatio, err = strconv.ParseFloat(
+				strings.TrimPrefix(param, utils.MetaProbe+utils.ConcatenatedKeySep), 64); err != nil {
+				return nil, err
+			}
+			if qe.probeRatio <= 0 || qe.probeRatio > 1 {
+				return nil, fmt.Errorf("invalid %s sorting parameter: <%s>", utils.MetaProbe, param)
+			}
+			hasOpts = true
+		default:
+			qe.sortingParameters = append(qe.sortingParameters, param)
+		}
+	}
+	if len(qe.rules) == 0 {
+		if hasOpts {
+			return nil, fmt.Errorf("missing %s sorting parameters", utils.MetaExclude)
+		}
+		return nil, nil
+	}
+	return
+}
+
+// excluded returns the first rule excluding the route based on its metrics
+// the metrics not available yet are not considered
+func (qe *qosExclusion) excluded(metrics map[string]float64) (metricID string, val float64, excl bool) {
+	for _, er := range qe.rules {
+		var has bool
+		if val, has = metrics[er.metricID]; !has || val == utils.StatsNA {
+			continue
+		}
+		if er.excludes(val) {
+			return er.metricID, val, true
+		}
+	}
+	return utils.EmptyString, 0, false
+}
+
+// restored checks that all the metrics of the rules are past the restore thresholds
+func (qe *qosExclusion) restored(metrics map[string]float64) bool {
+	for _, er := range qe.rules {
+		if val, has := metrics[er.metricID]; !has || val == utils.StatsNA ||
+			!er.restores(val) {
+			return false
+		}
+	}
+	return true
+}
+
+// unavailable checks if any of the metrics of the rules is not available
+// (ie: the blacklisted route got no traffic for the StatQueue TTL)
+func (qe *qosExclusion) unavailable(metrics map[string]float64) bool {
+	for _, er := range qe.rules {
+		if val, has := metrics[er.metricID]; !has || val == utils.StatsNA {
+			return true
+		}
+	}
+	return false
+}
+
+// probe decides if the route in probing is returned for the current query
+func (qe *qosExclusion) probe() bool {
+	return qe.probeRatio >= 1 || rand.Float64() < qe.probeRatio
+}
+
+func newQOSRoutesStatus() *qosRoutesStatus {
+	return &qosRoutesStatus{routes: make(map[string]map[string]*RouteQOSStatus)}
+}
+
+// qosRoutesStatus is the registry of the blacklisted routes indexed on profile tenantID and route ID
+type qosRoutesStatus struct {
+	sync.Mutex
+	routes map[string]map[string]*RouteQOSStatus
+}
+
+// applyExclusion removes the blacklisted routes out of sRoutes, reporting them in sRoutes.Blacklist
+// returns the status changes so they can be sent to ThresholdS
+func (qs *qosRoutesStatus) applyExclusion(qe *qosExclusion, sRoutes *SortedRoutes,
+	now time.Time) (updates []*RouteQOSStatus) {
+	tntID := utils.ConcatenatedKey(qe.tenant, sRoutes.ProfileID)
+	qs.Lock()
+	defer qs.Unlock()
+	prflSts := qs.routes[tntID]
+	routes := make([]*SortedRoute, 0, len(sRoutes.Routes))
+	for _, sRoute := range sRoutes.Routes {
+		rSts, has := prflSts[sRoute.RouteID]
+		if !has {
+			metricID, val, excl := qe.excluded(sRoute.sortingDataF64)
+			if !excl {
+				routes = append(routes, sRoute)
+				continue
+			}
+			rSts = &RouteQOSStatus{
+				RouteID:  sRoute.RouteID,
+				Status:   utils.MetaBlacklisted,
+				Since:    now,
+				MetricID: metricID,
+				Value:    val,
+			}
+			if prflSts == nil {
+				prflSts = make(map[string]*RouteQOSStatus)
+				qs.routes[tntID] = prflSts
+			}
+			prflSts[sRoute.RouteID] = rSts
+			updates = append(updates, rSts.Clone())
+			sRoutes.Blacklist = append(sRoutes.Blacklist, rSts.Clone())
+			continue
+		}
+		if qe.restored(sRoute.sortingDataF64) {
+			delete(prflSts, sRoute.RouteID)
+			updates = append(updates, &RouteQOSStatus{
+				RouteID: sRoute.RouteID,
+				Status:  utils.MetaRestored,
+				Since:   now,
+			})
+			routes = append(routes, sRoute)
+			continue
+		}
+		if rSts.Status == utils.MetaBlacklisted &&
+			(qe.cooldown != 0 && now.Sub(rSts.Since) >= qe.cooldown || // cooldown passed, start probing
+				qe.unavailable(sRoute.sortingDataF64)) { // nothing left to restore on, probe for fresh metrics
+			rSts.Status = utils.MetaProbing
+			rSts.Since = now
+			updates = append(updates, rSts.Clone())
+		}
+		sRoutes.Blacklist = append(sRoutes.Blacklist, rSts.Clone())
+		if rSts.Status == utils.MetaProbing && qe.probe() {
+			routes = append(routes, sRoute)
+		}
+	}
+	if len(prflSts) == 0 {
+		delete(qs.routes, tntID)
+	}
+	sRoutes.Routes = routes
+	return
+}
@@ -56,6 +56,8 @@ type RouteProfile struct {
 	SortingParameters  []string
 	Routes             []*Route
 	Weight             float64
+
+	qosExclusion *qosExclusion // exclusion rules parsed out of the *qos SortingParameters
 }
 
 // RouteProfileWithAPIOpts is used in replicatorV1 for dispatcher
@@ -91,6 +93,12 @@ func (rp *RouteProfile) compileCacheParameters() error {
 			}
 		}
 	}
+	if rp.Sorting == utils.MetaQOS {
+		var err error
+		if rp.qosExclusion, err = newQOSExclusion(rp.Tenant, rp.SortingParameters); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -116,10 +124,11 @@ func (lps RouteProfiles) Sort() {
 func NewRouteService(dm *DataManager,
 	filterS *FilterS, cgrcfg *config.CGRConfig, connMgr *ConnManager) (rS *RouteService) {
 	rS = &RouteService{
-		dm:      dm,
-		filterS: filterS,
-		cgrcfg:  cgrcfg,
-		connMgr: connMgr,
+		dm:        dm,
+		filterS:   filterS,
+		cgrcfg:    cgrcfg,
+		connMgr:   connMgr,
+		qosStatus: newQOSRoutesStatus(),
 	}
 	rS.sorter = NewRouteSortDispatcher(rS)
 	return
@@ -127,11 +136,12 @@ func NewRouteService(dm *DataManager,
 
 // RouteService is the service computing route queries
 type RouteService struct {
-	dm      *DataManager
-	filterS *FilterS
-	cgrcfg  *config.CGRConfig
-	sorter  RouteSortDispatcher
-	connMgr *ConnManager
+	dm        *DataManager
+	filterS   *FilterS
+	cgrcfg    *config.CGRConfig
+	sorter    RouteSortDispatcher
+	connMgr   *ConnManager
+	qosStatus *qosRoutesStatus // routes blacklisted by the *qos exclusion rules
 }
 
 // Shutdown is called to shutdown the service
@@ -372,6 +382,38 @@ func (rpS *RouteService) resourceUsage(resIDs []string, tenant string) (tUsage f
 	return
 }
 
+// processThresholds sends the status changes of the routes blacklisted by the *qos exclusion rules to ThresholdS
+func (rpS *RouteService) processThresholds(tnt, prflID string, updates []*RouteQOSStatus) {
+	if len(rpS.cgrcfg.RouteSCfg().ThresholdSConns) == 0 {
+		return
+	}
+	for _, upd := range updates {
+		thEv := &utils.CGREvent{
+			Tenant: tnt,
+			ID:     utils.GenUUID(),
+			Event: map[string]interface{}{
+				utils.EventType: utils.RouteUpdate,
+				utils.ProfileID: prflID,
+				utils.RouteID:   upd.RouteID,
+				utils.Status:    upd.Status,
+				utils.MetricID:  upd.MetricID,
+				utils.Value:     upd.Value,
+			},
+			APIOpts: map[string]interface{}{
+				utils.MetaEventType: utils.RouteUpdate,
+			},
+		}
+		var tIDs []string
+		if err := rpS.connMgr.Call(rpS.cgrcfg.RouteSCfg().ThresholdSConns, nil,
+			utils.ThresholdSv1ProcessEvent, thEv, &tIDs); err != nil &&
+			err.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing event %+v with %s.",
+					utils.RouteS, err.Error(), thEv, utils.ThresholdS))
+		}
+	}
+}
+
 func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 	extraOpts *optsGetRoutes) (srtRoute *SortedRoute, pass bool, err error) {
 	sortedSpl := &SortedRoute{
@@ -554,6 +596,7 @@ type optsGetRoutes struct {
 	paginator         *utils.Paginator
 	sortingParameters []string //used for QOS strategy
 	sortingStrategy   string
+	qosExclusion      *qosExclusion // exclusion rules for QOS strategy
 }
 
 // V1GetRoutes returns the list of valid routes
@@ -624,6 +667,10 @@ func (rpS *RouteService) sortedRoutesForProfile(tnt string, rPrfl *RouteProfile,
 	pag utils.Paginator, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
 	extraOpts.sortingParameters = rPrfl.SortingParameters // populate sortingParameters in extraOpts
 	extraOpts.sortingStrategy = rPrfl.Sorting             // populate sortingStrategy in extraOpts
+	extraOpts.qosExclusion = rPrfl.qosExclusion
+	if rPrfl.qosExclusion != nil { // sort only on the metrics, without the exclusion parameters
+		extraOpts.sortingParameters = rPrfl.qosExclusion.sortingParameters
+	}
 	//construct the DP and pass it to filterS
 	nM := utils.MapStorage{
 		utils.MetaReq:  ev.Event,
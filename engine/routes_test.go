@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/rpcclient"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -756,3 +757,257 @@ func TestRoutesSortedForEventWithLimitAndOffset2(t *testing.T) {
 		t.Errorf("Expecting: %+v,received: %+v", utils.ToJSON(eFirstRouteProfile), utils.ToJSON(sprf))
 	}
 }
+
+func TestRoutesQOSExclusionCompile(t *testing.T) {
+	rPrf := &RouteProfile{
+		Tenant:  "cgrates.org",
+		ID:      "ROUTE_QOS",
+		Sorting: utils.MetaQOS,
+		SortingParameters: []string{utils.MetaASR, utils.MetaACD,
+			"*exclude:*asr:*lt:10:20", "*exclude:*pdd:*gt:5", "*cooldown:1m", "*probe:0.5"},
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	exp := &qosExclusion{
+		tenant:            "cgrates.org",
+		sortingParameters: []string{utils.MetaASR, utils.MetaACD},
+		rules: []*qosExclusionRule{
+			{metricID: utils.MetaASR, operator: utils.MetaLessThan, exclude: 10, restore: 20},
+			{metricID: utils.MetaPDD, operator: utils.MetaGreaterThan, exclude: 5, restore: 5},
+		},
+		cooldown:   time.Minute,
+		probeRatio: 0.5,
+	}
+	if !reflect.DeepEqual(exp, rPrf.qosExclusion) {
+		t.Errorf("Expected %+v, received %+v", exp, rPrf.qosExclusion)
+	}
+	rPrf.SortingParameters = []string{utils.MetaASR}
+	if err := rPrf.Compile(); err != nil {
+		t.Error(err)
+	} else if rPrf.qosExclusion != nil {
+		t.Errorf("Expected no exclusion, received %+v", rPrf.qosExclusion)
+	}
+	for _, params := range [][]string{
+		{"*exclude:*asr:*lt"},
+		{"*exclude:*asr:*eq:10"},
+		{"*exclude:*asr:*lt:10:5"},
+		{"*exclude:*acd:*gte:10:20"},
+		{"*exclude:*asr:*lt:10", "*probe:2"},
+		{"*cooldown:1m"},
+	} {
+		rPrf.SortingParameters = params
+		if err := rPrf.Compile(); err == nil {
+			t.Errorf("Expected error for %+v", params)
+		}
+	}
+}
+
+func TestRoutesQOSExclusionHysteresis(t *testing.T) {
+	qe, err := newQOSExclusion("cgrates.org", []string{utils.MetaASR,
+		"*exclude:*asr:*lt:10:20", "*cooldown:1m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	qs := newQOSRoutesStatus()
+	sortedRoutes := func(asr float64) *SortedRoutes {
+		return &SortedRoutes{
+			ProfileID: "ROUTE_QOS",
+			Sorting:   utils.MetaQOS,
+			Routes: []*SortedRoute{
+				{RouteID: "route1", sortingDataF64: map[string]float64{utils.MetaASR: 50}},
+				{RouteID: "route2", sortingDataF64: map[string]float64{utils.MetaASR: asr}},
+			},
+		}
+	}
+	tm := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	// not enough data in stats yet
+	sRts := sortedRoutes(utils.StatsNA)
+	if upd := qs.applyExclusion(qe, sRts, tm); len(upd) != 0 {
+		t.Errorf("Unexpected updates: %s", utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); len(rIDs) != 2 {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	}
+	sRts = sortedRoutes(5)
+	expSts := &RouteQOSStatus{
+		RouteID:  "route2",
+		Status:   utils.MetaBlacklisted,
+		Since:    tm,
+		MetricID: utils.MetaASR,
+		Value:    5,
+	}
+	if upd := qs.applyExclusion(qe, sRts, tm); !reflect.DeepEqual([]*RouteQOSStatus{expSts}, upd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expSts), utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	} else if !reflect.DeepEqual([]*RouteQOSStatus{expSts}, sRts.Blacklist) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expSts), utils.ToJSON(sRts.Blacklist))
+	}
+	// above the exclude threshold but under the restore one
+	sRts = sortedRoutes(15)
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(time.Second)); len(upd) != 0 {
+		t.Errorf("Unexpected updates: %s", utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	}
+	// cooldown passed, the route is probed
+	sRts = sortedRoutes(15)
+	expSts.Status = utils.MetaProbing
+	expSts.Since = tm.Add(time.Minute)
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(time.Minute)); !reflect.DeepEqual([]*RouteQOSStatus{expSts}, upd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expSts), utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1", "route2"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	} else if !reflect.DeepEqual([]*RouteQOSStatus{expSts}, sRts.Blacklist) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expSts), utils.ToJSON(sRts.Blacklist))
+	}
+	sRts = sortedRoutes(20)
+	expUpd := []*RouteQOSStatus{{
+		RouteID: "route2",
+		Status:  utils.MetaRestored,
+		Since:   tm.Add(2 * time.Minute),
+	}}
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(2*time.Minute)); !reflect.DeepEqual(expUpd, upd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expUpd), utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1", "route2"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	} else if sRts.Blacklist != nil {
+		t.Errorf("Unexpected blacklist: %s", utils.ToJSON(sRts.Blacklist))
+	}
+	if len(qs.routes) != 0 {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(qs.routes))
+	}
+}
+
+func TestRoutesQOSExclusionMetricsUnavailable(t *testing.T) {
+	qe, err := newQOSExclusion("cgrates.org", []string{utils.MetaASR,
+		"*exclude:*asr:*lt:10:20"}) // no cooldown
+	if err != nil {
+		t.Fatal(err)
+	}
+	qs := newQOSRoutesStatus()
+	sortedRoutes := func(asr float64) *SortedRoutes {
+		return &SortedRoutes{
+			ProfileID: "ROUTE_QOS",
+			Sorting:   utils.MetaQOS,
+			Routes: []*SortedRoute{
+				{RouteID: "route1", sortingDataF64: map[string]float64{utils.MetaASR: asr}},
+			},
+		}
+	}
+	tm := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	if upd := qs.applyExclusion(qe, sortedRoutes(5), tm); len(upd) != 1 ||
+		upd[0].Status != utils.MetaBlacklisted {
+		t.Errorf("Unexpected updates: %s", utils.ToJSON(upd))
+	}
+	sRts := sortedRoutes(15)
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(time.Hour)); len(upd) != 0 {
+		t.Errorf("Unexpected updates: %s", utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); len(rIDs) != 0 {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	}
+	// the metrics expired without traffic, the route is probed even without cooldown
+	sRts = sortedRoutes(utils.StatsNA)
+	expSts := &RouteQOSStatus{
+		RouteID:  "route1",
+		Status:   utils.MetaProbing,
+		Since:    tm.Add(2 * time.Hour),
+		MetricID: utils.MetaASR,
+		Value:    5,
+	}
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(2*time.Hour)); !reflect.DeepEqual([]*RouteQOSStatus{expSts}, upd) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expSts), utils.ToJSON(upd))
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	}
+	sRts = sortedRoutes(25)
+	if upd := qs.applyExclusion(qe, sRts, tm.Add(3*time.Hour)); len(upd) != 1 ||
+		upd[0].Status != utils.MetaRestored {
+		t.Errorf("Unexpected updates: %s", utils.ToJSON(upd))
+	} else if len(qs.routes) != 0 {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(qs.routes))
+	}
+}
+
+func TestRoutesQOSExclusionThresholds(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().StatSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats)}
+	cfg.RouteSCfg().ThresholdSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)}
+	var thEvs []*utils.CGREvent
+	ccM := &ccMock{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.StatSv1GetQueueFloatMetrics: func(args, reply interface{}) error {
+				metrics := map[string]float64{utils.MetaASR: 60}
+				if args.(*utils.TenantIDWithAPIOpts).ID == "STAT_2" {
+					metrics[utils.MetaASR] = 8
+				}
+				*reply.(*map[string]float64) = metrics
+				return nil
+			},
+			utils.ThresholdSv1ProcessEvent: func(args, reply interface{}) error {
+				thEvs = append(thEvs, args.(*utils.CGREvent))
+				return nil
+			},
+		},
+	}
+	statsConn := make(chan rpcclient.ClientConnector, 1)
+	statsConn <- ccM
+	thdsConn := make(chan rpcclient.ClientConnector, 1)
+	thdsConn <- ccM
+	rpS := NewRouteService(nil, nil, cfg, NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):      statsConn,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): thdsConn,
+	}))
+	rPrf := &RouteProfile{
+		Tenant:            "cgrates.org",
+		ID:                "ROUTE_QOS",
+		Sorting:           utils.MetaQOS,
+		SortingParameters: []string{utils.MetaASR, "*exclude:*asr:*lt:10:20"},
+		Routes: []*Route{
+			{ID: "route1", StatIDs: []string{"STAT_1"}, Weight: 10},
+			{ID: "route2", StatIDs: []string{"STAT_2"}, Weight: 20},
+		},
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event:  map[string]interface{}{utils.AccountField: "1001"},
+	}
+	rpS.filterS = NewFilterS(cfg, nil, nil)
+	sRts, err := rpS.sortedRoutesForProfile("cgrates.org", rPrf, ev, utils.Paginator{}, &optsGetRoutes{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	}
+	if len(sRts.Blacklist) != 1 || sRts.Blacklist[0].RouteID != "route2" ||
+		sRts.Blacklist[0].Status != utils.MetaBlacklisted {
+		t.Errorf("Unexpected blacklist: %s", utils.ToJSON(sRts.Blacklist))
+	}
+	if len(thEvs) != 1 {
+		t.Fatalf("Expected one event to ThresholdS, received: %s", utils.ToJSON(thEvs))
+	}
+	expEv := map[string]interface{}{
+		utils.EventType: utils.RouteUpdate,
+		utils.ProfileID: "ROUTE_QOS",
+		utils.RouteID:   "route2",
+		utils.Status:    utils.MetaBlacklisted,
+		utils.MetricID:  utils.MetaASR,
+		utils.Value:     8.,
+	}
+	if !reflect.DeepEqual(expEv, thEvs[0].Event) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expEv), utils.ToJSON(thEvs[0].Event))
+	}
+	// the route stays blacklisted without sending the same update again
+	if sRts, err = rpS.sortedRoutesForProfile("cgrates.org", rPrf, ev, utils.Paginator{}, &optsGetRoutes{}); err != nil {
+		t.Fatal(err)
+	} else if rIDs := sRts.RouteIDs(); !reflect.DeepEqual([]string{"route1"}, rIDs) {
+		t.Errorf("Unexpected routes: %+v", rIDs)
+	} else if len(thEvs) != 1 {
+		t.Errorf("Unexpected events to ThresholdS: %s", utils.ToJSON(thEvs))
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// StoredSession is the form in which an active session is backed up into DataDB
+type StoredSession struct {
+	CGRID         string
+	Tenant        string
+	ResourceID    string
+	ClientConnID  string
+	EventStart    MapEvent
+	DebitInterval time.Duration
+	Chargeable    bool
+	SRuns         []*StoredSRun
+	OptsStart     MapEvent
+}
+
+// StoredSRun is the form in which a session run is backed up into DataDB
+type StoredSRun struct {
+	Event         MapEvent
+	CD            *CallDescriptor
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	TotalUsage    time.Duration
+	NextAutoDebit *time.Time
+	RatingGroup   string
+}
+
+// Clone returns a deep copy of the StoredSession
+func (ss *StoredSession) Clone() (cln *StoredSession) {
+	cln = &StoredSession{
+		CGRID:         ss.CGRID,
+		Tenant:        ss.Tenant,
+		ResourceID:    ss.ResourceID,
+		ClientConnID:  ss.ClientConnID,
+		EventStart:    ss.EventStart.Clone(),
+		DebitInterval: ss.DebitInterval,
+		Chargeable:    ss.Chargeable,
+		OptsStart:     ss.OptsStart.Clone(),
+	}
+	if ss.SRuns != nil {
+		cln.SRuns = make([]*StoredSRun, len(ss.SRuns))
+		for i, sr := range ss.SRuns {
+			cln.SRuns[i] = sr.Clone()
+		}
+	}
+	return
+}
+
+// Clone returns a deep copy of the StoredSRun
+func (sr *StoredSRun) Clone() (cln *StoredSRun) {
+	cln = &StoredSRun{
+		Event:         sr.Event.Clone(),
+		ExtraDuration: sr.ExtraDuration,
+		LastUsage:     sr.LastUsage,
+		TotalUsage:    sr.TotalUsage,
+		RatingGroup:   sr.RatingGroup,
+	}
+	if sr.CD != nil {
+		cln.CD = sr.CD.Clone()
+	}
+	if sr.EventCost != nil {
+		cln.EventCost = sr.EventCost.Clone()
+	}
+	if sr.NextAutoDebit != nil {
+		cln.NextAutoDebit = utils.TimePointer(*sr.NextAutoDebit)
+	}
+	return
+}
@@ -20,6 +20,8 @@ package engine
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,20 +46,23 @@ type StatWithCompress struct {
 // cfg serves as general purpose container to pass config options to metric
 func NewStatMetric(metricID string, minItems int, filterIDs []string) (sm StatMetric, err error) {
 	metrics := map[string]func(int, string, []string) (StatMetric, error){
-		utils.MetaASR:      NewASR,
-		utils.MetaACD:      NewACD,
-		utils.MetaTCD:      NewTCD,
-		utils.MetaACC:      NewACC,
-		utils.MetaTCC:      NewTCC,
-		utils.MetaPDD:      NewPDD,
-		utils.MetaDDC:      NewDDC,
-		utils.MetaSum:      NewStatSum,
-		utils.MetaAverage:  NewStatAverage,
-		utils.MetaDistinct: NewStatDistinct,
+		utils.MetaASR:        NewASR,
+		utils.MetaACD:        NewACD,
+		utils.MetaTCD:        NewTCD,
+		utils.MetaACC:        NewACC,
+		utils.MetaTCC:        NewTCC,
+		utils.MetaPDD:        NewPDD,
+		utils.MetaDDC:        NewDDC,
+		utils.MetaSum:        NewStatSum,
+		utils.MetaAverage:    NewStatAverage,
+		utils.MetaDistinct:   NewStatDistinct,
+		utils.MetaPercentile: NewStatPercentile,
+		utils.MetaMedian:     NewStatMedian,
 	}
 	// split the metricID
 	// in case of *sum we have *sum#~*req.FieldName
-	metricSplit := strings.Split(metricID, utils.HashtagSep)
+	// in case of *percentile we have *percentile#95#~*req.FieldName
+	metricSplit := strings.SplitN(metricID, utils.HashtagSep, 2)
 	if _, has := metrics[metricSplit[0]]; !has {
 		return nil, fmt.Errorf("unsupported metric type <%s>", metricSplit[0])
 	}
@@ -1457,3 +1462,233 @@ func (dst *StatDistinct) GetCompressFactor(events map[string]int) map[string]int
 	}
 	return events
 }
+
+const (
+	sketchRelativeAccuracy = 0.01          // maximum relative error of the estimated quantiles
+	sketchMinValue         = 1e-9          // values smaller than this are counted as 0
+	sketchZeroIndex        = math.MinInt32 // index of the bucket counting the 0 values
+)
+
+var (
+	sketchGamma    = (1 + sketchRelativeAccuracy) / (1 - sketchRelativeAccuracy)
+	sketchLogGamma = math.Log(sketchGamma)
+)
+
+// StatSketch counts the values into buckets growing logarithmically so the quantiles
+// are estimated within sketchRelativeAccuracy with a number of buckets independent of the number of values
+type StatSketch map[int]int64 // map[bucketIndex]count
+
+// sketchIndex returns the index of the bucket counting the value
+func sketchIndex(val float64) int {
+	if val < sketchMinValue {
+		return sketchZeroIndex
+	}
+	return int(math.Ceil(math.Log(val) / sketchLogGamma))
+}
+
+// sketchValue returns the value representing the bucket
+func sketchValue(idx int) float64 {
+	if idx == sketchZeroIndex {
+		return 0
+	}
+	return 2 * math.Pow(sketchGamma, float64(idx)) / (sketchGamma + 1)
+}
+
+// indexes returns the sorted indexes of the buckets
+func (sk StatSketch) indexes() (idxs []int) {
+	idxs = make([]int, 0, len(sk))
+	for idx := range sk {
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	return
+}
+
+func (sk StatSketch) add(idx int, count int64) {
+	sk[idx] += count
+}
+
+func (sk StatSketch) rem(idx int, count int64) {
+	if sk[idx] -= count; sk[idx] <= 0 {
+		delete(sk, idx)
+	}
+}
+
+// quantile returns the estimated value of the q quantile (between 0 and 1)
+func (sk StatSketch) quantile(q float64) float64 {
+	idxs := sk.indexes()
+	if len(idxs) == 0 {
+		return 0
+	}
+	var count int64
+	for _, cnt := range sk {
+		count += cnt
+	}
+	rank := q * float64(count-1)
+	var cum int64
+	for _, idx := range idxs {
+		if cum += sk[idx]; float64(cum) > rank {
+			return sketchValue(idx)
+		}
+	}
+	return sketchValue(idxs[len(idxs)-1])
+}
+
+// NewStatPercentile instantiates the *percentile metric out of <percentile>#<FieldName>
+func NewStatPercentile(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	params := strings.SplitN(extraParams, utils.HashtagSep, 2)
+	if len(params) != 2 {
+		return nil, fmt.Errorf("invalid format for percentile metric <%s>", extraParams)
+	}
+	percentile, err := strconv.ParseFloat(params[0], 64)
+	if err != nil || percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("invalid percentile <%s>", params[0])
+	}
+	return newStatPercentile(minItems, percentile, params[1], filterIDs), nil
+}
+
+// NewStatMedian instantiates the *median metric, the 50th percentile of FieldName
+func NewStatMedian(minItems int, extraParams string, filterIDs []string) (StatMetric, error) {
+	return newStatPercentile(minItems, 50, extraParams, filterIDs), nil
+}
+
+func newStatPercentile(minItems int, percentile float64, fieldName string, filterIDs []string) *StatPercentile {
+	return &StatPercentile{Sketch: make(StatSketch), Events: make(map[string]StatSketch),
+		Percentile: percentile, MinItems: minItems, FieldName: fieldName, FilterIDs: filterIDs}
+}
+
+// StatPercentile implements the *percentile and *median metrics
+type StatPercentile struct {
+	FilterIDs  []string
+	Percentile float64
+	Sketch     StatSketch
+	Count      int64
+	Events     map[string]StatSketch // map[EventTenantID]map[bucketIndex]count
+	MinItems   int
+	FieldName  string
+	val        *float64 // cached percentile value
+}
+
+// getValue returns prc.val
+func (prc *StatPercentile) getValue(roundingDecimal int) float64 {
+	if prc.val == nil {
+		if (prc.MinItems > 0 && prc.Count < int64(prc.MinItems)) || (prc.Count == 0) {
+			prc.val = utils.Float64Pointer(utils.StatsNA)
+		} else {
+			prc.val = utils.Float64Pointer(utils.Round(prc.Sketch.quantile(prc.Percentile/100),
+				roundingDecimal, utils.MetaRoundingMiddle))
+		}
+	}
+	return *prc.val
+}
+
+func (prc *StatPercentile) GetStringValue(roundingDecimal int) (valStr string) {
+	if val := prc.getValue(roundingDecimal); val == utils.StatsNA {
+		valStr = utils.NotAvailable
+	} else {
+		valStr = strconv.FormatFloat(val, 'f', -1, 64)
+	}
+	return
+}
+
+func (prc *StatPercentile) GetValue(roundingDecimal int) (v interface{}) {
+	return prc.getValue(roundingDecimal)
+}
+
+func (prc *StatPercentile) GetFloat64Value(roundingDecimal int) (v float64) {
+	return prc.getValue(roundingDecimal)
+}
+
+func (prc *StatPercentile) AddEvent(evID string, ev utils.DataProvider) (err error) {
+	var val float64
+	var ival interface{}
+	if ival, err = utils.DPDynamicInterface(prc.FieldName, ev); err != nil {
+		if err == utils.ErrNotFound {
+			err = utils.ErrPrefix(err, prc.FieldName)
+		}
+		return
+	} else if val, err = utils.IfaceAsFloat64(ival); err != nil {
+		return
+	} else if val < 0 {
+		return utils.ErrPrefix(utils.ErrNegative, prc.FieldName)
+	}
+	idx := sketchIndex(val)
+	prc.Sketch.add(idx, 1)
+	if _, has := prc.Events[evID]; !has {
+		prc.Events[evID] = make(StatSketch)
+	}
+	prc.Events[evID].add(idx, 1)
+	prc.Count++
+	prc.val = nil
+	return
+}
+
+func (prc *StatPercentile) RemEvent(evID string) (err error) {
+	evSketch, has := prc.Events[evID]
+	if !has {
+		return utils.ErrNotFound
+	}
+	if len(evSketch) == 0 {
+		delete(prc.Events, evID)
+		return utils.ErrNotFound
+	}
+	idx := evSketch.indexes()[0] // compressed events are removed starting with the smallest value
+	evSketch.rem(idx, 1)
+	if len(evSketch) == 0 {
+		delete(prc.Events, evID)
+	}
+	prc.Sketch.rem(idx, 1)
+	prc.Count--
+	prc.val = nil
+	return
+}
+
+func (prc *StatPercentile) Marshal(ms Marshaler) (marshaled []byte, err error) {
+	return ms.Marshal(prc)
+}
+
+func (prc *StatPercentile) LoadMarshaled(ms Marshaler, marshaled []byte) (err error) {
+	return ms.Unmarshal(marshaled, prc)
+}
+
+// GetFilterIDs is part of StatMetric interface
+func (prc *StatPercentile) GetFilterIDs() []string {
+	return prc.FilterIDs
+}
+
+// GetMinItems returns the minim items for the metric
+func (prc *StatPercentile) GetMinItems() (minIts int) { return prc.MinItems }
+
+// Compress is part of StatMetric interface
+// the events are replaced by a copy of the sketch so the memory stays bounded
+func (prc *StatPercentile) Compress(queueLen int64, defaultID string, roundingDecimal int) (eventIDs []string) {
+	if prc.Count < queueLen {
+		for id := range prc.Events {
+			eventIDs = append(eventIDs, id)
+		}
+		return
+	}
+	evSketch := make(StatSketch, len(prc.Sketch))
+	for idx, count := range prc.Sketch {
+		evSketch[idx] = count
+	}
+	prc.Events = map[string]StatSketch{defaultID: evSketch}
+	return []string{defaultID}
+}
+
+// GetCompressFactor is part of StatMetric interface
+func (prc *StatPercentile) GetCompressFactor(events map[string]int) map[string]int {
+	for id, evSketch := range prc.Events {
+		compressFactor := 0
+		for _, count := range evSketch {
+			compressFactor += int(count)
+		}
+		if _, has := events[id]; !has {
+			events[id] = compressFactor
+		}
+		if events[id] < compressFactor {
+			events[id] = compressFactor
+		}
+	}
+	return events
+}
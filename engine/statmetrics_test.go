@@ -18,9 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"math"
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -4177,3 +4179,100 @@ func TestStatMetricsStatASRAddEventErr3(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Recevied <%+v>", utils.ErrAccountNotFound, err)
 	}
 }
+
+func TestStatPercentile(t *testing.T) {
+	if _, err := NewStatMetric("*percentile#~*req.Cost", 0, nil); err == nil {
+		t.Error("Expected error for missing percentile")
+	}
+	if _, err := NewStatMetric("*percentile#101#~*req.Cost", 0, nil); err == nil {
+		t.Error("Expected error for percentile out of range")
+	}
+	p95, err := NewStatMetric("*percentile#95#~*req.Cost", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	median, err := NewStatMetric("*median#~*req.Cost", 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p95.GetStringValue(2) != utils.NotAvailable {
+		t.Errorf("Expected %s, received %s", utils.NotAvailable, p95.GetStringValue(2))
+	}
+	for i := 1; i <= 100; i++ {
+		ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: i}}
+		evID := "EVENT_" + strconv.Itoa(i)
+		if err := p95.AddEvent(evID, ev); err != nil {
+			t.Fatal(err)
+		}
+		if err := median.AddEvent(evID, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if val := p95.GetFloat64Value(2); math.Abs(val-95) > 95*sketchRelativeAccuracy {
+		t.Errorf("Expected 95 within accuracy, received %v", val)
+	}
+	if val := median.GetFloat64Value(2); math.Abs(val-50) > 50*sketchRelativeAccuracy {
+		t.Errorf("Expected 50 within accuracy, received %v", val)
+	}
+	for i := 51; i <= 100; i++ {
+		if err := median.RemEvent("EVENT_" + strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if val := median.GetFloat64Value(2); math.Abs(val-25) > 25*sketchRelativeAccuracy {
+		t.Errorf("Expected 25 within accuracy, received %v", val)
+	}
+	if err := median.RemEvent("EVENT_100"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := median.AddEvent("EVENT_NEG", utils.MapStorage{utils.MetaReq: utils.MapStorage{
+		utils.Cost: -1}}); err == nil || err.Error() != "NEGATIVE:~*req.Cost" {
+		t.Errorf("Expected NEGATIVE error, received %v", err)
+	}
+}
+
+func TestStatPercentileCompress(t *testing.T) {
+	prc, _ := NewStatMedian(0, "~*req.Usage", nil)
+	for i, usage := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 0} {
+		if err := prc.AddEvent("EVENT_"+strconv.Itoa(i), utils.MapStorage{
+			utils.MetaReq: utils.MapStorage{utils.Usage: usage}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	val := prc.GetFloat64Value(-1)
+	if eIDs := prc.Compress(10, "EVENT_3", -1); len(eIDs) != 4 {
+		t.Errorf("Expected all the events, received %v", eIDs)
+	}
+	if eIDs := prc.Compress(4, "EVENT_3", -1); !reflect.DeepEqual([]string{"EVENT_3"}, eIDs) {
+		t.Errorf("Expected %v, received %v", []string{"EVENT_3"}, eIDs)
+	}
+	if rcv := prc.GetCompressFactor(make(map[string]int)); !reflect.DeepEqual(map[string]int{"EVENT_3": 4}, rcv) {
+		t.Errorf("Unexpected compress factor: %v", rcv)
+	}
+	if rcv := prc.GetFloat64Value(-1); rcv != val {
+		t.Errorf("Expected %v, received %v", val, rcv)
+	}
+	if err := prc.RemEvent("EVENT_3"); err != nil { // removes the 0 value
+		t.Fatal(err)
+	}
+	if rcv := prc.GetFloat64Value(-1); math.Abs(rcv-2e9) > 2e9*sketchRelativeAccuracy {
+		t.Errorf("Expected 2s within accuracy, received %v", rcv)
+	}
+}
+
+func TestStatPercentileMarshal(t *testing.T) {
+	prc, _ := NewStatPercentile(2, "99#~*req.Cost", []string{})
+	prc.AddEvent("EVENT_1", utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: "20"}})
+	prc.AddEvent("EVENT_2", utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: 0}})
+	for _, ms := range []Marshaler{&jMarshaler, NewCodecMsgpackMarshaler()} {
+		nPrc := new(StatPercentile)
+		if b, err := prc.Marshal(ms); err != nil {
+			t.Error(err)
+		} else if err := nPrc.LoadMarshaled(ms, b); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(prc.GetFloat64Value(2), nPrc.GetFloat64Value(2)) ||
+			!reflect.DeepEqual(prc.(*StatPercentile).Events, nPrc.Events) {
+			t.Errorf("Expected: %s , received: %s", utils.ToJSON(prc), utils.ToJSON(nPrc))
+		}
+	}
+}
@@ -222,6 +222,9 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 		if sqPrfl.TTL > 0 {
 			sq.ttl = utils.DurationPointer(sqPrfl.TTL)
 		}
+		if sqPrfl.WindowType == utils.MetaSliding && sqPrfl.WindowSize > 0 {
+			sq.ttl = utils.DurationPointer(sqPrfl.WindowSize) // the events leave the *sliding window after WindowSize
+		}
 		sq.sqPrfl = sqPrfl
 		sqs = append(sqs, sq)
 	}
@@ -250,14 +253,33 @@ func (sS *StatService) getStatQueue(tnt, id string) (sq *StatQueue, err error) {
 	if sq, err = sS.dm.GetStatQueue(tnt, id, true, true, utils.EmptyString); err != nil {
 		return
 	}
+	// roll the windows before expiring so the closed ones keep their items, as in ProcessEvent
+	var rolled bool
+	if rolled, err = sS.rollWindows(sq); err != nil {
+		return
+	}
 	var removed int
-	if removed, err = sq.remExpired(); err != nil || removed == 0 {
+	if removed, err = sq.remExpired(); err != nil ||
+		(removed == 0 && !rolled) {
 		return
 	}
 	sS.storeStatQueue(sq)
 	return
 }
 
+// rollWindows closes the ended windows of the queue based on its profile
+func (sS *StatService) rollWindows(sq *StatQueue) (rolled bool, err error) {
+	var sqPrfl *StatQueueProfile
+	if sqPrfl, err = sS.dm.GetStatQueueProfile(sq.Tenant, sq.ID,
+		true, true, utils.NonTransactional); err != nil {
+		if err == utils.ErrNotFound { // no profile, no windows
+			err = nil
+		}
+		return
+	}
+	return sq.rollWindows(sqPrfl, time.Now(), sS.cgrcfg.GeneralCfg().RoundingDecimals)
+}
+
 // storeStatQueue will store the sq if needed
 func (sS *StatService) storeStatQueue(sq *StatQueue) {
 	if sS.cgrcfg.StatSCfg().StoreInterval != 0 && sq.dirty != nil { // don't save
@@ -463,11 +485,39 @@ func (sS *StatService) V1GetQueueStringMetrics(args *utils.TenantID, reply *map[
 		}
 		return err
 	}
-	metrics := make(map[string]string, len(sq.SQMetrics))
-	for metricID, metric := range sq.SQMetrics {
-		metrics[metricID] = metric.GetStringValue(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	*reply = sq.stringMetrics(sS.cgrcfg.GeneralCfg().RoundingDecimals)
+	return
+}
+
+// V1GetQueueWindows returns the closed windows of the queue, oldest first
+func (sS *StatService) V1GetQueueWindows(args *utils.TenantID, reply *[]*StatWindow) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
 	}
-	*reply = metrics
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	// make sure statQueue is locked at process level
+	lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+		config.CgrConfig().GeneralCfg().LockingTimeout,
+		statQueueLockKey(tnt, args.ID))
+	defer guardian.Guardian.UnguardIDs(lkID)
+	sq, err := sS.getStatQueue(tnt, args.ID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	if len(sq.Windows) == 0 {
+		return utils.ErrNotFound
+	}
+	wnds := make([]*StatWindow, len(sq.Windows))
+	for i, wnd := range sq.Windows {
+		wnds[i] = wnd.Clone()
+	}
+	*reply = wnds
 	return
 }
 
@@ -537,17 +587,11 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 		true, true, utils.NonTransactional); err != nil {
 		return
 	}
-	sq.SQItems = make([]SQItem, 0)
-	metrics := sq.SQMetrics
-	sq.SQMetrics = make(map[string]StatMetric)
-	for id, m := range metrics {
-		var metric StatMetric
-		if metric, err = NewStatMetric(id,
-			m.GetMinItems(), m.GetFilterIDs()); err != nil {
-			return
-		}
-		sq.SQMetrics[id] = metric
+	if err = sq.reset(); err != nil {
+		return
 	}
+	sq.Windows = nil
+	sq.WindowEnd = nil
 	sq.dirty = utils.BoolPointer(true)
 	sS.storeStatQueue(sq)
 	*rply = utils.OK
@@ -3149,3 +3149,94 @@ func TestStatQueueStoreStatQueueCacheSetErr(t *testing.T) {
 
 	utils.Logger.SetLogLevel(0)
 }
+
+func TestStatQueueV1GetQueueWindows(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	sS := NewStatService(dm, cfg, NewFilterS(cfg, nil, dm), nil)
+
+	wndStart := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	sq := &StatQueue{
+		Tenant: "cgrates.org",
+		ID:     "SQ_WND",
+		SQMetrics: map[string]StatMetric{
+			utils.MetaTCC: &StatTCC{Events: make(map[string]*StatWithCompress)},
+		},
+		Windows: []*StatWindow{
+			{
+				Start:   wndStart,
+				End:     wndStart.Add(5 * time.Minute),
+				Metrics: map[string]string{utils.MetaTCC: "10"},
+			},
+		},
+	}
+	if err := dm.SetStatQueue(sq); err != nil {
+		t.Fatal(err)
+	}
+	var reply []*StatWindow
+	if err := sS.V1GetQueueWindows(&utils.TenantID{ID: "SQ_WND"}, &reply); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(sq.Windows, reply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(sq.Windows), utils.ToJSON(reply))
+	}
+	strMetrics := make(map[string]string)
+	if err := sS.V1GetQueueStringMetrics(&utils.TenantID{ID: "SQ_WND"}, &strMetrics); err != nil {
+		t.Error(err)
+	} else if len(strMetrics) != 1 {
+		t.Errorf("Expected only the current metrics, received %s", utils.ToJSON(strMetrics))
+	}
+	if err := sS.V1GetQueueWindows(&utils.TenantID{}, &reply); err == nil ||
+		err.Error() != "MANDATORY_IE_MISSING: [ID]" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	sq.ID = "SQ_NO_WND"
+	sq.Windows = nil
+	if err := dm.SetStatQueue(sq); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.V1GetQueueWindows(&utils.TenantID{ID: "SQ_NO_WND"}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestStatQueueV1GetQueueWindowsExpiredAfterWindow(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	sS := NewStatService(dm, cfg, NewFilterS(cfg, nil, dm), nil)
+
+	if err := dm.SetStatQueueProfile(&StatQueueProfile{
+		Tenant:      "cgrates.org",
+		ID:          "SQ_WND",
+		WindowType:  utils.MetaTumbling,
+		WindowSize:  5 * time.Minute,
+		WindowCount: 2,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	sq, err := NewStatQueue("cgrates.org", "SQ_WND", []*MetricWithFilters{
+		{MetricID: "*sum#~*req.Cost"}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sq.WindowEnd = utils.TimePointer(now.Add(-time.Second))
+	// the event expired after the end of the window so it is part of it
+	sq.SQItems = []SQItem{{EventID: "EV1", ExpiryTime: utils.TimePointer(now.Add(-500 * time.Millisecond))}}
+	if err := sq.SQMetrics["*sum#~*req.Cost"].AddEvent("EV1",
+		utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Cost: 10.}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetStatQueue(sq); err != nil {
+		t.Fatal(err)
+	}
+	var reply []*StatWindow
+	if err := sS.V1GetQueueWindows(&utils.TenantID{ID: "SQ_WND"}, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply) != 1 || reply[0].Metrics["*sum#~*req.Cost"] != "10" {
+		t.Errorf("Expected the window to keep the expired event, received %s", utils.ToJSON(reply))
+	}
+}
@@ -64,6 +64,8 @@ type CSVStorage struct {
 	chargerProfilesFn        []string
 	dispatcherProfilesFn     []string
 	dispatcherHostsFn        []string
+	exchangeRatesFn          []string
+	lookupTablesFn           []string
 }
 
 // NewCSVStorage creates a CSV storege that takes the data from the paths specified
@@ -72,7 +74,8 @@ func NewCSVStorage(sep rune,
 	destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, statsFn, thresholdsFn, filterFn, routeProfilesFn,
-	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn []string) *CSVStorage {
+	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn,
+	exchangeRatesFn, lookupTablesFn []string) *CSVStorage {
 	return &CSVStorage{
 		sep:                      sep,
 		generator:                NewCsvFile,
@@ -96,6 +99,8 @@ func NewCSVStorage(sep rune,
 		chargerProfilesFn:        chargerProfilesFn,
 		dispatcherProfilesFn:     dispatcherProfilesFn,
 		dispatcherHostsFn:        dispatcherHostsFn,
+		exchangeRatesFn:          exchangeRatesFn,
+		lookupTablesFn:           lookupTablesFn,
 	}
 }
 
@@ -125,6 +130,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 	chargersPaths := appendName(allFoldersPath, utils.ChargersCsv)
 	dispatcherprofilesPaths := appendName(allFoldersPath, utils.DispatcherProfilesCsv)
 	dispatcherhostsPaths := appendName(allFoldersPath, utils.DispatcherHostsCsv)
+	exchangeRatesPaths := appendName(allFoldersPath, utils.ExchangeRatesCsv)
+	lookupTablesPaths := appendName(allFoldersPath, utils.LookupTablesCsv)
 	return NewCSVStorage(sep,
 		destinationsPaths,
 		timingsPaths,
@@ -146,6 +153,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		exchangeRatesPaths,
+		lookupTablesPaths,
 	)
 }
 
@@ -155,14 +164,16 @@ func NewStringCSVStorage(sep rune,
 	destinationratetimingsFn, ratingprofilesFn, sharedgroupsFn,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, statsFn, thresholdsFn, filterFn, routeProfilesFn,
-	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn string) *CSVStorage {
+	attributeProfilesFn, chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn,
+	exchangeRatesFn, lookupTablesFn string) *CSVStorage {
 	c := NewCSVStorage(sep, []string{destinationsFn}, []string{timingsFn},
 		[]string{ratesFn}, []string{destinationratesFn}, []string{destinationratetimingsFn},
 		[]string{ratingprofilesFn}, []string{sharedgroupsFn}, []string{actionsFn},
 		[]string{actiontimingsFn}, []string{actiontriggersFn}, []string{accountactionsFn},
 		[]string{resProfilesFn}, []string{statsFn}, []string{thresholdsFn}, []string{filterFn},
 		[]string{routeProfilesFn}, []string{attributeProfilesFn}, []string{chargerProfilesFn},
-		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn})
+		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn},
+		[]string{exchangeRatesFn}, []string{lookupTablesFn})
 	c.generator = NewCsvString
 	return c
 }
@@ -204,6 +215,8 @@ func NewGoogleCSVStorage(sep rune, spreadsheetID string) (*CSVStorage, error) {
 		getIfExist(utils.Chargers),
 		getIfExist(utils.DispatcherProfiles),
 		getIfExist(utils.DispatcherHosts),
+		getIfExist(utils.ExchangeRates),
+		getIfExist(utils.LookupTables),
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvGoogle{
@@ -236,6 +249,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 	var chargersPaths []string
 	var dispatcherprofilesPaths []string
 	var dispatcherhostsPaths []string
+	var exchangeRatesPaths []string
+	var lookupTablesPaths []string
 
 	for _, baseURL := range strings.Split(dataPath, utils.InfieldSep) {
 		if !strings.HasSuffix(baseURL, utils.CSVSuffix) {
@@ -259,6 +274,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			chargersPaths = append(chargersPaths, joinURL(baseURL, utils.ChargersCsv))
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, joinURL(baseURL, utils.DispatcherProfilesCsv))
 			dispatcherhostsPaths = append(dispatcherhostsPaths, joinURL(baseURL, utils.DispatcherHostsCsv))
+			exchangeRatesPaths = append(exchangeRatesPaths, joinURL(baseURL, utils.ExchangeRatesCsv))
+			lookupTablesPaths = append(lookupTablesPaths, joinURL(baseURL, utils.LookupTablesCsv))
 			continue
 		}
 		switch {
@@ -302,6 +319,10 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, baseURL)
 		case strings.HasSuffix(baseURL, utils.DispatcherHostsCsv):
 			dispatcherhostsPaths = append(dispatcherhostsPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.ExchangeRatesCsv):
+			exchangeRatesPaths = append(exchangeRatesPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.LookupTablesCsv):
+			lookupTablesPaths = append(lookupTablesPaths, baseURL)
 		}
 	}
 
@@ -326,6 +347,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 		chargersPaths,
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
+		exchangeRatesPaths,
+		lookupTablesPaths,
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvURL{}
@@ -365,9 +388,14 @@ func appendName(paths []string, fileName string) (out []string) {
 
 func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process func(interface{})) error {
 	collumnCount := getColumnCount(listType)
+	requiredCount := getRequiredColumnCount(listType)
+	nrFields := collumnCount
+	if requiredCount != collumnCount { // optional columns, the number of fields is checked per record
+		nrFields = -1
+	}
 	for _, fileName := range fns {
 		csvReader := csvs.generator()
-		err := csvReader.Open(fileName, csvs.sep, collumnCount)
+		err := csvReader.Open(fileName, csvs.sep, nrFields)
 		if err != nil {
 			// maybe a log to view if failed to open file
 			continue // try read the rest
@@ -379,6 +407,11 @@ func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process
 					log.Printf("bad line in %s, %s\n", fileName, err.Error())
 					return err
 				}
+				if len(record) < requiredCount || len(record) > collumnCount {
+					err = fmt.Errorf("wrong number of fields: %d", len(record))
+					log.Printf("bad line in %s, %s\n", fileName, err.Error())
+					return err
+				}
 				if item, err := csvLoad(listType, record); err != nil {
 					log.Printf("error loading %s: %v", "", err)
 					return err
@@ -644,6 +677,30 @@ func (csvs *CSVStorage) GetTPDispatcherHosts(tpid, tenant, id string) ([]*utils.
 	return result, nil
 }
 
+func (csvs *CSVStorage) GetTPExchangeRateProfiles(tpid, tenant, id string) ([]*utils.TPExchangeRateProfile, error) {
+	var tpXRs ExchangeRateMdls
+	if err := csvs.proccesData(ExchangeRateMdl{}, csvs.exchangeRatesFn, func(tp interface{}) {
+		xr := tp.(ExchangeRateMdl)
+		xr.Tpid = tpid
+		tpXRs = append(tpXRs, &xr)
+	}); err != nil {
+		return nil, err
+	}
+	return tpXRs.AsTPExchangeRateProfiles(), nil
+}
+
+func (csvs *CSVStorage) GetTPLookupTables(tpid, tenant, id string) ([]*utils.TPLookupTable, error) {
+	var tpLkts LookupTableMdls
+	if err := csvs.proccesData(LookupTableMdl{}, csvs.lookupTablesFn, func(tp interface{}) {
+		lkt := tp.(LookupTableMdl)
+		lkt.Tpid = tpid
+		tpLkts = append(tpLkts, &lkt)
+	}); err != nil {
+		return nil, err
+	}
+	return tpLkts.AsTPLookupTables(), nil
+}
+
 func (csvs *CSVStorage) GetTpIds(colName string) ([]string, error) {
 	return nil, utils.ErrNotImplemented
 }
@@ -862,8 +919,12 @@ func (c *csvGoogle) Read() (record []string, err error) {
 	if err != nil {
 		return
 	}
-	record = make([]string, c.nrFields)
-	for i := 0; i < c.nrFields; i++ {
+	nrFields := c.nrFields
+	if nrFields < 0 { // variable number of fields
+		nrFields = len(row)
+	}
+	record = make([]string, nrFields)
+	for i := 0; i < nrFields; i++ {
 		if i < len(row) {
 			record[i] = utils.IfaceAsString(row[i])
 			if i == 0 && strings.HasPrefix(record[i], "#") {
@@ -128,6 +128,21 @@ type DataDB interface {
 	GetDispatcherHostDrv(string, string) (*DispatcherHost, error)
 	SetDispatcherHostDrv(*DispatcherHost) error
 	RemoveDispatcherHostDrv(string, string) error
+	GetTierCounterDrv(string, string) (*TierCounter, error)
+	SetTierCounterDrv(*TierCounter) error
+	RemoveTierCounterDrv(string, string) error
+	GetRerateJobDrv(string, string) (*RerateJob, error)
+	SetRerateJobDrv(*RerateJob) error
+	RemoveRerateJobDrv(string, string) error
+	GetSessionsBackupDrv(string) ([]*StoredSession, error)
+	SetSessionsBackupDrv(string, []*StoredSession) error
+	RemoveSessionsBackupDrv(string) error
+	GetExchangeRateProfileDrv(string, string) (*ExchangeRateProfile, error)
+	SetExchangeRateProfileDrv(*ExchangeRateProfile) error
+	RemoveExchangeRateProfileDrv(string, string) error
+	GetLookupTableEntryDrv(string, string, string) (map[string]string, error)
+	SetLookupTableDrv(*LookupTable) error
+	RemoveLookupTableDrv(string, string) error
 }
 
 type StorDB interface {
@@ -144,6 +159,10 @@ type CdrStorage interface {
 	RemoveSMCost(*SMCost) error
 	RemoveSMCosts(qryFltr *utils.SMCostFilter) error
 	GetCDRs(*utils.CDRsFilter, bool) ([]*CDR, int64, error)
+	SetInvoice(*Invoice) error
+	GetInvoices(tenant, account, id string) ([]*Invoice, error) // empty tenant for all the tenants
+	SetLedgerEntries([]*LedgerEntry) error
+	GetLedgerEntries(*LedgerFilter) ([]*LedgerEntry, error)
 }
 
 type LoadStorage interface {
@@ -177,6 +196,8 @@ type LoadReader interface {
 	GetTPChargers(string, string, string) ([]*utils.TPChargerProfile, error)
 	GetTPDispatcherProfiles(string, string, string) ([]*utils.TPDispatcherProfile, error)
 	GetTPDispatcherHosts(string, string, string) ([]*utils.TPDispatcherHost, error)
+	GetTPExchangeRateProfiles(string, string, string) ([]*utils.TPExchangeRateProfile, error)
+	GetTPLookupTables(string, string, string) ([]*utils.TPLookupTable, error)
 }
 
 type LoadWriter interface {
@@ -201,6 +222,8 @@ type LoadWriter interface {
 	SetTPChargers([]*utils.TPChargerProfile) error
 	SetTPDispatcherProfiles([]*utils.TPDispatcherProfile) error
 	SetTPDispatcherHosts([]*utils.TPDispatcherHost) error
+	SetTPExchangeRateProfiles([]*utils.TPExchangeRateProfile) error
+	SetTPLookupTables([]*utils.TPLookupTable) error
 }
 
 // NewMarshaler returns the marshaler type selected by mrshlerStr
@@ -467,6 +467,7 @@ func (iDB *InternalDB) SetAccountDrv(acc *Account) (err error) {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
 			ac.AllowNegative = acc.AllowNegative
+			ac.CreditLimits = acc.CreditLimits
 			ac.Disabled = acc.Disabled
 			acc = ac
 		}
@@ -781,6 +782,120 @@ func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetTierCounterDrv(tenant, id string) (tc *TierCounter, err error) {
+	x, ok := iDB.db.Get(utils.CacheTierCounters, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*TierCounter), nil
+}
+
+func (iDB *InternalDB) SetTierCounterDrv(tc *TierCounter) (err error) {
+	iDB.db.Set(utils.CacheTierCounters, tc.TenantID(), tc, nil,
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveTierCounterDrv(tenant, id string) (err error) {
+	iDB.db.Remove(utils.CacheTierCounters, utils.ConcatenatedKey(tenant, id),
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRerateJobDrv(tenant, id string) (job *RerateJob, err error) {
+	x, ok := iDB.db.Get(utils.CacheRerateJobs, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*RerateJob).Clone(), nil
+}
+
+func (iDB *InternalDB) SetRerateJobDrv(job *RerateJob) (err error) {
+	iDB.db.Set(utils.CacheRerateJobs, job.TenantID(), job.Clone(), nil,
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRerateJobDrv(tenant, id string) (err error) {
+	iDB.db.Remove(utils.CacheRerateJobs, utils.ConcatenatedKey(tenant, id),
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetSessionsBackupDrv(nodeID string) (ss []*StoredSession, err error) {
+	x, ok := iDB.db.Get(utils.CacheSessionsBackup, nodeID)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	stored := x.([]*StoredSession)
+	ss = make([]*StoredSession, len(stored))
+	for i, s := range stored {
+		ss[i] = s.Clone()
+	}
+	return
+}
+
+func (iDB *InternalDB) SetSessionsBackupDrv(nodeID string, ss []*StoredSession) (err error) {
+	stored := make([]*StoredSession, len(ss))
+	for i, s := range ss {
+		stored[i] = s.Clone()
+	}
+	iDB.db.Set(utils.CacheSessionsBackup, nodeID, stored, nil,
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveSessionsBackupDrv(nodeID string) (err error) {
+	iDB.db.Remove(utils.CacheSessionsBackup, nodeID,
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetExchangeRateProfileDrv(tenant, id string) (xrp *ExchangeRateProfile, err error) {
+	x, ok := iDB.db.Get(utils.CacheExchangeRateProfiles, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*ExchangeRateProfile).Clone(), nil
+}
+
+func (iDB *InternalDB) SetExchangeRateProfileDrv(xrp *ExchangeRateProfile) (err error) {
+	iDB.db.Set(utils.CacheExchangeRateProfiles, xrp.TenantID(), xrp.Clone(), nil,
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	iDB.db.Remove(utils.CacheExchangeRateProfiles, utils.ConcatenatedKey(tenant, id),
+		true, utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetLookupTableEntryDrv(tenant, id, key string) (flds map[string]string, err error) {
+	x, ok := iDB.db.Get(utils.CacheLookupTables, utils.ConcatenatedKey(tenant, id, key))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return cloneLookupEntry(x.(map[string]string)), nil
+}
+
+func (iDB *InternalDB) SetLookupTableDrv(lkt *LookupTable) (err error) {
+	for key, flds := range lkt.Entries {
+		iDB.db.Set(utils.CacheLookupTables, utils.ConcatenatedKey(lkt.Tenant, lkt.ID, key),
+			cloneLookupEntry(flds), nil, true, utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) RemoveLookupTableDrv(tenant, id string) (err error) {
+	for _, entryID := range iDB.db.GetItemIDs(utils.CacheLookupTables,
+		utils.ConcatenatedKey(tenant, id, utils.EmptyString)) {
+		iDB.db.Remove(utils.CacheLookupTables, entryID,
+			true, utils.NonTransactional)
+	}
+	return
+}
+
 func (iDB *InternalDB) RemoveLoadIDsDrv() (err error) {
 	return utils.ErrNotImplemented
 }
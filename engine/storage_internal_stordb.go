@@ -563,6 +563,50 @@ func (iDB *InternalDB) GetTPDispatcherHosts(tpid, tenant, id string) (dpps []*ut
 	return
 }
 
+func (iDB *InternalDB) GetTPExchangeRateProfiles(tpid, tenant, id string) (xrps []*utils.TPExchangeRateProfile, err error) {
+	key := tpid
+	if tenant != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + tenant
+	}
+	if id != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + id
+	}
+	ids := iDB.db.GetItemIDs(utils.CacheTBLTPExchangeRates, key)
+	for _, id := range ids {
+		x, ok := iDB.db.Get(utils.CacheTBLTPExchangeRates, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		xrps = append(xrps, x.(*utils.TPExchangeRateProfile))
+	}
+	if len(xrps) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (iDB *InternalDB) GetTPLookupTables(tpid, tenant, id string) (lkts []*utils.TPLookupTable, err error) {
+	key := tpid
+	if tenant != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + tenant
+	}
+	if id != utils.EmptyString {
+		key += utils.ConcatenatedKeySep + id
+	}
+	ids := iDB.db.GetItemIDs(utils.CacheTBLTPLookupTables, key)
+	for _, id := range ids {
+		x, ok := iDB.db.Get(utils.CacheTBLTPLookupTables, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		lkts = append(lkts, x.(*utils.TPLookupTable))
+	}
+	if len(lkts) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
 //implement LoadWriter interface
 func (iDB *InternalDB) RemTpData(table, tpid string, args map[string]string) (err error) {
 	if table == utils.EmptyString {
@@ -814,6 +858,22 @@ func (iDB *InternalDB) SetTPDispatcherHosts(dpps []*utils.TPDispatcherHost) (err
 	return
 }
 
+func (iDB *InternalDB) SetTPExchangeRateProfiles(xrps []*utils.TPExchangeRateProfile) (err error) {
+	for _, xrp := range xrps {
+		iDB.db.Set(utils.CacheTBLTPExchangeRates, utils.ConcatenatedKey(xrp.TPid, xrp.Tenant, xrp.ID), xrp, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) SetTPLookupTables(lkts []*utils.TPLookupTable) (err error) {
+	for _, lkt := range lkts {
+		iDB.db.Set(utils.CacheTBLTPLookupTables, utils.ConcatenatedKey(lkt.TPid, lkt.Tenant, lkt.ID), lkt, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
 //implement CdrStorage interface
 func (iDB *InternalDB) SetCDR(cdr *CDR, allowUpdate bool) (err error) {
 	if cdr.OrderID == 0 {
@@ -1468,3 +1528,79 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return err
 }
+
+// SetInvoice stores the invoice indexed by its account
+func (iDB *InternalDB) SetInvoice(inv *Invoice) (err error) {
+	iDB.db.Set(utils.CacheInvoicesTBL, inv.TenantID(), inv,
+		[]string{utils.ConcatenatedKey(inv.Tenant, inv.Account)},
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// SetLedgerEntries appends the entries to the balance ledger indexed by their account
+func (iDB *InternalDB) SetLedgerEntries(les []*LedgerEntry) (err error) {
+	for _, le := range les {
+		iDB.db.Set(utils.CacheBalanceLedgerTBL, utils.ConcatenatedKey(le.Tenant, le.Account, utils.GenUUID()), le,
+			[]string{utils.ConcatenatedKey(le.Tenant, le.Account)},
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+// GetLedgerEntries returns the ledger entries matching the filter, sorted on time
+func (iDB *InternalDB) GetLedgerEntries(lf *LedgerFilter) (les []*LedgerEntry, err error) {
+	var keys []string
+	if len(lf.Accounts) == 0 {
+		keys = iDB.db.GetItemIDs(utils.CacheBalanceLedgerTBL, lf.Tenant+utils.ConcatenatedKeySep)
+	}
+	for _, acnt := range lf.Accounts {
+		keys = append(keys, iDB.db.GetGroupItemIDs(utils.CacheBalanceLedgerTBL,
+			utils.ConcatenatedKey(lf.Tenant, acnt))...)
+	}
+	for _, key := range keys {
+		x, ok := iDB.db.Get(utils.CacheBalanceLedgerTBL, key)
+		if !ok || x == nil {
+			continue
+		}
+		if le := x.(*LedgerEntry); lf.Matches(le) {
+			les = append(les, le)
+		}
+	}
+	sortLedgerEntries(les)
+	if les = paginateLedgerEntries(les, lf.Paginator); len(les) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// GetInvoices returns the invoices of the tenant filtered by account and ID
+func (iDB *InternalDB) GetInvoices(tenant, account, id string) (invs []*Invoice, err error) {
+	var keys []string
+	switch {
+	case id != utils.EmptyString:
+		keys = []string{utils.ConcatenatedKey(tenant, id)}
+	case account != utils.EmptyString:
+		keys = iDB.db.GetGroupItemIDs(utils.CacheInvoicesTBL, utils.ConcatenatedKey(tenant, account))
+	default:
+		var prfx string
+		if tenant != utils.EmptyString {
+			prfx = tenant + utils.ConcatenatedKeySep
+		}
+		keys = iDB.db.GetItemIDs(utils.CacheInvoicesTBL, prfx)
+	}
+	for _, key := range keys {
+		x, ok := iDB.db.Get(utils.CacheInvoicesTBL, key)
+		if !ok || x == nil {
+			continue
+		}
+		inv := x.(*Invoice)
+		if account != utils.EmptyString && inv.Account != account {
+			continue
+		}
+		invs = append(invs, inv)
+	}
+	if len(invs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -76,6 +77,11 @@ const (
 	ColDpp  = "dispatcher_profiles"
 	ColDph  = "dispatcher_hosts"
 	ColLID  = "load_ids"
+	ColTcr  = "tier_counters"
+	ColRrj  = "rerate_jobs"
+	ColSbk  = "sessions_backup"
+	ColLkt  = "lookup_tables"
+	ColXrp  = "exchange_rate_profiles"
 )
 
 var (
@@ -308,11 +314,11 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 	}
 	err = nil
 	switch col {
-	case ColAct, ColApl, ColAAp, ColAtr, ColRpl, ColDst, ColRds, ColLht, ColIndx:
+	case ColAct, ColApl, ColAAp, ColAtr, ColRpl, ColDst, ColRds, ColLht, ColIndx, ColSbk, ColLkt:
 		if err = ms.enusureIndex(col, true, "key"); err != nil {
 			return
 		}
-	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph:
+	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColTcr, ColRrj, ColXrp:
 		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
 			return
 		}
@@ -327,7 +333,7 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 		utils.TBLTPStats, utils.TBLTPResources, utils.TBLTPDispatchers,
 		utils.TBLTPDispatcherHosts, utils.TBLTPChargers,
-		utils.TBLTPRoutes, utils.TBLTPThresholds:
+		utils.TBLTPRoutes, utils.TBLTPThresholds, utils.TBLTPExchangeRates, utils.TBLTPLookupTables:
 		if err = ms.enusureIndex(col, true, "tpid", "id"); err != nil {
 			return
 		}
@@ -346,6 +352,17 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 				return
 			}
 		}
+	case utils.InvoicesTBL:
+		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, "tenant", "account"); err != nil {
+			return
+		}
+	case utils.BalanceLedgerTBL:
+		if err = ms.enusureIndex(col, false, "tenant", "account", "time"); err != nil {
+			return
+		}
 	case utils.SessionCostsTBL:
 		if err = ms.enusureIndex(col, true, CGRIDLow,
 			RunIDLow); err != nil {
@@ -377,7 +394,7 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
 			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp,
-			ColRpf, ColShg, ColAcc} {
+			ColRpf, ColShg, ColAcc, ColTcr, ColRrj, ColXrp, ColSbk, ColLkt} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -389,7 +406,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL,
+			utils.InvoicesTBL, utils.BalanceLedgerTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -650,6 +668,12 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField3(sctx, ColIndx, utils.ChargerFilterIndexes, "key")
 		case utils.DispatcherFilterIndexes:
 			result, err = ms.getField3(sctx, ColIndx, utils.DispatcherFilterIndexes, "key")
+		case utils.RerateJobPrefix:
+			result, err = ms.getField2(sctx, ColRrj, utils.RerateJobPrefix, subject, tntID)
+		case utils.ExchangeRateProfilePrefix:
+			result, err = ms.getField2(sctx, ColXrp, utils.ExchangeRateProfilePrefix, subject, tntID)
+		case utils.LookupTablePrefix:
+			result, err = ms.getField(sctx, ColLkt, utils.LookupTablePrefix, subject, "key")
 		case utils.ActionPlanIndexes:
 			result, err = ms.getField3(sctx, ColIndx, utils.ActionPlanIndexes, "key")
 		case utils.FilterIndexPrfx:
@@ -1024,6 +1048,7 @@ func (ms *MongoStorage) SetAccountDrv(acc *Account) error {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
 			ac.AllowNegative = acc.AllowNegative
+			ac.CreditLimits = acc.CreditLimits
 			ac.Disabled = acc.Disabled
 			acc = ac
 		}
@@ -1839,6 +1864,217 @@ func (ms *MongoStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetTierCounterDrv(tenant, id string) (r *TierCounter, err error) {
+	r = new(TierCounter)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColTcr).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(r); err != nil {
+			r = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetTierCounterDrv(r *TierCounter) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColTcr).UpdateOne(sctx, bson.M{"tenant": r.Tenant, "id": r.ID},
+			bson.M{"$set": r},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveTierCounterDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColTcr).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRerateJobDrv(tenant, id string) (r *RerateJob, err error) {
+	r = new(RerateJob)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRrj).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(r); err != nil {
+			r = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRerateJobDrv(r *RerateJob) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRrj).UpdateOne(sctx, bson.M{"tenant": r.Tenant, "id": r.ID},
+			bson.M{"$set": r},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRerateJobDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRrj).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetSessionsBackupDrv(nodeID string) (ss []*StoredSession, err error) {
+	var kv struct {
+		Key   string
+		Value []byte
+	}
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColSbk).FindOne(sctx, bson.M{"key": nodeID})
+		if err := cur.Decode(&kv); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	err = ms.ms.Unmarshal(kv.Value, &ss)
+	return
+}
+
+func (ms *MongoStorage) SetSessionsBackupDrv(nodeID string, ss []*StoredSession) (err error) {
+	result, err := ms.ms.Marshal(ss)
+	if err != nil {
+		return err
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSbk).UpdateOne(sctx, bson.M{"key": nodeID},
+			bson.M{"$set": struct {
+				Key   string
+				Value []byte
+			}{Key: nodeID, Value: result}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveSessionsBackupDrv(nodeID string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColSbk).DeleteOne(sctx, bson.M{"key": nodeID})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetExchangeRateProfileDrv(tenant, id string) (r *ExchangeRateProfile, err error) {
+	r = new(ExchangeRateProfile)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColXrp).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(r); err != nil {
+			r = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetExchangeRateProfileDrv(r *ExchangeRateProfile) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColXrp).UpdateOne(sctx, bson.M{"tenant": r.Tenant, "id": r.ID},
+			bson.M{"$set": r},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColXrp).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetLookupTableEntryDrv(tenant, id, key string) (flds map[string]string, err error) {
+	var kv struct {
+		Key    string
+		Fields map[string]string
+	}
+	if err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColLkt).FindOne(sctx, bson.M{"key": utils.ConcatenatedKey(tenant, id, key)})
+		if err := cur.Decode(&kv); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return kv.Fields, nil
+}
+
+func (ms *MongoStorage) SetLookupTableDrv(lkt *LookupTable) (err error) {
+	if len(lkt.Entries) == 0 {
+		return
+	}
+	mdls := make([]mongo.WriteModel, 0, len(lkt.Entries))
+	for key, flds := range lkt.Entries {
+		entryID := utils.ConcatenatedKey(lkt.Tenant, lkt.ID, key)
+		mdls = append(mdls, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"key": entryID}).
+			SetUpdate(bson.M{"$set": struct {
+				Key    string
+				Fields map[string]string
+			}{Key: entryID, Fields: flds}}).
+			SetUpsert(true))
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColLkt).BulkWrite(sctx, mdls, options.BulkWrite().SetOrdered(false))
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColLkt).DeleteMany(sctx, bson.M{"key": bsonx.Regex(
+			"^"+regexp.QuoteMeta(utils.ConcatenatedKey(tenant, id, utils.EmptyString)), "")})
+		if err != nil {
+			return err
+		}
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
 	fop := options.FindOne()
 	if itemIDPrefix != "" {
@@ -1175,6 +1175,112 @@ func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	return cdrs, 0, err
 }
 
+// SetInvoice stores the invoice, overwriting the one with the same ID
+func (ms *MongoStorage) SetInvoice(inv *Invoice) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.InvoicesTBL).UpdateOne(sctx, bson.M{"tenant": inv.Tenant, "id": inv.ID},
+			bson.M{"$set": inv},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+// SetLedgerEntries appends the entries to the balance ledger
+func (ms *MongoStorage) SetLedgerEntries(les []*LedgerEntry) error {
+	docs := make([]interface{}, len(les))
+	for i, le := range les {
+		docs[i] = le
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.BalanceLedgerTBL).InsertMany(sctx, docs)
+		return err
+	})
+}
+
+// GetLedgerEntries returns the ledger entries matching the filter, sorted on time
+func (ms *MongoStorage) GetLedgerEntries(lf *LedgerFilter) (les []*LedgerEntry, err error) {
+	filter := bson.M{"tenant": lf.Tenant}
+	for key, vals := range map[string][]string{
+		"account":     lf.Accounts,
+		"balanceid":   lf.BalanceIDs,
+		"balancetype": lf.BalanceTypes,
+		"source":      lf.Sources,
+		"refid":       lf.RefIDs,
+	} {
+		if len(vals) != 0 {
+			filter[key] = bson.M{"$in": vals}
+		}
+	}
+	if !lf.TimeStart.IsZero() || !lf.TimeEnd.IsZero() {
+		timeFltr := bson.M{}
+		if !lf.TimeStart.IsZero() {
+			timeFltr["$gte"] = lf.TimeStart
+		}
+		if !lf.TimeEnd.IsZero() {
+			timeFltr["$lt"] = lf.TimeEnd
+		}
+		filter["time"] = timeFltr
+	}
+	fop := options.Find().SetSort(bson.D{{Key: "time", Value: 1}})
+	if lf.Limit != nil && *lf.Limit > 0 {
+		fop = fop.SetLimit(int64(*lf.Limit))
+	}
+	if lf.Offset != nil && *lf.Offset > 0 {
+		fop = fop.SetSkip(int64(*lf.Offset))
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.BalanceLedgerTBL).Find(sctx, filter, fop)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var le LedgerEntry
+			if err := cur.Decode(&le); err != nil {
+				return err
+			}
+			les = append(les, &le)
+		}
+		if len(les) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return les, err
+}
+
+// GetInvoices returns the invoices of the tenant filtered by account and ID
+func (ms *MongoStorage) GetInvoices(tenant, account, id string) (invs []*Invoice, err error) {
+	filter := bson.M{}
+	if tenant != "" {
+		filter["tenant"] = tenant
+	}
+	if account != "" {
+		filter["account"] = account
+	}
+	if id != "" {
+		filter["id"] = id
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.InvoicesTBL).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var inv Invoice
+			if err := cur.Decode(&inv); err != nil {
+				return err
+			}
+			invs = append(invs, &inv)
+		}
+		if len(invs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return invs, err
+}
+
 func (ms *MongoStorage) SetTPStats(tpSTs []*utils.TPStatProfile) (err error) {
 	if len(tpSTs) == 0 {
 		return
@@ -1529,6 +1635,102 @@ func (ms *MongoStorage) SetTPDispatcherHosts(tpDPPs []*utils.TPDispatcherHost) (
 	})
 }
 
+func (ms *MongoStorage) GetTPExchangeRateProfiles(tpid, tenant, id string) ([]*utils.TPExchangeRateProfile, error) {
+	filter := bson.M{"tpid": tpid}
+	if id != "" {
+		filter["id"] = id
+	}
+	if tenant != "" {
+		filter["tenant"] = tenant
+	}
+	var results []*utils.TPExchangeRateProfile
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPExchangeRates).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var tp utils.TPExchangeRateProfile
+			err := cur.Decode(&tp)
+			if err != nil {
+				return err
+			}
+			results = append(results, &tp)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
+func (ms *MongoStorage) SetTPExchangeRateProfiles(tpXRPs []*utils.TPExchangeRateProfile) (err error) {
+	if len(tpXRPs) == 0 {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tpXRPs {
+			_, err = ms.getCol(utils.TBLTPExchangeRates).UpdateOne(sctx, bson.M{"tpid": tp.TPid, "tenant": tp.Tenant, "id": tp.ID},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ms *MongoStorage) GetTPLookupTables(tpid, tenant, id string) ([]*utils.TPLookupTable, error) {
+	filter := bson.M{"tpid": tpid}
+	if id != "" {
+		filter["id"] = id
+	}
+	if tenant != "" {
+		filter["tenant"] = tenant
+	}
+	var results []*utils.TPLookupTable
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPLookupTables).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var tp utils.TPLookupTable
+			err := cur.Decode(&tp)
+			if err != nil {
+				return err
+			}
+			results = append(results, &tp)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
+func (ms *MongoStorage) SetTPLookupTables(tpLkts []*utils.TPLookupTable) (err error) {
+	if len(tpLkts) == 0 {
+		return
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tpLkts {
+			_, err = ms.getCol(utils.TBLTPLookupTables).UpdateOne(sctx, bson.M{"tpid": tp.TPid, "tenant": tp.Tenant, "id": tp.ID},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) GetVersions(itm string) (vrs Versions, err error) {
 	fop := options.FindOne()
 	if itm != "" {
@@ -511,6 +511,7 @@ func (rs *RedisStorage) SetAccountDrv(acc *Account) (err error) {
 			ac.ActionTriggers = acc.ActionTriggers
 			ac.UnitCounters = acc.UnitCounters
 			ac.AllowNegative = acc.AllowNegative
+			ac.CreditLimits = acc.CreditLimits
 			ac.Disabled = acc.Disabled
 			acc = ac
 		}
@@ -1118,6 +1119,132 @@ func (rs *RedisStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return rs.Cmd(nil, redis_DEL, utils.DispatcherHostPrefix+utils.ConcatenatedKey(tenant, id))
 }
 
+func (rs *RedisStorage) GetTierCounterDrv(tenant, id string) (r *TierCounter, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.TierCounterPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &r)
+	return
+}
+
+func (rs *RedisStorage) SetTierCounterDrv(r *TierCounter) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(r); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.TierCounterPrefix+r.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveTierCounterDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.TierCounterPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetRerateJobDrv(tenant, id string) (r *RerateJob, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RerateJobPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &r)
+	return
+}
+
+func (rs *RedisStorage) SetRerateJobDrv(r *RerateJob) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(r); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RerateJobPrefix+r.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveRerateJobDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RerateJobPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetSessionsBackupDrv(nodeID string) (ss []*StoredSession, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.SessionsBackupPrefix+nodeID); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &ss)
+	return
+}
+
+func (rs *RedisStorage) SetSessionsBackupDrv(nodeID string, ss []*StoredSession) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(ss); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.SessionsBackupPrefix+nodeID, string(result))
+}
+
+func (rs *RedisStorage) RemoveSessionsBackupDrv(nodeID string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.SessionsBackupPrefix+nodeID)
+}
+
+func (rs *RedisStorage) GetExchangeRateProfileDrv(tenant, id string) (r *ExchangeRateProfile, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.ExchangeRateProfilePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &r)
+	return
+}
+
+func (rs *RedisStorage) SetExchangeRateProfileDrv(r *ExchangeRateProfile) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(r); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.ExchangeRateProfilePrefix+r.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.ExchangeRateProfilePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetLookupTableEntryDrv(tenant, id, key string) (flds map[string]string, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id, key)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &flds)
+	return
+}
+
+func (rs *RedisStorage) SetLookupTableDrv(lkt *LookupTable) (err error) {
+	for key, flds := range lkt.Entries {
+		var result []byte
+		if result, err = rs.ms.Marshal(flds); err != nil {
+			return
+		}
+		if err = rs.Cmd(nil, redis_SET, utils.LookupTablePrefix+utils.ConcatenatedKey(lkt.Tenant, lkt.ID, key),
+			string(result)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (rs *RedisStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	return rs.RemoveKeysForPrefix(utils.LookupTablePrefix + utils.ConcatenatedKey(tenant, id, utils.EmptyString))
+}
+
 func (rs *RedisStorage) GetStorageType() string {
 	return utils.Redis
 }
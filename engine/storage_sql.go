@@ -105,7 +105,8 @@ func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 		utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 		utils.TBLTPFilters, utils.SessionCostsTBL, utils.CDRsTBL, utils.TBLTPActionPlans,
 		utils.TBLVersions, utils.TBLTPRoutes, utils.TBLTPAttributes, utils.TBLTPChargers,
-		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts, utils.InvoicesTBL,
+		utils.TBLTPExchangeRates, utils.TBLTPLookupTables, utils.BalanceLedgerTBL,
 	}
 	for _, tbl := range tbls {
 		if sqls.db.Migrator().HasTable(tbl) {
@@ -124,7 +125,7 @@ func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 	qryStr := fmt.Sprintf(" (SELECT tpid FROM %s)", colName)
 	if colName == "" {
 		qryStr = fmt.Sprintf(
-			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
+			"(SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s) UNION (SELECT tpid FROM %s)",
 			utils.TBLTPTimings,
 			utils.TBLTPDestinations,
 			utils.TBLTPRates,
@@ -145,6 +146,8 @@ func (sqls *SQLStorage) GetTpIds(colName string) ([]string, error) {
 			utils.TBLTPChargers,
 			utils.TBLTPDispatchers,
 			utils.TBLTPDispatcherHosts,
+			utils.TBLTPExchangeRates,
+			utils.TBLTPLookupTables,
 		)
 	}
 	rows, err = sqls.Db.Query(qryStr)
@@ -241,7 +244,8 @@ func (sqls *SQLStorage) RemTpData(table, tpid string, args map[string]string) er
 			utils.TBLTPSharedGroups, utils.TBLTPActions, utils.TBLTPActionTriggers,
 			utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 			utils.TBLTPFilters, utils.TBLTPActionPlans, utils.TBLTPRoutes, utils.TBLTPAttributes,
-			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts} {
+			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+			utils.TBLTPExchangeRates, utils.TBLTPLookupTables} {
 			if err := tx.Table(tblName).Where("tpid = ?", tpid).Delete(nil).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -728,6 +732,50 @@ func (sqls *SQLStorage) SetTPDispatcherHosts(tpDPPs []*utils.TPDispatcherHost) e
 	return nil
 }
 
+func (sqls *SQLStorage) SetTPExchangeRateProfiles(tpXRPs []*utils.TPExchangeRateProfile) error {
+	if len(tpXRPs) == 0 {
+		return nil
+	}
+	tx := sqls.db.Begin()
+	for _, xrp := range tpXRPs {
+		// Remove previous
+		if err := tx.Where(&ExchangeRateMdl{Tpid: xrp.TPid, Tenant: xrp.Tenant, ID: xrp.ID}).Delete(ExchangeRateMdl{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, mdl := range APItoModelTPExchangeRateProfile(xrp) {
+			if err := tx.Create(mdl).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (sqls *SQLStorage) SetTPLookupTables(tpLkts []*utils.TPLookupTable) error {
+	if len(tpLkts) == 0 {
+		return nil
+	}
+	tx := sqls.db.Begin()
+	for _, lkt := range tpLkts {
+		// Remove previous
+		if err := tx.Where(&LookupTableMdl{Tpid: lkt.TPid, Tenant: lkt.Tenant, ID: lkt.ID}).Delete(LookupTableMdl{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		for _, mdl := range APItoModelTPLookupTable(lkt) {
+			if err := tx.Create(mdl).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
 func (sqls *SQLStorage) SetSMCost(smc *SMCost) error {
 	if smc.CostDetails == nil {
 		return nil
@@ -898,6 +946,149 @@ func (sqls *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	return nil
 }
 
+// SetInvoice stores the invoice, updating the one with the same ID
+func (sqls *SQLStorage) SetInvoice(inv *Invoice) error {
+	invSQL := &InvoiceSQL{
+		Tenant:      inv.Tenant,
+		InvoiceID:   inv.ID,
+		Account:     inv.Account,
+		PeriodStart: inv.PeriodStart,
+		PeriodEnd:   inv.PeriodEnd,
+		Status:      inv.Status,
+		Lines:       utils.ToJSON(inv.Lines),
+		Total:       inv.Total,
+		ReplacedBy:  inv.ReplacedBy,
+		CreatedAt:   inv.CreatedAt,
+		UpdatedAt:   time.Now(),
+	}
+	tx := sqls.db.Begin()
+	var exInv InvoiceSQL
+	if err := tx.Where(&InvoiceSQL{Tenant: inv.Tenant, InvoiceID: inv.ID}).
+		Limit(1).Find(&exInv).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	invSQL.ID = exInv.ID // update the existing row if any
+	if err := tx.Save(invSQL).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// GetInvoices returns the invoices of the tenant filtered by account and ID
+func (sqls *SQLStorage) GetInvoices(tenant, account, id string) ([]*Invoice, error) {
+	filter := &InvoiceSQL{Tenant: tenant}
+	if account != "" {
+		filter.Account = account
+	}
+	if id != "" {
+		filter.InvoiceID = id
+	}
+	var results []*InvoiceSQL
+	if err := sqls.db.Where(filter).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	invs := make([]*Invoice, len(results))
+	for i, result := range results {
+		invs[i] = &Invoice{
+			Tenant:      result.Tenant,
+			ID:          result.InvoiceID,
+			Account:     result.Account,
+			PeriodStart: result.PeriodStart,
+			PeriodEnd:   result.PeriodEnd,
+			Status:      result.Status,
+			Total:       result.Total,
+			ReplacedBy:  result.ReplacedBy,
+			CreatedAt:   result.CreatedAt,
+		}
+		if err := json.Unmarshal([]byte(result.Lines), &invs[i].Lines); err != nil {
+			return nil, err
+		}
+	}
+	return invs, nil
+}
+
+// SetLedgerEntries appends the entries to the balance ledger
+func (sqls *SQLStorage) SetLedgerEntries(les []*LedgerEntry) error {
+	tx := sqls.db.Begin()
+	for _, le := range les {
+		if err := tx.Save(&BalanceLedgerSQL{
+			Tenant:      le.Tenant,
+			Account:     le.Account,
+			BalanceUUID: le.BalanceUUID,
+			BalanceID:   le.BalanceID,
+			BalanceType: le.BalanceType,
+			OldValue:    le.OldValue,
+			NewValue:    le.NewValue,
+			Source:      le.Source,
+			RefID:       le.RefID,
+			CreatedAt:   le.Time,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+// GetLedgerEntries returns the ledger entries matching the filter, sorted on time
+func (sqls *SQLStorage) GetLedgerEntries(lf *LedgerFilter) ([]*LedgerEntry, error) {
+	q := sqls.db.Table(utils.BalanceLedgerTBL).Where("tenant = ?", lf.Tenant)
+	for column, vals := range map[string][]string{
+		"account":      lf.Accounts,
+		"balance_id":   lf.BalanceIDs,
+		"balance_type": lf.BalanceTypes,
+		"source":       lf.Sources,
+		"ref_id":       lf.RefIDs,
+	} {
+		if len(vals) != 0 {
+			q = q.Where(column+" IN (?)", vals)
+		}
+	}
+	if !lf.TimeStart.IsZero() {
+		q = q.Where("created_at >= ?", lf.TimeStart)
+	}
+	if !lf.TimeEnd.IsZero() {
+		q = q.Where("created_at < ?", lf.TimeEnd)
+	}
+	q = q.Order("created_at, id")
+	if lf.Limit != nil && *lf.Limit > 0 {
+		q = q.Limit(*lf.Limit)
+	}
+	if lf.Offset != nil && *lf.Offset > 0 {
+		q = q.Offset(*lf.Offset)
+	}
+	var results []*BalanceLedgerSQL
+	if err := q.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	les := make([]*LedgerEntry, len(results))
+	for i, result := range results {
+		les[i] = &LedgerEntry{
+			Tenant:      result.Tenant,
+			Account:     result.Account,
+			BalanceUUID: result.BalanceUUID,
+			BalanceID:   result.BalanceID,
+			BalanceType: result.BalanceType,
+			OldValue:    result.OldValue,
+			NewValue:    result.NewValue,
+			Source:      result.Source,
+			RefID:       result.RefID,
+			Time:        result.CreatedAt,
+		}
+	}
+	return les, nil
+}
+
 // GetCDRs has ability to remove the selected CDRs, count them or simply return them
 // qryFltr.Unscoped will ignore soft deletes or delete records permanently
 func (sqls *SQLStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR, int64, error) {
@@ -1554,6 +1745,44 @@ func (sqls *SQLStorage) GetTPDispatcherHosts(tpid, tenant, id string) ([]*utils.
 	return arls, nil
 }
 
+func (sqls *SQLStorage) GetTPExchangeRateProfiles(tpid, tenant, id string) ([]*utils.TPExchangeRateProfile, error) {
+	var xrs ExchangeRateMdls
+	q := sqls.db.Where("tpid = ?", tpid)
+	if len(id) != 0 {
+		q = q.Where("id = ?", id)
+	}
+	if len(tenant) != 0 {
+		q = q.Where("tenant = ?", tenant)
+	}
+	if err := q.Find(&xrs).Error; err != nil {
+		return nil, err
+	}
+	xrps := xrs.AsTPExchangeRateProfiles()
+	if len(xrps) == 0 {
+		return xrps, utils.ErrNotFound
+	}
+	return xrps, nil
+}
+
+func (sqls *SQLStorage) GetTPLookupTables(tpid, tenant, id string) ([]*utils.TPLookupTable, error) {
+	var lkts LookupTableMdls
+	q := sqls.db.Where("tpid = ?", tpid)
+	if len(id) != 0 {
+		q = q.Where("id = ?", id)
+	}
+	if len(tenant) != 0 {
+		q = q.Where("tenant = ?", tenant)
+	}
+	if err := q.Find(&lkts).Error; err != nil {
+		return nil, err
+	}
+	tpLkts := lkts.AsTPLookupTables()
+	if len(tpLkts) == 0 {
+		return tpLkts, utils.ErrNotFound
+	}
+	return tpLkts, nil
+}
+
 // GetVersions returns slice of all versions or a specific version if tag is specified
 func (sqls *SQLStorage) GetVersions(itm string) (vrs Versions, err error) {
 	q := sqls.db.Model(&TBLVersion{})
@@ -0,0 +1,186 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TierCounter keeps the cumulative usage of an account or subject on a tiered RatingPlan
+// within the current cycle
+type TierCounter struct {
+	Tenant     string
+	ID         string // RatingPlanID:Counter:AccountOrSubject
+	CycleStart time.Time
+	Usage      time.Duration
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (tc *TierCounter) TenantID() string {
+	return utils.ConcatenatedKey(tc.Tenant, tc.ID)
+}
+
+// UsageAt returns the usage accumulated in the cycle containing t
+func (tc *TierCounter) UsageAt(t time.Time, cycle string) time.Duration {
+	if !tierCycleStart(t, cycle).Equal(tc.CycleStart) {
+		return 0
+	}
+	return tc.Usage
+}
+
+// AddUsage adds usage to the cycle containing t, resetting the counter if a new cycle started
+// usage belonging to an older cycle is ignored
+func (tc *TierCounter) AddUsage(t time.Time, cycle string, usage time.Duration) {
+	cycleStart := tierCycleStart(t, cycle)
+	switch {
+	case cycleStart.After(tc.CycleStart):
+		tc.CycleStart = cycleStart
+		tc.Usage = 0
+	case cycleStart.Before(tc.CycleStart):
+		return
+	}
+	if tc.Usage += usage; tc.Usage < 0 {
+		tc.Usage = 0
+	}
+}
+
+// tierCycleStart returns the start of the cycle containing t
+func tierCycleStart(t time.Time, cycle string) time.Time {
+	y, m, d := t.Date()
+	switch cycle {
+	case utils.MetaDaily:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case utils.MetaWeekly:
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case utils.MetaMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case utils.MetaYearly:
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location())
+	default: // *unlimited
+		return time.Time{}
+	}
+}
+
+// tierCounterID builds the ID of the counter used by a tiered RatingPlan
+func tierCounterID(rpID string, tcCfg *config.TierCounterCfg, account, subject string) string {
+	if tcCfg.Counter == utils.MetaSubject {
+		return utils.ConcatenatedKey(rpID, tcCfg.Counter, utils.FirstNonEmpty(subject, account))
+	}
+	return utils.ConcatenatedKey(rpID, tcCfg.Counter, utils.FirstNonEmpty(account, subject))
+}
+
+// loadTierUsages populates the TierUsage of the RatingInfos matching tiered RatingPlans
+func (cd *CallDescriptor) loadTierUsages() (err error) {
+	tieredRPs := config.CgrConfig().RalsCfg().TieredRatingPlans
+	if len(tieredRPs) == 0 {
+		return
+	}
+	for _, ri := range cd.RatingInfos {
+		tcCfg, has := tieredRPs[ri.RatingPlanId]
+		if !has {
+			continue
+		}
+		var tc *TierCounter
+		if tc, err = dm.GetTierCounter(cd.Tenant,
+			tierCounterID(ri.RatingPlanId, tcCfg, cd.Account, cd.Subject)); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+			tc = new(TierCounter)
+		}
+		usage := tc.UsageAt(cd.TimeStart, tcCfg.Cycle)
+		ri.TierUsage = &usage
+	}
+	return
+}
+
+// tierUsages returns the usage charged by each tiered RatingPlan within the EventCost
+func (ec *EventCost) tierUsages(tieredRPs map[string]*config.TierCounterCfg) (usages map[string]time.Duration) {
+	usages = make(map[string]time.Duration)
+	for _, cIl := range ec.Charges {
+		rating, has := ec.Rating[cIl.RatingID]
+		if !has {
+			continue
+		}
+		rpID, canCast := ec.RatingFilters[rating.RatingFiltersID][utils.RatingPlanID].(string)
+		if !canCast {
+			continue
+		}
+		if _, has := tieredRPs[rpID]; !has {
+			continue
+		}
+		usages[rpID] += *cIl.TotalUsage()
+	}
+	return
+}
+
+// updateTierCounters adds the usage charged in the CDR to the counters of the tiered RatingPlans
+// the usage is substracted if refund is true
+func (cdrS *CDRServer) updateTierCounters(cdr *CDR, ec *EventCost, refund bool) (err error) {
+	tieredRPs := cdrS.cgrCfg.RalsCfg().TieredRatingPlans
+	if len(tieredRPs) == 0 || ec == nil {
+		return
+	}
+	for rpID, usage := range ec.tierUsages(tieredRPs) {
+		if refund {
+			usage = -usage
+		}
+		tcCfg := tieredRPs[rpID]
+		tcID := tierCounterID(rpID, tcCfg, cdr.Account, cdr.Subject)
+		if err = cdrS.guard.Guard(func() (gErr error) {
+			tc, gErr := cdrS.dm.GetTierCounter(cdr.Tenant, tcID)
+			if gErr != nil {
+				if gErr != utils.ErrNotFound {
+					return
+				}
+				tc = &TierCounter{Tenant: cdr.Tenant, ID: tcID}
+			}
+			tc.AddUsage(ec.StartTime, tcCfg.Cycle, usage)
+			return cdrS.dm.SetTierCounter(tc)
+		}, cdrS.cgrCfg.GeneralCfg().LockingTimeout,
+			utils.TierCounterPrefix+utils.ConcatenatedKey(cdr.Tenant, tcID)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// tier returns the GroupIntervalStart of the rate applied on a timespan rated by a tiered RatingPlan
+func (ts *TimeSpan) tier() (tier string, has bool) {
+	if ts.ratingInfo == nil || ts.ratingInfo.TierUsage == nil ||
+		ts.RateInterval == nil || ts.RateInterval.Rating == nil {
+		return
+	}
+	grpStart := ts.GetGroupStart()
+	var tierStart time.Duration
+	for _, rate := range ts.RateInterval.Rating.Rates {
+		if rate.GroupIntervalStart <= grpStart &&
+			(!has || rate.GroupIntervalStart > tierStart) {
+			tierStart, has = rate.GroupIntervalStart, true
+		}
+	}
+	if has {
+		tier = tierStart.String()
+	}
+	return
+}
@@ -0,0 +1,242 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTierCycleStart(t *testing.T) {
+	tm := time.Date(2021, time.March, 18, 15, 4, 5, 0, time.UTC) // Thursday
+	for cycle, exp := range map[string]time.Time{
+		utils.MetaDaily:     time.Date(2021, time.March, 18, 0, 0, 0, 0, time.UTC),
+		utils.MetaWeekly:    time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC),
+		utils.MetaMonthly:   time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC),
+		utils.MetaYearly:    time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		utils.MetaUnlimited: {},
+	} {
+		if rcv := tierCycleStart(tm, cycle); !rcv.Equal(exp) {
+			t.Errorf("For cycle %s expected %v, received %v", cycle, exp, rcv)
+		}
+	}
+	sunday := time.Date(2021, time.March, 21, 23, 0, 0, 0, time.UTC)
+	if rcv, exp := tierCycleStart(sunday, utils.MetaWeekly),
+		time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC); !rcv.Equal(exp) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+}
+
+func TestTierCounterAddUsage(t *testing.T) {
+	tc := &TierCounter{Tenant: "cgrates.org", ID: "RP_TIER:*account:1001"}
+	march := time.Date(2021, time.March, 18, 15, 0, 0, 0, time.UTC)
+	tc.AddUsage(march, utils.MetaMonthly, time.Hour)
+	tc.AddUsage(march.Add(time.Hour), utils.MetaMonthly, 30*time.Minute)
+	if rcv := tc.UsageAt(march, utils.MetaMonthly); rcv != 90*time.Minute {
+		t.Errorf("Expected %v, received %v", 90*time.Minute, rcv)
+	}
+	april := time.Date(2021, time.April, 2, 10, 0, 0, 0, time.UTC)
+	if rcv := tc.UsageAt(april, utils.MetaMonthly); rcv != 0 {
+		t.Errorf("Expected usage reset on new cycle, received %v", rcv)
+	}
+	tc.AddUsage(april, utils.MetaMonthly, 10*time.Minute)
+	if rcv := tc.UsageAt(april, utils.MetaMonthly); rcv != 10*time.Minute {
+		t.Errorf("Expected %v, received %v", 10*time.Minute, rcv)
+	}
+	tc.AddUsage(march, utils.MetaMonthly, time.Hour) // older cycle, ignored
+	if rcv := tc.UsageAt(april, utils.MetaMonthly); rcv != 10*time.Minute {
+		t.Errorf("Expected %v, received %v", 10*time.Minute, rcv)
+	}
+	tc.AddUsage(april, utils.MetaMonthly, -time.Hour)
+	if rcv := tc.UsageAt(april, utils.MetaMonthly); rcv != 0 {
+		t.Errorf("Expected %v, received %v", 0, rcv)
+	}
+}
+
+func TestTierCounterID(t *testing.T) {
+	if rcv, exp := tierCounterID("RP_TIER", &config.TierCounterCfg{Counter: utils.MetaAccount},
+		"1001", "1002"), "RP_TIER:*account:1001"; rcv != exp {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+	if rcv, exp := tierCounterID("RP_TIER", &config.TierCounterCfg{Counter: utils.MetaSubject},
+		"1001", "1002"), "RP_TIER:*subject:1002"; rcv != exp {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+}
+
+func TestTimespanSplitTieredRates(t *testing.T) {
+	tierUsage := 50 * time.Minute
+	i := &RateInterval{
+		Timing: &RITiming{},
+		Rating: &RIRate{
+			Rates: RateGroups{
+				&RGRate{0, 2, time.Second, time.Second},
+				&RGRate{time.Hour, 1, time.Second, time.Second},
+			},
+		},
+	}
+	t1 := time.Date(2012, time.February, 3, 17, 30, 0, 0, time.UTC)
+	t2 := time.Date(2012, time.February, 3, 17, 50, 0, 0, time.UTC)
+	ts := &TimeSpan{TimeStart: t1, TimeEnd: t2, DurationIndex: 20 * time.Minute,
+		ratingInfo: &RatingInfo{TierUsage: &tierUsage}}
+	nts := ts.SplitByRateInterval(i, false)
+	if nts == nil {
+		t.Fatal("Expected the timespan to be split on tier boundary")
+	}
+	splitTime := time.Date(2012, time.February, 3, 17, 40, 0, 0, time.UTC)
+	if ts.TimeEnd != splitTime || nts.TimeStart != splitTime {
+		t.Errorf("Expected split at %v, received %v and %v", splitTime, ts.TimeEnd, nts.TimeStart)
+	}
+	if c1, c2 := ts.CalculateCost(), nts.CalculateCost(); c1 != 1200 || c2 != 600 {
+		t.Errorf("Wrong costs: %v %v", c1, c2)
+	}
+	if tier, has := ts.tier(); !has || tier != "0s" {
+		t.Errorf("Expected tier 0s, received %q", tier)
+	}
+	if tier, has := nts.tier(); !has || tier != "1h0m0s" {
+		t.Errorf("Expected tier 1h0m0s, received %q", tier)
+	}
+	ts.ratingInfo = &RatingInfo{}
+	if _, has := ts.tier(); has {
+		t.Error("Expected no tier for non-tiered RatingPlan")
+	}
+}
+
+func TestCallDescriptorLoadTierUsages(t *testing.T) {
+	tieredRPs := config.CgrConfig().RalsCfg().TieredRatingPlans
+	defer func() {
+		config.CgrConfig().RalsCfg().TieredRatingPlans = tieredRPs
+	}()
+	config.CgrConfig().RalsCfg().TieredRatingPlans = map[string]*config.TierCounterCfg{
+		"RP_TIER": {Counter: utils.MetaAccount, Cycle: utils.MetaMonthly},
+	}
+	tStart := time.Date(2021, time.March, 18, 15, 0, 0, 0, time.UTC)
+	tc := &TierCounter{Tenant: "cgrates.org", ID: "RP_TIER:*account:1001"}
+	tc.AddUsage(tStart, utils.MetaMonthly, 2*time.Hour)
+	if err := dm.SetTierCounter(tc); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveTierCounter(tc.Tenant, tc.ID)
+	cd := &CallDescriptor{
+		Tenant:    "cgrates.org",
+		Account:   "1001",
+		Subject:   "1001",
+		TimeStart: tStart,
+		RatingInfos: RatingInfos{
+			{RatingPlanId: "RP_TIER"},
+			{RatingPlanId: "RP_FLAT"},
+		},
+	}
+	if err := cd.loadTierUsages(); err != nil {
+		t.Fatal(err)
+	}
+	if ri := cd.RatingInfos[0]; ri.TierUsage == nil || *ri.TierUsage != 2*time.Hour {
+		t.Errorf("Expected tier usage %v, received %+v", 2*time.Hour, ri.TierUsage)
+	}
+	if ri := cd.RatingInfos[1]; ri.TierUsage != nil {
+		t.Errorf("Expected no tier usage, received %v", *ri.TierUsage)
+	}
+}
+
+func TestEventCostTierUsages(t *testing.T) {
+	tierUsage := 50 * time.Minute
+	tStart := time.Date(2012, time.February, 3, 17, 30, 0, 0, time.UTC)
+	ri := &RatingInfo{RatingPlanId: "RP_TIER", TierUsage: &tierUsage}
+	ts := &TimeSpan{
+		TimeStart:     tStart,
+		TimeEnd:       tStart.Add(20 * time.Minute),
+		DurationIndex: 20 * time.Minute,
+		RateInterval: &RateInterval{
+			Timing: &RITiming{},
+			Rating: &RIRate{Rates: RateGroups{
+				&RGRate{0, 2, time.Minute, time.Minute},
+				&RGRate{time.Hour, 1, time.Minute, time.Minute},
+			}},
+		},
+		Increments:     Increments{{Duration: time.Minute, Cost: 1, CompressFactor: 20}},
+		CompressFactor: 1,
+	}
+	ts.setRatingInfo(ri)
+	cc := &CallCost{Timespans: TimeSpans{ts}}
+	ec := NewEventCostFromCallCost(cc, "cgrid", utils.MetaDefault)
+	if tier := ec.RatingFilters[ec.Rating[ec.Charges[0].RatingID].RatingFiltersID][utils.Tier]; tier != "0s" {
+		t.Errorf("Expected tier 0s, received %v", tier)
+	}
+	usages := ec.tierUsages(map[string]*config.TierCounterCfg{"RP_TIER": {}})
+	if usages["RP_TIER"] != 20*time.Minute {
+		t.Errorf("Expected usage %v, received %v", 20*time.Minute, usages["RP_TIER"])
+	}
+	if usages = ec.tierUsages(nil); len(usages) != 0 {
+		t.Errorf("Expected no usages, received %v", usages)
+	}
+}
+
+func TestTierCountersRefundFailed(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RalsCfg().TieredRatingPlans = map[string]*config.TierCounterCfg{
+		"RP_TIER": {Counter: utils.MetaAccount, Cycle: utils.MetaUnlimited},
+	}
+	tmpDm := dm
+	defer func() { dm = tmpDm }()
+	dm = NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	cdrS := &CDRServer{
+		cgrCfg: cfg,
+		dm:     dm,
+		guard:  guardian.Guardian,
+	}
+	tc := &TierCounter{Tenant: "cgrates.org", ID: "RP_TIER:*account:1001"}
+	tc.AddUsage(time.Now(), utils.MetaUnlimited, time.Hour)
+	if err := dm.SetTierCounter(tc); err != nil {
+		t.Fatal(err)
+	}
+	ec := &EventCost{
+		CGRID:     "CGRID1",
+		StartTime: time.Now(),
+		Charges: []*ChargingInterval{{
+			RatingID:       "RATING1",
+			Increments:     []*ChargingIncrement{{Usage: time.Minute, CompressFactor: 1}},
+			CompressFactor: 1,
+		}},
+		Rating:        Rating{"RATING1": {RatingFiltersID: "RF1"}},
+		RatingFilters: RatingFilters{"RF1": {utils.RatingPlanID: "RP_TIER"}},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.CGRID:        "CGRID1",
+			utils.RequestType:  utils.MetaPostpaid,
+			utils.AccountField: "1001",
+			utils.CostDetails:  ec,
+		},
+	}
+	// no connection to RALs so the refund fails
+	if _, err := cdrS.processEvents([]*utils.CGREvent{ev},
+		false, false, true, false, false, false, false, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetTierCounter("cgrates.org", "RP_TIER:*account:1001"); err != nil {
+		t.Error(err)
+	} else if usage := rcv.UsageAt(time.Now(), utils.MetaUnlimited); usage != time.Hour {
+		t.Errorf("Expected the counters untouched, received usage: %v", usage)
+	}
+}
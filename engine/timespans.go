@@ -84,6 +84,7 @@ type MonetaryInfo struct {
 	ID           string
 	Value        float64
 	RateInterval *RateInterval
+	ExchangeRate float64 // rate converting the cost into the balance currency, 0 if not converted
 }
 
 func (mi *MonetaryInfo) Clone() *MonetaryInfo {
@@ -461,6 +462,14 @@ func (ts *TimeSpan) setRatingInfo(rp *RatingInfo) {
 	ts.RatingPlanId = rp.RatingPlanId
 }
 
+// currency returns the currency the TimeSpan was rated in
+func (ts *TimeSpan) currency() string {
+	if ts.ratingInfo == nil {
+		return utils.EmptyString
+	}
+	return ts.ratingInfo.Currency
+}
+
 func (ts *TimeSpan) createIncrementsSlice() {
 	if ts.RateInterval == nil {
 		return
@@ -673,11 +682,19 @@ func (ts *TimeSpan) GetGroupStart() time.Duration {
 	if s < 0 {
 		s = 0
 	}
-	return s
+	return ts.tierUsage() + s
 }
 
 func (ts *TimeSpan) GetGroupEnd() time.Duration {
-	return ts.DurationIndex
+	return ts.tierUsage() + ts.DurationIndex
+}
+
+// tierUsage returns the usage counted before the call for tiered RatingPlans
+func (ts *TimeSpan) tierUsage() time.Duration {
+	if ts.ratingInfo == nil || ts.ratingInfo.TierUsage == nil {
+		return 0
+	}
+	return *ts.ratingInfo.TierUsage
 }
 
 // sets the DurationIndex attribute to reflect new timespan
@@ -320,6 +320,30 @@ func (tpExp *TPExporter) Run() error {
 		toExportMap[utils.DispatcherHostsCsv] = append(toExportMap[utils.DispatcherHostsCsv], APItoModelTPDispatcherHost(sd))
 	}
 
+	storDataExchangeRates, err := tpExp.storDb.GetTPExchangeRateProfiles(tpExp.tpID, "", "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s, when getting %s from stordb for export", utils.ApierS, err, utils.TpExchangeRates))
+		withError = true
+	}
+	for _, sd := range storDataExchangeRates {
+		sdModels := APItoModelTPExchangeRateProfile(sd)
+		for _, sdModel := range sdModels {
+			toExportMap[utils.ExchangeRatesCsv] = append(toExportMap[utils.ExchangeRatesCsv], sdModel)
+		}
+	}
+
+	storDataLookupTables, err := tpExp.storDb.GetTPLookupTables(tpExp.tpID, "", "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: %s, when getting %s from stordb for export", utils.ApierS, err, utils.TpLookupTables))
+		withError = true
+	}
+	for _, sd := range storDataLookupTables {
+		sdModels := APItoModelTPLookupTable(sd)
+		for _, sdModel := range sdModels {
+			toExportMap[utils.LookupTablesCsv] = append(toExportMap[utils.LookupTablesCsv], sdModel)
+		}
+	}
+
 	if len(toExportMap) == 0 { // if we don't have anything to export we return not found error
 		return utils.ErrNotFound
 	}
@@ -60,6 +60,8 @@ var fileHandlers = map[string]func(*TPCSVImporter, string) error{
 	utils.ChargersCsv:           (*TPCSVImporter).importChargerProfiles,
 	utils.DispatcherProfilesCsv: (*TPCSVImporter).importDispatcherProfiles,
 	utils.DispatcherHostsCsv:    (*TPCSVImporter).importDispatcherHosts,
+	utils.ExchangeRatesCsv:      (*TPCSVImporter).importExchangeRates,
+	utils.LookupTablesCsv:       (*TPCSVImporter).importLookupTables,
 }
 
 func (tpImp *TPCSVImporter) Run() error {
@@ -357,3 +359,25 @@ func (tpImp *TPCSVImporter) importDispatcherHosts(fn string) error {
 	}
 	return tpImp.StorDb.SetTPDispatcherHosts(dpps)
 }
+
+func (tpImp *TPCSVImporter) importExchangeRates(fn string) error {
+	if tpImp.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	xrps, err := tpImp.csvr.GetTPExchangeRateProfiles(tpImp.TPid, "", "")
+	if err != nil {
+		return err
+	}
+	return tpImp.StorDb.SetTPExchangeRateProfiles(xrps)
+}
+
+func (tpImp *TPCSVImporter) importLookupTables(fn string) error {
+	if tpImp.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	lkts, err := tpImp.csvr.GetTPLookupTables(tpImp.TPid, "", "")
+	if err != nil {
+		return err
+	}
+	return tpImp.StorDb.SetTPLookupTables(lkts)
+}
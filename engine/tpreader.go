@@ -55,6 +55,8 @@ type TpReader struct {
 	chargerProfiles    map[utils.TenantID]*utils.TPChargerProfile
 	dispatcherProfiles map[utils.TenantID]*utils.TPDispatcherProfile
 	dispatcherHosts    map[utils.TenantID]*utils.TPDispatcherHost
+	exchangeRates      map[utils.TenantID]*utils.TPExchangeRateProfile
+	lookupTables       map[utils.TenantID]*utils.TPLookupTable
 	acntActionPlans    map[string][]string
 	cacheConns         []string
 	schedulerConns     []string
@@ -100,6 +102,8 @@ func (tpr *TpReader) Init() {
 	tpr.chargerProfiles = make(map[utils.TenantID]*utils.TPChargerProfile)
 	tpr.dispatcherProfiles = make(map[utils.TenantID]*utils.TPDispatcherProfile)
 	tpr.dispatcherHosts = make(map[utils.TenantID]*utils.TPDispatcherHost)
+	tpr.exchangeRates = make(map[utils.TenantID]*utils.TPExchangeRateProfile)
+	tpr.lookupTables = make(map[utils.TenantID]*utils.TPLookupTable)
 	tpr.filters = make(map[utils.TenantID]*utils.TPFilterProfile)
 	tpr.acntActionPlans = make(map[string][]string)
 }
@@ -207,9 +211,10 @@ func (tpr *TpReader) LoadRatingPlansFiltered(tag string) (bool, error) {
 	}
 
 	bindings := MapTPRatingPlanBindings(mpRpls)
+	currencies := MapTPRatingPlanCurrencies(mpRpls)
 
 	for tag, rplBnds := range bindings {
-		ratingPlan := &RatingPlan{Id: tag}
+		ratingPlan := &RatingPlan{Id: tag, Currency: currencies[tag]}
 		for _, rp := range rplBnds {
 			tm := tpr.timings
 			_, exists := tpr.timings[rp.TimingId]
@@ -286,6 +291,7 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 		return err
 	}
 	bindings := MapTPRatingPlanBindings(tps)
+	currencies := MapTPRatingPlanCurrencies(tps)
 	for tag, rplBnds := range bindings {
 		for _, rplBnd := range rplBnds {
 			t, exists := tpr.timings[rplBnd.TimingId]
@@ -299,7 +305,7 @@ func (tpr *TpReader) LoadRatingPlans() (err error) {
 			}
 			plan, exists := tpr.ratingPlans[tag]
 			if !exists {
-				plan = &RatingPlan{Id: tag}
+				plan = &RatingPlan{Id: tag, Currency: currencies[tag]}
 				tpr.ratingPlans[plan.Id] = plan
 			}
 			for _, dr := range drs.DestinationRates {
@@ -1273,6 +1279,40 @@ func (tpr *TpReader) LoadDispatcherHosts() error {
 	return tpr.LoadDispatcherHostsFiltered("")
 }
 
+func (tpr *TpReader) LoadExchangeRatesFiltered(tag string) (err error) {
+	xrps, err := tpr.lr.GetTPExchangeRateProfiles(tpr.tpid, "", tag)
+	if err != nil {
+		return err
+	}
+	mapExchangeRates := make(map[utils.TenantID]*utils.TPExchangeRateProfile)
+	for _, xrp := range xrps {
+		mapExchangeRates[utils.TenantID{Tenant: xrp.Tenant, ID: xrp.ID}] = xrp
+	}
+	tpr.exchangeRates = mapExchangeRates
+	return nil
+}
+
+func (tpr *TpReader) LoadExchangeRates() error {
+	return tpr.LoadExchangeRatesFiltered("")
+}
+
+func (tpr *TpReader) LoadLookupTablesFiltered(tag string) (err error) {
+	lkts, err := tpr.lr.GetTPLookupTables(tpr.tpid, "", tag)
+	if err != nil {
+		return err
+	}
+	mapLookupTables := make(map[utils.TenantID]*utils.TPLookupTable)
+	for _, lkt := range lkts {
+		mapLookupTables[utils.TenantID{Tenant: lkt.Tenant, ID: lkt.ID}] = lkt
+	}
+	tpr.lookupTables = mapLookupTables
+	return nil
+}
+
+func (tpr *TpReader) LoadLookupTables() error {
+	return tpr.LoadLookupTablesFiltered("")
+}
+
 func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadDestinations(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
@@ -1334,6 +1374,12 @@ func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadDispatcherHosts(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
+	if err = tpr.LoadExchangeRates(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
+	if err = tpr.LoadLookupTables(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
 	return nil
 }
 
@@ -1677,6 +1723,39 @@ func (tpr *TpReader) WriteToDatabase(verbose, disableReverse bool) (err error) {
 	if len(tpr.dispatcherHosts) != 0 {
 		loadIDs[utils.CacheDispatcherHosts] = loadID
 	}
+	if verbose {
+		log.Print("ExchangeRateProfiles:")
+	}
+	for _, tpXRP := range tpr.exchangeRates {
+		var xrp *ExchangeRateProfile
+		if xrp, err = APItoExchangeRateProfile(tpXRP, tpr.timezone); err != nil {
+			return
+		}
+		if err = tpr.dm.SetExchangeRateProfile(xrp); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", xrp.TenantID())
+		}
+	}
+	if len(tpr.exchangeRates) != 0 {
+		loadIDs[utils.CacheExchangeRateProfiles] = loadID
+	}
+	if verbose {
+		log.Print("LookupTables:")
+	}
+	for _, tpLkt := range tpr.lookupTables {
+		lkt := APItoLookupTable(tpLkt)
+		if err = tpr.dm.SetLookupTable(lkt); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", lkt.TenantID())
+		}
+	}
+	if len(tpr.lookupTables) != 0 {
+		loadIDs[utils.CacheLookupTables] = loadID
+	}
 
 	if verbose {
 		log.Print("Timings:")
@@ -1774,6 +1853,10 @@ func (tpr *TpReader) ShowStatistics() {
 	log.Print("DispatcherProfiles: ", len(tpr.dispatcherProfiles))
 	// Dispatcher Hosts
 	log.Print("DispatcherHosts: ", len(tpr.dispatcherHosts))
+	// Exchange rate profiles
+	log.Print("ExchangeRateProfiles: ", len(tpr.exchangeRates))
+	// Lookup tables
+	log.Print("LookupTables: ", len(tpr.lookupTables))
 }
 
 // GetLoadedIds returns the identities loaded for a specific category, useful for cache reloads
@@ -1932,6 +2015,24 @@ func (tpr *TpReader) GetLoadedIds(categ string) ([]string, error) {
 			i++
 		}
 		return keys, nil
+
+	case utils.ExchangeRateProfilePrefix:
+		keys := make([]string, len(tpr.exchangeRates))
+		i := 0
+		for k := range tpr.exchangeRates {
+			keys[i] = k.TenantID()
+			i++
+		}
+		return keys, nil
+
+	case utils.LookupTablePrefix: // the entries are cached individually
+		keys := make(utils.StringSet)
+		for k, tpLkt := range tpr.lookupTables {
+			for _, tpLE := range tpLkt.Entries {
+				keys.Add(utils.ConcatenatedKey(k.Tenant, k.ID, tpLE.Key))
+			}
+		}
+		return keys.AsSlice(), nil
 	}
 	return nil, errors.New("Unsupported load category")
 }
@@ -2116,6 +2217,28 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disableReverse bool) (err error
 			log.Print("\t", utils.ConcatenatedKey(tpDsh.Tenant, tpDsh.ID))
 		}
 	}
+	if verbose {
+		log.Print("ExchangeRateProfiles:")
+	}
+	for _, tpXRP := range tpr.exchangeRates {
+		if err = tpr.dm.RemoveExchangeRateProfile(tpXRP.Tenant, tpXRP.ID); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", utils.ConcatenatedKey(tpXRP.Tenant, tpXRP.ID))
+		}
+	}
+	if verbose {
+		log.Print("LookupTables:")
+	}
+	for _, tpLkt := range tpr.lookupTables {
+		if _, err = tpr.dm.RemoveLookupTable(tpLkt.Tenant, tpLkt.ID); err != nil {
+			return
+		}
+		if verbose {
+			log.Print("\t", utils.ConcatenatedKey(tpLkt.Tenant, tpLkt.ID))
+		}
+	}
 
 	if verbose {
 		log.Print("Timings:")
@@ -2214,6 +2337,12 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disableReverse bool) (err error
 	if len(tpr.dispatcherHosts) != 0 {
 		loadIDs[utils.CacheDispatcherHosts] = loadID
 	}
+	if len(tpr.exchangeRates) != 0 {
+		loadIDs[utils.CacheExchangeRateProfiles] = loadID
+	}
+	if len(tpr.lookupTables) != 0 {
+		loadIDs[utils.CacheLookupTables] = loadID
+	}
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
@@ -2247,32 +2376,36 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, opts map[string]i
 	chargerIDs, _ := tpr.GetLoadedIds(utils.ChargerProfilePrefix)
 	dppIDs, _ := tpr.GetLoadedIds(utils.DispatcherProfilePrefix)
 	dphIDs, _ := tpr.GetLoadedIds(utils.DispatcherHostPrefix)
+	xrpIDs, _ := tpr.GetLoadedIds(utils.ExchangeRateProfilePrefix)
+	lktIDs, _ := tpr.GetLoadedIds(utils.LookupTablePrefix)
 	aps, _ := tpr.GetLoadedIds(utils.ActionPlanPrefix)
 
 	//compose Reload Cache argument
 	cacheArgs := map[string][]string{
-		utils.CacheDestinations:        dstIds,
-		utils.CacheReverseDestinations: revDstIDs,
-		utils.CacheTimings:             tmgIds,
-		utils.CacheRatingPlans:         rplIds,
-		utils.CacheRatingProfiles:      rpfIds,
-		utils.CacheActions:             actIds,
-		utils.CacheActionPlans:         aps,
-		utils.CacheAccountActionPlans:  aapIDs,
-		utils.CacheSharedGroups:        shgIds,
-		utils.CacheResourceProfiles:    rspIDs,
-		utils.CacheResources:           rspIDs,
-		utils.CacheActionTriggers:      aatIDs,
-		utils.CacheStatQueues:          stqpIDs,
-		utils.CacheStatQueueProfiles:   stqpIDs,
-		utils.CacheThresholds:          trspfIDs,
-		utils.CacheThresholdProfiles:   trspfIDs,
-		utils.CacheFilters:             flrIDs,
-		utils.CacheRouteProfiles:       routeIDs,
-		utils.CacheAttributeProfiles:   apfIDs,
-		utils.CacheChargerProfiles:     chargerIDs,
-		utils.CacheDispatcherProfiles:  dppIDs,
-		utils.CacheDispatcherHosts:     dphIDs,
+		utils.CacheDestinations:         dstIds,
+		utils.CacheReverseDestinations:  revDstIDs,
+		utils.CacheTimings:              tmgIds,
+		utils.CacheRatingPlans:          rplIds,
+		utils.CacheRatingProfiles:       rpfIds,
+		utils.CacheActions:              actIds,
+		utils.CacheActionPlans:          aps,
+		utils.CacheAccountActionPlans:   aapIDs,
+		utils.CacheSharedGroups:         shgIds,
+		utils.CacheResourceProfiles:     rspIDs,
+		utils.CacheResources:            rspIDs,
+		utils.CacheActionTriggers:       aatIDs,
+		utils.CacheStatQueues:           stqpIDs,
+		utils.CacheStatQueueProfiles:    stqpIDs,
+		utils.CacheThresholds:           trspfIDs,
+		utils.CacheThresholdProfiles:    trspfIDs,
+		utils.CacheFilters:              flrIDs,
+		utils.CacheRouteProfiles:        routeIDs,
+		utils.CacheAttributeProfiles:    apfIDs,
+		utils.CacheChargerProfiles:      chargerIDs,
+		utils.CacheDispatcherProfiles:   dppIDs,
+		utils.CacheDispatcherHosts:      dphIDs,
+		utils.CacheExchangeRateProfiles: xrpIDs,
+		utils.CacheLookupTables:         lktIDs,
 	}
 
 	// verify if we need to clear indexes
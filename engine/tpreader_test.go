@@ -923,30 +923,32 @@ func TestTPReaderGetLoadedIdsError(t *testing.T) {
 func TestTPReaderReloadCache(t *testing.T) {
 	cfg := config.NewDefaultCGRConfig()
 	argExpect := &utils.AttrReloadCacheWithAPIOpts{
-		APIOpts:               map[string]interface{}{},
-		Tenant:                "cgrates.org",
-		ActionIDs:             []string{"ActionsID"},
-		ActionPlanIDs:         []string{"ActionPlansID"},
-		ActionTriggerIDs:      []string{"ActionTriggersID"},
-		DestinationIDs:        []string{"DestinationsID"},
-		TimingIDs:             []string{"TimingsID"},
-		RatingPlanIDs:         []string{"RatingPlansID"},
-		RatingProfileIDs:      []string{"RatingProfilesID"},
-		SharedGroupIDs:        []string{"SharedGroupsID"},
-		ResourceProfileIDs:    []string{"cgrates.org:resourceProfilesID"},
-		StatsQueueProfileIDs:  []string{"cgrates.org:statProfilesID"},
-		ThresholdProfileIDs:   []string{"cgrates.org:thresholdProfilesID"},
-		FilterIDs:             []string{"cgrates.org:filtersID"},
-		RouteProfileIDs:       []string{"cgrates.org:routeProfilesID"},
-		AttributeProfileIDs:   []string{"cgrates.org:attributeProfilesID"},
-		ChargerProfileIDs:     []string{"cgrates.org:chargerProfilesID"},
-		DispatcherProfileIDs:  []string{"cgrates.org:dispatcherProfilesID"},
-		DispatcherHostIDs:     []string{"cgrates.org:dispatcherHostsID"},
-		ResourceIDs:           []string{"cgrates.org:resourceProfilesID"},
-		StatsQueueIDs:         []string{"cgrates.org:statProfilesID"},
-		ThresholdIDs:          []string{"cgrates.org:thresholdProfilesID"},
-		AccountActionPlanIDs:  []string{"AccountActionPlansID"},
-		ReverseDestinationIDs: []string{},
+		APIOpts:                map[string]interface{}{},
+		Tenant:                 "cgrates.org",
+		ActionIDs:              []string{"ActionsID"},
+		ActionPlanIDs:          []string{"ActionPlansID"},
+		ActionTriggerIDs:       []string{"ActionTriggersID"},
+		DestinationIDs:         []string{"DestinationsID"},
+		TimingIDs:              []string{"TimingsID"},
+		RatingPlanIDs:          []string{"RatingPlansID"},
+		RatingProfileIDs:       []string{"RatingProfilesID"},
+		SharedGroupIDs:         []string{"SharedGroupsID"},
+		ResourceProfileIDs:     []string{"cgrates.org:resourceProfilesID"},
+		StatsQueueProfileIDs:   []string{"cgrates.org:statProfilesID"},
+		ThresholdProfileIDs:    []string{"cgrates.org:thresholdProfilesID"},
+		FilterIDs:              []string{"cgrates.org:filtersID"},
+		RouteProfileIDs:        []string{"cgrates.org:routeProfilesID"},
+		AttributeProfileIDs:    []string{"cgrates.org:attributeProfilesID"},
+		ChargerProfileIDs:      []string{"cgrates.org:chargerProfilesID"},
+		DispatcherProfileIDs:   []string{"cgrates.org:dispatcherProfilesID"},
+		DispatcherHostIDs:      []string{"cgrates.org:dispatcherHostsID"},
+		ExchangeRateProfileIDs: []string{"cgrates.org:EUR"},
+		LookupTableIDs:         []string{"cgrates.org:LRN:+4986517174963"},
+		ResourceIDs:            []string{"cgrates.org:resourceProfilesID"},
+		StatsQueueIDs:          []string{"cgrates.org:statProfilesID"},
+		ThresholdIDs:           []string{"cgrates.org:thresholdProfilesID"},
+		AccountActionPlanIDs:   []string{"AccountActionPlansID"},
+		ReverseDestinationIDs:  []string{},
 	}
 	rpcInternal := make(chan rpcclient.ClientConnector, 1)
 	rpcInternal <- &ccMock{
@@ -1019,6 +1021,17 @@ func TestTPReaderReloadCache(t *testing.T) {
 		dispatcherHosts: map[utils.TenantID]*utils.TPDispatcherHost{
 			{Tenant: "cgrates.org", ID: "dispatcherHostsID"}: {},
 		},
+		exchangeRates: map[utils.TenantID]*utils.TPExchangeRateProfile{
+			{Tenant: "cgrates.org", ID: "EUR"}: {},
+		},
+		lookupTables: map[utils.TenantID]*utils.TPLookupTable{
+			{Tenant: "cgrates.org", ID: "LRN"}: {
+				Entries: []*utils.TPLookupEntry{
+					{Key: "+4986517174963", FieldName: "LRN", Value: "+4986517174960"},
+					{Key: "+4986517174963", FieldName: "Carrier", Value: "CARRIER_1"},
+				},
+			},
+		},
 		acntActionPlans: map[string][]string{
 			"AccountActionPlansID": {},
 		},
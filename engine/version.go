@@ -44,6 +44,7 @@ var (
 		utils.CostDetails:   "cgr-migrator -exec=*cost_details",
 		utils.SessionSCosts: "cgr-migrator -exec=*sessions_costs",
 		utils.CDRs:          "cgr-migrator -exec=*cdrs",
+		utils.Invoices:      "cgr-migrator -exec=*invoices",
 	}
 	allVers map[string]string // init will fill this with a merge of data+stor
 )
@@ -176,7 +177,7 @@ func CurrentStorDBVersions() Versions {
 		utils.CostDetails:        2,
 		utils.SessionSCosts:      3,
 		utils.CDRs:               2,
-		utils.TpRatingPlans:      1,
+		utils.TpRatingPlans:      2,
 		utils.TpFilters:          1,
 		utils.TpDestinationRates: 1,
 		utils.TpActionTriggers:   1,
@@ -185,7 +186,7 @@ func CurrentStorDBVersions() Versions {
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
 		utils.TpRoutes:           1,
-		utils.TpStats:            1,
+		utils.TpStats:            2,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
 		utils.TpResources:        1,
@@ -197,6 +198,7 @@ func CurrentStorDBVersions() Versions {
 		utils.TpRatingProfile:    1,
 		utils.TpChargers:         1,
 		utils.TpDispatchers:      1,
+		utils.Invoices:           1,
 	}
 }
 
@@ -62,6 +62,9 @@ func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 			}
 		}
 		out, err := cfgFld.Value.ParseDataProvider(csvProvider)
+		if err == utils.ErrNotFound && !cfgFld.Mandatory {
+			continue // the optional columns can be missing out of the record
+		}
 		if err != nil {
 			return err
 		}
@@ -146,9 +149,12 @@ func (cP *csvProvider) FieldAsInterface(fldPath []string) (data interface{}, err
 		return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
 	}
 	var cfgFieldIdx int
-	if cfgFieldIdx, err = strconv.Atoi(fldPath[len(fldPath)-1]); err != nil || len(cP.req) <= cfgFieldIdx {
+	if cfgFieldIdx, err = strconv.Atoi(fldPath[len(fldPath)-1]); err != nil {
 		return nil, fmt.Errorf("Ignoring record: %q with error : %+v", cP.req, err)
 	}
+	if len(cP.req) <= cfgFieldIdx {
+		return nil, utils.ErrNotFound
+	}
 	data = cP.req[cfgFieldIdx]
 
 	cP.cache.Set(fldPath, data)
@@ -387,3 +387,35 @@ func TestLoadersFieldAsInterfaceError(t *testing.T) {
 		t.Errorf("Expected %+v, received %+q", expected, err)
 	}
 }
+
+func TestUpdateFromCsvOptionalColumns(t *testing.T) {
+	stsFlds := []*config.FCTemplate{
+		{Tag: "Tenant",
+			Path:      "Tenant",
+			Type:      utils.MetaVariable,
+			Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "ID",
+			Path:      "ID",
+			Type:      utils.MetaVariable,
+			Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "WindowType",
+			Path:  "WindowType",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+	}
+	lData := make(LoaderData)
+	if err := lData.UpdateFromCSV("Stats.csv", []string{"cgrates.org", "SQ_1"}, stsFlds,
+		"cgrates.org", nil); err != nil {
+		t.Error(err)
+	}
+	if exp := (LoaderData{"Tenant": "cgrates.org", "ID": "SQ_1"}); !reflect.DeepEqual(exp, lData) {
+		t.Errorf("Expected %+v, received %+v", exp, lData)
+	}
+	lData = make(LoaderData)
+	if err := lData.UpdateFromCSV("Stats.csv", []string{"cgrates.org"}, stsFlds,
+		"cgrates.org", nil); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
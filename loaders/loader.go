@@ -554,6 +554,59 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				cacheArgs[utils.CacheDispatcherHosts] = ids
 			}
 		}
+	case utils.MetaExchangeRates:
+		for _, lDataSet := range lds {
+			xrModels := make(engine.ExchangeRateMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				xrModels[i] = new(engine.ExchangeRateMdl)
+				if err = utils.UpdateStructWithIfaceMap(xrModels[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpXRP := range xrModels.AsTPExchangeRateProfiles() {
+				xrp, err := engine.APItoExchangeRateProfile(tpXRP, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRateProfile: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(xrp)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, xrp.TenantID())
+				if err := ldr.dm.SetExchangeRateProfile(xrp); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheExchangeRateProfiles] = ids
+			}
+		}
+	case utils.MetaLookupTables:
+		for _, lDataSet := range lds {
+			lktModels := make(engine.LookupTableMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				lktModels[i] = new(engine.LookupTableMdl)
+				if err = utils.UpdateStructWithIfaceMap(lktModels[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpLkt := range lktModels.AsTPLookupTables() {
+				lkt := engine.APItoLookupTable(tpLkt)
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: LookupTable: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(lkt)))
+					continue
+				}
+				// get IDs of the entries so we can reload in cache
+				ids = append(ids, lkt.EntryIDs()...)
+				if err := ldr.dm.SetLookupTable(lkt); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheLookupTables] = ids
+			}
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
@@ -801,6 +854,41 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				cacheArgs[utils.CacheDispatcherHosts] = ids
 			}
 		}
+	case utils.MetaExchangeRates:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRateProfileID: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveExchangeRateProfile(tntIDStruct.Tenant,
+					tntIDStruct.ID); err != nil {
+					return err
+				}
+				cacheArgs[utils.CacheExchangeRateProfiles] = ids
+			}
+		}
+	case utils.MetaLookupTables:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: LookupTableID: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs of the entries so we can remove them from cache
+				entryIDs, err := ldr.dm.RemoveLookupTable(tntIDStruct.Tenant,
+					tntIDStruct.ID)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, entryIDs...)
+				cacheArgs[utils.CacheLookupTables] = ids
+			}
+		}
 	}
 
 	if len(ldr.cacheConns) != 0 {
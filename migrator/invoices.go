@@ -0,0 +1,74 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package migrator
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func (m *Migrator) migrateCurrentInvoices() (err error) {
+	invs, err := m.storDBIn.StorDB().GetInvoices(utils.EmptyString, utils.EmptyString, utils.EmptyString)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	for _, inv := range invs {
+		if m.dryRun {
+			continue
+		}
+		if err = m.storDBOut.StorDB().SetInvoice(inv); err != nil {
+			return
+		}
+		m.stats[utils.Invoices]++
+	}
+	return
+}
+
+func (m *Migrator) migrateInvoices() (err error) {
+	var vrs engine.Versions
+	current := engine.CurrentStorDBVersions()
+	if vrs, err = m.getVersions(utils.Invoices); err != nil {
+		return
+	}
+	switch version := vrs[utils.Invoices]; version {
+	default:
+		return fmt.Errorf("Unsupported version %v", version)
+	case 0: // table created before being versioned
+		if m.dryRun {
+			break
+		}
+		if err = m.setVersions(utils.Invoices); err != nil {
+			return
+		}
+		fallthrough
+	case current[utils.Invoices]:
+		if m.sameStorDB {
+			break
+		}
+		if err = m.migrateCurrentInvoices(); err != nil {
+			return
+		}
+	}
+	return m.ensureIndexesStorDB(utils.InvoicesTBL)
+}
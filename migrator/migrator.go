@@ -109,6 +109,8 @@ func (m *Migrator) Migrate(taskIDs []string) (err error, stats map[string]int) {
 			err = m.migrateCDRs()
 		case utils.MetaSessionsCosts:
 			err = m.migrateSessionSCosts()
+		case utils.MetaInvoices:
+			err = m.migrateInvoices()
 		case utils.MetaAccounts:
 			err = m.migrateAccounts()
 		case utils.MetaActionPlans:
@@ -323,6 +325,9 @@ func (m *Migrator) Migrate(taskIDs []string) (err error, stats map[string]int) {
 			if err := m.migrateSessionSCosts(); err != nil {
 				log.Print("ERROR: ", utils.MetaSessionsCosts, " ", err)
 			}
+			if err := m.migrateInvoices(); err != nil {
+				log.Print("ERROR: ", utils.MetaInvoices, " ", err)
+			}
 			err = nil
 		}
 	}
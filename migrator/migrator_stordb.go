@@ -28,6 +28,8 @@ type MigratorStorDB interface {
 	remV1CDRs(v1Cdr *v1Cdrs) (err error)
 	createV1SMCosts() (err error)
 	renameV1SMCosts() (err error)
+	addV1TPRatingPlansCurrency() (err error)
+	addV1TPStatsWindows() (err error)
 	getV2SMCost() (v2Cost *v2SessionsCost, err error)
 	setV2SMCost(v2Cost *v2SessionsCost) (err error)
 	remV2SMCost(v2Cost *v2SessionsCost) (err error)
@@ -78,7 +78,8 @@ func NewMigratorStorDB(db_type, host, port, name, user, pass, marshaler string,
 	return
 }
 func (m *Migrator) getVersions(str string) (vrs engine.Versions, err error) {
-	if str == utils.CDRs || str == utils.SessionSCosts || strings.HasPrefix(str, "Tp") {
+	if str == utils.CDRs || str == utils.SessionSCosts || str == utils.Invoices ||
+		strings.HasPrefix(str, "Tp") {
 		vrs, err = m.storDBIn.StorDB().GetVersions(utils.EmptyString)
 	} else {
 		vrs, err = m.dmIN.DataManager().DataDB().GetVersions(utils.EmptyString)
@@ -98,7 +99,8 @@ func (m *Migrator) getVersions(str string) (vrs engine.Versions, err error) {
 }
 
 func (m *Migrator) setVersions(str string) (err error) {
-	if str == utils.CDRs || str == utils.SessionSCosts || strings.HasPrefix(str, "Tp") {
+	if str == utils.CDRs || str == utils.SessionSCosts || str == utils.Invoices ||
+		strings.HasPrefix(str, "Tp") {
 		vrs := engine.Versions{str: engine.CurrentStorDBVersions()[str]}
 		err = m.storDBOut.StorDB().SetVersions(vrs, false)
 	} else {
@@ -67,6 +67,14 @@ func (iDBMig *internalStorDBMigrator) createV1SMCosts() (err error) {
 	return utils.ErrNotImplemented
 }
 
+func (iDBMig *internalStorDBMigrator) addV1TPRatingPlansCurrency() (err error) {
+	return
+}
+
+func (iDBMig *internalStorDBMigrator) addV1TPStatsWindows() (err error) {
+	return
+}
+
 //get
 func (iDBMig *internalStorDBMigrator) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	return nil, utils.ErrNotImplemented
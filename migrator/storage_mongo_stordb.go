@@ -97,6 +97,18 @@ func (v1ms *mongoStorDBMigrator) createV1SMCosts() (err error) {
 		bson.D{{Key: "create", Value: utils.OldSMCosts}, {Key: "size", Value: 1024}, {Key: "capped", Value: true}}).Err()
 }
 
+// addV1TPRatingPlansCurrency has nothing to change since the documents without currency
+// are decoded with the default one
+func (v1ms *mongoStorDBMigrator) addV1TPRatingPlansCurrency() (err error) {
+	return
+}
+
+// addV1TPStatsWindows has nothing to change since the documents without windows
+// are decoded with no windows
+func (v1ms *mongoStorDBMigrator) addV1TPStatsWindows() (err error) {
+	return
+}
+
 //get
 func (v1ms *mongoStorDBMigrator) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	if v1ms.cursor == nil {
@@ -137,6 +137,27 @@ func (mgSQL *migratorSQL) createV1SMCosts() (err error) {
 	return
 }
 
+// addV1TPRatingPlansCurrency adds the currency column to the tp_rating_plans table
+func (mgSQL *migratorSQL) addV1TPRatingPlansCurrency() (err error) {
+	_, err = mgSQL.sqlStorage.Db.Exec("ALTER TABLE tp_rating_plans ADD COLUMN currency VARCHAR(8) NOT NULL DEFAULT ''")
+	return
+}
+
+// addV1TPStatsWindows adds the time window columns to the tp_stats table
+func (mgSQL *migratorSQL) addV1TPStatsWindows() (err error) {
+	for _, col := range []string{
+		"window_type VARCHAR(16) NOT NULL DEFAULT ''",
+		"window_size VARCHAR(32) NOT NULL DEFAULT ''",
+		"window_slide VARCHAR(32) NOT NULL DEFAULT ''",
+		"window_count INTEGER NOT NULL DEFAULT 0",
+	} {
+		if _, err = mgSQL.sqlStorage.Db.Exec("ALTER TABLE tp_stats ADD COLUMN " + col); err != nil {
+			return
+		}
+	}
+	return
+}
+
 func (mgSQL *migratorSQL) getV2SMCost() (v2Cost *v2SessionsCost, err error) {
 	if mgSQL.rowIter == nil {
 		mgSQL.rowIter, err = mgSQL.sqlStorage.Db.Query("SELECT * FROM session_costs")
@@ -65,6 +65,22 @@ func (m *Migrator) migrateTPratingplans() (err error) {
 		return
 	}
 	switch vrs[utils.TpRatingPlans] {
+	case 1:
+		// the currency column was added to the RatingPlans, empty for the default currency
+		if m.dryRun {
+			break
+		}
+		if err = m.storDBIn.addV1TPRatingPlansCurrency(); err != nil {
+			return
+		}
+		if !m.sameStorDB {
+			if err = m.migrateCurrentTPratingplans(); err != nil {
+				return
+			}
+		}
+		if err = m.setVersions(utils.TpRatingPlans); err != nil {
+			return
+		}
 	case current[utils.TpRatingPlans]:
 		if m.sameStorDB {
 			break
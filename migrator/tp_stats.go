@@ -65,6 +65,22 @@ func (m *Migrator) migrateTPstats() (err error) {
 		return
 	}
 	switch vrs[utils.TpStats] {
+	case 1:
+		// the time window columns were added to the Stats, empty for no windows
+		if m.dryRun {
+			break
+		}
+		if err = m.storDBIn.addV1TPStatsWindows(); err != nil {
+			return
+		}
+		if !m.sameStorDB {
+			if err = m.migrateCurrentTPstats(); err != nil {
+				return
+			}
+		}
+		if err = m.setVersions(utils.TpStats); err != nil {
+			return
+		}
 	case current[utils.TpStats]:
 		if m.sameStorDB {
 			break
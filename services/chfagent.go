@@ -0,0 +1,132 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewCHFAgent returns the CHF Agent
+func NewCHFAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &CHFAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		connMgr:     connMgr,
+		srvDep:      srvDep,
+	}
+}
+
+// CHFAgent implements Agent interface
+type CHFAgent struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	shdChan     *utils.SyncedChan
+
+	chf     *agents.CHFAgent
+	connMgr *engine.ConnManager
+	srvDep  map[string]*sync.WaitGroup
+
+	oldListen string
+}
+
+// Start should handle the sercive start
+func (chf *CHFAgent) Start() (err error) {
+	if chf.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	filterS := <-chf.filterSChan
+	chf.filterSChan <- filterS
+
+	chf.Lock()
+	defer chf.Unlock()
+	chf.oldListen = chf.cfg.CHFAgentCfg().Listen
+	chf.chf, err = agents.NewCHFAgent(chf.cfg, filterS, chf.connMgr)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.CHFAgent, err.Error()))
+		chf.chf = nil
+		return
+	}
+	go chf.listenAndServe()
+	return
+}
+
+// Reload handles the change of config
+func (chf *CHFAgent) Reload() (err error) {
+	if chf.oldListen == chf.cfg.CHFAgentCfg().Listen {
+		return
+	}
+	chf.Lock()
+	defer chf.Unlock()
+	if err = chf.chf.Shutdown(); err != nil {
+		return
+	}
+	chf.oldListen = chf.cfg.CHFAgentCfg().Listen
+	if err = chf.chf.Reload(); err != nil {
+		return
+	}
+	go chf.listenAndServe()
+	return
+}
+
+func (chf *CHFAgent) listenAndServe() (err error) {
+	if err = chf.chf.ListenAndServe(); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.CHFAgent, err.Error()))
+		chf.shdChan.CloseOnce() // stop the engine here
+	}
+	return
+}
+
+// Shutdown stops the service
+func (chf *CHFAgent) Shutdown() (err error) {
+	chf.Lock()
+	defer chf.Unlock()
+	if err = chf.chf.Shutdown(); err != nil {
+		return
+	}
+	chf.chf = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (chf *CHFAgent) IsRunning() bool {
+	chf.RLock()
+	defer chf.RUnlock()
+	return chf != nil && chf.chf != nil
+}
+
+// ServiceName returns the service name
+func (chf *CHFAgent) ServiceName() string {
+	return utils.CHFAgent
+}
+
+// ShouldRun returns if the service should be running
+func (chf *CHFAgent) ShouldRun() bool {
+	return chf.cfg.CHFAgentCfg().Enabled
+}
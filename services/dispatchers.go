@@ -65,6 +65,7 @@ type DispatcherService struct {
 	rpc      *v1.DispatcherSv1
 	connChan chan rpcclient.ClientConnector
 	anz      *AnalyzerService
+	stopChan chan struct{}
 	srvDep   map[string]*sync.WaitGroup
 }
 
@@ -87,6 +88,8 @@ func (dspS *DispatcherService) Start() (err error) {
 	defer dspS.Unlock()
 
 	dspS.dspS = dispatchers.NewDispatcherService(datadb, dspS.cfg, fltrS, dspS.connMgr)
+	dspS.stopChan = make(chan struct{})
+	go dspS.dspS.ListenAndServe(dspS.stopChan)
 
 	// for the moment we dispable Apier through dispatcher
 	// until we figured out a better sollution in case of gob server
@@ -156,6 +159,7 @@ func (dspS *DispatcherService) Reload() (err error) {
 func (dspS *DispatcherService) Shutdown() (err error) {
 	dspS.Lock()
 	defer dspS.Unlock()
+	close(dspS.stopChan)
 	dspS.dspS.Shutdown()
 	dspS.dspS = nil
 	dspS.rpc = nil
@@ -58,6 +58,7 @@ func TestDispatcherSCoverage(t *testing.T) {
 		connChan:    make(chan rpcclient.ClientConnector, 1),
 		anz:         anz,
 		srvDep:      srvDep,
+		stopChan:    make(chan struct{}),
 	}
 	srv2.dspS = &dispatchers.DispatcherService{}
 	if !srv2.IsRunning() {
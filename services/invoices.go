@@ -0,0 +1,132 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewInvoiceService returns the InvoiceS service
+func NewInvoiceService(cfg *config.CGRConfig, dm *DataDBService,
+	storDB *StorDBService, server *cores.Server,
+	internalInvoiceSChan chan rpcclient.ClientConnector,
+	connMgr *engine.ConnManager, anz *AnalyzerService,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &InvoiceService{
+		connChan: internalInvoiceSChan,
+		cfg:      cfg,
+		dm:       dm,
+		storDB:   storDB,
+		server:   server,
+		connMgr:  connMgr,
+		anz:      anz,
+		srvDep:   srvDep,
+	}
+}
+
+// InvoiceService implements Service interface
+type InvoiceService struct {
+	sync.RWMutex
+	cfg    *config.CGRConfig
+	dm     *DataDBService
+	storDB *StorDBService
+	server *cores.Server
+
+	invS     *engine.InvoiceService
+	rpc      *v1.InvoiceSv1
+	connChan chan rpcclient.ClientConnector
+	connMgr  *engine.ConnManager
+
+	stopChan chan struct{}
+	anz      *AnalyzerService
+	srvDep   map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (invService *InvoiceService) Start() (err error) {
+	if invService.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.InvoiceS))
+
+	dbchan := invService.dm.GetDMChan()
+	datadb := <-dbchan
+	dbchan <- datadb
+
+	storDBChan := make(chan engine.StorDB, 1)
+	invService.stopChan = make(chan struct{})
+	invService.storDB.RegisterSyncChan(storDBChan)
+
+	invService.Lock()
+	defer invService.Unlock()
+
+	invService.invS = engine.NewInvoiceService(invService.cfg, storDBChan, datadb, invService.connMgr)
+	go invService.invS.ListenAndServe(invService.stopChan)
+	runtime.Gosched()
+	invService.rpc = v1.NewInvoiceSv1(invService.invS)
+	if !invService.cfg.DispatcherSCfg().Enabled {
+		invService.server.RpcRegister(invService.rpc)
+	}
+	invService.connChan <- invService.anz.GetInternalCodec(invService.invS, utils.InvoiceS)
+	return
+}
+
+// Reload handles the change of config
+func (invService *InvoiceService) Reload() (err error) {
+	return
+}
+
+// Shutdown stops the service
+func (invService *InvoiceService) Shutdown() (err error) {
+	invService.Lock()
+	close(invService.stopChan)
+	invService.invS = nil
+	invService.rpc = nil
+	<-invService.connChan
+	invService.Unlock()
+	return
+}
+
+// IsRunning returns if the service is running
+func (invService *InvoiceService) IsRunning() bool {
+	invService.RLock()
+	defer invService.RUnlock()
+	return invService != nil && invService.invS != nil
+}
+
+// ServiceName returns the service name
+func (invService *InvoiceService) ServiceName() string {
+	return utils.InvoiceS
+}
+
+// ShouldRun returns if the service should be running
+func (invService *InvoiceService) ShouldRun() bool {
+	return invService.cfg.InvoiceSCfg().Enabled
+}
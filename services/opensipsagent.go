@@ -0,0 +1,113 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewOpenSIPSAgent returns the OpenSIPS Agent
+func NewOpenSIPSAgent(cfg *config.CGRConfig,
+	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &OpenSIPSAgent{
+		cfg:     cfg,
+		shdChan: shdChan,
+		connMgr: connMgr,
+		srvDep:  srvDep,
+	}
+}
+
+// OpenSIPSAgent implements Agent interface
+type OpenSIPSAgent struct {
+	sync.RWMutex
+	cfg     *config.CGRConfig
+	shdChan *utils.SyncedChan
+
+	osips   *agents.OpenSIPSAgent
+	connMgr *engine.ConnManager
+	srvDep  map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (osa *OpenSIPSAgent) Start() (err error) {
+	if osa.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	osa.Lock()
+	defer osa.Unlock()
+
+	osa.osips = agents.NewOpenSIPSAgent(osa.cfg.OsipsAgentCfg(), osa.connMgr,
+		utils.FirstNonEmpty(osa.cfg.OsipsAgentCfg().Timezone, osa.cfg.GeneralCfg().DefaultTimezone))
+	go osa.listenAndServe(osa.osips)
+	return
+}
+
+func (osa *OpenSIPSAgent) listenAndServe(oa *agents.OpenSIPSAgent) {
+	if err := oa.ListenAndServe(); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: %s", utils.OpenSIPSAgent, err))
+		osa.shdChan.CloseOnce()
+	}
+}
+
+// Reload handles the change of config
+func (osa *OpenSIPSAgent) Reload() (err error) {
+	osa.Lock()
+	defer osa.Unlock()
+	if err = osa.osips.Shutdown(); err != nil {
+		return
+	}
+	osa.osips.Reload()
+	go osa.listenAndServe(osa.osips)
+	return
+}
+
+// Shutdown stops the service
+func (osa *OpenSIPSAgent) Shutdown() (err error) {
+	osa.Lock()
+	defer osa.Unlock()
+	err = osa.osips.Shutdown()
+	osa.osips = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (osa *OpenSIPSAgent) IsRunning() bool {
+	osa.RLock()
+	defer osa.RUnlock()
+	return osa != nil && osa.osips != nil
+}
+
+// ServiceName returns the service name
+func (osa *OpenSIPSAgent) ServiceName() string {
+	return utils.OpenSIPSAgent
+}
+
+// ShouldRun returns if the service should be running
+func (osa *OpenSIPSAgent) ShouldRun() bool {
+	return osa.cfg.OsipsAgentCfg().Enabled
+}
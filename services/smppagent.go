@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewSMPPAgent returns the SMPP Agent
+func NewSMPPAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &SMPPAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		connMgr:     connMgr,
+		srvDep:      srvDep,
+	}
+}
+
+// SMPPAgent implements Agent interface
+type SMPPAgent struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	shdChan     *utils.SyncedChan
+
+	smpp    *agents.SMPPAgent
+	connMgr *engine.ConnManager
+	srvDep  map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (sa *SMPPAgent) Start() (err error) {
+	if sa.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	filterS := <-sa.filterSChan
+	sa.filterSChan <- filterS
+
+	sa.Lock()
+	defer sa.Unlock()
+	sa.smpp = agents.NewSMPPAgent(sa.cfg, filterS, sa.connMgr)
+	go sa.listenAndServe()
+	return
+}
+
+// Reload handles the change of config
+// rebinding all the connections since the SMSCs could have changed
+func (sa *SMPPAgent) Reload() (err error) {
+	sa.Lock()
+	defer sa.Unlock()
+	if err = sa.smpp.Shutdown(); err != nil {
+		return
+	}
+	if err = sa.smpp.Reload(); err != nil {
+		return
+	}
+	go sa.listenAndServe()
+	return
+}
+
+func (sa *SMPPAgent) listenAndServe() (err error) {
+	if err = sa.smpp.ListenAndServe(); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.SMPPAgent, err.Error()))
+		sa.shdChan.CloseOnce() // stop the engine here
+	}
+	return
+}
+
+// Shutdown stops the service
+func (sa *SMPPAgent) Shutdown() (err error) {
+	sa.Lock()
+	defer sa.Unlock()
+	if err = sa.smpp.Shutdown(); err != nil {
+		return
+	}
+	sa.smpp = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (sa *SMPPAgent) IsRunning() bool {
+	sa.RLock()
+	defer sa.RUnlock()
+	return sa != nil && sa.smpp != nil
+}
+
+// ServiceName returns the service name
+func (sa *SMPPAgent) ServiceName() string {
+	return utils.SMPPAgent
+}
+
+// ShouldRun returns if the service should be running
+func (sa *SMPPAgent) ShouldRun() bool {
+	return sa.cfg.SMPPAgentCfg().Enabled
+}
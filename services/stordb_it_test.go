@@ -181,7 +181,7 @@ func TestStorDBReloadVersion1(t *testing.T) {
 		utils.SessionSCosts: 3,
 		//old version for CDRs
 		utils.CDRs:               1,
-		utils.TpRatingPlans:      1,
+		utils.TpRatingPlans:      2,
 		utils.TpFilters:          1,
 		utils.TpDestinationRates: 1,
 		utils.TpActionTriggers:   1,
@@ -190,7 +190,7 @@ func TestStorDBReloadVersion1(t *testing.T) {
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
 		utils.TpRoutes:           1,
-		utils.TpStats:            1,
+		utils.TpStats:            2,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
 		utils.TpResources:        1,
@@ -263,7 +263,7 @@ func TestStorDBReloadVersion2(t *testing.T) {
 		utils.SessionSCosts: 3,
 		//old version for CDRs
 		utils.CDRs:               1,
-		utils.TpRatingPlans:      1,
+		utils.TpRatingPlans:      2,
 		utils.TpFilters:          1,
 		utils.TpDestinationRates: 1,
 		utils.TpActionTriggers:   1,
@@ -272,7 +272,7 @@ func TestStorDBReloadVersion2(t *testing.T) {
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
 		utils.TpRoutes:           1,
-		utils.TpStats:            1,
+		utils.TpStats:            2,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
 		utils.TpResources:        1,
@@ -341,7 +341,7 @@ func TestStorDBReloadVersion3(t *testing.T) {
 		utils.SessionSCosts: 3,
 		//old version for CDRs
 		utils.CDRs:               1,
-		utils.TpRatingPlans:      1,
+		utils.TpRatingPlans:      2,
 		utils.TpFilters:          1,
 		utils.TpDestinationRates: 1,
 		utils.TpActionTriggers:   1,
@@ -350,7 +350,7 @@ func TestStorDBReloadVersion3(t *testing.T) {
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
 		utils.TpRoutes:           1,
-		utils.TpStats:            1,
+		utils.TpStats:            2,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
 		utils.TpResources:        1,
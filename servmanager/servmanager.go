@@ -209,16 +209,24 @@ func (srvMngr *ServiceManager) handleReload() {
 			}()
 		case <-srvMngr.GetConfig().GetReloadChan(config.CDRS_JSN):
 			go srvMngr.reloadService(utils.CDRServer)
+		case <-srvMngr.GetConfig().GetReloadChan(config.InvoiceSCfgJson):
+			go srvMngr.reloadService(utils.InvoiceS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.SessionSJson):
 			go srvMngr.reloadService(utils.SessionS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.ERsJson):
 			go srvMngr.reloadService(utils.ERs)
 		case <-srvMngr.GetConfig().GetReloadChan(config.DNSAgentJson):
 			go srvMngr.reloadService(utils.DNSAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.CHFAgentJson):
+			go srvMngr.reloadService(utils.CHFAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.SMPPAgentJson):
+			go srvMngr.reloadService(utils.SMPPAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.FreeSWITCHAgentJSN):
 			go srvMngr.reloadService(utils.FreeSWITCHAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.KamailioAgentJSN):
 			go srvMngr.reloadService(utils.KamailioAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.OsipsAgentJSN):
+			go srvMngr.reloadService(utils.OpenSIPSAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.AsteriskAgentJSN):
 			go srvMngr.reloadService(utils.AsteriskAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.RA_JSN):
@@ -20,6 +20,7 @@ package sessions
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -299,3 +300,67 @@ func getDerivedMaxUsage(maxUsages map[string]time.Duration, derivedReply bool) (
 	out[utils.MetaRaw] = maxUsage
 	return
 }
+
+// RatingGroupReply is the answer for one rating group within Multiple-Services Credit Control
+type RatingGroupReply struct {
+	MaxUsage            time.Duration
+	FinalUnitIndication bool // the granted usage is lower than the requested one, no more units after it
+}
+
+// getMSCC returns the Multiple-Services Credit Control within the event, indexed on rating group
+func getMSCC(ev engine.MapEvent) (mscc map[string]engine.MapEvent, err error) {
+	iface, has := ev[utils.MSCC]
+	if !has || iface == nil {
+		return
+	}
+	var rgs map[string]interface{}
+	switch v := iface.(type) {
+	case map[string]interface{}:
+		rgs = v
+	case engine.MapEvent:
+		rgs = v
+	case map[string]engine.MapEvent:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot cast <%s> field: %s", utils.MSCC, utils.ToJSON(iface))
+	}
+	mscc = make(map[string]engine.MapEvent)
+	for rg, rgIface := range rgs {
+		switch rgEv := rgIface.(type) {
+		case map[string]interface{}:
+			mscc[rg] = rgEv
+		case engine.MapEvent:
+			mscc[rg] = rgEv
+		default:
+			return nil, fmt.Errorf("cannot cast <%s> field for rating group <%s>: %s",
+				utils.MSCC, rg, utils.ToJSON(rgIface))
+		}
+	}
+	return
+}
+
+// newRatingGroupEvent derives the event of a rating group session run out of the one received from ChargerS
+func newRatingGroupEvent(chrgrEv, rgEv engine.MapEvent, rg string) (ev engine.MapEvent) {
+	ev = chrgrEv.Clone()
+	for k, v := range rgEv {
+		if k == utils.Usage || k == utils.LastUsed {
+			continue
+		}
+		ev[k] = v
+	}
+	ev[utils.RatingGroup] = rg
+	ev[utils.RunID] = utils.ConcatenatedKey(chrgrEv.GetStringIgnoreErrors(utils.RunID), rg)
+	return
+}
+
+// msccAsDataNode returns the rating groups reply as DataNode, indexed on rating group
+func msccAsDataNode(mscc map[string]*RatingGroupReply) (nd *utils.DataNode) {
+	nd = &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	for rg, rgRply := range mscc {
+		nd.Map[rg] = &utils.DataNode{Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			utils.CapMaxUsage:         utils.NewLeafNode(rgRply.MaxUsage),
+			utils.FinalUnitIndication: utils.NewLeafNode(rgRply.FinalUnitIndication),
+		}}
+	}
+	return
+}
@@ -132,6 +132,69 @@ func (s *Session) Clone() (cln *Session) {
 	return
 }
 
+// asStoredSession converts the session into the form stored in DataDB (thread safe)
+func (s *Session) asStoredSession() (ss *engine.StoredSession) {
+	s.RLock()
+	ss = &engine.StoredSession{
+		CGRID:         s.CGRID,
+		Tenant:        s.Tenant,
+		ResourceID:    s.ResourceID,
+		ClientConnID:  s.ClientConnID,
+		EventStart:    s.EventStart.Clone(),
+		DebitInterval: s.DebitInterval,
+		Chargeable:    s.Chargeable,
+		OptsStart:     s.OptsStart.Clone(),
+	}
+	if s.SRuns != nil {
+		ss.SRuns = make([]*engine.StoredSRun, len(s.SRuns))
+		for i, sr := range s.SRuns {
+			srCln := sr.Clone()
+			ss.SRuns[i] = &engine.StoredSRun{
+				Event:         srCln.Event,
+				CD:            srCln.CD,
+				EventCost:     srCln.EventCost,
+				ExtraDuration: srCln.ExtraDuration,
+				LastUsage:     srCln.LastUsage,
+				TotalUsage:    srCln.TotalUsage,
+				NextAutoDebit: srCln.NextAutoDebit,
+				RatingGroup:   srCln.RatingGroup,
+			}
+		}
+	}
+	s.RUnlock()
+	return
+}
+
+// newSessionFromStoredSession recreates the session out of the one stored in DataDB
+func newSessionFromStoredSession(ss *engine.StoredSession) (s *Session) {
+	s = &Session{
+		CGRID:         ss.CGRID,
+		Tenant:        ss.Tenant,
+		ResourceID:    ss.ResourceID,
+		ClientConnID:  ss.ClientConnID,
+		EventStart:    ss.EventStart,
+		DebitInterval: ss.DebitInterval,
+		Chargeable:    ss.Chargeable,
+		OptsStart:     ss.OptsStart,
+	}
+	if ss.SRuns != nil {
+		s.SRuns = make([]*SRun, len(ss.SRuns))
+		for i, sr := range ss.SRuns {
+			s.SRuns[i] = &SRun{
+				Event:         sr.Event,
+				CD:            sr.CD,
+				EventCost:     sr.EventCost,
+				ExtraDuration: sr.ExtraDuration,
+				LastUsage:     sr.LastUsage,
+				TotalUsage:    sr.TotalUsage,
+				NextAutoDebit: sr.NextAutoDebit,
+				RatingGroup:   sr.RatingGroup,
+			}
+		}
+	}
+	return
+}
+
 // AsExternalSessions returns the session as a list of ExternalSession using all SRuns (thread safe)
 func (s *Session) AsExternalSessions(tmz, nodeID string) (aSs []*ExternalSession) {
 	s.RLock()
@@ -264,6 +327,7 @@ type SRun struct {
 	LastUsage     time.Duration // last requested Duration
 	TotalUsage    time.Duration // sum of lastUsage
 	NextAutoDebit *time.Time
+	RatingGroup   string // populated for the runs forked out of Multiple-Services Credit Control
 }
 
 // Clone returns the cloned version of SRun
@@ -273,6 +337,7 @@ func (sr *SRun) Clone() (clsr *SRun) {
 		ExtraDuration: sr.ExtraDuration,
 		LastUsage:     sr.LastUsage,
 		TotalUsage:    sr.TotalUsage,
+		RatingGroup:   sr.RatingGroup,
 	}
 	if sr.CD != nil {
 		clsr.CD = sr.CD.Clone()
@@ -324,6 +389,36 @@ func (s *Session) updateSRuns(updEv engine.MapEvent, alterableFields utils.Strin
 	}
 }
 
+// hasRatingGroups returns true if the credit of the session is controlled per rating group
+// not thread-safe, it should be protected in another layer
+func (s *Session) hasRatingGroups() bool {
+	for _, sr := range s.SRuns {
+		if sr.RatingGroup != utils.EmptyString {
+			return true
+		}
+	}
+	return false
+}
+
+// updateMSCCUsage sets the total usage of the rating group runs out of the credit control received on terminate
+// the runs of the rating groups not present keep their usage
+func (s *Session) updateMSCCUsage(mscc map[string]engine.MapEvent) {
+	for _, sr := range s.SRuns {
+		rgEv, has := mscc[sr.RatingGroup]
+		if sr.RatingGroup == utils.EmptyString || !has {
+			continue
+		}
+		if tUsage, err := rgEv.GetDuration(utils.Usage); err == nil {
+			sr.TotalUsage = tUsage
+		} else if lastUsage, err := rgEv.GetDuration(utils.LastUsed); err == nil &&
+			sr.LastUsage != lastUsage {
+			sr.TotalUsage -= sr.LastUsage
+			sr.TotalUsage += lastUsage
+			sr.LastUsage = lastUsage
+		}
+	}
+}
+
 // UpdateSRuns updates the SRuns event with the alterable fields (is thread safe)
 func (s *Session) UpdateSRuns(updEv engine.MapEvent, alterableFields utils.StringSet) {
 	if alterableFields.Size() == 0 { // do not lock if we can't update any field
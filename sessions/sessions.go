@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -39,6 +40,9 @@ import (
 var (
 	// ErrForcedDisconnect is used to specify the reason why the session was disconnected
 	ErrForcedDisconnect = errors.New("FORCED_DISCONNECT")
+
+	// restoredSyncTimeout limits the sync of the restored sessions, the ones not found on the clients being left to their TTL
+	restoredSyncTimeout = 5 * time.Minute
 )
 
 // NewSessionS constructs  a new SessionS instance
@@ -96,6 +100,12 @@ type SessionS struct {
 // ListenAndServe starts the service and binds it to the listen loop
 func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.SessionS))
+	if backupInterval := sS.cgrCfg.SessionSCfg().BackupInterval; backupInterval != 0 {
+		sS.restoreSessions(stopChan)
+		if backupInterval > 0 {
+			go sS.backupSessionsLoop(backupInterval, stopChan)
+		}
+	}
 	if sS.cgrCfg.SessionSCfg().ChannelSyncInterval != 0 {
 		for { // Schedule sync channels to run repeately
 			select {
@@ -110,6 +120,15 @@ func (sS *SessionS) ListenAndServe(stopChan chan struct{}) {
 
 // Shutdown is called by engine to clear states
 func (sS *SessionS) Shutdown() (err error) {
+	if sS.cgrCfg.SessionSCfg().BackupInterval != 0 { // keep the sessions for the next start
+		for _, s := range sS.getSessions(utils.EmptyString, false) {
+			s.Lock()
+			s.stopSTerminator()
+			s.stopDebitLoops()
+			s.Unlock()
+		}
+		return sS.storeSessionsBackup()
+	}
 	if len(sS.cgrCfg.SessionSCfg().ReplicationConns) == 0 {
 		var hasErr bool
 		for _, s := range sS.getSessions("", false) { // Force sessions shutdown
@@ -180,6 +199,17 @@ func (sS *SessionS) biJClntID(c rpcclient.ClientConnector) (clntConnID string) {
 	return
 }
 
+// biJClientsByID is a thread-safe method to return the active clients for BiJson indexed on connection ID
+func (sS *SessionS) biJClientsByID() (clnts map[string]*biJClient) {
+	sS.biJMux.RLock()
+	clnts = make(map[string]*biJClient, len(sS.biJIDs))
+	for connID, clnt := range sS.biJIDs {
+		clnts[connID] = clnt
+	}
+	sS.biJMux.RUnlock()
+	return
+}
+
 // biJClnts is a thread-safe method to return the list of active clients for BiJson
 func (sS *SessionS) biJClients() (clnts []*biJClient) {
 	sS.biJMux.RLock()
@@ -550,6 +580,11 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 			s.Unlock()
 			return
 		}
+		if s.SRuns[sRunIdx].RatingGroup == utils.EmptyString && s.hasRatingGroups() {
+			// the credit is controlled per rating group since the run was started
+			s.Unlock()
+			return
+		}
 		var maxDebit time.Duration
 		if maxDebit, err = sS.debitSession(s, sRunIdx, dbtIvl, nil); err != nil {
 			utils.Logger.Warning(
@@ -671,6 +706,7 @@ func (sS *SessionS) refundSession(s *Session, sRunIdx int, rUsage time.Duration)
 		Destination: sr.CD.Destination,
 		ToR:         utils.FirstNonEmpty(sr.CD.ToR, utils.MetaVoice),
 		Increments:  incrmts,
+		Source:      utils.MetaSessionS,
 	}
 	var acnt engine.Account
 	if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil, utils.ResponderRefundIncrements,
@@ -745,6 +781,7 @@ func (sS *SessionS) roundCost(s *Session, sRunIdx int) (err error) {
 		cd.CgrID = s.CGRID
 		cd.RunID = runID
 		cd.Increments = roundIncrements
+		cd.Source = utils.MetaSessionS
 		response := new(engine.Account)
 		if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
 			utils.ResponderRefundRounding,
@@ -1185,43 +1222,165 @@ func (sS *SessionS) newSession(cgrEv *utils.CGREvent, resID, clntConnID string,
 		return nil, utils.ErrExists
 	}
 
+	var mscc map[string]engine.MapEvent
+	if mscc, err = getMSCC(s.EventStart); err != nil {
+		return
+	}
 	var chrgrs []*engine.ChrgSProcessEventReply
 	if chrgrs, err = sS.processChargerS(cgrEv); err != nil {
 		return
 	}
-	s.SRuns = make([]*SRun, len(chrgrs))
-	for i, chrgr := range chrgrs {
+	rgs := make([]string, 0, len(mscc))
+	for rg := range mscc {
+		rgs = append(rgs, rg)
+	}
+	sort.Strings(rgs)
+	s.SRuns = make([]*SRun, 0, len(chrgrs))
+	for _, chrgr := range chrgrs {
 		me := engine.MapEvent(chrgr.CGREvent.Event)
-		startTime := me.GetTimeIgnoreErrors(utils.AnswerTime,
+		delete(me, utils.MSCC) // the runs are not carrying the credit control of the other rating groups
+		if len(rgs) == 0 {
+			s.SRuns = append(s.SRuns, sS.newSRun(s, chrgr.CGREvent.Tenant, me,
+				s.EventStart.GetDurationIgnoreErrors(utils.Usage), forceDuration))
+			continue
+		}
+		for _, rg := range rgs { // one run per rating group
+			sr := sS.newSRun(s, chrgr.CGREvent.Tenant, newRatingGroupEvent(me, mscc[rg], rg),
+				mscc[rg].GetDurationIgnoreErrors(utils.Usage), forceDuration)
+			sr.RatingGroup = rg
+			s.SRuns = append(s.SRuns, sr)
+		}
+	}
+	return
+}
+
+// newSRun builds the session run out of the event received from ChargerS
+func (sS *SessionS) newSRun(s *Session, tnt string, me engine.MapEvent,
+	usage time.Duration, forceDuration bool) *SRun {
+	startTime := me.GetTimeIgnoreErrors(utils.AnswerTime,
+		sS.cgrCfg.GeneralCfg().DefaultTimezone)
+	if startTime.IsZero() { // AnswerTime not parsable, try SetupTime
+		startTime = s.EventStart.GetTimeIgnoreErrors(utils.SetupTime,
 			sS.cgrCfg.GeneralCfg().DefaultTimezone)
-		if startTime.IsZero() { // AnswerTime not parsable, try SetupTime
-			startTime = s.EventStart.GetTimeIgnoreErrors(utils.SetupTime,
-				sS.cgrCfg.GeneralCfg().DefaultTimezone)
-		}
-		category := me.GetStringIgnoreErrors(utils.Category)
-		if len(category) == 0 {
-			category = sS.cgrCfg.GeneralCfg().DefaultCategory
-		}
-		subject := me.GetStringIgnoreErrors(utils.Subject)
-		if len(subject) == 0 {
-			subject = me.GetStringIgnoreErrors(utils.AccountField)
-		}
-		s.SRuns[i] = &SRun{
-			Event: me,
-			CD: &engine.CallDescriptor{
-				CgrID:         s.CGRID,
-				RunID:         me.GetStringIgnoreErrors(utils.RunID),
-				ToR:           me.GetStringIgnoreErrors(utils.ToR),
-				Tenant:        chrgr.CGREvent.Tenant,
-				Category:      category,
-				Subject:       subject,
-				Account:       me.GetStringIgnoreErrors(utils.AccountField),
-				Destination:   me.GetStringIgnoreErrors(utils.Destination),
-				TimeStart:     startTime,
-				TimeEnd:       startTime.Add(s.EventStart.GetDurationIgnoreErrors(utils.Usage)),
-				ExtraFields:   me.AsMapString(utils.MainCDRFields),
-				ForceDuration: forceDuration,
-			},
+	}
+	category := me.GetStringIgnoreErrors(utils.Category)
+	if len(category) == 0 {
+		category = sS.cgrCfg.GeneralCfg().DefaultCategory
+	}
+	subject := me.GetStringIgnoreErrors(utils.Subject)
+	if len(subject) == 0 {
+		subject = me.GetStringIgnoreErrors(utils.AccountField)
+	}
+	return &SRun{
+		Event: me,
+		CD: &engine.CallDescriptor{
+			CgrID:         s.CGRID,
+			RunID:         me.GetStringIgnoreErrors(utils.RunID),
+			ToR:           me.GetStringIgnoreErrors(utils.ToR),
+			Tenant:        tnt,
+			Category:      category,
+			Subject:       subject,
+			Account:       me.GetStringIgnoreErrors(utils.AccountField),
+			Destination:   me.GetStringIgnoreErrors(utils.Destination),
+			TimeStart:     startTime,
+			TimeEnd:       startTime.Add(usage),
+			ExtraFields:   me.AsMapString(utils.MainCDRFields),
+			ForceDuration: forceDuration,
+			SetupTime: s.EventStart.GetTimeIgnoreErrors(utils.SetupTime,
+				sS.cgrCfg.GeneralCfg().DefaultTimezone),
+			Source: utils.MetaSessionS,
+		},
+	}
+}
+
+// forkRatingGroups adds the session runs for the rating groups not yet part of the session
+// not thread-safe, it should be protected in another layer
+func (sS *SessionS) forkRatingGroups(s *Session, mscc map[string]engine.MapEvent) (err error) {
+	sRGs := utils.NewStringSet(nil)
+	for _, sr := range s.SRuns {
+		sRGs.Add(sr.RatingGroup)
+	}
+	rgs := make([]string, 0, len(mscc))
+	for rg := range mscc {
+		if !sRGs.Has(rg) {
+			rgs = append(rgs, rg)
+		}
+	}
+	if len(rgs) == 0 {
+		return
+	}
+	sort.Strings(rgs)
+	var chrgrs []*engine.ChrgSProcessEventReply
+	if chrgrs, err = sS.processChargerS(&utils.CGREvent{
+		Tenant:  s.Tenant,
+		ID:      utils.GenUUID(),
+		Event:   s.EventStart.Clone(),
+		APIOpts: s.OptsStart.Clone(),
+	}); err != nil {
+		return
+	}
+	forceDuration := len(s.SRuns) != 0 && s.SRuns[0].CD.ForceDuration
+	for _, chrgr := range chrgrs {
+		me := engine.MapEvent(chrgr.CGREvent.Event)
+		delete(me, utils.MSCC)
+		for _, rg := range rgs {
+			sr := sS.newSRun(s, chrgr.CGREvent.Tenant, newRatingGroupEvent(me, mscc[rg], rg),
+				mscc[rg].GetDurationIgnoreErrors(utils.Usage), forceDuration)
+			sr.RatingGroup = rg
+			s.SRuns = append(s.SRuns, sr)
+			if s.DebitInterval > 0 &&
+				sr.Event.GetStringIgnoreErrors(utils.RequestType) == utils.MetaPrepaid {
+				if s.debitStop == nil {
+					s.debitStop = make(chan struct{})
+				}
+				go sS.debitLoopSession(s, len(s.SRuns)-1, s.DebitInterval)
+			}
+		}
+	}
+	sS.indexSession(s, false)
+	return
+}
+
+// rgRequestedUsage returns the usage requested for the rating group of the session run
+func (sS *SessionS) rgRequestedUsage(sr *SRun, rgEv engine.MapEvent) (usage time.Duration, err error) {
+	if usage, err = rgEv.GetDuration(utils.Usage); err != utils.ErrNotFound {
+		return
+	}
+	return sS.cgrCfg.SessionSCfg().GetDefaultUsage(sr.Event.GetStringIgnoreErrors(utils.ToR)), nil
+}
+
+// msccReply groups the usage granted to the session runs on rating group
+// with sRunsUsage nil the requested usage is granted
+func (sS *SessionS) msccReply(s *Session, mscc map[string]engine.MapEvent,
+	sRunsUsage map[string]time.Duration) (rply map[string]*RatingGroupReply, err error) {
+	rply = make(map[string]*RatingGroupReply)
+	s.RLock()
+	defer s.RUnlock()
+	for _, sr := range s.SRuns {
+		rgEv, has := mscc[sr.RatingGroup]
+		if sr.RatingGroup == utils.EmptyString || !has {
+			continue
+		}
+		var reqUsage time.Duration
+		if reqUsage, err = sS.rgRequestedUsage(sr, rgEv); err != nil {
+			return
+		}
+		maxUsage := reqUsage
+		if sRunsUsage != nil {
+			if maxUsage, has = sRunsUsage[sr.CD.RunID]; !has {
+				continue
+			}
+		}
+		rgRply, has := rply[sr.RatingGroup]
+		if !has {
+			rgRply = &RatingGroupReply{MaxUsage: maxUsage}
+			rply[sr.RatingGroup] = rgRply
+		} else if maxUsage < rgRply.MaxUsage {
+			rgRply.MaxUsage = maxUsage
+		}
+		if maxUsage < reqUsage &&
+			sr.Event.GetStringIgnoreErrors(utils.RequestType) == utils.MetaPrepaid {
+			rgRply.FinalUnitIndication = true
 		}
 	}
 	return
@@ -1372,36 +1531,20 @@ func (sS *SessionS) syncSessions() {
 	if asCount == 0 { // no need to sync the sessions if none is active
 		return
 	}
-	queriedCGRIDs := engine.NewSafEvent(nil) // need this to be
-	var err error
+	queriedCGRIDs := make(utils.StringSet)
 	for _, clnt := range sS.biJClients() {
-		errChan := make(chan error)
-		go func() {
-			var queriedSessionIDs []*SessionID
-			if err := clnt.conn.Call(utils.SessionSv1GetActiveSessionIDs,
-				utils.EmptyString, &queriedSessionIDs); err != nil {
-				errChan <- err
-			}
-			for _, sessionID := range queriedSessionIDs {
-				queriedCGRIDs.Set(sessionID.CGRID(), struct{}{})
-			}
-			errChan <- nil
-		}()
-		select {
-		case err = <-errChan:
-			if err != nil && err.Error() != utils.ErrNoActiveSession.Error() {
-				utils.Logger.Warning(
-					fmt.Sprintf("<%s> error <%s> quering session ids", utils.SessionS, err.Error()))
-			}
-		case <-time.After(sS.cgrCfg.GeneralCfg().ReplyTimeout):
+		cgrIDs, err := sS.queryClientCGRIDs(clnt)
+		if err != nil {
 			utils.Logger.Warning(
-				fmt.Sprintf("<%s> timeout quering session ids ", utils.SessionS))
+				fmt.Sprintf("<%s> error <%s> quering session ids", utils.SessionS, err.Error()))
+			continue
 		}
+		queriedCGRIDs.AddSlice(cgrIDs.AsSlice())
 	}
 	var toBeRemoved []string
 	sS.aSsMux.RLock()
 	for cgrid := range sS.aSessions {
-		if !queriedCGRIDs.HasField(cgrid) {
+		if !queriedCGRIDs.Has(cgrid) {
 			toBeRemoved = append(toBeRemoved, cgrid)
 		}
 	}
@@ -1409,6 +1552,30 @@ func (sS *SessionS) syncSessions() {
 	sS.terminateSyncSessions(toBeRemoved)
 }
 
+// queryClientCGRIDs returns the CGRIDs of the sessions active on the client
+func (sS *SessionS) queryClientCGRIDs(clnt *biJClient) (cgrIDs utils.StringSet, err error) {
+	errChan := make(chan error, 1)
+	var queriedSessionIDs []*SessionID
+	go func() {
+		errChan <- clnt.conn.Call(utils.SessionSv1GetActiveSessionIDs,
+			utils.EmptyString, &queriedSessionIDs)
+	}()
+	select {
+	case err = <-errChan:
+		if err != nil && err.Error() != utils.ErrNoActiveSession.Error() {
+			return
+		}
+		err = nil
+	case <-time.After(sS.cgrCfg.GeneralCfg().ReplyTimeout):
+		return nil, utils.ErrReplyTimeout
+	}
+	cgrIDs = make(utils.StringSet)
+	for _, sessionID := range queriedSessionIDs {
+		cgrIDs.Add(sessionID.CGRID())
+	}
+	return
+}
+
 // Extracted from syncSessions in order to test all cases
 func (sS *SessionS) terminateSyncSessions(toBeRemoved []string) {
 	for _, cgrID := range toBeRemoved {
@@ -1426,6 +1593,140 @@ func (sS *SessionS) terminateSyncSessions(toBeRemoved []string) {
 	}
 }
 
+// backupID returns the key of the sessions backup, stable across restarts
+// the NodeID generated on each start when not configured cannot be used
+func (sS *SessionS) backupID() string {
+	if sS.cgrCfg.NodeIDGenerated() {
+		return utils.MetaDefault
+	}
+	return sS.cgrCfg.GeneralCfg().NodeID
+}
+
+// storeSessionsBackup overwrites the active sessions backup of this node in DataDB
+func (sS *SessionS) storeSessionsBackup() (err error) {
+	aSs := sS.getSessions(utils.EmptyString, false)
+	ss := make([]*engine.StoredSession, len(aSs))
+	for i, s := range aSs {
+		ss[i] = s.asStoredSession()
+	}
+	if err = sS.dm.SetSessionsBackup(sS.backupID(), ss); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed backing up active sessions, err: <%s>",
+				utils.SessionS, err.Error()))
+	}
+	return
+}
+
+// backupSessionsLoop will backup the active sessions on each interval until stopChan is closed
+func (sS *SessionS) backupSessionsLoop(interval time.Duration, stopChan chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(interval):
+			sS.storeSessionsBackup()
+		}
+	}
+}
+
+// restoreSessions will activate the sessions backed up by this node
+// and sync them with the agents so the ones ended in the meantime are terminated
+func (sS *SessionS) restoreSessions(stopChan chan struct{}) {
+	ss, err := sS.dm.GetSessionsBackup(sS.backupID())
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed restoring active sessions, err: <%s>",
+					utils.SessionS, err.Error()))
+		}
+		return
+	}
+	var restored int
+	toSync := make(map[string]string) // connection ID of the client for each restored session
+	for _, storedS := range ss {
+		if len(sS.getSessions(storedS.CGRID, false)) != 0 { // already active
+			continue
+		}
+		s := newSessionFromStoredSession(storedS)
+		sS.registerSession(s, false)
+		s.Lock()
+		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
+		s.Unlock()
+		restored++
+		if s.ClientConnID != utils.EmptyString { // without client the session cannot be synced
+			toSync[s.CGRID] = s.ClientConnID
+		}
+	}
+	// the backup is kept until overwritten so the sessions are not lost if the engine stops before syncing them
+	utils.Logger.Info(fmt.Sprintf("<%s> restored %d active sessions", utils.SessionS, restored))
+	if len(toSync) == 0 {
+		return
+	}
+	go sS.syncRestoredSessions(toSync, stopChan)
+}
+
+// syncRestoredSessions binds the restored sessions to the clients reporting them as active
+// since the clients reconnect with a new connection ID, terminating the ones not active anymore on their clients
+// the sessions not found within restoredSyncTimeout are left to their TTL
+func (sS *SessionS) syncRestoredSessions(restored map[string]string, stopChan chan struct{}) {
+	timeout := time.After(restoredSyncTimeout)
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-timeout:
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> %d restored sessions not found on the clients, leaving them to their TTL",
+					utils.SessionS, len(restored)))
+			return
+		case <-time.After(sS.cgrCfg.GeneralCfg().ReplyTimeout):
+		}
+		for cgrID := range restored {
+			if len(sS.getSessions(cgrID, false)) == 0 { // ended in the meantime
+				delete(restored, cgrID)
+			}
+		}
+		var toBeRemoved []string
+		for connID, clnt := range sS.biJClientsByID() {
+			activeCGRIDs, err := sS.queryClientCGRIDs(clnt)
+			if err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error <%s> quering session ids of client <%s>",
+						utils.SessionS, err.Error(), connID))
+				continue
+			}
+			for cgrID, clntConnID := range restored {
+				switch {
+				case activeCGRIDs.Has(cgrID):
+					sS.bindSessionClient(cgrID, connID)
+				case clntConnID == connID: // same client without the session
+					toBeRemoved = append(toBeRemoved, cgrID)
+				default: // belonging to another client
+					continue
+				}
+				delete(restored, cgrID)
+			}
+		}
+		sS.terminateSyncSessions(toBeRemoved)
+		if len(restored) == 0 {
+			sS.storeSessionsBackup()
+			return
+		}
+	}
+}
+
+// bindSessionClient updates the connection ID towards the client of the active session
+func (sS *SessionS) bindSessionClient(cgrID, connID string) {
+	ss := sS.getSessions(cgrID, false)
+	if len(ss) == 0 {
+		return
+	}
+	ss[0].Lock()
+	ss[0].ClientConnID = connID
+	ss[0].Unlock()
+}
+
 // initSessionDebitLoops will init the debit loops for a session
 // not thread-safe, it should be protected in another layer
 func (sS *SessionS) initSessionDebitLoops(s *Session) {
@@ -1521,6 +1822,15 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 	if updtEv == nil {
 		updtEv = engine.MapEvent(s.EventStart.Clone())
 	}
+	var mscc map[string]engine.MapEvent
+	if mscc, err = getMSCC(updtEv); err != nil {
+		return
+	}
+	if !isMsg && len(mscc) != 0 {
+		if err = sS.forkRatingGroups(s, mscc); err != nil {
+			return
+		}
+	}
 
 	var reqMaxUsage time.Duration
 	if reqMaxUsage, err = updtEv.GetDuration(utils.Usage); err != nil {
@@ -1532,17 +1842,33 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 		updtEv[utils.Usage] = reqMaxUsage
 	}
 	maxUsage = make(map[string]time.Duration)
+	hasRGs := s.hasRatingGroups()
 	for i, sr := range s.SRuns {
+		if hasRGs && sr.RatingGroup == utils.EmptyString {
+			continue // the usage is charged on the rating group runs, avoid debiting it twice
+		}
+		srReqUsage := reqMaxUsage
+		lastUsed := updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)
+		if mscc != nil && sr.RatingGroup != utils.EmptyString {
+			rgEv, has := mscc[sr.RatingGroup]
+			if !has { // rating group not part of this request
+				continue
+			}
+			if srReqUsage, err = sS.rgRequestedUsage(sr, rgEv); err != nil {
+				return
+			}
+			lastUsed = rgEv.GetDurationPtrIgnoreErrors(utils.LastUsed)
+		}
 		reqType := sr.Event.GetStringIgnoreErrors(utils.RequestType)
 		if reqType == utils.MetaNone {
-			maxUsage[sr.CD.RunID] = reqMaxUsage
+			maxUsage[sr.CD.RunID] = srReqUsage
 			continue
 		}
 		var rplyMaxUsage time.Duration
 		if reqType != utils.MetaPrepaid || s.debitStop != nil {
-			rplyMaxUsage = reqMaxUsage
-		} else if rplyMaxUsage, err = sS.debitSession(s, i, reqMaxUsage,
-			updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)); err != nil {
+			rplyMaxUsage = srReqUsage
+		} else if rplyMaxUsage, err = sS.debitSession(s, i, srReqUsage,
+			lastUsed); err != nil {
 			return
 		}
 		maxUsage[sr.CD.RunID] = rplyMaxUsage
@@ -2177,6 +2503,7 @@ type V1InitSessionReply struct {
 	Attributes         *engine.AttrSProcessEventReply `json:",omitempty"`
 	ResourceAllocation *string                        `json:",omitempty"`
 	MaxUsage           *time.Duration                 `json:",omitempty"`
+	MSCC               map[string]*RatingGroupReply   `json:",omitempty"`
 	ThresholdIDs       *[]string                      `json:",omitempty"`
 	StatQueueIDs       *[]string                      `json:",omitempty"`
 
@@ -2213,6 +2540,9 @@ func (v1Rply *V1InitSessionReply) AsNavigableMap() map[string]*utils.DataNode {
 	} else if v1Rply.needsMaxUsage {
 		cgrReply[utils.CapMaxUsage] = utils.NewLeafNode(0)
 	}
+	if v1Rply.MSCC != nil {
+		cgrReply[utils.MSCC] = msccAsDataNode(v1Rply.MSCC)
+	}
 
 	if v1Rply.ThresholdIDs != nil {
 		thIDs := &utils.DataNode{Type: utils.NMSliceType, Slice: make([]*utils.DataNode, len(*v1Rply.ThresholdIDs))}
@@ -2317,10 +2647,10 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 		s.RLock() // avoid concurrency with activeDebit
 		isPrepaid := s.debitStop != nil
 		s.RUnlock()
+		var sRunsUsage map[string]time.Duration
 		if isPrepaid { //active debit
 			rply.MaxUsage = utils.DurationPointer(sS.cgrCfg.SessionSCfg().GetDefaultUsage(utils.IfaceAsString(args.CGREvent.Event[utils.ToR])))
 		} else {
-			var sRunsUsage map[string]time.Duration
 			if sRunsUsage, err = sS.updateSession(s, nil, args.APIOpts, false); err != nil {
 				return utils.NewErrRALs(err)
 			}
@@ -2335,6 +2665,11 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 			}
 			rply.MaxUsage = &maxUsage
 		}
+		if mscc, _ := getMSCC(args.CGREvent.Event); len(mscc) != 0 { // already validated when creating the session
+			if rply.MSCC, err = sS.msccReply(s, mscc, sRunsUsage); err != nil {
+				return utils.NewErrRALs(err)
+			}
+		}
 	}
 	if args.ProcessThresholds {
 		tIDs, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
@@ -2434,6 +2769,7 @@ func (V1UpdateSessionArgs) RPCClone() {}
 type V1UpdateSessionReply struct {
 	Attributes *engine.AttrSProcessEventReply `json:",omitempty"`
 	MaxUsage   *time.Duration                 `json:",omitempty"`
+	MSCC       map[string]*RatingGroupReply   `json:",omitempty"`
 
 	needsMaxUsage bool // for gob encoding only
 }
@@ -2465,6 +2801,9 @@ func (v1Rply *V1UpdateSessionReply) AsNavigableMap() map[string]*utils.DataNode
 	} else if v1Rply.needsMaxUsage {
 		cgrReply[utils.CapMaxUsage] = utils.NewLeafNode(0)
 	}
+	if v1Rply.MSCC != nil {
+		cgrReply[utils.MSCC] = msccAsDataNode(v1Rply.MSCC)
+	}
 	return cgrReply
 }
 
@@ -2550,6 +2889,11 @@ func (sS *SessionS) BiRPCv1UpdateSession(clnt rpcclient.ClientConnector,
 			}
 		}
 		rply.MaxUsage = &maxUsage
+		if mscc, _ := getMSCC(ev); len(mscc) != 0 { // already validated when updating the session
+			if rply.MSCC, err = sS.msccReply(s, mscc, sRunsUsage); err != nil {
+				return utils.NewErrRALs(err)
+			}
+		}
 	}
 	return
 }
@@ -2689,14 +3033,22 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 		if !isMsg {
 			s.UpdateSRuns(ev, sS.cgrCfg.SessionSCfg().AlterableFields)
 		}
+		var mscc map[string]engine.MapEvent
+		if mscc, err = getMSCC(ev); err != nil {
+			return
+		}
 		s.Lock()
 		s.Chargeable = opts.GetBoolOrDefault(utils.OptsChargeable, true)
+		tUsage := ev.GetDurationPtrIgnoreErrors(utils.Usage)
+		lastUsage := ev.GetDurationPtrIgnoreErrors(utils.LastUsed)
+		if len(mscc) != 0 { // usage reported per rating group
+			s.updateMSCCUsage(mscc)
+			tUsage, lastUsage = nil, nil
+		}
+		err = sS.endSession(s, tUsage, lastUsage,
+			ev.GetTimePtrIgnoreErrors(utils.AnswerTime, utils.EmptyString), isMsg)
 		s.Unlock()
-		if err = sS.terminateSession(s,
-			ev.GetDurationPtrIgnoreErrors(utils.Usage),
-			ev.GetDurationPtrIgnoreErrors(utils.LastUsed),
-			ev.GetTimePtrIgnoreErrors(utils.AnswerTime, utils.EmptyString),
-			isMsg); err != nil {
+		if err != nil {
 			return utils.NewErrRALs(err)
 		}
 	}
@@ -3434,6 +3786,8 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 						TimeStart:     startTime,
 						TimeEnd:       startTime.Add(ev.GetDurationIgnoreErrors(utils.Usage)),
 						ForceDuration: ralsOpts.Has(utils.MetaFD),
+						SetupTime: ev.GetTimeIgnoreErrors(utils.SetupTime,
+							sS.cgrCfg.GeneralCfg().DefaultTimezone),
 					}
 					var cc engine.CallCost
 					if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
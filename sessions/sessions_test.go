@@ -3206,3 +3206,405 @@ func TestBiRPCv1ProcessEventNoTenant(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSessionSBackupRestoreSessions(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "node1"
+	cfg.SessionSCfg().BackupInterval = -1
+	cfg.SessionSCfg().SessionTTL = time.Hour
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	s := &Session{
+		CGRID:      "session1",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.OriginID: "12345"},
+		Chargeable: true,
+		SRuns: []*SRun{{
+			Event:      engine.MapEvent{utils.RunID: utils.MetaDefault, utils.RequestType: utils.MetaPostpaid},
+			CD:         &engine.CallDescriptor{RunID: utils.MetaDefault, Tenant: "cgrates.org"},
+			TotalUsage: time.Minute,
+		}},
+		OptsStart: engine.MapEvent{utils.OptsSessionsTTL: "2h"},
+	}
+	sS.registerSession(s, false)
+	s.Lock()
+	sS.setSTerminator(s, nil)
+	s.Unlock()
+	if err := sS.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if s.sTerminator.endChan != nil {
+		t.Error("Expected the session terminator to be stopped")
+	}
+	exp := []*engine.StoredSession{s.asStoredSession()}
+	if rcv, err := dm.GetSessionsBackup("node1"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	sS = NewSessionS(cfg, dm, nil)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	sS.restoreSessions(stopChan)
+	if ss := sS.getSessions("session1", false); len(ss) != 1 {
+		t.Fatalf("Expected the session to be restored, received: %s", utils.ToJSON(ss))
+	} else if ss[0].sTerminator == nil || ss[0].sTerminator.ttl != 2*time.Hour {
+		t.Errorf("Expected the session terminator to be restored, received: %+v", ss[0].sTerminator)
+	} else if !ss[0].Chargeable || ss[0].SRuns[0].TotalUsage != time.Minute {
+		t.Errorf("Unexpected restored session: %s", utils.ToJSON(ss[0]))
+	}
+	if rcv, err := dm.GetSessionsBackup("node1"); err != nil { // kept until overwritten
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestSessionSSyncRestoredSessions(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "node1"
+	cfg.GeneralCfg().ReplyTimeout = 10 * time.Millisecond
+	cfg.SessionSCfg().BackupInterval = -1
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	newStoredSession := func(originID, connID string) *engine.StoredSession {
+		return (&Session{
+			CGRID:        utils.Sha1(originID, "host1"),
+			Tenant:       "cgrates.org",
+			ClientConnID: connID,
+			EventStart:   engine.MapEvent{utils.OriginID: originID, utils.OriginHost: "host1"},
+			SRuns: []*SRun{{
+				Event: engine.MapEvent{utils.RunID: utils.MetaDefault, utils.RequestType: utils.MetaPostpaid},
+				CD:    &engine.CallDescriptor{RunID: utils.MetaDefault, Tenant: "cgrates.org"},
+			}},
+		}).asStoredSession()
+	}
+	if err := dm.SetSessionsBackup("node1", []*engine.StoredSession{
+		newStoredSession("active", "agent1"),
+		newStoredSession("ended", "agent1"),
+		newStoredSession("other", "agent2"), // agent not connected
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sS := NewSessionS(cfg, dm, nil)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	sS.restoreSessions(stopChan)
+	if ss := sS.getSessions(utils.EmptyString, false); len(ss) != 3 {
+		t.Fatalf("Expected 3 restored sessions, received: %s", utils.ToJSON(ss))
+	}
+	time.Sleep(5 * cfg.GeneralCfg().ReplyTimeout) // the agents are not connected yet
+	if ss := sS.getSessions(utils.EmptyString, false); len(ss) != 3 {
+		t.Errorf("Expected the sessions kept until the agents connect, received: %s", utils.ToJSON(ss))
+	}
+	sS.RegisterIntBiJConn(&testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.SessionSv1GetActiveSessionIDs: func(args interface{}, reply interface{}) error {
+				*reply.(*[]*SessionID) = []*SessionID{{OriginHost: "host1", OriginID: "active"}}
+				return nil
+			},
+			utils.SessionSv1DisconnectSession: func(args interface{}, reply interface{}) error {
+				*reply.(*string) = utils.OK
+				return nil
+			},
+		},
+	}, "agent1")
+	for i := 0; i < 50 && len(sS.getSessions(utils.Sha1("ended", "host1"), false)) != 0; i++ {
+		time.Sleep(cfg.GeneralCfg().ReplyTimeout)
+	}
+	if ss := sS.getSessions(utils.Sha1("ended", "host1"), false); len(ss) != 0 {
+		t.Errorf("Expected the ended session to be terminated, received: %s", utils.ToJSON(ss))
+	}
+	if ss := sS.getSessions(utils.EmptyString, false); len(ss) != 2 {
+		t.Errorf("Expected 2 active sessions, received: %s", utils.ToJSON(ss))
+	}
+	// agent2 reconnects with a new connection ID
+	sS.RegisterIntBiJConn(&testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.SessionSv1GetActiveSessionIDs: func(args interface{}, reply interface{}) error {
+				*reply.(*[]*SessionID) = []*SessionID{{OriginHost: "host1", OriginID: "other"}}
+				return nil
+			},
+		},
+	}, "agent3")
+	otherCGRID := utils.Sha1("other", "host1")
+	var connID string
+	for i := 0; i < 50 && connID != "agent3"; i++ {
+		time.Sleep(cfg.GeneralCfg().ReplyTimeout)
+		ss := sS.getSessions(otherCGRID, false)
+		if len(ss) != 1 {
+			t.Fatalf("Expected the session to be kept, received: %s", utils.ToJSON(ss))
+		}
+		ss[0].RLock()
+		connID = ss[0].ClientConnID
+		ss[0].RUnlock()
+	}
+	if connID != "agent3" {
+		t.Errorf("Expected the session bound to <agent3>, received: <%s>", connID)
+	}
+}
+
+func TestSessionSSyncRestoredSessionsTimeout(t *testing.T) {
+	tmp := restoredSyncTimeout
+	restoredSyncTimeout = 50 * time.Millisecond
+	defer func() { restoredSyncTimeout = tmp }()
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().ReplyTimeout = 10 * time.Millisecond
+	cfg.SessionSCfg().BackupInterval = -1
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	sS.registerSession(&Session{
+		CGRID:        "session1",
+		Tenant:       "cgrates.org",
+		ClientConnID: "agent1",
+		EventStart:   engine.MapEvent{utils.OriginID: "12345"},
+	}, false)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	done := make(chan struct{})
+	go func() {
+		sS.syncRestoredSessions(map[string]string{"session1": "agent1"}, stopChan)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the sync to give up after the timeout")
+	}
+	if ss := sS.getSessions("session1", false); len(ss) != 1 { // left to the TTL
+		t.Errorf("Expected the session to be kept, received: %s", utils.ToJSON(ss))
+	}
+}
+
+func TestSessionSBackupGeneratedNodeID(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().BackupInterval = -1
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, nil)
+	sS.registerSession(&Session{
+		CGRID:      "session1",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.OriginID: "12345"},
+	}, false)
+	if err := sS.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetSessionsBackup(utils.MetaDefault); err != nil {
+		t.Fatal(err)
+	}
+	cfg = config.NewDefaultCGRConfig() // a new NodeID is generated on each start
+	cfg.SessionSCfg().BackupInterval = -1
+	sS = NewSessionS(cfg, dm, nil)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	sS.restoreSessions(stopChan)
+	if ss := sS.getSessions("session1", false); len(ss) != 1 {
+		t.Errorf("Expected the session to be restored, received: %s", utils.ToJSON(ss))
+	}
+}
+
+func TestSessionSMultipleServicesCreditControl(t *testing.T) {
+	engine.Cache.Clear(nil)
+	chrgrsMock := &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ChargerSv1ProcessEvent: func(args interface{}, reply interface{}) error {
+				ev := engine.MapEvent(args.(*utils.CGREvent).Event).Clone()
+				ev[utils.RunID] = utils.MetaDefault
+				*reply.(*[]*engine.ChrgSProcessEventReply) = []*engine.ChrgSProcessEventReply{{
+					ChargerSProfile: "DEFAULT",
+					CGREvent:        &utils.CGREvent{Tenant: "cgrates.org", Event: ev},
+				}}
+				return nil
+			},
+		},
+	}
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- chrgrsMock
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().ChargerSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers)}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers): sMock})
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, connMgr)
+
+	ev := map[string]interface{}{
+		utils.OriginID:     "mscc1",
+		utils.RequestType:  utils.MetaNone,
+		utils.AccountField: "1001",
+		utils.MSCC: map[string]interface{}{
+			"1": map[string]interface{}{utils.Usage: "10s"},
+			"2": map[string]interface{}{utils.Usage: 1024, utils.ToR: utils.MetaData},
+		},
+	}
+	initArgs := &V1InitSessionArgs{InitSession: true,
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "init", Event: ev}}
+	var initRply V1InitSessionReply
+	if err := sS.BiRPCv1InitiateSession(nil, initArgs, &initRply); err != nil {
+		t.Fatal(err)
+	}
+	expMSCC := map[string]*RatingGroupReply{
+		"1": {MaxUsage: 10 * time.Second},
+		"2": {MaxUsage: 1024},
+	}
+	if !reflect.DeepEqual(expMSCC, initRply.MSCC) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expMSCC), utils.ToJSON(initRply.MSCC))
+	}
+	s := sS.getSessions(GetSetCGRID(ev), false)[0]
+	if len(s.SRuns) != 2 {
+		t.Fatalf("Unexpected session runs: %s", utils.ToJSON(s.SRuns))
+	}
+	for i, rg := range []string{"1", "2"} {
+		if s.SRuns[i].RatingGroup != rg ||
+			s.SRuns[i].CD.RunID != utils.ConcatenatedKey(utils.MetaDefault, rg) ||
+			s.SRuns[i].Event.HasField(utils.MSCC) {
+			t.Errorf("Unexpected session run: %s", utils.ToJSON(s.SRuns[i]))
+		}
+	}
+	if tor := s.SRuns[1].Event.GetStringIgnoreErrors(utils.ToR); tor != utils.MetaData {
+		t.Errorf("Unexpected ToR: %q", tor)
+	}
+
+	// a new rating group within update forks its own runs
+	updtArgs := &V1UpdateSessionArgs{UpdateSession: true,
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "update", Event: map[string]interface{}{
+			utils.OriginID: "mscc1",
+			utils.MSCC: map[string]interface{}{
+				"3": map[string]interface{}{utils.Usage: "5s"},
+			},
+		}}}
+	var updtRply V1UpdateSessionReply
+	if err := sS.BiRPCv1UpdateSession(nil, updtArgs, &updtRply); err != nil {
+		t.Fatal(err)
+	}
+	expMSCC = map[string]*RatingGroupReply{"3": {MaxUsage: 5 * time.Second}}
+	if !reflect.DeepEqual(expMSCC, updtRply.MSCC) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expMSCC), utils.ToJSON(updtRply.MSCC))
+	}
+	if len(s.SRuns) != 3 || s.SRuns[2].RatingGroup != "3" {
+		t.Fatalf("Unexpected session runs: %s", utils.ToJSON(s.SRuns))
+	}
+
+	// final unit indication when granting less than requested
+	s.SRuns[0].Event[utils.RequestType] = utils.MetaPrepaid
+	if rply, err := sS.msccReply(s, map[string]engine.MapEvent{"1": {utils.Usage: "10s"}},
+		map[string]time.Duration{utils.ConcatenatedKey(utils.MetaDefault, "1"): 3 * time.Second}); err != nil {
+		t.Error(err)
+	} else if exp := map[string]*RatingGroupReply{
+		"1": {MaxUsage: 3 * time.Second, FinalUnitIndication: true}}; !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+	s.SRuns[0].Event[utils.RequestType] = utils.MetaNone
+
+	var tRply string
+	if err := sS.BiRPCv1TerminateSession(nil, &V1TerminateSessionArgs{TerminateSession: true,
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "terminate", Event: map[string]interface{}{
+			utils.OriginID: "mscc1",
+			utils.MSCC: map[string]interface{}{
+				"1": map[string]interface{}{utils.Usage: "20s"},
+				"2": map[string]interface{}{utils.LastUsed: 512},
+			},
+		}}}, &tRply); err != nil {
+		t.Fatal(err)
+	}
+	for i, usage := range []time.Duration{20 * time.Second, 512, 0} {
+		if s.SRuns[i].TotalUsage != usage {
+			t.Errorf("Expected usage %v for rating group %s, received: %v",
+				usage, s.SRuns[i].RatingGroup, s.SRuns[i].TotalUsage)
+		}
+	}
+	if aSs := sS.getSessions(s.CGRID, false); len(aSs) != 0 {
+		t.Errorf("Session not terminated: %s", utils.ToJSON(aSs))
+	}
+}
+
+func TestSessionSUpdateSessionRunsWithoutRatingGroup(t *testing.T) {
+	engine.Cache.Clear(nil)
+	debited := make(map[string]int) // RunID: MaxDebit calls
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ChargerSv1ProcessEvent: func(args interface{}, reply interface{}) error {
+				ev := engine.MapEvent(args.(*utils.CGREvent).Event).Clone()
+				ev[utils.RunID] = utils.MetaDefault
+				*reply.(*[]*engine.ChrgSProcessEventReply) = []*engine.ChrgSProcessEventReply{{
+					ChargerSProfile: "DEFAULT",
+					CGREvent:        &utils.CGREvent{Tenant: "cgrates.org", Event: ev},
+				}}
+				return nil
+			},
+			utils.ResponderMaxDebit: func(args interface{}, reply interface{}) error {
+				cd := args.(*engine.CallDescriptorWithAPIOpts)
+				debited[cd.RunID]++
+				*reply.(*engine.CallCost) = engine.CallCost{Timespans: []*engine.TimeSpan{{
+					TimeStart: cd.TimeStart, TimeEnd: cd.TimeEnd}}}
+				return nil
+			},
+		},
+	}
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().ChargerSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers)}
+	cfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers)}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaChargers): sMock})
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	sS := NewSessionS(cfg, dm, connMgr)
+
+	ev := map[string]interface{}{
+		utils.OriginID:     "mscc2",
+		utils.RequestType:  utils.MetaPrepaid,
+		utils.AccountField: "1001",
+		utils.Usage:        10 * time.Second,
+	}
+	var initRply V1InitSessionReply
+	if err := sS.BiRPCv1InitiateSession(nil, &V1InitSessionArgs{InitSession: true,
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "init", Event: ev}}, &initRply); err != nil {
+		t.Fatal(err)
+	}
+	if exp := map[string]int{utils.MetaDefault: 1}; !reflect.DeepEqual(exp, debited) {
+		t.Errorf("Expected %v, received %v", exp, debited)
+	}
+
+	// once the credit is controlled per rating group the run without it is not debited anymore
+	var updtRply V1UpdateSessionReply
+	if err := sS.BiRPCv1UpdateSession(nil, &V1UpdateSessionArgs{UpdateSession: true,
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", ID: "update", Event: map[string]interface{}{
+			utils.OriginID: "mscc2",
+			utils.Usage:    10 * time.Second,
+			utils.MSCC: map[string]interface{}{
+				"1": map[string]interface{}{utils.Usage: "10s"},
+			},
+		}}}, &updtRply); err != nil {
+		t.Fatal(err)
+	}
+	if exp := map[string]int{
+		utils.MetaDefault: 1,
+		utils.ConcatenatedKey(utils.MetaDefault, "1"): 1,
+	}; !reflect.DeepEqual(exp, debited) {
+		t.Errorf("Expected %v, received %v", exp, debited)
+	}
+	if exp := map[string]*RatingGroupReply{"1": {MaxUsage: 10 * time.Second}}; !reflect.DeepEqual(exp, updtRply.MSCC) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(updtRply.MSCC))
+	}
+	s := sS.getSessions(GetSetCGRID(ev), false)[0]
+	if s.SRuns[0].TotalUsage != 10*time.Second {
+		t.Errorf("Unexpected usage for the run without rating group: %v", s.SRuns[0].TotalUsage)
+	}
+}
+
+func TestSessionsV1InitSessionReplyAsNavigableMapMSCC(t *testing.T) {
+	rply := &V1InitSessionReply{MaxUsage: utils.DurationPointer(time.Second),
+		MSCC: map[string]*RatingGroupReply{"1": {MaxUsage: time.Second, FinalUnitIndication: true}}}
+	exp := map[string]*utils.DataNode{
+		utils.CapMaxUsage: utils.NewLeafNode(time.Second),
+		utils.MSCC: {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+			"1": {Type: utils.NMMapType, Map: map[string]*utils.DataNode{
+				utils.CapMaxUsage:         utils.NewLeafNode(time.Second),
+				utils.FinalUnitIndication: utils.NewLeafNode(true),
+			}},
+		}},
+	}
+	if rcv := rply.AsNavigableMap(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
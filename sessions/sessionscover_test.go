@@ -1398,6 +1398,7 @@ func TestNewSession(t *testing.T) {
 					Category:    "call",
 					Destination: "10",
 					ExtraFields: map[string]string{},
+					Source:      utils.MetaSessionS,
 				},
 			},
 		},
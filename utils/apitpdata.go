@@ -221,6 +221,7 @@ type TPRatingPlan struct {
 	TPid               string                 // Tariff plan id
 	ID                 string                 // RatingPlan profile id
 	RatingPlanBindings []*TPRatingPlanBinding // Set of destinationid-rateid bindings
+	Currency           string                 // currency of the costs, empty for the default one
 }
 
 type TPRatingPlanBinding struct {
@@ -840,6 +841,7 @@ type AttrSetAccount struct {
 	ActionPlanID     string
 	ActionTriggersID string
 	ExtraOptions     map[string]bool
+	CreditLimits     map[string]float64
 	ReloadScheduler  bool
 }
 
@@ -854,6 +856,27 @@ type AttrGetCallCost struct {
 	RunId string // Run Id
 }
 
+// AttrReserveBalance is used by the APIs holding an amount out of the account balances
+type AttrReserveBalance struct {
+	Tenant      string
+	Account     string
+	ID          string // the reservation ID
+	BalanceType string
+	BalanceID   string // restricts the reservation to one balance
+	Amount      float64
+	TTL         string // the reservation is released once expired
+	APIOpts     map[string]interface{}
+}
+
+// AttrReservation is used by the APIs committing or releasing a reservation
+type AttrReservation struct {
+	Tenant  string
+	Account string
+	ID      string
+	Amount  *float64 // committed amount, the whole reservation if nil
+	APIOpts map[string]interface{}
+}
+
 type AttrSetBalance struct {
 	Tenant          string
 	Account         string
@@ -978,6 +1001,10 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	WindowType         string
+	WindowSize         string
+	WindowSlide        string
+	WindowCount        int
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
@@ -1122,6 +1149,36 @@ type TPDispatcherHostConn struct {
 	CaCertificate     string
 }
 
+// TPExchangeRateProfile is used in APIs to manage remotely offline ExchangeRateProfile
+type TPExchangeRateProfile struct {
+	TPid   string
+	Tenant string
+	ID     string // the currency the rates are converting from
+	Rates  []*TPExchangeRate
+}
+
+// TPExchangeRate is used in TPExchangeRateProfile
+type TPExchangeRate struct {
+	Currency       string // the currency converted into
+	ActivationTime string
+	Rate           float64
+}
+
+// TPLookupTable is used in APIs to manage remotely offline LookupTable
+type TPLookupTable struct {
+	TPid    string
+	Tenant  string
+	ID      string
+	Entries []*TPLookupEntry
+}
+
+// TPLookupEntry is one field of a key in TPLookupTable
+type TPLookupEntry struct {
+	Key       string
+	FieldName string
+	Value     string
+}
+
 type UsageInterval struct {
 	Min *time.Duration
 	Max *time.Duration
@@ -1295,6 +1352,8 @@ func NewAttrReloadCacheWithOpts() *AttrReloadCacheWithAPIOpts {
 		ChargerProfileIDs:        []string{MetaAny},
 		DispatcherProfileIDs:     []string{MetaAny},
 		DispatcherHostIDs:        []string{MetaAny},
+		ExchangeRateProfileIDs:   []string{MetaAny},
+		LookupTableIDs:           []string{MetaAny},
 		TimingIDs:                []string{MetaAny},
 		AttributeFilterIndexIDs:  []string{MetaAny},
 		ResourceFilterIndexIDs:   []string{MetaAny},
@@ -1312,27 +1371,29 @@ func NewAttrReloadCacheWithOptsFromMap(arg map[string][]string, tnt string, opts
 		Tenant:  tnt,
 		APIOpts: opts,
 
-		DestinationIDs:        arg[CacheDestinations],
-		ReverseDestinationIDs: arg[CacheReverseDestinations],
-		RatingPlanIDs:         arg[CacheRatingPlans],
-		RatingProfileIDs:      arg[CacheRatingProfiles],
-		ActionIDs:             arg[CacheActions],
-		ActionPlanIDs:         arg[CacheActionPlans],
-		AccountActionPlanIDs:  arg[CacheAccountActionPlans],
-		ActionTriggerIDs:      arg[CacheActionTriggers],
-		SharedGroupIDs:        arg[CacheSharedGroups],
-		ResourceProfileIDs:    arg[CacheResourceProfiles],
-		ResourceIDs:           arg[CacheResources],
-		StatsQueueIDs:         arg[CacheStatQueues],
-		StatsQueueProfileIDs:  arg[CacheStatQueueProfiles],
-		ThresholdIDs:          arg[CacheThresholds],
-		ThresholdProfileIDs:   arg[CacheThresholdProfiles],
-		FilterIDs:             arg[CacheFilters],
-		RouteProfileIDs:       arg[CacheRouteProfiles],
-		AttributeProfileIDs:   arg[CacheAttributeProfiles],
-		ChargerProfileIDs:     arg[CacheChargerProfiles],
-		DispatcherProfileIDs:  arg[CacheDispatcherProfiles],
-		DispatcherHostIDs:     arg[CacheDispatcherHosts],
+		DestinationIDs:         arg[CacheDestinations],
+		ReverseDestinationIDs:  arg[CacheReverseDestinations],
+		RatingPlanIDs:          arg[CacheRatingPlans],
+		RatingProfileIDs:       arg[CacheRatingProfiles],
+		ActionIDs:              arg[CacheActions],
+		ActionPlanIDs:          arg[CacheActionPlans],
+		AccountActionPlanIDs:   arg[CacheAccountActionPlans],
+		ActionTriggerIDs:       arg[CacheActionTriggers],
+		SharedGroupIDs:         arg[CacheSharedGroups],
+		ResourceProfileIDs:     arg[CacheResourceProfiles],
+		ResourceIDs:            arg[CacheResources],
+		StatsQueueIDs:          arg[CacheStatQueues],
+		StatsQueueProfileIDs:   arg[CacheStatQueueProfiles],
+		ThresholdIDs:           arg[CacheThresholds],
+		ThresholdProfileIDs:    arg[CacheThresholdProfiles],
+		FilterIDs:              arg[CacheFilters],
+		RouteProfileIDs:        arg[CacheRouteProfiles],
+		AttributeProfileIDs:    arg[CacheAttributeProfiles],
+		ChargerProfileIDs:      arg[CacheChargerProfiles],
+		DispatcherProfileIDs:   arg[CacheDispatcherProfiles],
+		DispatcherHostIDs:      arg[CacheDispatcherHosts],
+		ExchangeRateProfileIDs: arg[CacheExchangeRateProfiles],
+		LookupTableIDs:         arg[CacheLookupTables],
 
 		TimingIDs:                arg[CacheTimings],
 		AttributeFilterIndexIDs:  arg[CacheAttributeFilterIndexes],
@@ -1370,6 +1431,8 @@ type AttrReloadCacheWithAPIOpts struct {
 	ChargerProfileIDs        []string               `json:",omitempty"`
 	DispatcherProfileIDs     []string               `json:",omitempty"`
 	DispatcherHostIDs        []string               `json:",omitempty"`
+	ExchangeRateProfileIDs   []string               `json:",omitempty"`
+	LookupTableIDs           []string               `json:",omitempty"`
 	TimingIDs                []string               `json:",omitempty"`
 	AttributeFilterIndexIDs  []string               `json:",omitempty"`
 	ResourceFilterIndexIDs   []string               `json:",omitempty"`
@@ -1383,27 +1446,29 @@ type AttrReloadCacheWithAPIOpts struct {
 
 func (a *AttrReloadCacheWithAPIOpts) Map() map[string][]string {
 	return map[string][]string{
-		CacheDestinations:        a.DestinationIDs,
-		CacheReverseDestinations: a.ReverseDestinationIDs,
-		CacheRatingPlans:         a.RatingPlanIDs,
-		CacheRatingProfiles:      a.RatingProfileIDs,
-		CacheActions:             a.ActionIDs,
-		CacheActionPlans:         a.ActionPlanIDs,
-		CacheAccountActionPlans:  a.AccountActionPlanIDs,
-		CacheActionTriggers:      a.ActionTriggerIDs,
-		CacheSharedGroups:        a.SharedGroupIDs,
-		CacheResourceProfiles:    a.ResourceProfileIDs,
-		CacheResources:           a.ResourceIDs,
-		CacheStatQueues:          a.StatsQueueIDs,
-		CacheStatQueueProfiles:   a.StatsQueueProfileIDs,
-		CacheThresholds:          a.ThresholdIDs,
-		CacheThresholdProfiles:   a.ThresholdProfileIDs,
-		CacheFilters:             a.FilterIDs,
-		CacheRouteProfiles:       a.RouteProfileIDs,
-		CacheAttributeProfiles:   a.AttributeProfileIDs,
-		CacheChargerProfiles:     a.ChargerProfileIDs,
-		CacheDispatcherProfiles:  a.DispatcherProfileIDs,
-		CacheDispatcherHosts:     a.DispatcherHostIDs,
+		CacheDestinations:         a.DestinationIDs,
+		CacheReverseDestinations:  a.ReverseDestinationIDs,
+		CacheRatingPlans:          a.RatingPlanIDs,
+		CacheRatingProfiles:       a.RatingProfileIDs,
+		CacheActions:              a.ActionIDs,
+		CacheActionPlans:          a.ActionPlanIDs,
+		CacheAccountActionPlans:   a.AccountActionPlanIDs,
+		CacheActionTriggers:       a.ActionTriggerIDs,
+		CacheSharedGroups:         a.SharedGroupIDs,
+		CacheResourceProfiles:     a.ResourceProfileIDs,
+		CacheResources:            a.ResourceIDs,
+		CacheStatQueues:           a.StatsQueueIDs,
+		CacheStatQueueProfiles:    a.StatsQueueProfileIDs,
+		CacheThresholds:           a.ThresholdIDs,
+		CacheThresholdProfiles:    a.ThresholdProfileIDs,
+		CacheFilters:              a.FilterIDs,
+		CacheRouteProfiles:        a.RouteProfileIDs,
+		CacheAttributeProfiles:    a.AttributeProfileIDs,
+		CacheChargerProfiles:      a.ChargerProfileIDs,
+		CacheDispatcherProfiles:   a.DispatcherProfileIDs,
+		CacheDispatcherHosts:      a.DispatcherHostIDs,
+		CacheExchangeRateProfiles: a.ExchangeRateProfileIDs,
+		CacheLookupTables:         a.LookupTableIDs,
 
 		CacheTimings:                 a.TimingIDs,
 		CacheAttributeFilterIndexes:  a.AttributeFilterIndexIDs,
@@ -987,6 +987,8 @@ func TestNewAttrReloadCacheWithOpts(t *testing.T) {
 		ChargerProfileIDs:        []string{MetaAny},
 		DispatcherProfileIDs:     []string{MetaAny},
 		DispatcherHostIDs:        []string{MetaAny},
+		ExchangeRateProfileIDs:   []string{MetaAny},
+		LookupTableIDs:           []string{MetaAny},
 		TimingIDs:                []string{MetaAny},
 		AttributeFilterIndexIDs:  []string{MetaAny},
 		ResourceFilterIndexIDs:   []string{MetaAny},
@@ -1004,7 +1006,7 @@ func TestNewAttrReloadCacheWithOpts(t *testing.T) {
 }
 
 func TestNewAttrReloadCacheWithOptsFromMap(t *testing.T) {
-	excluded := NewStringSet([]string{MetaAPIBan, MetaAccounts, MetaLoadIDs})
+	excluded := NewStringSet([]string{MetaAPIBan, MetaAccounts, MetaLoadIDs, MetaTierCounters, MetaRerateJobs, MetaSessionsBackup})
 	mp := make(map[string][]string)
 	for k := range CacheInstanceToPrefix {
 		if !excluded.Has(k) {
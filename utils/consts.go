@@ -31,7 +31,7 @@ var (
 	GitLastLog string // If set, it will be processed as part of versioning
 
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
-		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses, CacheClosedSessions,
+		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses, CacheClosedSessions,
 		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
 		CacheRatingProfilesTmp, CacheCapsEvents, CacheReplicationHosts})
 
@@ -41,14 +41,16 @@ var (
 		CacheThresholdProfiles, CacheThresholds, CacheFilters, CacheRouteProfiles, CacheAttributeProfiles,
 		CacheResourceFilterIndexes, CacheStatFilterIndexes, CacheThresholdFilterIndexes, CacheRouteFilterIndexes,
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
-		CacheReverseFilterIndexes, CacheActionPlans, CacheAccountActionPlans, CacheAccounts, CacheVersions})
+		CacheReverseFilterIndexes, CacheActionPlans, CacheAccountActionPlans, CacheAccounts, CacheVersions,
+		CacheTierCounters, CacheRerateJobs, CacheExchangeRateProfiles, CacheLookupTables, CacheSessionsBackup})
 
 	StorDBPartitions = NewStringSet([]string{CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
 		CacheTBLTPActionPlans, CacheTBLTPActionTriggers, CacheTBLTPAccountActions, CacheTBLTPResources,
 		CacheTBLTPStats, CacheTBLTPThresholds, CacheTBLTPFilters, CacheSessionCostsTBL, CacheCDRsTBL,
 		CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
-		CacheTBLTPDispatcherHosts, CacheVersions})
+		CacheTBLTPDispatcherHosts, CacheVersions, CacheInvoicesTBL, CacheTBLTPExchangeRates,
+		CacheTBLTPLookupTables, CacheBalanceLedgerTBL})
 
 	// CachePartitions enables creation of cache partitions
 	CachePartitions = JoinStringSet(extraDBPartition, DataDBPartitions)
@@ -76,6 +78,8 @@ var (
 		CacheChargerProfiles:         ChargerProfilePrefix,
 		CacheDispatcherProfiles:      DispatcherProfilePrefix,
 		CacheDispatcherHosts:         DispatcherHostPrefix,
+		CacheExchangeRateProfiles:    ExchangeRateProfilePrefix,
+		CacheLookupTables:            LookupTablePrefix,
 		CacheResourceFilterIndexes:   ResourceFilterIndexes,
 		CacheStatFilterIndexes:       StatFilterIndexes,
 		CacheThresholdFilterIndexes:  ThresholdFilterIndexes,
@@ -86,6 +90,9 @@ var (
 
 		CacheLoadIDs:              LoadIDPrefix,
 		CacheAccounts:             AccountPrefix,
+		CacheTierCounters:         TierCounterPrefix,
+		CacheRerateJobs:           RerateJobPrefix,
+		CacheSessionsBackup:       SessionsBackupPrefix,
 		CacheReverseFilterIndexes: FilterIndexPrfx,
 		MetaAPIBan:                MetaAPIBan, // special case as it is not in a DB
 	}
@@ -136,15 +143,19 @@ var (
 		TBLTPFilters:          CacheTBLTPFilters,
 		SessionCostsTBL:       CacheSessionCostsTBL,
 		CDRsTBL:               CacheCDRsTBL,
+		InvoicesTBL:           CacheInvoicesTBL,
+		BalanceLedgerTBL:      CacheBalanceLedgerTBL,
 		TBLTPRoutes:           CacheTBLTPRoutes,
 		TBLTPAttributes:       CacheTBLTPAttributes,
 		TBLTPChargers:         CacheTBLTPChargers,
 		TBLTPDispatchers:      CacheTBLTPDispatchers,
 		TBLTPDispatcherHosts:  CacheTBLTPDispatcherHosts,
+		TBLTPExchangeRates:    CacheTBLTPExchangeRates,
+		TBLTPLookupTables:     CacheTBLTPLookupTables,
 	}
 
 	// ProtectedSFlds are the fields that sessions should not alter
-	ProtectedSFlds = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage})
+	ProtectedSFlds = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage, MSCC})
 
 	ConcurrentReqsLimit    int
 	ConcurrentReqsStrategy string
@@ -301,6 +312,11 @@ const (
 	ThresholdProfilePrefix    = "thp_"
 	StatQueuePrefix           = "stq_"
 	LoadIDPrefix              = "lid_"
+	TierCounterPrefix         = "tcr_"
+	RerateJobPrefix           = "rrj_"
+	SessionsBackupPrefix      = "sbk_"
+	ExchangeRateProfilePrefix = "xrp_"
+	LookupTablePrefix         = "lkt_"
 	LoadInstKey               = "load_history"
 	CreateCDRsTablesSQL       = "create_cdrs_tables.sql"
 	CreateTariffPlanTablesSQL = "create_tariffplan_tables.sql"
@@ -314,6 +330,8 @@ const (
 	MetaAMQPjsonMap           = "*amqp_json_map"
 	MetaAMQPV1jsonMap         = "*amqpv1_json_map"
 	MetaRPC                   = "*rpc"
+	MetaARI                   = "*ari"
+	MetaAMI                   = "*ami"
 	MetaSQSjsonMap            = "*sqs_json_map"
 	MetaKafkajsonMap          = "*kafka_json_map"
 	MetaNatsjsonMap           = "*nats_json_map"
@@ -331,6 +349,7 @@ const (
 	MetaCounterEvent          = "*event"
 	MetaBalance               = "*balance"
 	MetaAccount               = "*account"
+	MetaSubject               = "*subject"
 	EventName                 = "EventName"
 	// action trigger threshold types
 	TriggerMinEventCounter   = "*min_event_counter"
@@ -347,6 +366,7 @@ const (
 	MetaCCUsage              = "*cc_usage"
 	MetaSIPCID               = "*sipcid"
 	MetaValueExponent        = "*value_exponent"
+	MetaLookup               = "*lookup"
 	NegativePrefix           = "!"
 	MatchStartPrefix         = "^"
 	MatchGreaterThanOrEqual  = ">="
@@ -394,6 +414,15 @@ const (
 	MetaReplicator           = "*replicator"
 	MetaRerate               = "*rerate"
 	MetaRefund               = "*refund"
+	MetaExpiry               = "*expiry"
+	MetaRunning              = "*running"
+	MetaCompleted            = "*completed"
+	MetaCanceled             = "*canceled"
+	MetaFailed               = "*failed"
+	MetaIssued               = "*issued"
+	MetaVoided               = "*voided"
+	MetaRecurringFee         = "*recurring_fee"
+	MetaInvoices             = "*invoices"
 	MetaStats                = "*stats"
 	MetaResponder            = "*responder"
 	MetaCore                 = "*core"
@@ -422,6 +451,7 @@ const (
 	XMLSuffix                = ".xml"
 	CSVSuffix                = ".csv"
 	FWVSuffix                = ".fwv"
+	XLSXSuffix               = ".xlsx"
 	ContentJSON              = "json"
 	ContentForm              = "form"
 	FileLockPrefix           = "file_"
@@ -455,6 +485,8 @@ const (
 	Filters                  = "Filters"
 	DispatcherProfiles       = "DispatcherProfiles"
 	DispatcherHosts          = "DispatcherHosts"
+	ExchangeRates            = "ExchangeRates"
+	LookupTables             = "LookupTables"
 	MetaEveryMinute          = "*every_minute"
 	MetaHourly               = "*hourly"
 	ID                       = "ID"
@@ -489,10 +521,18 @@ const (
 	EventSource           = "EventSource"
 	AccountID             = "AccountID"
 	AccountIDs            = "AccountIDs"
+	InvoiceID             = "InvoiceID"
+	PeriodStart           = "PeriodStart"
+	PeriodEnd             = "PeriodEnd"
+	ActionPlanID          = "ActionPlanID"
+	Quantity              = "Quantity"
+	Status                = "Status"
+	Total                 = "Total"
 	ResourceID            = "ResourceID"
 	TotalUsage            = "TotalUsage"
 	StatID                = "StatID"
 	BalanceType           = "BalanceType"
+	Amount                = "Amount"
 	BalanceID             = "BalanceID"
 	BalanceDestinationIds = "BalanceDestinationIds"
 	BalanceWeight         = "BalanceWeight"
@@ -506,17 +546,22 @@ const (
 	Units                 = "Units"
 	AccountUpdate         = "AccountUpdate"
 	BalanceUpdate         = "BalanceUpdate"
+	CreditLimitReached    = "CreditLimitReached"
 	StatUpdate            = "StatUpdate"
 	ResourceUpdate        = "ResourceUpdate"
+	RouteUpdate           = "RouteUpdate"
 	CDR                   = "CDR"
 	CDRs                  = "CDRs"
 	ExpiryTime            = "ExpiryTime"
 	AllowNegative         = "AllowNegative"
+	CreditLimits          = "CreditLimits"
+	CreditLimit           = "CreditLimit"
 	Disabled              = "Disabled"
 	Initial               = "Initial"
 	Action                = "Action"
 
 	SessionSCosts            = "SessionSCosts"
+	Invoices                 = "Invoices"
 	Timing                   = "Timing"
 	RQF                      = "RQF"
 	Resource                 = "Resource"
@@ -540,6 +585,12 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaExclude              = "*exclude"
+	MetaCooldown             = "*cooldown"
+	MetaProbe                = "*probe"
+	MetaBlacklisted          = "*blacklisted"
+	MetaProbing              = "*probing"
+	MetaRestored             = "*restored"
 	Weight                   = "Weight"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
@@ -549,6 +600,11 @@ const (
 	Categories               = "Categories"
 	Blocker                  = "Blocker"
 	RatingPlanID             = "RatingPlanID"
+	Tier                     = "Tier"
+	Currency                 = "Currency"
+	ExchangeRate             = "ExchangeRate"
+	ActivationTime           = "ActivationTime"
+	Rate                     = "Rate"
 	StartTime                = "StartTime"
 	EndTime                  = "EndTime"
 	AccountSummary           = "AccountSummary"
@@ -575,6 +631,8 @@ const (
 	RatingID                 = "RatingID"
 	ExtraChargeID            = "ExtraChargeID"
 	ConnectFee               = "ConnectFee"
+	Prefix                   = "Prefix"
+	EffectiveDate            = "EffectiveDate"
 	RoundingMethod           = "RoundingMethod"
 	RoundingDecimals         = "RoundingDecimals"
 	MaxCostStrategy          = "MaxCostStrategy"
@@ -618,6 +676,7 @@ const (
 	MetricIDs                = "MetricIDs"
 	MetricFilterIDs          = "MetricFilterIDs"
 	FieldName                = "FieldName"
+	Key                      = "Key"
 	Path                     = "Path"
 	MetaRound                = "*round"
 	Pong                     = "Pong"
@@ -722,6 +781,8 @@ const (
 	MetaEventNumber         = "*event_number"
 	LoadIDs                 = "load_ids"
 	DNSAgent                = "DNSAgent"
+	CHFAgent                = "CHFAgent"
+	SMPPAgent               = "SMPPAgent"
 	TLSNoCaps               = "tls"
 	UsageID                 = "UsageID"
 	Replacement             = "Replacement"
@@ -781,6 +842,7 @@ const (
 	MetaLoaders             = "*loaders"
 	TmpSuffix               = ".tmp"
 	MetaDiamreq             = "*diamreq"
+	MetaRadDAReq            = "*radDAReq"
 	MetaCost                = "*cost"
 	MetaGroup               = "*group"
 	InternalRPCSet          = "InternalRPCSet"
@@ -808,6 +870,7 @@ const (
 	MetaMonthEnd            = "*month_end"
 	APIKey                  = "ApiKey"
 	RouteID                 = "RouteID"
+	MetricID                = "MetricID"
 	MetaMonthlyEstimated    = "*monthly_estimated"
 	MetaProcessedProfileIDs = "*processedProfileIDs"
 	MetaAttrPrfTenantID     = "*apTenantID"
@@ -870,6 +933,29 @@ const (
 	MetaCostIncrement       = "*costIncrement"
 	Length                  = "Length"
 
+	// chf
+	ChargingDataRef = "ChargingDataRef"
+	CHFCreate       = "Create"
+	CHFUpdate       = "Update"
+	CHFRelease      = "Release"
+
+	// smpp
+	SystemID         = "SystemID"
+	SMPPSubmitSM     = "submit_sm"
+	SMPPDeliverSM    = "deliver_sm"
+	SMPPSubmitSMResp = "submit_sm_resp"
+
+	// multiple-services credit control
+	MSCC                = "MSCC"
+	RatingGroup         = "RatingGroup"
+	FinalUnitIndication = "FinalUnitIndication"
+	MetaMSCC            = "*mscc"
+	MetaMSCCReq         = "*msccReq"
+	MetaMSCCRep         = "*msccRep"
+	MetaCHFMSCCReq      = "*chfMsccReq"
+	MetaCHFMSCCRep      = "*chfMsccRep"
+	MSCCIndex           = "MSCCIndex"
+
 	// dns
 	DNSQueryType          = "QueryType"
 	DNSQueryName          = "QueryName"
@@ -916,6 +1002,10 @@ const (
 	DNSTtl                = "Ttl"
 	DNSRdlength           = "Rdlength"
 	DNSData               = "Data"
+	DNSPriority           = "Priority"
+	DNSPort               = "Port"
+	DNSTarget             = "Target"
+	DNSTxt                = "Txt"
 )
 
 // Migrator Action
@@ -945,21 +1035,50 @@ const (
 	MetaRoutes              = "*routes"
 	MetaAttributes          = "*attributes"
 	MetaLoadIDs             = "*load_ids"
+	MetaTierCounters        = "*tier_counters"
+	MetaRerateJobs          = "*rerate_jobs"
+	MetaSessionsBackup      = "*sessions_backup"
+	MetaExchangeRates       = "*exchange_rates"
+	MetaLookupTables        = "*lookup_tables"
 )
 
 // MetaMetrics
 const (
-	MetaASR      = "*asr"
-	MetaACD      = "*acd"
-	MetaTCD      = "*tcd"
-	MetaACC      = "*acc"
-	MetaTCC      = "*tcc"
-	MetaPDD      = "*pdd"
-	MetaDDC      = "*ddc"
-	MetaSum      = "*sum"
-	MetaAverage  = "*average"
-	MetaDistinct = "*distinct"
-	MetaRAR      = "*rar"
+	MetaASR        = "*asr"
+	MetaACD        = "*acd"
+	MetaTCD        = "*tcd"
+	MetaACC        = "*acc"
+	MetaTCC        = "*tcc"
+	MetaPDD        = "*pdd"
+	MetaDDC        = "*ddc"
+	MetaSum        = "*sum"
+	MetaAverage    = "*average"
+	MetaDistinct   = "*distinct"
+	MetaPercentile = "*percentile"
+	MetaMedian     = "*median"
+	MetaRAR        = "*rar"
+)
+
+// Value types, selected through the layout of the template fields
+const (
+	MetaInteger = "*integer"
+	MetaFloat   = "*float"
+	MetaBool    = "*bool"
+)
+
+// Rate deck changes
+const (
+	MetaNew       = "*new"
+	MetaIncreased = "*increased"
+	MetaDecreased = "*decreased"
+	MetaChanged   = "*changed"
+	MetaRemoved   = "*removed"
+)
+
+// StatQueue windows
+const (
+	MetaTumbling = "*tumbling"
+	MetaSliding  = "*sliding"
 )
 
 // Services
@@ -978,6 +1097,7 @@ const (
 	CacheS      = "CacheS"
 	AnalyzerS   = "AnalyzerS"
 	CDRServer   = "CDRServer"
+	InvoiceS    = "InvoiceS"
 	ResponderS  = "ResponderS"
 	GuardianS   = "GuardianS"
 	ApierS      = "ApierS"
@@ -1107,29 +1227,34 @@ const (
 	TpDispatchers        = "TpDispatchers"
 	TpDispatcherProfiles = "TpDispatcherProfiles"
 	TpDispatcherHosts    = "TpDispatcherHosts"
+	TpExchangeRates      = "TpExchangeRates"
+	TpLookupTables       = "TpLookupTables"
 )
 
 // Dispatcher Const
 const (
-	MetaFirst          = "*first"
-	MetaRandom         = "*random"
-	MetaRoundRobin     = "*round_robin"
-	MetaRatio          = "*ratio"
-	MetaDefaultRatio   = "*default_ratio"
-	ThresholdSv1       = "ThresholdSv1"
-	StatSv1            = "StatSv1"
-	ResourceSv1        = "ResourceSv1"
-	RouteSv1           = "RouteSv1"
-	AttributeSv1       = "AttributeSv1"
-	SessionSv1         = "SessionSv1"
-	ChargerSv1         = "ChargerSv1"
-	MetaAuth           = "*auth"
-	APIMethods         = "APIMethods"
-	NestingSep         = "."
-	ArgDispatcherField = "ArgDispatcher"
-)
-
-//Filter types
+	MetaFirst              = "*first"
+	MetaRandom             = "*random"
+	MetaRoundRobin         = "*round_robin"
+	MetaRatio              = "*ratio"
+	MetaDefaultRatio       = "*default_ratio"
+	MetaHash               = "*hash"
+	MetaHashField          = "*hash_field"
+	MetaWeightedRoundRobin = "*weighted_round_robin"
+	ThresholdSv1           = "ThresholdSv1"
+	StatSv1                = "StatSv1"
+	ResourceSv1            = "ResourceSv1"
+	RouteSv1               = "RouteSv1"
+	AttributeSv1           = "AttributeSv1"
+	SessionSv1             = "SessionSv1"
+	ChargerSv1             = "ChargerSv1"
+	MetaAuth               = "*auth"
+	APIMethods             = "APIMethods"
+	NestingSep             = "."
+	ArgDispatcherField     = "ArgDispatcher"
+)
+
+// Filter types
 const (
 	MetaNot                = "*not"
 	MetaString             = "*string"
@@ -1152,6 +1277,11 @@ const (
 	MetaAPIBan             = "*apiban"
 	MetaActivationInterval = "*ai"
 	MetaRegex              = "*regex"
+	MetaExpr               = "*expr"
+	MetaGeoIP              = "*geoip"
+	MetaGeoDistance        = "*geo_distance"
+	MetaTimeOfDay          = "*time_of_day"
+	MetaDayOfWeek          = "*day_of_week"
 
 	MetaNotString             = "*notstring"
 	MetaNotPrefix             = "*notprefix"
@@ -1168,6 +1298,11 @@ const (
 	MetaNotAPIBan             = "*notapiban"
 	MetaNotActivationInterval = "*notai"
 	MetaNotRegex              = "*notregex"
+	MetaNotExpr               = "*notexpr"
+	MetaNotGeoIP              = "*notgeoip"
+	MetaNotGeoDistance        = "*notgeo_distance"
+	MetaNotTimeOfDay          = "*nottime_of_day"
+	MetaNotDayOfWeek          = "*notday_of_week"
 
 	MetaEC = "*ec"
 )
@@ -1309,11 +1444,17 @@ const (
 	APIerSv1GetReverseDestination             = "APIerSv1.GetReverseDestination"
 	APIerSv1AddBalance                        = "APIerSv1.AddBalance"
 	APIerSv1DebitBalance                      = "APIerSv1.DebitBalance"
+	APIerSv1ReserveBalance                    = "APIerSv1.ReserveBalance"
+	APIerSv1CommitReservation                 = "APIerSv1.CommitReservation"
+	APIerSv1ReleaseReservation                = "APIerSv1.ReleaseReservation"
 	APIerSv1SetAccount                        = "APIerSv1.SetAccount"
 	APIerSv1GetAccountsCount                  = "APIerSv1.GetAccountsCount"
 	APIerSv1GetDataDBVersions                 = "APIerSv1.GetDataDBVersions"
 	APIerSv1GetStorDBVersions                 = "APIerSv1.GetStorDBVersions"
 	APIerSv1GetCDRs                           = "APIerSv1.GetCDRs"
+	APIerSv1GetLedgerEntries                  = "APIerSv1.GetLedgerEntries"
+	APIerSv1ReissueInvoice                    = "APIerSv1.ReissueInvoice"
+	APIerSv1VoidInvoice                       = "APIerSv1.VoidInvoice"
 	APIerSv1GetTPAccountActions               = "APIerSv1.GetTPAccountActions"
 	APIerSv1SetTPAccountActions               = "APIerSv1.SetTPAccountActions"
 	APIerSv1GetTPAccountActionsByLoadId       = "APIerSv1.GetTPAccountActionsByLoadId"
@@ -1545,6 +1686,16 @@ const (
 	APIerSv1RemoveChargerProfile  = "APIerSv1.RemoveChargerProfile"
 	APIerSv1SetChargerProfile     = "APIerSv1.SetChargerProfile"
 	APIerSv1GetChargerProfileIDs  = "APIerSv1.GetChargerProfileIDs"
+
+	APIerSv1GetExchangeRateProfile    = "APIerSv1.GetExchangeRateProfile"
+	APIerSv1SetExchangeRateProfile    = "APIerSv1.SetExchangeRateProfile"
+	APIerSv1RemoveExchangeRateProfile = "APIerSv1.RemoveExchangeRateProfile"
+	APIerSv1GetExchangeRateProfileIDs = "APIerSv1.GetExchangeRateProfileIDs"
+
+	APIerSv1GetLookupTableEntry = "APIerSv1.GetLookupTableEntry"
+	APIerSv1SetLookupTable      = "APIerSv1.SetLookupTable"
+	APIerSv1RemoveLookupTable   = "APIerSv1.RemoveLookupTable"
+	APIerSv1GetLookupTableIDs   = "APIerSv1.GetLookupTableIDs"
 )
 
 // ThresholdS APIs
@@ -1568,6 +1719,7 @@ const (
 	StatSv1GetQueueIDs             = "StatSv1.GetQueueIDs"
 	StatSv1GetQueueStringMetrics   = "StatSv1.GetQueueStringMetrics"
 	StatSv1GetQueueFloatMetrics    = "StatSv1.GetQueueFloatMetrics"
+	StatSv1GetQueueWindows         = "StatSv1.GetQueueWindows"
 	StatSv1Ping                    = "StatSv1.Ping"
 	StatSv1GetStatQueuesForEvent   = "StatSv1.GetStatQueuesForEvent"
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
@@ -1654,6 +1806,7 @@ const (
 	DispatcherSv1RemoteStatus        = "DispatcherSv1.RemoteStatus"
 	DispatcherSv1RemoteSleep         = "DispatcherSv1.RemoteSleep"
 	DispatcherSv1RemotePing          = "DispatcherSv1.RemotePing"
+	DispatcherSv1GetHostsStatus      = "DispatcherSv1.GetHostsStatus"
 )
 
 // RegistrarS APIs
@@ -1672,6 +1825,14 @@ const (
 	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
 )
 
+// InvoiceS APIs
+const (
+	InvoiceSv1                   = "InvoiceSv1"
+	InvoiceSv1Ping               = "InvoiceSv1.Ping"
+	InvoiceSv1CloseBillingPeriod = "InvoiceSv1.CloseBillingPeriod"
+	InvoiceSv1GetInvoices        = "InvoiceSv1.GetInvoices"
+)
+
 // LoaderS APIs
 const (
 	LoaderSv1       = "LoaderSv1"
@@ -1720,6 +1881,9 @@ const (
 	CDRsV1StoreSessionCost   = "CDRsV1.StoreSessionCost"
 	CDRsV1ProcessEvent       = "CDRsV1.ProcessEvent"
 	CDRsV1Ping               = "CDRsV1.Ping"
+	CDRsV1StartRerate        = "CDRsV1.StartRerate"
+	CDRsV1GetRerateStatus    = "CDRsV1.GetRerateStatus"
+	CDRsV1CancelRerate       = "CDRsV1.CancelRerate"
 	CDRsV2                   = "CDRsV2"
 	CDRsV2StoreSessionCost   = "CDRsV2.StoreSessionCost"
 	CDRsV2ProcessEvent       = "CDRsV2.ProcessEvent"
@@ -1741,7 +1905,7 @@ const (
 	EeSv1ProcessEvent = "EeSv1.ProcessEvent"
 )
 
-//cgr_ variables
+// cgr_ variables
 const (
 	CGRAccount         = "cgr_account"
 	CGRRoute           = "cgr_route"
@@ -1758,7 +1922,7 @@ const (
 	CGROpts            = "cgr_opts"
 )
 
-//CSV file name
+// CSV file name
 const (
 	TimingsCsv            = "Timings.csv"
 	DestinationsCsv       = "Destinations.csv"
@@ -1780,6 +1944,8 @@ const (
 	ChargersCsv           = "Chargers.csv"
 	DispatcherProfilesCsv = "DispatcherProfiles.csv"
 	DispatcherHostsCsv    = "DispatcherHosts.csv"
+	ExchangeRatesCsv      = "ExchangeRates.csv"
+	LookupTablesCsv       = "LookupTables.csv"
 )
 
 // Table Name
@@ -1801,6 +1967,8 @@ const (
 	TBLTPFilters          = "tp_filters"
 	SessionCostsTBL       = "session_costs"
 	CDRsTBL               = "cdrs"
+	InvoicesTBL           = "invoices"
+	BalanceLedgerTBL      = "balance_ledger"
 	TBLTPRoutes           = "tp_routes"
 	TBLTPAttributes       = "tp_attributes"
 	TBLTPChargers         = "tp_chargers"
@@ -1808,6 +1976,8 @@ const (
 	OldSMCosts            = "sm_costs"
 	TBLTPDispatchers      = "tp_dispatcher_profiles"
 	TBLTPDispatcherHosts  = "tp_dispatcher_hosts"
+	TBLTPExchangeRates    = "tp_exchange_rates"
+	TBLTPLookupTables     = "tp_lookup_tables"
 )
 
 // Cache Name
@@ -1846,6 +2016,7 @@ const (
 	CacheChargerFilterIndexes    = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes = "*dispatcher_filter_indexes"
 	CacheDiameterMessages        = "*diameter_messages"
+	CacheRadiusPackets           = "*radius_packets"
 	CacheRPCResponses            = "*rpc_responses"
 	CacheClosedSessions          = "*closed_sessions"
 	MetaPrecaching               = "*precaching"
@@ -1860,6 +2031,11 @@ const (
 	CacheReverseFilterIndexes    = "*reverse_filter_indexes"
 	CacheAccounts                = "*accounts"
 	CacheVersions                = "*versions"
+	CacheTierCounters            = "*tier_counters"
+	CacheRerateJobs              = "*rerate_jobs"
+	CacheSessionsBackup          = "*sessions_backup"
+	CacheExchangeRateProfiles    = "*exchange_rate_profiles"
+	CacheLookupTables            = "*lookup_tables"
 	CacheCapsEvents              = "*caps_events"
 	CacheReplicationHosts        = "*replication_hosts"
 
@@ -1881,11 +2057,15 @@ const (
 	CacheTBLTPFilters          = "*tp_filters"
 	CacheSessionCostsTBL       = "*session_costs"
 	CacheCDRsTBL               = "*cdrs"
+	CacheInvoicesTBL           = "*invoices"
+	CacheBalanceLedgerTBL      = "*balance_ledger"
 	CacheTBLTPRoutes           = "*tp_routes"
 	CacheTBLTPAttributes       = "*tp_attributes"
 	CacheTBLTPChargers         = "*tp_chargers"
 	CacheTBLTPDispatchers      = "*tp_dispatcher_profiles"
 	CacheTBLTPDispatcherHosts  = "*tp_dispatcher_hosts"
+	CacheTBLTPExchangeRates    = "*tp_exchange_rates"
+	CacheTBLTPLookupTables     = "*tp_lookup_tables"
 )
 
 // Prefix for indexing
@@ -1910,6 +2090,7 @@ const (
 	AsteriskAgent   = "AsteriskAgent"
 	HTTPAgent       = "HTTPAgent"
 	SIPAgent        = "SIPAgent"
+	OpenSIPSAgent   = "OpenSIPSAgent"
 )
 
 // Google_API
@@ -1956,6 +2137,7 @@ const (
 	RSRSepCfg           = "rsr_separator"
 	MaxParallelConnsCfg = "max_parallel_conns"
 	EEsConnsCfg         = "ees_conns"
+	InvoiceSConnsCfg    = "invoices_conns"
 )
 
 // StorDbCfg
@@ -2062,6 +2244,7 @@ const (
 	StatSConnsCfg     = "stats_conns"
 	ResourceSConnsCfg = "resources_conns"
 	ApierSConnsCfg    = "apiers_conns"
+	GeoIPDBPathsCfg   = "geoip_db_paths"
 )
 
 // RalsCfg
@@ -2074,6 +2257,11 @@ const (
 	MaxComputedUsageCfg        = "max_computed_usage"
 	BalanceRatingSubjectCfg    = "balance_rating_subject"
 	MaxIncrementsCfg           = "max_increments"
+	TieredRatingPlansCfg       = "tiered_rating_plans"
+	DefaultCurrencyCfg         = "default_currency"
+	BalanceLedgerCfg           = "balance_ledger"
+	CounterCfg                 = "counter"
+	CycleCfg                   = "cycle"
 )
 
 // SchedulerCfg
@@ -2116,6 +2304,7 @@ const (
 	SessionIndexesCfg      = "session_indexes"
 	ClientProtocolCfg      = "client_protocol"
 	ChannelSyncIntervalCfg = "channel_sync_interval"
+	BackupIntervalCfg      = "backup_interval"
 	TerminateAttemptsCfg   = "terminate_attempts"
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
@@ -2154,6 +2343,11 @@ const (
 	TimezoneCfg   = "timezone"
 	TimezoneCfgC  = "Timezone"
 
+	// OsipsAgentCfg
+	ListenUDPCfg = "listen_udp"
+	MiConnsCfg   = "mi_conns"
+	MiAddrCfg    = "mi_addr"
+
 	// AsteriskConnCfg
 	UserCf = "user"
 
@@ -2176,6 +2370,13 @@ const (
 	ForcedDisconnectCfg   = "forced_disconnect"
 	TemplatesCfg          = "templates"
 	RequestProcessorsCfg  = "request_processors"
+	PeersCfg              = "peers"
+	RoutesCfg             = "routes"
+	RelayTimeoutCfg       = "relay_timeout"
+	WatchdogIntervalCfg   = "watchdog_interval"
+	RealmCfg              = "realm"
+	ApplicationIDsCfg     = "application_ids"
+	PeerIDsCfg            = "peer_ids"
 
 	// RequestProcessor
 	RequestFieldsCfg = "request_fields"
@@ -2186,6 +2387,23 @@ const (
 	ListenAcctCfg         = "listen_acct"
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
+	ClientDaAddressesCfg  = "client_da_addresses"
+	RequestsCacheKeyCfg   = "requests_cache_key"
+	DMRTemplateCfg        = "dmr_template"
+	CoATemplateCfg        = "coa_template"
+	HostCfg               = "host"
+	PortCfg               = "port"
+	RetransmitsCfg        = "retransmits"
+
+	// CHFAgentCfg
+	APIRootCfg = "api_root"
+
+	// SMPPAgentCfg
+	SystemIDCfg          = "system_id"
+	ClientPasswordsCfg   = "client_passwords"
+	SMSCConnsCfg         = "smsc_conns"
+	SystemTypeCfg        = "system_type"
+	ReconnectIntervalCfg = "reconnect_interval"
 
 	// AttributeSCfg
 	IndexedSelectsCfg           = "indexed_selects"
@@ -2258,6 +2476,10 @@ const (
 	IndexTypeCfg       = "index_type"
 	DBPathCfg          = "db_path"
 
+	// InvoiceSCfg
+	EEsIDsCfg = "ees_ids"
+	RunIDsCfg = "run_ids"
+
 	// CoreSCfg
 	CapsCfg              = "caps"
 	CapsStrategyCfg      = "caps_strategy"
@@ -2269,7 +2491,8 @@ const (
 	MaxUsage      = "max_usage"
 
 	// DispatcherSCfg
-	AnySubsystemCfg = "any_subsystem"
+	AnySubsystemCfg        = "any_subsystem"
+	HealthCheckIntervalCfg = "health_check_interval"
 )
 
 // FC Template
@@ -2332,6 +2555,7 @@ const (
 	SchedulerConnsCfg  = "scheduler_conns"
 	GapiCredentialsCfg = "gapi_credentials"
 	GapiTokenCfg       = "gapi_token"
+	RoundingMethodCfg  = "rounding_method"
 )
 
 // MigratorCgrCfg
@@ -2689,7 +2913,7 @@ const (
 	Opts             = "Opts"
 )
 
-//CMD constants
+// CMD constants
 const (
 	//Common
 	VerboseCgr      = "verbose"
@@ -2730,23 +2954,26 @@ const (
 	MemProfFileCgr       = "mem_final.prof"
 	CpuPathCgr           = "cpu.prof"
 	//Cgr loader
-	CgrLoader         = "cgr-loader"
-	StorDBTypeCgr     = "stordb_type"
-	StorDBHostCgr     = "stordb_host"
-	StorDBPortCgr     = "stordb_port"
-	StorDBNameCgr     = "stordb_name"
-	StorDBUserCgr     = "stordb_user"
-	StorDBPasswdCgr   = "stordb_passwd"
-	CachingArgCgr     = "caching"
-	FieldSepCgr       = "field_sep"
-	ImportIDCgr       = "import_id"
-	DisableReverseCgr = "disable_reverse_mappings"
-	FlushStorDB       = "flush_stordb"
-	RemoveCgr         = "remove"
-	FromStorDBCgr     = "from_stordb"
-	ToStorDBcgr       = "to_stordb"
-	CacheSAddress     = "caches_address"
-	SchedulerAddress  = "scheduler_address"
+	CgrLoader           = "cgr-loader"
+	StorDBTypeCgr       = "stordb_type"
+	StorDBHostCgr       = "stordb_host"
+	StorDBPortCgr       = "stordb_port"
+	StorDBNameCgr       = "stordb_name"
+	StorDBUserCgr       = "stordb_user"
+	StorDBPasswdCgr     = "stordb_passwd"
+	CachingArgCgr       = "caching"
+	FieldSepCgr         = "field_sep"
+	ImportIDCgr         = "import_id"
+	DisableReverseCgr   = "disable_reverse_mappings"
+	FlushStorDB         = "flush_stordb"
+	RemoveCgr           = "remove"
+	FromStorDBCgr       = "from_stordb"
+	ToStorDBcgr         = "to_stordb"
+	CacheSAddress       = "caches_address"
+	SchedulerAddress    = "scheduler_address"
+	RateDeckPathCgr     = "ratedeck_path"
+	RateDeckTemplateCgr = "ratedeck_template"
+	RateDeckCategoryCgr = "ratedeck_category"
 	//Cgr migrator
 	CgrMigrator = "cgr-migrator"
 	ExecCgr     = "exec"
@@ -2769,6 +2996,7 @@ var StringTmplType = StringSet{
 	MetaPrefix:          struct{}{},
 	MetaSuffix:          struct{}{},
 	MetaSIPCID:          struct{}{},
+	MetaLookup:          struct{}{},
 }
 
 // Time duration suffix
@@ -2793,4 +3021,7 @@ func init() {
 	buildCacheInstRevPrefixes()
 	CachePartitions.Remove(CacheAccounts)
 	CachePartitions.Remove(CacheVersions)
+	CachePartitions.Remove(CacheTierCounters)
+	CachePartitions.Remove(CacheRerateJobs)
+	CachePartitions.Remove(CacheSessionsBackup)
 }
@@ -110,6 +110,9 @@ func CheckInLineFilter(fltrs []string) (err error) {
 			if len(rules) < 3 {
 				return fmt.Errorf("inline parse error for string: <%s>", fltr)
 			}
+			if rules[0] == MetaExpr || rules[0] == MetaNotExpr { // the expression is checked when compiling the filter
+				continue
+			}
 			valFunc := IsPathValid
 			if rules[0] == MetaEmpty || rules[0] == MetaExists {
 				valFunc = IsPathValidForExporters
@@ -76,6 +76,8 @@ var (
 	ErrMaxConcurentRPCExceeded       = errors.New("MAX_CONCURENT_RPC_EXCEEDED") // but the codec will rewrite it with this one to be sure that we corectly dealocate the request
 	ErrMaxIterationsReached          = errors.New("maximum iterations reached")
 	ErrNegative                      = errors.New("NEGATIVE")
+	ErrJobNotRunning                 = errors.New("JOB_NOT_RUNNING")
+	ErrExchangeRateNotFound          = errors.New("EXCHANGE_RATE_NOT_FOUND")
 
 	ErrMap = map[string]error{
 		ErrNoMoreData.Error():              ErrNoMoreData,
@@ -115,6 +117,8 @@ var (
 		ErrIndexOutOfBounds.Error():        ErrIndexOutOfBounds,
 		ErrWrongPath.Error():               ErrWrongPath,
 		ErrHostNotFound.Error():            ErrHostNotFound,
+		ErrJobNotRunning.Error():           ErrJobNotRunning,
+		ErrExchangeRateNotFound.Error():    ErrExchangeRateNotFound,
 	}
 )
 
@@ -79,6 +79,12 @@ func CloneStringSlice(in []string) (cl []string) {
 	return
 }
 
+func CloneIntSlice(in []int) (cl []int) {
+	cl = make([]int, len(in))
+	copy(cl, in)
+	return
+}
+
 func SliceStringEqual(v1, v2 []string) bool {
 	if len(v1) != len(v2) {
 		return false
@@ -0,0 +1,193 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// xlsxSharedStrings is the xl/sharedStrings.xml part of a .xlsx file
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+// xlsxWorkbook is the xl/workbook.xml part of a .xlsx file
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships is the xl/_rels/workbook.xml.rels part of a .xlsx file
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxWorksheet is the worksheet part of a .xlsx file
+type xlsxWorksheet struct {
+	Rows []struct {
+		Ref   int `xml:"r,attr"` // 1-based row number, 0 if missing
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSXRecords returns the rows within the first sheet of the .xlsx file
+// the blank rows and cells are kept empty so the records follow the sheet layout
+func ReadXLSXRecords(path string) (records [][]string, err error) {
+	var zr *zip.ReadCloser
+	if zr, err = zip.OpenReader(path); err != nil {
+		return
+	}
+	defer zr.Close()
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	var sheetPath string
+	if sheetPath, err = xlsxFirstSheetPath(files); err != nil {
+		return
+	}
+	sheetFile, has := files[sheetPath]
+	if !has {
+		return nil, fmt.Errorf("no sheet found in <%s>", path)
+	}
+	var sheet xlsxWorksheet
+	if err = decodeZipXML(sheetFile, &sheet); err != nil {
+		return
+	}
+	var sharedStrings []string
+	if sstFile, has := files["xl/sharedStrings.xml"]; has {
+		var sst xlsxSharedStrings
+		if err = decodeZipXML(sstFile, &sst); err != nil {
+			return
+		}
+		sharedStrings = make([]string, len(sst.Items))
+		for i, si := range sst.Items {
+			sharedStrings[i] = si.Text
+			for _, r := range si.Runs {
+				sharedStrings[i] += r.Text
+			}
+		}
+	}
+	records = make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		rowIdx := len(records)
+		if row.Ref > 0 {
+			rowIdx = row.Ref - 1
+		}
+		for len(records) <= rowIdx {
+			records = append(records, nil)
+		}
+		for j, c := range row.Cells {
+			idx := j
+			if c.Ref != EmptyString {
+				idx = xlsxColumnIndex(c.Ref)
+			}
+			for len(records[rowIdx]) <= idx {
+				records[rowIdx] = append(records[rowIdx], EmptyString)
+			}
+			switch c.Type {
+			case "s":
+				var ssIdx int
+				if ssIdx, err = strconv.Atoi(c.Value); err != nil || ssIdx >= len(sharedStrings) {
+					return nil, fmt.Errorf("invalid shared string <%s> in cell <%s>", c.Value, c.Ref)
+				}
+				records[rowIdx][idx] = sharedStrings[ssIdx]
+			case "inlineStr":
+				records[rowIdx][idx] = c.Inline
+			default:
+				records[rowIdx][idx] = c.Value
+			}
+		}
+	}
+	return
+}
+
+// xlsxFirstSheetPath returns the path within the archive of the first sheet out of the workbook
+func xlsxFirstSheetPath(files map[string]*zip.File) (sheetPath string, err error) {
+	wbFile, has := files["xl/workbook.xml"]
+	if !has {
+		return "xl/worksheets/sheet1.xml", nil // minimal files without workbook
+	}
+	var wb xlsxWorkbook
+	if err = decodeZipXML(wbFile, &wb); err != nil {
+		return
+	}
+	if len(wb.Sheets) == 0 {
+		return EmptyString, errors.New("no sheet defined in the workbook")
+	}
+	relsFile, has := files["xl/_rels/workbook.xml.rels"]
+	if !has {
+		return EmptyString, errors.New("missing the workbook relationships")
+	}
+	var rels xlsxRelationships
+	if err = decodeZipXML(relsFile, &rels); err != nil {
+		return
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") { // absolute within the archive
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return EmptyString, fmt.Errorf("no relationship for the sheet <%s>", wb.Sheets[0].RelID)
+}
+
+// decodeZipXML unmarshals the xml file from the archive
+func decodeZipXML(f *zip.File, v interface{}) (err error) {
+	rc, err := f.Open()
+	if err != nil {
+		return
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// xlsxColumnIndex returns the column index out of the cell reference (ie: B3 is 1)
+func xlsxColumnIndex(ref string) (idx int) {
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		idx = idx*26 + int(c-'A'+1)
+	}
+	return idx - 1
+}
@@ -0,0 +1,126 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadXLSXRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratedeck.xlsx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="3" uniqueCount="3">
+<si><t>Prefix</t></si><si><t>Rate</t></si><si><r><t>Effective</t></r><r><t>Date</t></r></si>
+</sst>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+<row r="2"><c r="A2" t="inlineStr"><is><t>4420</t></is></c><c r="B2"><v>0.02</v></c><c r="C2"><v>44256</v></c></row>
+<row r="3"><c r="A3"><v>4475</v></c><c r="B3"><v>0.09</v></c></row>
+</sheetData></worksheet>`,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	exp := [][]string{
+		{"Prefix", "Rate", "EffectiveDate"},
+		{"4420", "0.02", "44256"},
+		{"4475", "0.09"},
+	}
+	if records, err := ReadXLSXRecords(path); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, records) {
+		t.Errorf("Expected %+v, received %+v", exp, records)
+	}
+	if _, err := ReadXLSXRecords(filepath.Join(t.TempDir(), "missing.xlsx")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestReadXLSXRecordsWorkbook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratedeck.xlsx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Rates" sheetId="2" r:id="rId3"/><sheet name="Notes" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/rates.xml"/>
+</Relationships>`,
+		"xl/worksheets/sheet1.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>Notes</t></is></c></row>
+</sheetData></worksheet>`,
+		"xl/worksheets/rates.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>Prefix</t></is></c><c r="B1" t="inlineStr"><is><t>Rate</t></is></c><c r="C1" t="inlineStr"><is><t>EffectiveDate</t></is></c></row>
+<row r="2"><c r="A2"><v>4420</v></c><c r="C2"><v>44256</v></c></row>
+<row r="4"><c r="A4"><v>4475</v></c><c r="B4"><v>0.09</v></c></row>
+</sheetData></worksheet>`,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	exp := [][]string{
+		{"Prefix", "Rate", "EffectiveDate"},
+		{"4420", "", "44256"},
+		nil,
+		{"4475", "0.09"},
+	}
+	if records, err := ReadXLSXRecords(path); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, records) {
+		t.Errorf("Expected %q, received %q", exp, records)
+	}
+}